@@ -0,0 +1,54 @@
+package arma
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type arma struct {
+	ID              int64
+	NúmeroSérie     string
+	Modelo          string
+	Calibre         string
+	CR              int
+	IDClube         int64
+	TipoRegistro    protocolo.ArmaTipoRegistro
+	DataCriação     time.Time
+	DataAtualização time.Time
+
+	// revisão utilizado para o controle de versão do objeto na base de dados,
+	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
+	// objeto.
+	revisão int
+}
+
+func novaArma(armaPedido protocolo.ArmaPedido) arma {
+	a := arma{}
+	a.preencher(armaPedido)
+	return a
+}
+
+// preencher copia os dados do pedido para a arma.
+func (a *arma) preencher(armaPedido protocolo.ArmaPedido) {
+	a.NúmeroSérie = armaPedido.NúmeroSérie
+	a.Modelo = armaPedido.Modelo
+	a.Calibre = armaPedido.Calibre
+	a.CR = armaPedido.CR
+	a.IDClube = armaPedido.Clube
+	a.TipoRegistro = armaPedido.TipoRegistro
+}
+
+func (a arma) protocolo() protocolo.ArmaResposta {
+	return protocolo.ArmaResposta{
+		ID:              a.ID,
+		NúmeroSérie:     a.NúmeroSérie,
+		Modelo:          a.Modelo,
+		Calibre:         a.Calibre,
+		CR:              a.CR,
+		Clube:           a.IDClube,
+		TipoRegistro:    a.TipoRegistro,
+		DataCriação:     a.DataCriação,
+		DataAtualização: a.DataAtualização,
+	}
+}
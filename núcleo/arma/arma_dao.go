@@ -0,0 +1,198 @@
+package arma
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type armaDAO interface {
+	criar(*arma) error
+	atualizar(*arma) error
+	resgatar(id int64) (arma, error)
+	resgatarPorNúmeroSérie(númeroSérie string) (arma, error)
+}
+
+var novaArmaDAO = func(sqlogger *bd.SQLogger) armaDAO {
+	return armaDAOImpl{sqlogger: sqlogger}
+}
+
+type armaDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a armaDAOImpl) criar(arma *arma) error {
+	if arma == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	arma.DataCriação = time.Now().UTC()
+	arma.revisão = 0
+
+	cr, idClube := proprietárioBD(*arma)
+	resultado := a.sqlogger.QueryRow(armaCriaçãoComando,
+		arma.NúmeroSérie,
+		arma.Modelo,
+		arma.Calibre,
+		cr,
+		idClube,
+		arma.TipoRegistro,
+		arma.DataCriação.UTC(),
+		arma.revisão,
+	)
+
+	if err := resultado.Scan(&arma.ID); err != nil {
+		return erros.Novo(err)
+	}
+
+	armaLogDAO := novaArmaLogDAO(a.sqlogger)
+	return erros.Novo(armaLogDAO.criar(*arma, bd.AçãoLogCriação))
+}
+
+func (a armaDAOImpl) atualizar(arma *arma) error {
+	if arma == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	arma.DataAtualização = time.Now().UTC()
+	arma.revisão++
+
+	cr, idClube := proprietárioBD(*arma)
+	resultado, err := a.sqlogger.Exec(armaAtualizaçãoComando,
+		arma.NúmeroSérie,
+		arma.Modelo,
+		arma.Calibre,
+		cr,
+		idClube,
+		arma.TipoRegistro,
+		arma.DataAtualização.UTC(),
+		arma.revisão,
+		arma.ID,
+		arma.revisão-1,
+	)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	atualizados, err := resultado.RowsAffected()
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if atualizados != 1 {
+		return erros.NãoAtualizado
+	}
+
+	armaLogDAO := novaArmaLogDAO(a.sqlogger)
+	return erros.Novo(armaLogDAO.criar(*arma, bd.AçãoLogAtualização))
+}
+
+func (a armaDAOImpl) resgatar(id int64) (arma, error) {
+	return a.resgatarPorComando(armaResgateComando, id)
+}
+
+func (a armaDAOImpl) resgatarPorNúmeroSérie(númeroSérie string) (arma, error) {
+	return a.resgatarPorComando(armaResgatePorNúmeroSérieComando, númeroSérie)
+}
+
+func (a armaDAOImpl) resgatarPorComando(comando string, argumento interface{}) (arma, error) {
+	resultado := a.sqlogger.QueryRow(comando, argumento)
+
+	var arm arma
+	var cr, idClube sql.NullInt64
+	var tipoRegistro string
+	var dataAtualização pq.NullTime
+
+	err := resultado.Scan(
+		&arm.ID,
+		&arm.NúmeroSérie,
+		&arm.Modelo,
+		&arm.Calibre,
+		&cr,
+		&idClube,
+		&tipoRegistro,
+		&arm.DataCriação,
+		&dataAtualização,
+		&arm.revisão,
+	)
+
+	arm.TipoRegistro = protocolo.ArmaTipoRegistro(tipoRegistro)
+
+	if cr.Valid {
+		arm.CR = int(cr.Int64)
+	}
+
+	if idClube.Valid {
+		arm.IDClube = idClube.Int64
+	}
+
+	if dataAtualização.Valid {
+		arm.DataAtualização = dataAtualização.Time
+	}
+
+	return arm, erros.Novo(err)
+}
+
+// proprietárioBD converte o proprietário da arma para o formato do banco de
+// dados, onde o proprietário não utilizado é armazenado como nulo.
+func proprietárioBD(arma arma) (cr, idClube sql.NullInt64) {
+	cr = sql.NullInt64{Int64: int64(arma.CR), Valid: arma.CR > 0}
+	idClube = sql.NullInt64{Int64: arma.IDClube, Valid: arma.IDClube > 0}
+	return
+}
+
+var (
+	armaTabela = "arma"
+
+	armaCriaçãoCampos = []string{
+		"id",
+		"numero_serie",
+		"modelo",
+		"calibre",
+		"cr",
+		"id_clube",
+		"tipo_registro",
+		"data_criacao",
+		"revisao",
+	}
+	armaCriaçãoCamposTexto = strings.Join(armaCriaçãoCampos, ", ")
+	armaCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s) RETURNING id`,
+		armaTabela, armaCriaçãoCamposTexto, bd.MarcadoresPSQL(len(armaCriaçãoCampos)-1))
+
+	armaAtualizaçãoComando = fmt.Sprintf(`UPDATE %s SET
+	numero_serie = $1,
+	modelo = $2,
+	calibre = $3,
+	cr = $4,
+	id_clube = $5,
+	tipo_registro = $6,
+	data_atualizacao = $7,
+	revisao = $8
+	WHERE id = $9 AND revisao = $10`, armaTabela)
+
+	armaResgateCampos = []string{
+		"id",
+		"numero_serie",
+		"modelo",
+		"calibre",
+		"cr",
+		"id_clube",
+		"tipo_registro",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	armaResgateCamposTexto = strings.Join(armaResgateCampos, ", ")
+	armaResgateComando     = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
+		armaResgateCamposTexto, armaTabela)
+	armaResgatePorNúmeroSérieComando = fmt.Sprintf(`SELECT %s FROM %s WHERE numero_serie = $1`,
+		armaResgateCamposTexto, armaTabela)
+)
@@ -0,0 +1,370 @@
+package arma
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestArmaDAOImpl_criar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		arma         *arma
+		armaEsperada arma
+		erroEsperado error
+	}{
+		{
+			descrição: "deve criar corretamente a arma",
+			simulação: func() {
+				testdb.StubQuery(armaCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExec(armaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			arma: &arma{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				revisão:      2, // revisão sempre inicia com zero
+			},
+			armaEsperada: arma{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+				revisão:      0,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a arma não está definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao criar a arma",
+			simulação: func() {
+				testdb.StubQueryError(armaCriaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			arma: &arma{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				IDClube:      1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao gerar uma entrada de log",
+			simulação: func() {
+				testdb.StubQuery(armaCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExecError(armaLogCriaçãoComando, fmt.Errorf("erro na criação do log"))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			arma: &arma{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			erroEsperado: errors.Errorf("erro na criação do log"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaArmaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.criar(cenário.arma)
+
+		if cenário.arma != nil {
+			if cenário.arma.DataCriação.Before(cenário.armaEsperada.DataCriação) {
+				t.Errorf("Item %d, “%s”: data de criação inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.armaEsperada.DataCriação, cenário.arma.DataCriação)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de criação já
+			// que é definida no próprio método.
+			cenário.armaEsperada.DataCriação = cenário.arma.DataCriação
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.armaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.arma, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaDAOImpl_atualizar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		arma         *arma
+		armaEsperada arma
+		erroEsperado error
+	}{
+		{
+			descrição: "deve atualizar corretamente a arma",
+			simulação: func() {
+				testdb.StubExec(armaAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(armaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			arma: &arma{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				IDClube:      1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data.Add(-time.Hour),
+			},
+			armaEsperada: arma{
+				ID:              1,
+				NúmeroSérie:     "HG72643653",
+				Modelo:          "TAURUS PT 938",
+				Calibre:         ".380",
+				IDClube:         1,
+				TipoRegistro:    protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+				revisão:         1,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a arma não está definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a arma",
+			simulação: func() {
+				testdb.StubExecError(armaAtualizaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			arma: &arma{
+				ID:          1,
+				NúmeroSérie: "HG72643653",
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar quando a atualização não surtiu efeito",
+			simulação: func() {
+				testdb.StubExec(armaAtualizaçãoComando, testdb.NewResult(0, nil, 0, nil))
+			},
+			arma: &arma{
+				ID:          1,
+				NúmeroSérie: "HG72643653",
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: erros.NãoAtualizado,
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaArmaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.atualizar(cenário.arma)
+
+		if cenário.arma != nil {
+			if cenário.arma.DataAtualização.Before(cenário.armaEsperada.DataAtualização) {
+				t.Errorf("Item %d, “%s”: data de atualização inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.armaEsperada.DataAtualização, cenário.arma.DataAtualização)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de
+			// atualização já que é definida no próprio método.
+			cenário.armaEsperada.DataAtualização = cenário.arma.DataAtualização
+
+			if cenário.erroEsperado != nil {
+				cenário.armaEsperada = *cenário.arma
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.armaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.arma, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaDAOImpl_resgatar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		id           int64
+		armaEsperada arma
+		erroEsperado error
+	}{
+		{
+			descrição: "deve resgatar corretamente uma arma de um atirador",
+			simulação: func() {
+				testdb.StubQuery(armaResgateComando, testdb.RowsFromSlice(armaResgateCampos, [][]driver.Value{
+					{1, "HG72643653", "TAURUS PT 938", ".380", 380308, nil, "sigma", data, nil, 0},
+				}))
+			},
+			id: 1,
+			armaEsperada: arma{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve resgatar corretamente uma arma de um clube",
+			simulação: func() {
+				testdb.StubQuery(armaResgateComando, testdb.RowsFromSlice(armaResgateCampos, [][]driver.Value{
+					{1, "HG72643653", "TAURUS PT 938", ".380", nil, 2, "sinarm", data, data, 3},
+				}))
+			},
+			id: 1,
+			armaEsperada: arma{
+				ID:              1,
+				NúmeroSérie:     "HG72643653",
+				Modelo:          "TAURUS PT 938",
+				Calibre:         ".380",
+				IDClube:         2,
+				TipoRegistro:    protocolo.ArmaTipoRegistroSINARM,
+				DataCriação:     data,
+				DataAtualização: data,
+				revisão:         3,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar uma arma",
+			simulação: func() {
+				testdb.StubQueryError(armaResgateComando, fmt.Errorf("erro de execução"))
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaArmaDAO(bd.NovoSQLogger(conexão, nil))
+		a, err := dao.resgatar(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.armaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(a, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaDAOImpl_resgatarPorNúmeroSérie(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		númeroSérie  string
+		armaEsperada arma
+		erroEsperado error
+	}{
+		{
+			descrição: "deve resgatar corretamente uma arma pelo número de série",
+			simulação: func() {
+				testdb.StubQuery(armaResgatePorNúmeroSérieComando, testdb.RowsFromSlice(armaResgateCampos, [][]driver.Value{
+					{1, "HG72643653", "TAURUS PT 938", ".380", 380308, nil, "sigma", data, nil, 0},
+				}))
+			},
+			númeroSérie: "HG72643653",
+			armaEsperada: arma{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar uma arma pelo número de série",
+			simulação: func() {
+				testdb.StubQueryError(armaResgatePorNúmeroSérieComando, fmt.Errorf("erro de execução"))
+			},
+			númeroSérie:  "HG72643653",
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaArmaDAO(bd.NovoSQLogger(conexão, nil))
+		a, err := dao.resgatarPorNúmeroSérie(cenário.númeroSérie)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.armaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(a, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package arma
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+type armaLogDAO interface {
+	criar(arma, bd.AçãoLog) error
+}
+
+var novaArmaLogDAO = func(sqlogger *bd.SQLogger) armaLogDAO {
+	return armaLogDAOImpl{sqlogger: sqlogger}
+}
+
+type armaLogDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a armaLogDAOImpl) criar(arma arma, ação bd.AçãoLog) error {
+	if err := a.sqlogger.Gerar(); err != nil {
+		return erros.Novo(err)
+	}
+
+	cr, idClube := proprietárioBD(arma)
+	_, err := a.sqlogger.Exec(armaLogCriaçãoComando,
+		a.sqlogger.Log.ID,
+		ação,
+		arma.ID,
+		arma.NúmeroSérie,
+		arma.Modelo,
+		arma.Calibre,
+		cr,
+		idClube,
+		arma.TipoRegistro,
+		arma.DataCriação.UTC(),
+		arma.DataAtualização.UTC(),
+		arma.revisão,
+	)
+
+	return erros.Novo(err)
+}
+
+var (
+	armaLogTabela = "arma_log"
+
+	armaLogCriaçãoCampos = []string{
+		"id",
+		"id_log",
+		"acao",
+		"id_arma",
+		"numero_serie",
+		"modelo",
+		"calibre",
+		"cr",
+		"id_clube",
+		"tipo_registro",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	armaLogCriaçãoCamposTexto = strings.Join(armaLogCriaçãoCampos, ", ")
+	armaLogCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s)`,
+		armaLogTabela, armaLogCriaçãoCamposTexto, bd.MarcadoresPSQL(len(armaLogCriaçãoCampos)-1))
+)
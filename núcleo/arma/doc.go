@@ -0,0 +1,4 @@
+// Package arma provê os serviços necessários em torno do acervo de armas dos
+// Atiradores e Clubes de Tiro, utilizado para validar as armas informadas nas
+// frequências.
+package arma
@@ -0,0 +1,66 @@
+package arma
+
+import (
+	"strconv"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
+)
+
+// validarNúmeroSérieDisponível garante que não existe outra arma cadastrada
+// com o mesmo número de série. O número de identificação informado é ignorado
+// na busca, permitindo que uma arma seja atualizada mantendo o seu próprio
+// número de série.
+func validarNúmeroSérieDisponível(dao armaDAO, id int64, númeroSérie string) (protocolo.Mensagens, error) {
+	a, err := dao.resgatarPorNúmeroSérie(númeroSérie)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return nil, nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if a.ID != id {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, númeroSérie),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// validarClubeProprietário garante que o Clube de Tiro proprietário da arma
+// está cadastrado. Quando a arma pertence a um atirador nada é verificado.
+func validarClubeProprietário(serviçoClube clube.Serviço, idClube int64) (protocolo.Mensagens, error) {
+	if idClube <= 0 {
+		return nil, nil
+	}
+
+	_, err := serviçoClube.ObterClube(idClube)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInválido, strconv.FormatInt(idClube, 10)),
+		), nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return nil, nil
+}
+
+// validarCalibre garante que o calibre da arma consta no catálogo de calibres.
+// Retorna o nome canônico do calibre para que seja armazenado no acervo.
+func validarCalibre(serviçoCalibre calibre.Serviço, nome string) (string, protocolo.Mensagens, error) {
+	c, err := serviçoCalibre.ResolverCalibre(nome)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return "", protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, nome),
+		), nil
+	} else if err != nil {
+		return "", nil, erros.Novo(err)
+	}
+
+	return c.Nome, nil, nil
+}
@@ -0,0 +1,134 @@
+package arma
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// Serviço disponibiliza as ações que podem ser feitas relacionadas ao acervo
+// de armas.
+type Serviço interface {
+	// CadastrarArma persiste em banco de dados uma nova arma no acervo. Não é
+	// permitido cadastrar duas armas com o mesmo número de série. O calibre é
+	// armazenado com o nome canônico do catálogo de calibres.
+	CadastrarArma(protocolo.ArmaPedido) (protocolo.ArmaResposta, error)
+
+	// ObterArma retorna os dados da arma a partir do seu número de
+	// identificação.
+	ObterArma(id int64) (protocolo.ArmaResposta, error)
+
+	// ObterArmaPorNúmeroSérie retorna os dados da arma a partir do seu número
+	// de série. Utilizado para identificar a arma informada nas frequências.
+	ObterArmaPorNúmeroSérie(númeroSérie string) (protocolo.ArmaResposta, error)
+
+	// AtualizarArma substitui os dados da arma pelos dados informados. É
+	// através desta ação que uma arma é transferida para outro proprietário.
+	AtualizarArma(protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error)
+}
+
+// NovoServiço inicializa um serviço concreto do acervo de armas. Pode ser
+// substituído em testes por simuladores, permitindo uma abstração da camada de
+// serviços.
+var NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) Serviço {
+	return serviço{
+		sqlogger:     s,
+		logger:       l,
+		configuração: configuração,
+	}
+}
+
+type serviço struct {
+	sqlogger     *bd.SQLogger
+	logger       log.Serviço
+	configuração config.Configuração
+}
+
+func (s serviço) CadastrarArma(armaPedido protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+	dao := novaArmaDAO(s.sqlogger)
+	serviçoClube := clube.NovoServiço(s.sqlogger, s.logger, s.configuração)
+
+	if mensagens, err := s.validar(dao, serviçoClube, 0, &armaPedido); err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.ArmaResposta{}, mensagens
+	}
+
+	a := novaArma(armaPedido)
+	if err := dao.criar(&a); err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) ObterArma(id int64) (protocolo.ArmaResposta, error) {
+	dao := novaArmaDAO(s.sqlogger)
+	a, err := dao.resgatar(id)
+	if err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) ObterArmaPorNúmeroSérie(númeroSérie string) (protocolo.ArmaResposta, error) {
+	dao := novaArmaDAO(s.sqlogger)
+	a, err := dao.resgatarPorNúmeroSérie(númeroSérie)
+	if err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) AtualizarArma(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+	dao := novaArmaDAO(s.sqlogger)
+	a, err := dao.resgatar(armaPedidoCompleto.ID)
+	if err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	}
+
+	serviçoClube := clube.NovoServiço(s.sqlogger, s.logger, s.configuração)
+	if mensagens, err := s.validar(dao, serviçoClube, a.ID, &armaPedidoCompleto.ArmaPedido); err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.ArmaResposta{}, mensagens
+	}
+
+	a.preencher(armaPedidoCompleto.ArmaPedido)
+	if err := dao.atualizar(&a); err != nil {
+		return protocolo.ArmaResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+// validar executa as regras de negócio comuns ao cadastro e à atualização de
+// uma arma. Quando o calibre é encontrado no catálogo, o calibre do pedido é
+// substituído pelo seu nome canônico.
+func (s serviço) validar(dao armaDAO, serviçoClube clube.Serviço, id int64, armaPedido *protocolo.ArmaPedido) (protocolo.Mensagens, error) {
+	serviçoCalibre := calibre.NovoServiço(s.sqlogger, s.logger, s.configuração)
+	nomeCalibre, mensagensCalibre, err := validarCalibre(serviçoCalibre, armaPedido.Calibre)
+	if err != nil {
+		return nil, erros.Novo(err)
+	} else if len(mensagensCalibre) == 0 {
+		armaPedido.Calibre = nomeCalibre
+	}
+
+	mensagensNúmeroSérie, err := validarNúmeroSérieDisponível(dao, id, armaPedido.NúmeroSérie)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	mensagensClube, err := validarClubeProprietário(serviçoClube, armaPedido.Clube)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return protocolo.JuntarMensagens(mensagensNúmeroSérie, mensagensCalibre, mensagensClube), nil
+}
@@ -0,0 +1,569 @@
+package arma
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestServiço_CadastrarArma(t *testing.T) {
+	data := time.Now()
+
+	serviçoClubeExistente := simulador.ServiçoClube{
+		SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+			return protocolo.ClubeResposta{ID: id}, nil
+		},
+	}
+
+	serviçoCalibreConhecido := simulador.ServiçoCalibre{
+		SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+			if nome == ".380" {
+				return protocolo.CalibreResposta{ID: 1, Nome: ".380 ACP", Classe: protocolo.CalibreClassePermitido}, nil
+			}
+			return protocolo.CalibreResposta{}, erros.NãoEncontrado
+		},
+	}
+
+	cenários := []struct {
+		descrição      string
+		armaPedido     protocolo.ArmaPedido
+		serviçoClube   clube.Serviço
+		serviçoCalibre calibre.Serviço
+		armaDAO        armaDAO
+		esperado       protocolo.ArmaResposta
+		erroEsperado   error
+	}{
+		{
+			descrição: "deve cadastrar corretamente uma arma de um atirador",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *arma) error {
+					a.ID = 1
+					a.DataCriação = data
+					return nil
+				},
+			},
+			esperado: protocolo.ArmaResposta{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380 ACP",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve cadastrar corretamente uma arma de um clube",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+			},
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *arma) error {
+					a.ID = 1
+					a.DataCriação = data
+					return nil
+				},
+			},
+			esperado: protocolo.ArmaResposta{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380 ACP",
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o número de série já está cadastrado e o clube não existe",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				Clube:       2,
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, erros.NãoEncontrado
+				},
+			},
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{ID: 2, NúmeroSérie: númeroSérie}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInválido, "2"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao verificar o número de série",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+		{
+			descrição: "deve detectar um erro ao verificar o clube",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Clube:       1,
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro ao obter o clube")
+				},
+			},
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao obter o clube"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar a arma",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				CR:          380308,
+			},
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *arma) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+		{
+			descrição: "deve detectar um calibre desconhecido",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".999",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, ".999"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resolver o calibre",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				CR:          380308,
+			},
+			serviçoClube: serviçoClubeExistente,
+			serviçoCalibre: simulador.ServiçoCalibre{
+				SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+					return protocolo.CalibreResposta{}, errors.Errorf("erro ao resolver o calibre")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao resolver o calibre"),
+		},
+	}
+
+	daoOriginal := novaArmaDAO
+	serviçoClubeOriginal := clube.NovoServiço
+	serviçoCalibreOriginal := calibre.NovoServiço
+	defer func() {
+		novaArmaDAO = daoOriginal
+		clube.NovoServiço = serviçoClubeOriginal
+		calibre.NovoServiço = serviçoCalibreOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaArmaDAO = func(sqlogger *bd.SQLogger) armaDAO {
+			return cenário.armaDAO
+		}
+
+		clube.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		calibre.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) calibre.Serviço {
+			return cenário.serviçoCalibre
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.CadastrarArma(cenário.armaPedido)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterArma(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		id           int64
+		armaDAO      armaDAO
+		esperado     protocolo.ArmaResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve obter corretamente uma arma",
+			id:        1,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{
+						ID:           id,
+						NúmeroSérie:  "HG72643653",
+						Modelo:       "TAURUS PT 938",
+						Calibre:      ".380",
+						CR:           380308,
+						TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+						DataCriação:  data,
+					}, nil
+				},
+			},
+			esperado: protocolo.ArmaResposta{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a arma não existe",
+			id:        1,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+	}
+
+	daoOriginal := novaArmaDAO
+	defer func() {
+		novaArmaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaArmaDAO = func(sqlogger *bd.SQLogger) armaDAO {
+			return cenário.armaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ObterArma(cenário.id)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterArmaPorNúmeroSérie(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		númeroSérie  string
+		armaDAO      armaDAO
+		esperado     protocolo.ArmaResposta
+		erroEsperado error
+	}{
+		{
+			descrição:   "deve obter corretamente uma arma pelo número de série",
+			númeroSérie: "HG72643653",
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{
+						ID:           1,
+						NúmeroSérie:  númeroSérie,
+						Modelo:       "TAURUS PT 938",
+						Calibre:      ".380",
+						IDClube:      1,
+						TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+						DataCriação:  data,
+					}, nil
+				},
+			},
+			esperado: protocolo.ArmaResposta{
+				ID:           1,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição:   "deve detectar quando a arma não existe",
+			númeroSérie: "HG72643653",
+			armaDAO: simulaArmaDAO{
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+	}
+
+	daoOriginal := novaArmaDAO
+	defer func() {
+		novaArmaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaArmaDAO = func(sqlogger *bd.SQLogger) armaDAO {
+			return cenário.armaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ObterArmaPorNúmeroSérie(cenário.númeroSérie)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_AtualizarArma(t *testing.T) {
+	data := time.Now()
+
+	serviçoClubeExistente := simulador.ServiçoClube{
+		SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+			return protocolo.ClubeResposta{ID: id}, nil
+		},
+	}
+
+	serviçoCalibreConhecido := simulador.ServiçoCalibre{
+		SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+			if nome == ".380" {
+				return protocolo.CalibreResposta{ID: 1, Nome: ".380 ACP", Classe: protocolo.CalibreClassePermitido}, nil
+			}
+			return protocolo.CalibreResposta{}, erros.NãoEncontrado
+		},
+	}
+
+	cenários := []struct {
+		descrição          string
+		armaPedidoCompleto protocolo.ArmaPedidoCompleto
+		serviçoClube       clube.Serviço
+		serviçoCalibre     calibre.Serviço
+		armaDAO            armaDAO
+		esperado           protocolo.ArmaResposta
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve transferir corretamente uma arma para um clube",
+			armaPedidoCompleto: protocolo.NovoArmaPedidoCompleto(1, protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			}),
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{
+						ID:           id,
+						NúmeroSérie:  "HG72643653",
+						Modelo:       "TAURUS PT 938",
+						Calibre:      ".380",
+						CR:           380308,
+						TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+						DataCriação:  data,
+					}, nil
+				},
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{ID: 1, NúmeroSérie: númeroSérie}, nil
+				},
+				simulaAtualizar: func(a *arma) error {
+					a.DataAtualização = data
+					return nil
+				},
+			},
+			esperado: protocolo.ArmaResposta{
+				ID:              1,
+				NúmeroSérie:     "HG72643653",
+				Modelo:          "TAURUS PT 938",
+				Calibre:         ".380 ACP",
+				Clube:           1,
+				TipoRegistro:    protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:     data,
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o novo número de série pertence a outra arma",
+			armaPedidoCompleto: protocolo.NovoArmaPedidoCompleto(1, protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				CR:          380308,
+			}),
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{ID: id, NúmeroSérie: "HG11111111"}, nil
+				},
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{ID: 2, NúmeroSérie: númeroSérie}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+			},
+		},
+		{
+			descrição: "deve detectar quando a arma não existe",
+			armaPedidoCompleto: protocolo.NovoArmaPedidoCompleto(1, protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			}),
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a arma",
+			armaPedidoCompleto: protocolo.NovoArmaPedidoCompleto(1, protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				CR:          380308,
+			}),
+			serviçoClube:   serviçoClubeExistente,
+			serviçoCalibre: serviçoCalibreConhecido,
+			armaDAO: simulaArmaDAO{
+				simulaResgatar: func(id int64) (arma, error) {
+					return arma{ID: id, NúmeroSérie: "HG72643653"}, nil
+				},
+				simulaResgatarPorNúmeroSérie: func(númeroSérie string) (arma, error) {
+					return arma{ID: 1, NúmeroSérie: númeroSérie}, nil
+				},
+				simulaAtualizar: func(a *arma) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+	}
+
+	daoOriginal := novaArmaDAO
+	serviçoClubeOriginal := clube.NovoServiço
+	serviçoCalibreOriginal := calibre.NovoServiço
+	defer func() {
+		novaArmaDAO = daoOriginal
+		clube.NovoServiço = serviçoClubeOriginal
+		calibre.NovoServiço = serviçoCalibreOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaArmaDAO = func(sqlogger *bd.SQLogger) armaDAO {
+			return cenário.armaDAO
+		}
+
+		clube.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		calibre.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) calibre.Serviço {
+			return cenário.serviçoCalibre
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.AtualizarArma(cenário.armaPedidoCompleto)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type simulaArmaDAO struct {
+	simulaCriar                  func(*arma) error
+	simulaAtualizar              func(*arma) error
+	simulaResgatar               func(id int64) (arma, error)
+	simulaResgatarPorNúmeroSérie func(númeroSérie string) (arma, error)
+}
+
+func (s simulaArmaDAO) criar(a *arma) error {
+	return s.simulaCriar(a)
+}
+
+func (s simulaArmaDAO) atualizar(a *arma) error {
+	return s.simulaAtualizar(a)
+}
+
+func (s simulaArmaDAO) resgatar(id int64) (arma, error) {
+	return s.simulaResgatar(id)
+}
+
+func (s simulaArmaDAO) resgatarPorNúmeroSérie(númeroSérie string) (arma, error) {
+	return s.simulaResgatarPorNúmeroSérie(númeroSérie)
+}
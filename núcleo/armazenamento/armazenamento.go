@@ -0,0 +1,57 @@
+// Package armazenamento disponibiliza repositórios de objetos binários
+// endereçados pelo conteúdo, utilizados para guardar as imagens das frequências
+// fora do banco de dados relacional. Um repositório global também está
+// disponível neste pacote.
+package armazenamento
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/registrobr/gostk/errors"
+)
+
+// Atual armazena o repositório global de objetos do sistema. Todas as imagens
+// devem ser gravadas e obtidas a partir desta variável, que deve ser
+// inicializada antes de atender as requisições.
+var Atual Armazenamento
+
+// Armazenamento representa um repositório de objetos binários. Cada objeto é
+// identificado pela referência derivada do seu conteúdo, de forma que gravar
+// o mesmo conteúdo mais de uma vez não ocupa espaço adicional.
+type Armazenamento interface {
+	// Armazenar grava o conteúdo no repositório, retornando a referência que
+	// permite obtê-lo posteriormente.
+	Armazenar(conteúdo []byte) (referência string, err error)
+
+	// Obter retorna o conteúdo associado a referência. Quando não existir um
+	// objeto com a referência o erro erros.NãoEncontrado é retornado.
+	Obter(referência string) ([]byte, error)
+}
+
+// ReferênciaInválida erro utilizado quando a referência informada não possui o
+// formato de um hash SHA-256 em hexadecimal.
+var ReferênciaInválida = errors.Errorf("Referência de objeto inválida")
+
+// Referência calcula a referência de um conteúdo, que é o hash SHA-256 em
+// hexadecimal com letras minúsculas.
+func Referência(conteúdo []byte) string {
+	hash := sha256.Sum256(conteúdo)
+	return hex.EncodeToString(hash[:])
+}
+
+// validarReferência garante que a referência é um hash SHA-256 em hexadecimal,
+// evitando que uma referência adulterada acesse objetos fora do repositório.
+func validarReferência(referência string) error {
+	if len(referência) != sha256.Size*2 {
+		return ReferênciaInválida
+	}
+
+	for _, caractere := range referência {
+		if (caractere < '0' || caractere > '9') && (caractere < 'a' || caractere > 'f') {
+			return ReferênciaInválida
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package armazenamento_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/armazenamento"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestReferência(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		conteúdo  []byte
+		esperado  string
+	}{
+		{
+			descrição: "deve calcular a referência de um conteúdo",
+			conteúdo:  []byte("imagem de teste"),
+			esperado:  "7586cf037e995223a49c28ef4efbe52cab2a56b35ad6072b7d003b317664ea67",
+		},
+		{
+			descrição: "deve calcular a referência de um conteúdo vazio",
+			conteúdo:  []byte{},
+			esperado:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+
+	for i, cenário := range cenários {
+		if obtido := armazenamento.Referência(cenário.conteúdo); obtido != cenário.esperado {
+			t.Errorf("Item %d, “%s”: referência inesperada. Esperado “%s”; encontrado “%s”",
+				i, cenário.descrição, cenário.esperado, obtido)
+		}
+	}
+}
+
+func TestLocal(t *testing.T) {
+	diretório, err := ioutil.TempDir("", "atirador-frequente-")
+	if err != nil {
+		t.Fatalf("Erro ao criar o diretório temporário. Detalhes: %s", err)
+	}
+	defer os.RemoveAll(diretório)
+
+	verificarArmazenamento(t, armazenamento.NovoLocal(diretório))
+
+	referência := armazenamento.Referência([]byte("imagem de teste"))
+	caminho := filepath.Join(diretório, referência[0:2], referência[2:4], referência)
+	if _, err := os.Stat(caminho); err != nil {
+		t.Errorf("Objeto não encontrado no caminho esperado “%s”. Detalhes: %s", caminho, err)
+	}
+}
+
+func TestS3(t *testing.T) {
+	var repositório repositórioS3
+	servidor := httptest.NewServer(&repositório)
+	defer servidor.Close()
+
+	s3, err := armazenamento.NovoS3(armazenamento.ParâmetrosS3{
+		Endereço:     servidor.URL,
+		Região:       "us-east-1",
+		Balde:        "imagens",
+		ChaveAcesso:  "chave",
+		ChaveSecreta: "segredo",
+	})
+
+	if err != nil {
+		t.Fatalf("Erro ao inicializar o repositório S3. Detalhes: %s", err)
+	}
+
+	verificarArmazenamento(t, s3)
+
+	if repositório.gravações != 1 {
+		t.Errorf("Quantidade de gravações inesperada. Esperado 1; encontrado %d", repositório.gravações)
+	}
+}
+
+// verificarArmazenamento executa os mesmos cenários em qualquer implementação
+// do repositório de objetos.
+func verificarArmazenamento(t *testing.T, a armazenamento.Armazenamento) {
+	conteúdo := []byte("imagem de teste")
+
+	referência, err := a.Armazenar(conteúdo)
+	if err != nil {
+		t.Fatalf("Erro ao armazenar o objeto. Detalhes: %s", err)
+	}
+
+	if esperado := armazenamento.Referência(conteúdo); referência != esperado {
+		t.Errorf("Referência inesperada. Esperado “%s”; encontrado “%s”", esperado, referência)
+	}
+
+	// gravar novamente o mesmo conteúdo deve manter a mesma referência
+	if novaReferência, err := a.Armazenar(conteúdo); err != nil {
+		t.Errorf("Erro ao armazenar novamente o objeto. Detalhes: %s", err)
+	} else if novaReferência != referência {
+		t.Errorf("Referência alterada. Esperado “%s”; encontrado “%s”", referência, novaReferência)
+	}
+
+	cenários := []struct {
+		descrição    string
+		referência   string
+		esperado     []byte
+		erroEsperado error
+	}{
+		{
+			descrição:  "deve obter um objeto armazenado",
+			referência: referência,
+			esperado:   conteúdo,
+		},
+		{
+			descrição:    "deve detectar um objeto inexistente",
+			referência:   armazenamento.Referência([]byte("outra imagem")),
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição:    "deve detectar uma referência inválida",
+			referência:   "../" + referência[3:],
+			erroEsperado: armazenamento.ReferênciaInválida,
+		},
+		{
+			descrição:    "deve detectar uma referência com letras maiúsculas",
+			referência:   strings.ToUpper(referência),
+			erroEsperado: armazenamento.ReferênciaInválida,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(a.Obter(cenário.referência)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// repositórioS3 simula um serviço compatível com o S3, respondendo somente as
+// operações utilizadas pelo repositório de objetos.
+type repositórioS3 struct {
+	sync.Mutex
+	objetos   map[string][]byte
+	gravações int
+}
+
+func (r *repositórioS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.objetos == nil {
+		r.objetos = make(map[string][]byte)
+	}
+
+	switch req.Method {
+	case "HEAD":
+		if _, ok := r.objetos[req.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+	case "PUT":
+		conteúdo, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		r.objetos[req.URL.Path] = conteúdo
+		r.gravações++
+
+	case "GET":
+		conteúdo, ok := r.objetos[req.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		w.Write(conteúdo)
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,81 @@
+package armazenamento
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+// NovoLocal inicializa um repositório que grava os objetos no sistema de
+// arquivos, abaixo do diretório informado. Os objetos são distribuídos em
+// subdiretórios formados pelos primeiros caracteres da referência, evitando
+// diretórios com uma quantidade excessiva de arquivos.
+func NovoLocal(diretório string) Armazenamento {
+	return local{diretório: diretório}
+}
+
+type local struct {
+	diretório string
+}
+
+func (l local) Armazenar(conteúdo []byte) (string, error) {
+	referência := Referência(conteúdo)
+	caminho := l.caminho(referência)
+
+	// como o caminho é derivado do conteúdo, um arquivo existente já possui
+	// exatamente os mesmos dados
+	if _, err := os.Stat(caminho); err == nil {
+		return referência, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(caminho), 0750); err != nil {
+		return "", erros.Novo(err)
+	}
+
+	// o conteúdo é gravado em um arquivo temporário no mesmo diretório e depois
+	// renomeado, evitando que uma leitura concorrente encontre o arquivo
+	// parcialmente escrito
+	arquivo, err := ioutil.TempFile(filepath.Dir(caminho), "."+referência+"-")
+	if err != nil {
+		return "", erros.Novo(err)
+	}
+
+	if _, err := arquivo.Write(conteúdo); err != nil {
+		arquivo.Close()
+		os.Remove(arquivo.Name())
+		return "", erros.Novo(err)
+	}
+
+	if err := arquivo.Close(); err != nil {
+		os.Remove(arquivo.Name())
+		return "", erros.Novo(err)
+	}
+
+	if err := os.Rename(arquivo.Name(), caminho); err != nil {
+		os.Remove(arquivo.Name())
+		return "", erros.Novo(err)
+	}
+
+	return referência, nil
+}
+
+func (l local) Obter(referência string) ([]byte, error) {
+	if err := validarReferência(referência); err != nil {
+		return nil, err
+	}
+
+	conteúdo, err := ioutil.ReadFile(l.caminho(referência))
+	if os.IsNotExist(err) {
+		return nil, erros.NãoEncontrado
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return conteúdo, nil
+}
+
+func (l local) caminho(referência string) string {
+	return filepath.Join(l.diretório, referência[0:2], referência[2:4], referência)
+}
@@ -0,0 +1,138 @@
+package armazenamento
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+// ParâmetrosS3 define as informações de acesso a um repositório compatível com
+// o protocolo do Amazon S3.
+type ParâmetrosS3 struct {
+	// Endereço URL do serviço compatível com o S3, como um MinIO ou Ceph. Quando
+	// não informado o serviço da Amazon é utilizado.
+	Endereço string
+
+	// Região onde o balde foi criado.
+	Região string
+
+	// Balde nome do balde (bucket) onde os objetos são gravados.
+	Balde string
+
+	// ChaveAcesso identificador da chave de acesso. Quando não informado as
+	// credenciais são obtidas do ambiente, como variáveis ou perfil da
+	// instância.
+	ChaveAcesso string
+
+	// ChaveSecreta segredo da chave de acesso.
+	ChaveSecreta string
+}
+
+// NovoS3 inicializa um repositório que grava os objetos em um serviço
+// compatível com o Amazon S3, utilizando a referência como nome do objeto.
+func NovoS3(parâmetros ParâmetrosS3) (Armazenamento, error) {
+	configuração := aws.NewConfig().WithRegion(parâmetros.Região)
+
+	// serviços compatíveis normalmente não suportam o balde como subdomínio
+	if parâmetros.Endereço != "" {
+		configuração = configuração.
+			WithEndpoint(parâmetros.Endereço).
+			WithS3ForcePathStyle(true)
+	}
+
+	if parâmetros.ChaveAcesso != "" {
+		configuração = configuração.WithCredentials(
+			credentials.NewStaticCredentials(parâmetros.ChaveAcesso, parâmetros.ChaveSecreta, ""),
+		)
+	}
+
+	sessão, err := session.NewSession(configuração)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return objetosS3{
+		cliente: s3.New(sessão),
+		balde:   parâmetros.Balde,
+	}, nil
+}
+
+type objetosS3 struct {
+	cliente *s3.S3
+	balde   string
+}
+
+func (o objetosS3) Armazenar(conteúdo []byte) (string, error) {
+	referência := Referência(conteúdo)
+
+	// evita transferir novamente um conteúdo que já está no repositório
+	_, err := o.cliente.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(o.balde),
+		Key:    aws.String(referência),
+	})
+
+	if err == nil {
+		return referência, nil
+	} else if !objetoInexistente(err) {
+		return "", erros.Novo(err)
+	}
+
+	_, err = o.cliente.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(o.balde),
+		Key:         aws.String(referência),
+		Body:        bytes.NewReader(conteúdo),
+		ContentType: aws.String(http.DetectContentType(conteúdo)),
+	})
+
+	if err != nil {
+		return "", erros.Novo(err)
+	}
+
+	return referência, nil
+}
+
+func (o objetosS3) Obter(referência string) ([]byte, error) {
+	if err := validarReferência(referência); err != nil {
+		return nil, err
+	}
+
+	resultado, err := o.cliente.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(o.balde),
+		Key:    aws.String(referência),
+	})
+
+	if objetoInexistente(err) {
+		return nil, erros.NãoEncontrado
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer resultado.Body.Close()
+
+	conteúdo, err := ioutil.ReadAll(resultado.Body)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return conteúdo, nil
+}
+
+// objetoInexistente identifica o erro do serviço quando o objeto não existe.
+// Consultas sem corpo de resposta, como a do HEAD, só informam o código HTTP.
+func objetoInexistente(err error) bool {
+	if falha, ok := err.(awserr.RequestFailure); ok && falha.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	if falha, ok := err.(awserr.Error); ok && falha.Code() == "NoSuchKey" {
+		return true
+	}
+
+	return false
+}
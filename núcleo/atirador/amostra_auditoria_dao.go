@@ -0,0 +1,84 @@
+package atirador
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+type amostraAuditoriaDAO interface {
+	criar(*amostraAuditoria) error
+	resgatar(id int64) (amostraAuditoria, error)
+}
+
+var novaAmostraAuditoriaDAO = func(sqlogger *bd.SQLogger) amostraAuditoriaDAO {
+	return amostraAuditoriaDAOImpl{sqlogger: sqlogger}
+}
+
+type amostraAuditoriaDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+// criar persiste os critérios e a semente do sorteio. A amostra não é alterada
+// após a criação, por isso não possui controle de revisão nem log próprio; as
+// alterações ficam registradas no log de cada auditoria.
+func (a amostraAuditoriaDAOImpl) criar(amostraAuditoria *amostraAuditoria) error {
+	if amostraAuditoria == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	amostraAuditoria.DataCriação = time.Now().UTC()
+
+	resultado := a.sqlogger.QueryRow(amostraAuditoriaCriaçãoComando,
+		amostraAuditoria.DataInício.UTC(),
+		amostraAuditoria.DataTérmino.UTC(),
+		amostraAuditoria.Percentual,
+		amostraAuditoria.QuantidadePorClube,
+		amostraAuditoria.Semente,
+		amostraAuditoria.DataCriação.UTC(),
+	)
+
+	return erros.Novo(resultado.Scan(&amostraAuditoria.ID))
+}
+
+func (a amostraAuditoriaDAOImpl) resgatar(id int64) (amostraAuditoria, error) {
+	resultado := a.sqlogger.QueryRow(amostraAuditoriaResgateComando, id)
+
+	var amostra amostraAuditoria
+	err := resultado.Scan(
+		&amostra.ID,
+		&amostra.DataInício,
+		&amostra.DataTérmino,
+		&amostra.Percentual,
+		&amostra.QuantidadePorClube,
+		&amostra.Semente,
+		&amostra.DataCriação,
+	)
+
+	return amostra, erros.Novo(err)
+}
+
+var (
+	amostraAuditoriaTabela = "amostra_auditoria"
+
+	amostraAuditoriaCriaçãoCampos = []string{
+		"id",
+		"data_inicio",
+		"data_termino",
+		"percentual",
+		"quantidade_por_clube",
+		"semente",
+		"data_criacao",
+	}
+	amostraAuditoriaCriaçãoCamposTexto = strings.Join(amostraAuditoriaCriaçãoCampos, ", ")
+	amostraAuditoriaCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s) RETURNING id`,
+		amostraAuditoriaTabela, amostraAuditoriaCriaçãoCamposTexto, bd.MarcadoresPSQL(len(amostraAuditoriaCriaçãoCampos)-1))
+
+	amostraAuditoriaResgateCampos      = amostraAuditoriaCriaçãoCampos
+	amostraAuditoriaResgateCamposTexto = strings.Join(amostraAuditoriaResgateCampos, ", ")
+	amostraAuditoriaResgateComando     = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
+		amostraAuditoriaResgateCamposTexto, amostraAuditoriaTabela)
+)
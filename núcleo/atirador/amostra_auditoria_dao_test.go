@@ -0,0 +1,154 @@
+package atirador
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestAmostraAuditoriaDAOImpl_criar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição       string
+		simulação       func()
+		amostra         *amostraAuditoria
+		amostraEsperada amostraAuditoria
+		erroEsperado    error
+	}{
+		{
+			descrição: "deve criar corretamente a amostra",
+			simulação: func() {
+				testdb.StubQuery(amostraAuditoriaCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			amostra: &amostraAuditoria{
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+			},
+			amostraEsperada: amostraAuditoria{
+				ID:          1,
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a amostra não está definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao criar a amostra",
+			simulação: func() {
+				testdb.StubQueryError(amostraAuditoriaCriaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			amostra: &amostraAuditoria{
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaAmostraAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.criar(cenário.amostra)
+
+		if cenário.amostra != nil {
+			if cenário.amostra.DataCriação.Before(cenário.amostraEsperada.DataCriação) {
+				t.Errorf("Item %d, “%s”: data de criação inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.amostraEsperada.DataCriação, cenário.amostra.DataCriação)
+			}
+
+			// a data de criação é definida no próprio método, por isso ela é igualada
+			// após a comparação para verificar os demais campos
+			cenário.amostraEsperada.DataCriação = cenário.amostra.DataCriação
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.amostraEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.amostra, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAmostraAuditoriaDAOImpl_resgatar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição       string
+		simulação       func()
+		id              int64
+		amostraEsperada amostraAuditoria
+		erroEsperado    error
+	}{
+		{
+			descrição: "deve resgatar corretamente uma amostra",
+			simulação: func() {
+				testdb.StubQuery(amostraAuditoriaResgateComando, testdb.RowsFromSlice(amostraAuditoriaResgateCampos, [][]driver.Value{
+					{1, data.AddDate(0, -1, 0), data, 0, 5, 42, data},
+				}))
+			},
+			id: 1,
+			amostraEsperada: amostraAuditoria{
+				ID:                 1,
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 5,
+				Semente:            42,
+				DataCriação:        data,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar uma amostra",
+			simulação: func() {
+				testdb.StubQueryError(amostraAuditoriaResgateComando, fmt.Errorf("erro de execução"))
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaAmostraAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		amostra, err := dao.resgatar(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.amostraEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(amostra, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package atirador
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type atirador struct {
+	ID              int64
+	CR              int
+	Nome            string
+	CPF             string
+	DataEmissão     time.Time
+	DataValidade    time.Time
+	Situação        protocolo.AtiradorSituação
+	DataCriação     time.Time
+	DataAtualização time.Time
+
+	// revisão utilizado para o controle de versão do objeto na base de dados,
+	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
+	// objeto.
+	revisão int
+}
+
+func novoAtirador(atiradorPedido protocolo.AtiradorPedido) atirador {
+	a := atirador{}
+	a.preencher(atiradorPedido)
+	return a
+}
+
+// preencher copia os dados do pedido para o atirador. Quando a situação não é
+// informada o atirador é considerado ativo.
+func (a *atirador) preencher(atiradorPedido protocolo.AtiradorPedido) {
+	a.CR = atiradorPedido.CR
+	a.Nome = atiradorPedido.Nome
+	a.CPF = atiradorPedido.CPF
+	a.DataEmissão = atiradorPedido.DataEmissão
+	a.DataValidade = atiradorPedido.DataValidade
+	a.Situação = atiradorPedido.Situação
+
+	if a.Situação == "" {
+		a.Situação = protocolo.AtiradorSituaçãoAtivo
+	}
+}
+
+func (a atirador) protocolo() protocolo.AtiradorResposta {
+	return protocolo.AtiradorResposta{
+		CR:              a.CR,
+		Nome:            a.Nome,
+		CPF:             a.CPF,
+		DataEmissão:     a.DataEmissão,
+		DataValidade:    a.DataValidade,
+		Situação:        a.Situação,
+		DataCriação:     a.DataCriação,
+		DataAtualização: a.DataAtualização,
+	}
+}
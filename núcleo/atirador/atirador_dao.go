@@ -0,0 +1,200 @@
+package atirador
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type atiradorDAO interface {
+	criar(*atirador) error
+	atualizar(*atirador) error
+	remover(*atirador) error
+	resgatarPorCR(cr int) (atirador, error)
+}
+
+var novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+	return atiradorDAOImpl{sqlogger: sqlogger}
+}
+
+type atiradorDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a atiradorDAOImpl) criar(atirador *atirador) error {
+	if atirador == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	atirador.DataCriação = time.Now().UTC()
+	atirador.revisão = 0
+
+	resultado := a.sqlogger.QueryRow(atiradorCriaçãoComando,
+		atirador.CR,
+		atirador.Nome,
+		atirador.CPF,
+		atirador.DataEmissão.UTC(),
+		atirador.DataValidade.UTC(),
+		atirador.Situação,
+		atirador.DataCriação.UTC(),
+		atirador.revisão,
+	)
+
+	if err := resultado.Scan(&atirador.ID); err != nil {
+		return erros.Novo(err)
+	}
+
+	atiradorLogDAO := novoAtiradorLogDAO(a.sqlogger)
+	return erros.Novo(atiradorLogDAO.criar(*atirador, bd.AçãoLogCriação))
+}
+
+func (a atiradorDAOImpl) atualizar(atirador *atirador) error {
+	if atirador == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	atirador.DataAtualização = time.Now().UTC()
+	atirador.revisão++
+
+	resultado, err := a.sqlogger.Exec(atiradorAtualizaçãoComando,
+		atirador.CR,
+		atirador.Nome,
+		atirador.CPF,
+		atirador.DataEmissão.UTC(),
+		atirador.DataValidade.UTC(),
+		atirador.Situação,
+		atirador.DataAtualização.UTC(),
+		atirador.revisão,
+		atirador.ID,
+		atirador.revisão-1,
+	)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	atualizados, err := resultado.RowsAffected()
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if atualizados != 1 {
+		return erros.NãoAtualizado
+	}
+
+	atiradorLogDAO := novoAtiradorLogDAO(a.sqlogger)
+	return erros.Novo(atiradorLogDAO.criar(*atirador, bd.AçãoLogAtualização))
+}
+
+// remover apaga o atirador da base de dados. O último estado do atirador é
+// mantido somente no log, permitindo a auditoria da remoção.
+func (a atiradorDAOImpl) remover(atirador *atirador) error {
+	if atirador == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	resultado, err := a.sqlogger.Exec(atiradorRemoçãoComando,
+		atirador.ID,
+		atirador.revisão,
+	)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	removidos, err := resultado.RowsAffected()
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if removidos != 1 {
+		return erros.NãoAtualizado
+	}
+
+	atiradorLogDAO := novoAtiradorLogDAO(a.sqlogger)
+	return erros.Novo(atiradorLogDAO.criar(*atirador, bd.AçãoLogRemoção))
+}
+
+func (a atiradorDAOImpl) resgatarPorCR(cr int) (atirador, error) {
+	resultado := a.sqlogger.QueryRow(atiradorResgatePorCRComando, cr)
+
+	var atr atirador
+	var situação string
+	var dataAtualização pq.NullTime
+
+	err := resultado.Scan(
+		&atr.ID,
+		&atr.CR,
+		&atr.Nome,
+		&atr.CPF,
+		&atr.DataEmissão,
+		&atr.DataValidade,
+		&situação,
+		&atr.DataCriação,
+		&dataAtualização,
+		&atr.revisão,
+	)
+
+	atr.Situação = protocolo.AtiradorSituação(situação)
+
+	if dataAtualização.Valid {
+		atr.DataAtualização = dataAtualização.Time
+	}
+
+	return atr, erros.Novo(err)
+}
+
+var (
+	atiradorTabela = "atirador"
+
+	atiradorCriaçãoCampos = []string{
+		"id",
+		"cr",
+		"nome",
+		"cpf",
+		"data_emissao",
+		"data_validade",
+		"situacao",
+		"data_criacao",
+		"revisao",
+	}
+	atiradorCriaçãoCamposTexto = strings.Join(atiradorCriaçãoCampos, ", ")
+	atiradorCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s) RETURNING id`,
+		atiradorTabela, atiradorCriaçãoCamposTexto, bd.MarcadoresPSQL(len(atiradorCriaçãoCampos)-1))
+
+	atiradorAtualizaçãoComando = fmt.Sprintf(`UPDATE %s SET
+	cr = $1,
+	nome = $2,
+	cpf = $3,
+	data_emissao = $4,
+	data_validade = $5,
+	situacao = $6,
+	data_atualizacao = $7,
+	revisao = $8
+	WHERE id = $9 AND revisao = $10`, atiradorTabela)
+
+	atiradorRemoçãoComando = fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND revisao = $2`, atiradorTabela)
+
+	atiradorResgateCampos = []string{
+		"id",
+		"cr",
+		"nome",
+		"cpf",
+		"data_emissao",
+		"data_validade",
+		"situacao",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	atiradorResgateCamposTexto  = strings.Join(atiradorResgateCampos, ", ")
+	atiradorResgatePorCRComando = fmt.Sprintf(`SELECT %s FROM %s WHERE cr = $1`,
+		atiradorResgateCamposTexto, atiradorTabela)
+)
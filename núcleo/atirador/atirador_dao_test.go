@@ -0,0 +1,375 @@
+package atirador
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestAtiradorDAOImpl_criar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição        string
+		simulação        func()
+		atirador         *atirador
+		atiradorEsperado atirador
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve criar corretamente o atirador",
+			simulação: func() {
+				testdb.StubQuery(atiradorCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExec(atiradorLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			atirador: &atirador{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+				revisão:      2, // revisão sempre inicia com zero
+			},
+			atiradorEsperado: atirador{
+				ID:           1,
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+				DataCriação:  data,
+				revisão:      0,
+			},
+		},
+		{
+			descrição:    "deve detectar quando o atirador não está definido",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao criar o atirador",
+			simulação: func() {
+				testdb.StubQueryError(atiradorCriaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			atirador: &atirador{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao gerar uma entrada de log",
+			simulação: func() {
+				testdb.StubQuery(atiradorCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExecError(atiradorLogCriaçãoComando, fmt.Errorf("erro na criação do log"))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			atirador: &atirador{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+			},
+			erroEsperado: errors.Errorf("erro na criação do log"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novoAtiradorDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.criar(cenário.atirador)
+
+		if cenário.atirador != nil {
+			if cenário.atirador.DataCriação.Before(cenário.atiradorEsperado.DataCriação) {
+				t.Errorf("Item %d, “%s”: data de criação inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.atiradorEsperado.DataCriação, cenário.atirador.DataCriação)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de criação já
+			// que é definida no próprio método.
+			cenário.atiradorEsperado.DataCriação = cenário.atirador.DataCriação
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.atiradorEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.atirador, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDAOImpl_atualizar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição        string
+		simulação        func()
+		atirador         *atirador
+		atiradorEsperado atirador
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve atualizar corretamente o atirador",
+			simulação: func() {
+				testdb.StubExec(atiradorAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(atiradorLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			atirador: &atirador{
+				ID:           1,
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoSuspenso,
+				DataCriação:  data.Add(-time.Hour),
+			},
+			atiradorEsperado: atirador{
+				ID:              1,
+				CR:              380308,
+				Nome:            "João da Silva",
+				CPF:             "52998224725",
+				DataEmissão:     data.AddDate(-1, 0, 0),
+				DataValidade:    data.AddDate(2, 0, 0),
+				Situação:        protocolo.AtiradorSituaçãoSuspenso,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+				revisão:         1,
+			},
+		},
+		{
+			descrição:    "deve detectar quando o atirador não está definido",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar o atirador",
+			simulação: func() {
+				testdb.StubExecError(atiradorAtualizaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			atirador: &atirador{
+				ID:          1,
+				CR:          380308,
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar quando a atualização não surtiu efeito",
+			simulação: func() {
+				testdb.StubExec(atiradorAtualizaçãoComando, testdb.NewResult(0, nil, 0, nil))
+			},
+			atirador: &atirador{
+				ID:          1,
+				CR:          380308,
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: erros.NãoAtualizado,
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novoAtiradorDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.atualizar(cenário.atirador)
+
+		if cenário.atirador != nil {
+			if cenário.atirador.DataAtualização.Before(cenário.atiradorEsperado.DataAtualização) {
+				t.Errorf("Item %d, “%s”: data de atualização inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.atiradorEsperado.DataAtualização, cenário.atirador.DataAtualização)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de
+			// atualização já que é definida no próprio método.
+			cenário.atiradorEsperado.DataAtualização = cenário.atirador.DataAtualização
+
+			if cenário.erroEsperado != nil {
+				cenário.atiradorEsperado = *cenário.atirador
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.atiradorEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.atirador, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDAOImpl_remover(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		atirador     *atirador
+		erroEsperado error
+	}{
+		{
+			descrição: "deve remover corretamente o atirador",
+			simulação: func() {
+				testdb.StubExec(atiradorRemoçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(atiradorLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			atirador: &atirador{
+				ID: 1,
+				CR: 380308,
+			},
+		},
+		{
+			descrição:    "deve detectar quando o atirador não está definido",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao remover o atirador",
+			simulação: func() {
+				testdb.StubExecError(atiradorRemoçãoComando, fmt.Errorf("erro de execução"))
+			},
+			atirador: &atirador{
+				ID: 1,
+				CR: 380308,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar quando a remoção não surtiu efeito",
+			simulação: func() {
+				testdb.StubExec(atiradorRemoçãoComando, testdb.NewResult(0, nil, 0, nil))
+			},
+			atirador: &atirador{
+				ID: 1,
+				CR: 380308,
+			},
+			erroEsperado: erros.NãoAtualizado,
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novoAtiradorDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.remover(cenário.atirador)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDAOImpl_resgatarPorCR(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição        string
+		simulação        func()
+		cr               int
+		atiradorEsperado atirador
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve resgatar corretamente um atirador pelo CR",
+			simulação: func() {
+				testdb.StubQuery(atiradorResgatePorCRComando, testdb.RowsFromSlice(atiradorResgateCampos, [][]driver.Value{
+					{
+						1, 380308, "João da Silva", "52998224725", data.AddDate(-1, 0, 0), data.AddDate(2, 0, 0),
+						"suspenso", data, data, 3,
+					},
+				}))
+			},
+			cr: 380308,
+			atiradorEsperado: atirador{
+				ID:              1,
+				CR:              380308,
+				Nome:            "João da Silva",
+				CPF:             "52998224725",
+				DataEmissão:     data.AddDate(-1, 0, 0),
+				DataValidade:    data.AddDate(2, 0, 0),
+				Situação:        protocolo.AtiradorSituaçãoSuspenso,
+				DataCriação:     data,
+				DataAtualização: data,
+				revisão:         3,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar um atirador pelo CR",
+			simulação: func() {
+				testdb.StubQueryError(atiradorResgatePorCRComando, fmt.Errorf("erro de execução"))
+			},
+			cr:           380308,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoAtiradorDAO(bd.NovoSQLogger(conexão, nil))
+		a, err := dao.resgatarPorCR(cenário.cr)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.atiradorEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(a, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package atirador
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+type atiradorLogDAO interface {
+	criar(atirador, bd.AçãoLog) error
+}
+
+var novoAtiradorLogDAO = func(sqlogger *bd.SQLogger) atiradorLogDAO {
+	return atiradorLogDAOImpl{sqlogger: sqlogger}
+}
+
+type atiradorLogDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a atiradorLogDAOImpl) criar(atirador atirador, ação bd.AçãoLog) error {
+	if err := a.sqlogger.Gerar(); err != nil {
+		return erros.Novo(err)
+	}
+
+	_, err := a.sqlogger.Exec(atiradorLogCriaçãoComando,
+		a.sqlogger.Log.ID,
+		ação,
+		atirador.ID,
+		atirador.CR,
+		atirador.Nome,
+		atirador.CPF,
+		atirador.DataEmissão.UTC(),
+		atirador.DataValidade.UTC(),
+		atirador.Situação,
+		atirador.DataCriação.UTC(),
+		atirador.DataAtualização.UTC(),
+		atirador.revisão,
+	)
+
+	return erros.Novo(err)
+}
+
+var (
+	atiradorLogTabela = "atirador_log"
+
+	atiradorLogCriaçãoCampos = []string{
+		"id",
+		"id_log",
+		"acao",
+		"id_atirador",
+		"cr",
+		"nome",
+		"cpf",
+		"data_emissao",
+		"data_validade",
+		"situacao",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	atiradorLogCriaçãoCamposTexto = strings.Join(atiradorLogCriaçãoCampos, ", ")
+	atiradorLogCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s)`,
+		atiradorLogTabela, atiradorLogCriaçãoCamposTexto, bd.MarcadoresPSQL(len(atiradorLogCriaçãoCampos)-1))
+)
@@ -0,0 +1,195 @@
+package atirador
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/randômico"
+)
+
+type amostraAuditoria struct {
+	ID                 int64
+	DataInício         time.Time
+	DataTérmino        time.Time
+	Percentual         int
+	QuantidadePorClube int
+	Semente            int64
+	DataCriação        time.Time
+}
+
+func novaAmostraAuditoria(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) amostraAuditoria {
+	semente := amostraAuditoriaPedido.Semente
+	if semente == 0 {
+		semente = randômico.FonteRandômica.Int63()
+	}
+
+	return amostraAuditoria{
+		DataInício:         amostraAuditoriaPedido.DataInício,
+		DataTérmino:        amostraAuditoriaPedido.DataTérmino,
+		Percentual:         amostraAuditoriaPedido.Percentual,
+		QuantidadePorClube: amostraAuditoriaPedido.QuantidadePorClube,
+		Semente:            semente,
+	}
+}
+
+// quantidade calcula quantas frequências devem ser sorteadas de um clube que
+// possui o total de frequências candidatas informado. O percentual é
+// arredondado para cima, garantindo que todo clube com frequências tenha ao
+// menos uma frequência auditada.
+func (a amostraAuditoria) quantidade(total int) int {
+	if a.QuantidadePorClube > 0 {
+		if a.QuantidadePorClube < total {
+			return a.QuantidadePorClube
+		}
+		return total
+	}
+
+	return (total*a.Percentual + 99) / 100
+}
+
+// sortear seleciona as frequências de cada clube que serão auditadas. As
+// candidatas devem estar ordenadas pelo clube e pelo número de identificação,
+// pois assim a mesma semente sempre resulta no mesmo sorteio. Frequências com
+// a imagem de confirmação sinalizada são sempre auditadas, além das sorteadas.
+func (a amostraAuditoria) sortear(candidatas []frequência) []frequência {
+	gerador := rand.New(rand.NewSource(a.Semente))
+
+	var sorteadas []frequência
+	for início := 0; início < len(candidatas); {
+		fim := início
+		for fim < len(candidatas) && candidatas[fim].IDClube == candidatas[início].IDClube {
+			fim++
+		}
+
+		frequênciasClube := candidatas[início:fim]
+
+		escolhidas := make(map[int]bool)
+		for _, índice := range gerador.Perm(len(frequênciasClube))[:a.quantidade(len(frequênciasClube))] {
+			escolhidas[índice] = true
+		}
+
+		for índice, f := range frequênciasClube {
+			if escolhidas[índice] || f.SinalizaçãoImagem != "" {
+				sorteadas = append(sorteadas, f)
+			}
+		}
+
+		início = fim
+	}
+
+	return sorteadas
+}
+
+func (a amostraAuditoria) protocolo(auditorias []auditoria) protocolo.AmostraAuditoriaResposta {
+	resposta := protocolo.AmostraAuditoriaResposta{
+		ID:                 a.ID,
+		DataInício:         a.DataInício,
+		DataTérmino:        a.DataTérmino,
+		Percentual:         a.Percentual,
+		QuantidadePorClube: a.QuantidadePorClube,
+		Semente:            a.Semente,
+		DataCriação:        a.DataCriação,
+		Auditorias:         make([]protocolo.AuditoriaListaItem, 0, len(auditorias)),
+	}
+
+	for _, auditoria := range auditorias {
+		resposta.Auditorias = append(resposta.Auditorias, auditoria.protocoloListaItem())
+	}
+
+	return resposta
+}
+
+type auditoria struct {
+	ID           int64
+	IDAmostra    int64
+	IDFrequência int64
+	Veredito     protocolo.AuditoriaVeredito
+	Observações  string
+	IDUsuário    int64
+	DataVeredito time.Time
+
+	// frequência dados da frequência auditada. Na listagem das auditorias de
+	// uma amostra somente os dados de identificação são preenchidos.
+	frequência frequência
+
+	// revisão utilizado para o controle de versão do objeto na base de dados,
+	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
+	// objeto.
+	revisão int
+}
+
+func novaAuditoria(idAmostra int64, frequência frequência) auditoria {
+	return auditoria{
+		IDAmostra:    idAmostra,
+		IDFrequência: frequência.ID,
+		frequência:   frequência,
+	}
+}
+
+// registrarVeredito armazena a análise do auditor e aplica na frequência o
+// efeito do veredito: uma aprovação devolve a frequência para a situação de
+// confirmada, enquanto uma fraude a invalida. Uma suspeita mantém a frequência
+// em auditoria aguardando um veredito definitivo.
+func (a *auditoria) registrarVeredito(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) protocolo.Mensagens {
+	if a.Veredito.Definitivo() {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAuditoriaConcluída),
+		)
+	}
+
+	var mensagens protocolo.Mensagens
+
+	switch auditoriaVereditoPedidoCompleta.Veredito {
+	case protocolo.AuditoriaVereditoAprovada:
+		mensagens = a.frequência.transitar(protocolo.FrequênciaSituaçãoConfirmada)
+	case protocolo.AuditoriaVereditoFraudulenta:
+		mensagens = a.frequência.transitar(protocolo.FrequênciaSituaçãoInvalidada)
+	}
+
+	if len(mensagens) > 0 {
+		return mensagens
+	}
+
+	a.Veredito = auditoriaVereditoPedidoCompleta.Veredito
+	a.Observações = auditoriaVereditoPedidoCompleta.Observações
+	a.IDUsuário = auditoriaVereditoPedidoCompleta.Identidade.IDUsuário
+	a.DataVeredito = time.Now().UTC()
+	return nil
+}
+
+func (a auditoria) protocolo() protocolo.AuditoriaResposta {
+	return protocolo.AuditoriaResposta{
+		ID:                   a.ID,
+		Amostra:              a.IDAmostra,
+		NúmeroControle:       protocolo.NovoNúmeroControle(a.frequência.ID, a.frequência.Controle),
+		CR:                   a.frequência.CR,
+		Clube:                a.frequência.IDClube,
+		Calibre:              a.frequência.Calibre,
+		ArmaUtilizada:        a.frequência.ArmaUtilizada,
+		QuantidadeMunição:    a.frequência.QuantidadeMunição,
+		DataInício:           a.frequência.DataInício,
+		DataTérmino:          a.frequência.DataTérmino,
+		DataConfirmação:      a.frequência.DataConfirmação,
+		Situação:             a.frequência.Situação,
+		ImagemNúmeroControle: a.frequência.ImagemNúmeroControle,
+		ImagemConfirmação:    a.frequência.ImagemConfirmação,
+		MiniaturaConfirmação: a.frequência.MiniaturaImagemConfirmação,
+		EXIFConfirmação:      a.frequência.EXIFImagemConfirmação,
+		SinalizaçãoImagem:    a.frequência.SinalizaçãoImagem,
+		DistânciaClube:       a.frequência.DistânciaClube,
+		Veredito:             a.Veredito,
+		Observações:          a.Observações,
+		DataVeredito:         a.DataVeredito,
+	}
+}
+
+func (a auditoria) protocoloListaItem() protocolo.AuditoriaListaItem {
+	return protocolo.AuditoriaListaItem{
+		ID:             a.ID,
+		NúmeroControle: protocolo.NovoNúmeroControle(a.frequência.ID, a.frequência.Controle),
+		CR:             a.frequência.CR,
+		Clube:          a.frequência.IDClube,
+		Veredito:       a.Veredito,
+	}
+}
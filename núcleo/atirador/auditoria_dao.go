@@ -0,0 +1,208 @@
+package atirador
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type auditoriaDAO interface {
+	criar(*auditoria) error
+	atualizar(*auditoria) error
+	resgatar(id int64) (auditoria, error)
+	listarPorAmostra(idAmostra int64) ([]auditoria, error)
+}
+
+var novaAuditoriaDAO = func(sqlogger *bd.SQLogger) auditoriaDAO {
+	return auditoriaDAOImpl{sqlogger: sqlogger}
+}
+
+type auditoriaDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a auditoriaDAOImpl) criar(auditoria *auditoria) error {
+	if auditoria == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	auditoria.revisão = 0
+
+	resultado := a.sqlogger.QueryRow(auditoriaCriaçãoComando,
+		auditoria.IDAmostra,
+		auditoria.IDFrequência,
+		auditoria.revisão,
+	)
+
+	if err := resultado.Scan(&auditoria.ID); err != nil {
+		return erros.Novo(err)
+	}
+
+	auditoriaLogDAO := novaAuditoriaLogDAO(a.sqlogger)
+	return erros.Novo(auditoriaLogDAO.criar(*auditoria, bd.AçãoLogCriação))
+}
+
+func (a auditoriaDAOImpl) atualizar(auditoria *auditoria) error {
+	if auditoria == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	auditoria.revisão++
+
+	resultado, err := a.sqlogger.Exec(auditoriaAtualizaçãoComando,
+		sql.NullString{String: string(auditoria.Veredito), Valid: auditoria.Veredito != ""},
+		auditoria.Observações,
+		sql.NullInt64{Int64: auditoria.IDUsuário, Valid: auditoria.IDUsuário > 0},
+		pq.NullTime{Time: auditoria.DataVeredito.UTC(), Valid: !auditoria.DataVeredito.IsZero()},
+		auditoria.revisão,
+		auditoria.ID,
+		auditoria.revisão-1,
+	)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	atualizados, err := resultado.RowsAffected()
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if atualizados != 1 {
+		return erros.NãoAtualizado
+	}
+
+	auditoriaLogDAO := novaAuditoriaLogDAO(a.sqlogger)
+	return erros.Novo(auditoriaLogDAO.criar(*auditoria, bd.AçãoLogAtualização))
+}
+
+func (a auditoriaDAOImpl) resgatar(id int64) (auditoria, error) {
+	resultado := a.sqlogger.QueryRow(auditoriaResgateComando, id)
+
+	var audit auditoria
+	var veredito sql.NullString
+	var idUsuário sql.NullInt64
+	var dataVeredito pq.NullTime
+
+	err := resultado.Scan(
+		&audit.ID,
+		&audit.IDAmostra,
+		&audit.IDFrequência,
+		&veredito,
+		&audit.Observações,
+		&idUsuário,
+		&dataVeredito,
+		&audit.revisão,
+	)
+
+	if veredito.Valid {
+		audit.Veredito = protocolo.AuditoriaVeredito(veredito.String)
+	}
+
+	if idUsuário.Valid {
+		audit.IDUsuário = idUsuário.Int64
+	}
+
+	if dataVeredito.Valid {
+		audit.DataVeredito = dataVeredito.Time
+	}
+
+	return audit, erros.Novo(err)
+}
+
+// listarPorAmostra retorna as auditorias da amostra junto com os dados de
+// identificação das frequências auditadas, ordenadas pelo clube e pela
+// frequência.
+func (a auditoriaDAOImpl) listarPorAmostra(idAmostra int64) ([]auditoria, error) {
+	linhas, err := a.sqlogger.Query(auditoriaListagemComando, idAmostra)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var auditorias []auditoria
+	for linhas.Next() {
+		var audit auditoria
+		var veredito sql.NullString
+
+		err := linhas.Scan(
+			&audit.ID,
+			&audit.IDAmostra,
+			&audit.IDFrequência,
+			&veredito,
+			&audit.frequência.Controle,
+			&audit.frequência.CR,
+			&audit.frequência.IDClube,
+		)
+
+		if err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		if veredito.Valid {
+			audit.Veredito = protocolo.AuditoriaVeredito(veredito.String)
+		}
+
+		audit.frequência.ID = audit.IDFrequência
+		auditorias = append(auditorias, audit)
+	}
+
+	return auditorias, erros.Novo(linhas.Err())
+}
+
+var (
+	auditoriaTabela = "auditoria"
+
+	auditoriaCriaçãoCampos = []string{
+		"id",
+		"id_amostra_auditoria",
+		"id_frequencia_atirador",
+		"revisao",
+	}
+	auditoriaCriaçãoCamposTexto = strings.Join(auditoriaCriaçãoCampos, ", ")
+	auditoriaCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s) RETURNING id`,
+		auditoriaTabela, auditoriaCriaçãoCamposTexto, bd.MarcadoresPSQL(len(auditoriaCriaçãoCampos)-1))
+
+	auditoriaAtualizaçãoComando = fmt.Sprintf(`UPDATE %s SET
+	veredito = $1,
+	observacoes = $2,
+	id_usuario = $3,
+	data_veredito = $4,
+	revisao = $5
+	WHERE id = $6 AND revisao = $7`, auditoriaTabela)
+
+	auditoriaResgateCampos = []string{
+		"id",
+		"id_amostra_auditoria",
+		"id_frequencia_atirador",
+		"veredito",
+		"observacoes",
+		"id_usuario",
+		"data_veredito",
+		"revisao",
+	}
+	auditoriaResgateCamposTexto = strings.Join(auditoriaResgateCampos, ", ")
+	auditoriaResgateComando     = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
+		auditoriaResgateCamposTexto, auditoriaTabela)
+
+	auditoriaListagemCampos = []string{
+		"a.id",
+		"a.id_amostra_auditoria",
+		"a.id_frequencia_atirador",
+		"a.veredito",
+		"f.controle",
+		"f.cr",
+		"f.id_clube",
+	}
+	auditoriaListagemCamposTexto = strings.Join(auditoriaListagemCampos, ", ")
+	auditoriaListagemComando     = fmt.Sprintf(`SELECT %s FROM %s a
+	JOIN %s f ON f.id = a.id_frequencia_atirador
+	WHERE a.id_amostra_auditoria = $1
+	ORDER BY f.id_clube, f.id`, auditoriaListagemCamposTexto, auditoriaTabela, frequênciaTabela)
+)
@@ -0,0 +1,335 @@
+package atirador
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestAuditoriaDAOImpl_criar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição         string
+		simulação         func()
+		auditoria         *auditoria
+		auditoriaEsperada auditoria
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve criar corretamente a auditoria",
+			simulação: func() {
+				testdb.StubQuery(auditoriaCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExec(auditoriaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			auditoria: &auditoria{
+				IDAmostra:    2,
+				IDFrequência: 3,
+				revisão:      2, // revisão sempre inicia com zero
+			},
+			auditoriaEsperada: auditoria{
+				ID:           1,
+				IDAmostra:    2,
+				IDFrequência: 3,
+				revisão:      0,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a auditoria não está definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao criar a auditoria",
+			simulação: func() {
+				testdb.StubQueryError(auditoriaCriaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			auditoria: &auditoria{
+				IDAmostra:    2,
+				IDFrequência: 3,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao gerar uma entrada de log",
+			simulação: func() {
+				testdb.StubQuery(auditoriaCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExecError(auditoriaLogCriaçãoComando, fmt.Errorf("erro na criação do log"))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			auditoria: &auditoria{
+				IDAmostra:    2,
+				IDFrequência: 3,
+			},
+			erroEsperado: errors.Errorf("erro na criação do log"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.criar(cenário.auditoria)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.auditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.auditoria, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaDAOImpl_atualizar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição         string
+		simulação         func()
+		auditoria         *auditoria
+		auditoriaEsperada auditoria
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve atualizar corretamente a auditoria",
+			simulação: func() {
+				testdb.StubExec(auditoriaAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(auditoriaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			auditoria: &auditoria{
+				ID:           1,
+				IDAmostra:    2,
+				IDFrequência: 3,
+				Veredito:     protocolo.AuditoriaVereditoFraudulenta,
+				Observações:  "Foto de outra pessoa",
+				IDUsuário:    4,
+				DataVeredito: data,
+				revisão:      0,
+			},
+			auditoriaEsperada: auditoria{
+				ID:           1,
+				IDAmostra:    2,
+				IDFrequência: 3,
+				Veredito:     protocolo.AuditoriaVereditoFraudulenta,
+				Observações:  "Foto de outra pessoa",
+				IDUsuário:    4,
+				DataVeredito: data,
+				revisão:      1,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a auditoria não está definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a auditoria",
+			simulação: func() {
+				testdb.StubExecError(auditoriaAtualizaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			auditoria: &auditoria{
+				ID:       1,
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar quando a auditoria foi alterada por outra transação",
+			simulação: func() {
+				testdb.StubExec(auditoriaAtualizaçãoComando, testdb.NewResult(0, nil, 0, nil))
+			},
+			auditoria: &auditoria{
+				ID:       1,
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			},
+			erroEsperado: erros.NãoAtualizado,
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.atualizar(cenário.auditoria)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.auditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.auditoria, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaDAOImpl_resgatar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição         string
+		simulação         func()
+		id                int64
+		auditoriaEsperada auditoria
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve resgatar corretamente uma auditoria com veredito",
+			simulação: func() {
+				testdb.StubQuery(auditoriaResgateComando, testdb.RowsFromSlice(auditoriaResgateCampos, [][]driver.Value{
+					{1, 2, 3, "suspeita", "Rosto coberto", 4, data, 1},
+				}))
+			},
+			id: 1,
+			auditoriaEsperada: auditoria{
+				ID:           1,
+				IDAmostra:    2,
+				IDFrequência: 3,
+				Veredito:     protocolo.AuditoriaVereditoSuspeita,
+				Observações:  "Rosto coberto",
+				IDUsuário:    4,
+				DataVeredito: data,
+				revisão:      1,
+			},
+		},
+		{
+			descrição: "deve resgatar corretamente uma auditoria sem veredito",
+			simulação: func() {
+				testdb.StubQuery(auditoriaResgateComando, testdb.RowsFromSlice(auditoriaResgateCampos, [][]driver.Value{
+					{1, 2, 3, nil, "", nil, nil, 0},
+				}))
+			},
+			id: 1,
+			auditoriaEsperada: auditoria{
+				ID:           1,
+				IDAmostra:    2,
+				IDFrequência: 3,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar uma auditoria",
+			simulação: func() {
+				testdb.StubQueryError(auditoriaResgateComando, fmt.Errorf("erro de execução"))
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		a, err := dao.resgatar(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.auditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(a, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaDAOImpl_listarPorAmostra(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		idAmostra          int64
+		auditoriasEsperada []auditoria
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve listar corretamente as auditorias da amostra",
+			simulação: func() {
+				testdb.StubQuery(auditoriaListagemComando, testdb.RowsFromSlice(auditoriaListagemCampos, [][]driver.Value{
+					{1, 2, 3, "aprovada", 98765, 380308, 1},
+					{2, 2, 5, nil, 98766, 380309, 2},
+				}))
+			},
+			idAmostra: 2,
+			auditoriasEsperada: []auditoria{
+				{
+					ID:           1,
+					IDAmostra:    2,
+					IDFrequência: 3,
+					Veredito:     protocolo.AuditoriaVereditoAprovada,
+					frequência: frequência{
+						ID:       3,
+						Controle: 98765,
+						CR:       380308,
+						IDClube:  1,
+					},
+				},
+				{
+					ID:           2,
+					IDAmostra:    2,
+					IDFrequência: 5,
+					frequência: frequência{
+						ID:       5,
+						Controle: 98766,
+						CR:       380309,
+						IDClube:  2,
+					},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao listar as auditorias",
+			simulação: func() {
+				testdb.StubQueryError(auditoriaListagemComando, fmt.Errorf("erro de execução"))
+			},
+			idAmostra:    2,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaAuditoriaDAO(bd.NovoSQLogger(conexão, nil))
+		auditorias, err := dao.listarPorAmostra(cenário.idAmostra)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.auditoriasEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(auditorias, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package atirador
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+type auditoriaLogDAO interface {
+	criar(auditoria, bd.AçãoLog) error
+}
+
+var novaAuditoriaLogDAO = func(sqlogger *bd.SQLogger) auditoriaLogDAO {
+	return auditoriaLogDAOImpl{sqlogger: sqlogger}
+}
+
+type auditoriaLogDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (a auditoriaLogDAOImpl) criar(auditoria auditoria, ação bd.AçãoLog) error {
+	if err := a.sqlogger.Gerar(); err != nil {
+		return erros.Novo(err)
+	}
+
+	_, err := a.sqlogger.Exec(auditoriaLogCriaçãoComando,
+		a.sqlogger.Log.ID,
+		ação,
+		auditoria.ID,
+		auditoria.IDAmostra,
+		auditoria.IDFrequência,
+		sql.NullString{String: string(auditoria.Veredito), Valid: auditoria.Veredito != ""},
+		auditoria.Observações,
+		sql.NullInt64{Int64: auditoria.IDUsuário, Valid: auditoria.IDUsuário > 0},
+		pq.NullTime{Time: auditoria.DataVeredito.UTC(), Valid: !auditoria.DataVeredito.IsZero()},
+		auditoria.revisão,
+	)
+
+	return erros.Novo(err)
+}
+
+var (
+	auditoriaLogTabela = "auditoria_log"
+
+	auditoriaLogCriaçãoCampos = []string{
+		"id",
+		"id_log",
+		"acao",
+		"id_auditoria",
+		"id_amostra_auditoria",
+		"id_frequencia_atirador",
+		"veredito",
+		"observacoes",
+		"id_usuario",
+		"data_veredito",
+		"revisao",
+	}
+	auditoriaLogCriaçãoCamposTexto = strings.Join(auditoriaLogCriaçãoCampos, ", ")
+	auditoriaLogCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s)`,
+		auditoriaLogTabela, auditoriaLogCriaçãoCamposTexto, bd.MarcadoresPSQL(len(auditoriaLogCriaçãoCampos)-1))
+)
@@ -0,0 +1,198 @@
+package atirador
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestAmostraAuditoria_sortear(t *testing.T) {
+	var candidatas []frequência
+	for id := int64(1); id <= 30; id++ {
+		// 20 frequências do clube 1, 7 do clube 2 e 3 do clube 3
+		idClube := int64(1)
+		if id > 20 {
+			idClube = 2
+		}
+		if id > 27 {
+			idClube = 3
+		}
+
+		candidatas = append(candidatas, frequência{ID: id, IDClube: idClube})
+	}
+
+	cenários := []struct {
+		descrição          string
+		amostra            amostraAuditoria
+		quantidadeEsperada map[int64]int
+	}{
+		{
+			descrição:          "deve sortear um percentual das frequências de cada clube",
+			amostra:            amostraAuditoria{Percentual: 10, Semente: 42},
+			quantidadeEsperada: map[int64]int{1: 2, 2: 1, 3: 1},
+		},
+		{
+			descrição:          "deve sortear uma quantidade fixa de frequências de cada clube",
+			amostra:            amostraAuditoria{QuantidadePorClube: 5, Semente: 42},
+			quantidadeEsperada: map[int64]int{1: 5, 2: 5, 3: 3},
+		},
+		{
+			descrição:          "deve sortear todas as frequências com o percentual máximo",
+			amostra:            amostraAuditoria{Percentual: 100, Semente: 7},
+			quantidadeEsperada: map[int64]int{1: 20, 2: 7, 3: 3},
+		},
+	}
+
+	for i, cenário := range cenários {
+		sorteadas := cenário.amostra.sortear(candidatas)
+
+		quantidade := make(map[int64]int)
+		for j, f := range sorteadas {
+			quantidade[f.IDClube]++
+
+			if j > 0 && sorteadas[j-1].ID >= f.ID {
+				t.Errorf("Item %d, “%s”: frequências sorteadas fora de ordem", i, cenário.descrição)
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.quantidadeEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(quantidade, nil); err != nil {
+			t.Error(err)
+		}
+
+		// a mesma semente deve sempre resultar no mesmo sorteio
+		verificadorResultado.DefinirEsperado(sorteadas, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.amostra.sortear(candidatas), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAmostraAuditoria_sortearSinalizadas(t *testing.T) {
+	var candidatas []frequência
+	for id := int64(1); id <= 10; id++ {
+		candidatas = append(candidatas, frequência{ID: id, IDClube: 1})
+	}
+	candidatas[2].SinalizaçãoImagem = protocolo.MensagemCódigoImagemForaClube
+	candidatas[6].SinalizaçãoImagem = protocolo.MensagemCódigoImagemSemMetadados
+
+	amostra := amostraAuditoria{QuantidadePorClube: 1, Semente: 42}
+	sorteadas := amostra.sortear(candidatas)
+
+	sinalizadas := 0
+	for _, f := range sorteadas {
+		if f.SinalizaçãoImagem != "" {
+			sinalizadas++
+		}
+	}
+
+	if sinalizadas != 2 {
+		t.Errorf("Esperado que as 2 frequências sinalizadas fossem auditadas, encontrado %d", sinalizadas)
+	}
+
+	if len(sorteadas) < 2 || len(sorteadas) > 3 {
+		t.Errorf("Quantidade de frequências auditadas %d inesperada", len(sorteadas))
+	}
+}
+
+func TestAuditoria_registrarVeredito(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		auditoria          auditoria
+		veredito           protocolo.AuditoriaVeredito
+		situaçãoEsperada   protocolo.FrequênciaSituação
+		vereditoEsperado   protocolo.AuditoriaVeredito
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve confirmar novamente uma frequência aprovada",
+			auditoria: auditoria{
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoEmAuditoria},
+			},
+			veredito:         protocolo.AuditoriaVereditoAprovada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoConfirmada,
+			vereditoEsperado: protocolo.AuditoriaVereditoAprovada,
+		},
+		{
+			descrição: "deve invalidar uma frequência fraudulenta",
+			auditoria: auditoria{
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoEmAuditoria},
+			},
+			veredito:         protocolo.AuditoriaVereditoFraudulenta,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoInvalidada,
+			vereditoEsperado: protocolo.AuditoriaVereditoFraudulenta,
+		},
+		{
+			descrição: "deve manter em auditoria uma frequência suspeita",
+			auditoria: auditoria{
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoEmAuditoria},
+			},
+			veredito:         protocolo.AuditoriaVereditoSuspeita,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoEmAuditoria,
+			vereditoEsperado: protocolo.AuditoriaVereditoSuspeita,
+		},
+		{
+			descrição: "deve permitir um veredito definitivo após uma suspeita",
+			auditoria: auditoria{
+				Veredito:   protocolo.AuditoriaVereditoSuspeita,
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoEmAuditoria},
+			},
+			veredito:         protocolo.AuditoriaVereditoFraudulenta,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoInvalidada,
+			vereditoEsperado: protocolo.AuditoriaVereditoFraudulenta,
+		},
+		{
+			descrição: "deve recusar um novo veredito em uma auditoria concluída",
+			auditoria: auditoria{
+				Veredito:   protocolo.AuditoriaVereditoAprovada,
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoConfirmada},
+			},
+			veredito:         protocolo.AuditoriaVereditoFraudulenta,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoConfirmada,
+			vereditoEsperado: protocolo.AuditoriaVereditoAprovada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAuditoriaConcluída),
+			),
+		},
+		{
+			descrição: "deve recusar o veredito de uma frequência que não está em auditoria",
+			auditoria: auditoria{
+				frequência: frequência{Situação: protocolo.FrequênciaSituaçãoCancelada},
+			},
+			veredito:         protocolo.AuditoriaVereditoAprovada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoCancelada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaCancelada),
+			),
+		},
+	}
+
+	for i, cenário := range cenários {
+		mensagens := cenário.auditoria.registrarVeredito(protocolo.NovaAuditoriaVereditoPedidoCompleta(1,
+			protocolo.Identidade{IDUsuário: 3, Papel: protocolo.PapelAuditor},
+			protocolo.AuditoriaVereditoPedido{Veredito: cenário.veredito, Observações: "Análise"},
+		))
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.situaçãoEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.auditoria.frequência.Situação, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.vereditoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.auditoria.Veredito, nil); err != nil {
+			t.Error(err)
+		}
+
+		if len(mensagens) == 0 && (cenário.auditoria.IDUsuário != 3 || cenário.auditoria.DataVeredito.IsZero()) {
+			t.Errorf("Item %d, “%s”: auditor ou data do veredito não registrados", i, cenário.descrição)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package atirador
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// cursor identifica a última frequência retornada em uma página da listagem,
+// permitindo continuar a busca a partir dela (keyset pagination). Além do
+// número de identificação, que desempata registros com o mesmo valor, é
+// armazenado o valor do campo utilizado na ordenação.
+type cursor struct {
+	Ordenação protocolo.FrequênciaOrdenação `json:"o"`
+	ID        int64                         `json:"i"`
+	Data      time.Time                     `json:"d,omitempty"`
+	CR        int                           `json:"c,omitempty"`
+}
+
+func novoCursor(f frequência, ordenação protocolo.FrequênciaOrdenação) cursor {
+	c := cursor{
+		Ordenação: ordenação,
+		ID:        f.ID,
+	}
+
+	switch ordenação {
+	case protocolo.FrequênciaOrdenaçãoDataInício, protocolo.FrequênciaOrdenaçãoDataInícioDecrescente:
+		c.Data = f.DataInício
+	case protocolo.FrequênciaOrdenaçãoDataCriação, protocolo.FrequênciaOrdenaçãoDataCriaçãoDecrescente:
+		c.Data = f.DataCriação
+	case protocolo.FrequênciaOrdenaçãoCR, protocolo.FrequênciaOrdenaçãoCRDecrescente:
+		c.CR = f.CR
+	}
+
+	return c
+}
+
+// interpretarCursor decodifica o cursor recebido do usuário. O cursor só é
+// aceito quando foi gerado para a mesma ordenação solicitada, já que o valor
+// armazenado depende do campo ordenado.
+func interpretarCursor(texto string, ordenação protocolo.FrequênciaOrdenação) (*cursor, protocolo.Mensagens) {
+	if texto == "" {
+		return nil, nil
+	}
+
+	cursorInválido := protocolo.NovasMensagens(
+		protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCursorInválido, texto),
+	)
+
+	conteúdo, err := base64.RawURLEncoding.DecodeString(texto)
+	if err != nil {
+		return nil, cursorInválido
+	}
+
+	var c cursor
+	if err := json.Unmarshal(conteúdo, &c); err != nil {
+		return nil, cursorInválido
+	}
+
+	if c.Ordenação != ordenação || c.ID <= 0 {
+		return nil, cursorInválido
+	}
+
+	return &c, nil
+}
+
+// valor retorna o valor do campo ordenado que será utilizado na comparação com
+// os registros do banco de dados.
+func (c cursor) valor() interface{} {
+	switch c.Ordenação {
+	case protocolo.FrequênciaOrdenaçãoCR, protocolo.FrequênciaOrdenaçãoCRDecrescente:
+		return c.CR
+	}
+
+	return c.Data.UTC()
+}
+
+func (c cursor) String() string {
+	// o erro é ignorado, pois a estrutura possui somente tipos que sempre podem
+	// ser convertidos para JSON
+	conteúdo, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(conteúdo)
+}
@@ -0,0 +1,324 @@
+package atirador
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
+)
+
+const (
+	exifTagModelo            = 0x0110
+	exifTagOrientação        = 0x0112
+	exifTagPonteiroExif      = 0x8769
+	exifTagPonteiroGPS       = 0x8825
+	exifTagDataOriginal      = 0x9003
+	exifTagFusoDataOriginal  = 0x9011
+	exifTagGPSLatitudeRef    = 0x0001
+	exifTagGPSLatitude       = 0x0002
+	exifTagGPSLongitudeRef   = 0x0003
+	exifTagGPSLongitude      = 0x0004
+	exifTipoASCII            = 2
+	exifTipoShort            = 3
+	exifTipoLong             = 4
+	exifTipoRacional         = 5
+	exifRacionaisMáximo      = 8
+	exifFormatoData          = "2006:01:02 15:04:05"
+	exifFormatoFuso          = "-07:00"
+	jpegMarcadorInício       = 0xd8
+	jpegMarcadorAPP1         = 0xe1
+	jpegMarcadorInícioImagem = 0xda
+	jpegMarcadorFim          = 0xd9
+)
+
+// metadadosImagem informações extraídas do EXIF da imagem de confirmação.
+type metadadosImagem struct {
+	// DataCaptura momento em que a foto foi tirada segundo a câmera, em UTC.
+	// Quando o fuso horário não é informado nos metadados a data é interpretada
+	// no fuso horário configurado.
+	DataCaptura time.Time
+
+	// ModeloCâmera modelo do equipamento que capturou a foto.
+	ModeloCâmera string
+
+	// Localização coordenadas GPS do local onde a foto foi tirada, quando
+	// registradas pela câmera.
+	Localização *protocolo.Coordenadas
+
+	// Orientação indica como a imagem deve ser rotacionada ou espelhada para
+	// ser exibida corretamente, conforme os valores de 1 a 8 definidos no EXIF.
+	// Fica zerada quando não é informada.
+	Orientação int
+}
+
+// extrairMetadadosImagem lê os metadados EXIF de uma imagem JPEG codificada em
+// base64. Imagens em outros formatos, ou com metadados que não puderam ser
+// interpretados, são tratadas como imagens sem metadados, já que o conteúdo é
+// de responsabilidade do cliente e não deve gerar um erro interno. A localização
+// é utilizada na data de captura sem fuso horário, sendo considerado UTC quando
+// não informada.
+func extrairMetadadosImagem(imagemBase64 string, localização *time.Location) (metadadosImagem, error) {
+	imagem, err := base64.StdEncoding.DecodeString(imagemBase64)
+	if err != nil {
+		return metadadosImagem{}, erros.Novo(err)
+	}
+
+	tiff, err := extrairSegmentoEXIF(imagem)
+	if err != nil || tiff == nil {
+		return metadadosImagem{}, nil
+	}
+
+	metadados, err := interpretarEXIF(tiff, localização)
+	if err != nil {
+		return metadadosImagem{}, nil
+	}
+
+	return metadados, nil
+}
+
+// extrairSegmentoEXIF percorre os segmentos do JPEG até encontrar o APP1 com o
+// cabeçalho EXIF, retornando o conteúdo TIFF que o segue.
+func extrairSegmentoEXIF(imagem []byte) ([]byte, error) {
+	if len(imagem) < 2 || imagem[0] != 0xff || imagem[1] != jpegMarcadorInício {
+		return nil, nil
+	}
+
+	cabeçalhoEXIF := []byte("Exif\x00\x00")
+
+	for posição := 2; posição+4 <= len(imagem); {
+		if imagem[posição] != 0xff {
+			return nil, errors.Errorf("marcador JPEG inválido na posição %d", posição)
+		}
+
+		marcador := imagem[posição+1]
+		if marcador == jpegMarcadorInícioImagem || marcador == jpegMarcadorFim {
+			break
+		}
+
+		tamanho := int(binary.BigEndian.Uint16(imagem[posição+2:]))
+		if tamanho < 2 || posição+2+tamanho > len(imagem) {
+			return nil, errors.Errorf("tamanho de segmento JPEG inválido na posição %d", posição)
+		}
+
+		conteúdo := imagem[posição+4 : posição+2+tamanho]
+		if marcador == jpegMarcadorAPP1 && bytes.HasPrefix(conteúdo, cabeçalhoEXIF) {
+			return conteúdo[len(cabeçalhoEXIF):], nil
+		}
+
+		posição += 2 + tamanho
+	}
+
+	return nil, nil
+}
+
+// interpretarEXIF lê a estrutura TIFF do EXIF, buscando o modelo da câmera no
+// diretório principal, a data de captura no subdiretório EXIF e as coordenadas
+// no subdiretório GPS.
+func interpretarEXIF(tiff []byte, localização *time.Location) (metadadosImagem, error) {
+	var metadados metadadosImagem
+
+	if len(tiff) < 8 {
+		return metadados, errors.Errorf("cabeçalho TIFF incompleto")
+	}
+
+	var ordem binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		ordem = binary.LittleEndian
+	case "MM":
+		ordem = binary.BigEndian
+	default:
+		return metadados, errors.Errorf("ordem de bytes TIFF desconhecida")
+	}
+
+	if ordem.Uint16(tiff[2:]) != 42 {
+		return metadados, errors.Errorf("identificador TIFF inválido")
+	}
+
+	principal, err := lerDiretórioEXIF(tiff, ordem, ordem.Uint32(tiff[4:]))
+	if err != nil {
+		return metadados, err
+	}
+
+	metadados.ModeloCâmera = principal.textos[exifTagModelo]
+	metadados.Orientação = int(principal.inteiros[exifTagOrientação])
+
+	if ponteiro, ok := principal.inteiros[exifTagPonteiroExif]; ok {
+		exif, err := lerDiretórioEXIF(tiff, ordem, ponteiro)
+		if err != nil {
+			return metadados, err
+		}
+
+		if metadados.DataCaptura, err = interpretarDataEXIF(exif, localização); err != nil {
+			return metadados, err
+		}
+	}
+
+	if ponteiro, ok := principal.inteiros[exifTagPonteiroGPS]; ok {
+		gps, err := lerDiretórioEXIF(tiff, ordem, ponteiro)
+		if err != nil {
+			return metadados, err
+		}
+
+		if metadados.Localização, err = interpretarCoordenadasEXIF(gps); err != nil {
+			return metadados, err
+		}
+	}
+
+	return metadados, nil
+}
+
+// interpretarDataEXIF converte a data de captura do subdiretório EXIF para
+// UTC. A data sem o deslocamento em relação ao UTC é considerada na localização
+// informada. Quando a data não é informada retorna uma data zerada.
+func interpretarDataEXIF(exif diretórioEXIF, localização *time.Location) (time.Time, error) {
+	if exif.textos[exifTagDataOriginal] == "" {
+		return time.Time{}, nil
+	}
+
+	if localização == nil {
+		localização = time.UTC
+	}
+
+	if fuso, err := time.Parse(exifFormatoFuso, exif.textos[exifTagFusoDataOriginal]); err == nil {
+		_, deslocamento := fuso.Zone()
+		localização = time.FixedZone("", deslocamento)
+	}
+
+	dataCaptura, err := time.ParseInLocation(exifFormatoData, exif.textos[exifTagDataOriginal], localização)
+	if err != nil {
+		return time.Time{}, erros.Novo(err)
+	}
+
+	return dataCaptura.UTC(), nil
+}
+
+// interpretarCoordenadasEXIF converte a latitude e a longitude do subdiretório
+// GPS, armazenadas em graus, minutos e segundos, para graus decimais. Quando as
+// coordenadas não são informadas retorna nil.
+func interpretarCoordenadasEXIF(gps diretórioEXIF) (*protocolo.Coordenadas, error) {
+	latitude, okLatitude := gps.racionais[exifTagGPSLatitude]
+	longitude, okLongitude := gps.racionais[exifTagGPSLongitude]
+	if !okLatitude || !okLongitude {
+		return nil, nil
+	}
+
+	grausDecimais := func(valores []float64, referência, negativa string) (float64, error) {
+		if len(valores) != 3 {
+			return 0, errors.Errorf("coordenada GPS com %d valores", len(valores))
+		}
+
+		graus := valores[0] + valores[1]/60 + valores[2]/3600
+		if strings.EqualFold(referência, negativa) {
+			graus = -graus
+		}
+		return graus, nil
+	}
+
+	var coordenadas protocolo.Coordenadas
+	var err error
+
+	if coordenadas.Latitude, err = grausDecimais(latitude, gps.textos[exifTagGPSLatitudeRef], "S"); err != nil {
+		return nil, err
+	}
+
+	if coordenadas.Longitude, err = grausDecimais(longitude, gps.textos[exifTagGPSLongitudeRef], "W"); err != nil {
+		return nil, err
+	}
+
+	if !coordenadas.Válidas() {
+		return nil, errors.Errorf("coordenadas GPS fora dos limites")
+	}
+
+	return &coordenadas, nil
+}
+
+// diretórioEXIF entradas de um diretório (IFD) relevantes para a verificação,
+// separadas pelo tipo do valor.
+type diretórioEXIF struct {
+	textos    map[uint16]string
+	inteiros  map[uint16]uint32
+	racionais map[uint16][]float64
+}
+
+// lerDiretórioEXIF interpreta as entradas de um diretório (IFD) a partir da
+// posição informada. Os textos são retornados sem o caractere nulo final, e
+// somente os inteiros com um único valor são considerados, já que são
+// utilizados como ponteiros para os subdiretórios ou como a orientação.
+func lerDiretórioEXIF(tiff []byte, ordem binary.ByteOrder, início uint32) (diretórioEXIF, error) {
+	diretório := diretórioEXIF{
+		textos:    make(map[uint16]string),
+		inteiros:  make(map[uint16]uint32),
+		racionais: make(map[uint16][]float64),
+	}
+
+	if int64(início)+2 > int64(len(tiff)) {
+		return diretório, errors.Errorf("diretório EXIF fora dos limites")
+	}
+
+	quantidade := int(ordem.Uint16(tiff[início:]))
+	entradas := tiff[início+2:]
+	if len(entradas) < quantidade*12 {
+		return diretório, errors.Errorf("diretório EXIF incompleto")
+	}
+
+	// valores de até 4 bytes são armazenados na própria entrada, os demais
+	// ficam na posição indicada por ela
+	conteúdo := func(entrada []byte, tamanho uint32) ([]byte, error) {
+		if tamanho <= 4 {
+			return entrada[8 : 8+tamanho], nil
+		}
+
+		deslocamento := ordem.Uint32(entrada[8:])
+		if int64(deslocamento)+int64(tamanho) > int64(len(tiff)) {
+			return nil, errors.Errorf("valor EXIF fora dos limites")
+		}
+		return tiff[deslocamento : deslocamento+tamanho], nil
+	}
+
+	for i := 0; i < quantidade; i++ {
+		entrada := entradas[i*12 : (i+1)*12]
+		tag := ordem.Uint16(entrada)
+		tipo := ordem.Uint16(entrada[2:])
+		total := ordem.Uint32(entrada[4:])
+
+		switch {
+		case tipo == exifTipoLong && total == 1:
+			diretório.inteiros[tag] = ordem.Uint32(entrada[8:])
+
+		case tipo == exifTipoShort && total == 1:
+			diretório.inteiros[tag] = uint32(ordem.Uint16(entrada[8:]))
+
+		case tipo == exifTipoASCII:
+			valor, err := conteúdo(entrada, total)
+			if err != nil {
+				return diretório, err
+			}
+			diretório.textos[tag] = strings.TrimSpace(strings.TrimRight(string(valor), "\x00"))
+
+		case tipo == exifTipoRacional && total <= exifRacionaisMáximo:
+			valor, err := conteúdo(entrada, total*8)
+			if err != nil {
+				return diretório, err
+			}
+
+			racionais := make([]float64, total)
+			for j := range racionais {
+				numerador := ordem.Uint32(valor[j*8:])
+				denominador := ordem.Uint32(valor[j*8+4:])
+				if denominador == 0 {
+					return diretório, errors.Errorf("valor racional EXIF com denominador zero")
+				}
+				racionais[j] = float64(numerador) / float64(denominador)
+			}
+			diretório.racionais[tag] = racionais
+		}
+	}
+
+	return diretório, nil
+}
@@ -0,0 +1,287 @@
+package atirador
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestExtrairMetadadosImagem(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("Erro ao carregar o fuso horário. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição          string
+		imagem             string
+		localização        *time.Location
+		metadadosEsperados metadadosImagem
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve extrair corretamente os metadados com fuso horário",
+			imagem:    gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00", nil),
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 17, 30, 0, 0, time.UTC),
+				ModeloCâmera: "Canon EOS 80D",
+			},
+		},
+		{
+			descrição:   "deve priorizar o fuso horário dos metadados sobre a localização configurada",
+			imagem:      gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "+01:00", nil),
+			localização: saoPaulo,
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 13, 30, 0, 0, time.UTC),
+				ModeloCâmera: "Canon EOS 80D",
+			},
+		},
+		{
+			descrição:   "deve extrair corretamente os metadados sem fuso horário na localização configurada",
+			imagem:      gerarImagemEXIF(t, binary.LittleEndian, "X1", "2017:03:10 14:30:00", "", nil),
+			localização: saoPaulo,
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 17, 30, 0, 0, time.UTC),
+				ModeloCâmera: "X1",
+			},
+		},
+		{
+			descrição:   "deve considerar o horário de verão da localização configurada",
+			imagem:      gerarImagemEXIF(t, binary.LittleEndian, "X1", "2017:01:10 14:30:00", "", nil),
+			localização: saoPaulo,
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 1, 10, 16, 30, 0, 0, time.UTC),
+				ModeloCâmera: "X1",
+			},
+		},
+		{
+			descrição: "deve extrair corretamente os metadados sem fuso horário e sem localização configurada",
+			imagem:    gerarImagemEXIF(t, binary.LittleEndian, "X1", "2017:03:10 14:30:00", "", nil),
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 14, 30, 0, 0, time.UTC),
+				ModeloCâmera: "X1",
+			},
+		},
+		{
+			descrição: "deve extrair corretamente as coordenadas GPS",
+			imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00",
+				&protocolo.Coordenadas{Latitude: -22.5, Longitude: -43.25}),
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 17, 30, 0, 0, time.UTC),
+				ModeloCâmera: "Canon EOS 80D",
+				Localização:  &protocolo.Coordenadas{Latitude: -22.5, Longitude: -43.25},
+			},
+		},
+		{
+			descrição: "deve extrair corretamente as coordenadas GPS no hemisfério norte",
+			imagem: gerarImagemEXIF(t, binary.LittleEndian, "X1", "2017:03:10 14:30:00", "",
+				&protocolo.Coordenadas{Latitude: 40.75, Longitude: 73.5}),
+			metadadosEsperados: metadadosImagem{
+				DataCaptura:  time.Date(2017, 3, 10, 14, 30, 0, 0, time.UTC),
+				ModeloCâmera: "X1",
+				Localização:  &protocolo.Coordenadas{Latitude: 40.75, Longitude: 73.5},
+			},
+		},
+		{
+			descrição: "deve extrair corretamente a orientação",
+			imagem: base64.StdEncoding.EncodeToString([]byte("\xff\xd8\xff\xe1\x00\x22Exif\x00\x00" +
+				"MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00" +
+				"\xff\xd9")),
+			metadadosEsperados: metadadosImagem{
+				Orientação: 6,
+			},
+		},
+		{
+			descrição: "deve ignorar uma data de captura em formato inválido",
+			imagem:    gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "10/03/2017 14:30", "", nil),
+		},
+		{
+			descrição: "deve ignorar uma imagem JPEG sem metadados",
+			imagem:    gerarImagemEXIF(t, nil, "", "", "", nil),
+		},
+		{
+			descrição: "deve ignorar uma imagem que não está no formato JPEG",
+			imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+		},
+		{
+			descrição: "deve ignorar metadados corrompidos",
+			imagem:    base64.StdEncoding.EncodeToString([]byte("\xff\xd8\xff\xe1\x00\x0eExif\x00\x00XX\x00\x2a\x00\x00\xff\xd9")),
+		},
+		{
+			descrição:    "deve detectar uma imagem com base64 inválido",
+			imagem:       "%%%",
+			erroEsperado: errors.Errorf("illegal base64 data at input byte 0"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		metadados, err := extrairMetadadosImagem(cenário.imagem, cenário.localização)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.metadadosEsperados, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(metadados, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// gerarImagemEXIF cria uma imagem JPEG codificada em base64 com os metadados
+// EXIF informados. Quando a ordem de bytes não é informada a imagem é gerada
+// sem metadados, e quando a localização não é informada os metadados não
+// possuem o subdiretório GPS.
+func gerarImagemEXIF(t *testing.T, ordem binary.ByteOrder, modelo, dataCaptura, fuso string, localização *protocolo.Coordenadas) string {
+	imagem := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			imagem.Set(x, y, color.RGBA{uint8(x * 16), uint8(y * 16), 128, 0xff})
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, imagem, nil); err != nil {
+		t.Fatalf("erro ao codificar a imagem JPEG. Detalhes: %s", err)
+	}
+
+	if ordem == nil {
+		return base64.StdEncoding.EncodeToString(buffer.Bytes())
+	}
+
+	// cada entrada possui a tag, o tipo e o conteúdo já codificado, que é
+	// armazenado na própria entrada ou ao final do TIFF conforme o tamanho
+	type entrada struct {
+		tag      uint16
+		tipo     uint16
+		total    uint32
+		conteúdo []byte
+	}
+
+	texto := func(tag uint16, valor string) entrada {
+		return entrada{tag, exifTipoASCII, uint32(len(valor) + 1), append([]byte(valor), 0)}
+	}
+
+	inteiro := func(tag uint16, valor uint32) entrada {
+		conteúdo := make([]byte, 4)
+		ordem.PutUint32(conteúdo, valor)
+		return entrada{tag, exifTipoLong, 1, conteúdo}
+	}
+
+	grausMinutosSegundos := func(tag uint16, graus float64) entrada {
+		if graus < 0 {
+			graus = -graus
+		}
+
+		minutos := (graus - float64(int(graus))) * 60
+		segundos := (minutos - float64(int(minutos))) * 60
+
+		conteúdo := make([]byte, 24)
+		for i, valor := range []uint32{uint32(graus), 1, uint32(minutos), 1, uint32(segundos * 1000), 1000} {
+			ordem.PutUint32(conteúdo[i*4:], valor)
+		}
+		return entrada{tag, exifTipoRacional, 3, conteúdo}
+	}
+
+	tamanhoDiretório := func(entradas int) int { return 2 + entradas*12 + 4 }
+
+	exif := []entrada{texto(exifTagDataOriginal, dataCaptura)}
+	if fuso != "" {
+		exif = append(exif, texto(exifTagFusoDataOriginal, fuso))
+	}
+
+	var gps []entrada
+	if localização != nil {
+		latitudeRef, longitudeRef := "N", "E"
+		if localização.Latitude < 0 {
+			latitudeRef = "S"
+		}
+		if localização.Longitude < 0 {
+			longitudeRef = "W"
+		}
+
+		gps = []entrada{
+			texto(exifTagGPSLatitudeRef, latitudeRef),
+			grausMinutosSegundos(exifTagGPSLatitude, localização.Latitude),
+			texto(exifTagGPSLongitudeRef, longitudeRef),
+			grausMinutosSegundos(exifTagGPSLongitude, localização.Longitude),
+		}
+	}
+
+	// os diretórios ficam no início do TIFF, logo após o cabeçalho, e os
+	// valores que não cabem nas entradas são adicionados ao final
+	quantidadePrincipal := 2
+	if gps != nil {
+		quantidadePrincipal++
+	}
+
+	posiçãoPrincipal := 8
+	posiçãoExif := posiçãoPrincipal + tamanhoDiretório(quantidadePrincipal)
+	posiçãoGPS := posiçãoExif + tamanhoDiretório(len(exif))
+	posiçãoValores := posiçãoGPS
+	if gps != nil {
+		posiçãoValores += tamanhoDiretório(len(gps))
+	}
+
+	principal := []entrada{
+		texto(exifTagModelo, modelo),
+		inteiro(exifTagPonteiroExif, uint32(posiçãoExif)),
+	}
+	if gps != nil {
+		principal = append(principal, inteiro(exifTagPonteiroGPS, uint32(posiçãoGPS)))
+	}
+
+	tiff := make([]byte, posiçãoValores)
+	if ordem == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	ordem.PutUint16(tiff[2:], 42)
+	ordem.PutUint32(tiff[4:], uint32(posiçãoPrincipal))
+
+	escreverDiretório := func(posição int, entradas []entrada) {
+		ordem.PutUint16(tiff[posição:], uint16(len(entradas)))
+		for i, e := range entradas {
+			p := posição + 2 + i*12
+			ordem.PutUint16(tiff[p:], e.tag)
+			ordem.PutUint16(tiff[p+2:], e.tipo)
+			ordem.PutUint32(tiff[p+4:], e.total)
+
+			if len(e.conteúdo) <= 4 {
+				copy(tiff[p+8:], e.conteúdo)
+				continue
+			}
+
+			ordem.PutUint32(tiff[p+8:], uint32(len(tiff)))
+			tiff = append(tiff, e.conteúdo...)
+		}
+	}
+
+	escreverDiretório(posiçãoPrincipal, principal)
+	escreverDiretório(posiçãoExif, exif)
+	if gps != nil {
+		escreverDiretório(posiçãoGPS, gps)
+	}
+
+	segmento := append([]byte("Exif\x00\x00"), tiff...)
+	cabeçalho := []byte{0xff, jpegMarcadorAPP1, 0, 0}
+	binary.BigEndian.PutUint16(cabeçalho[2:], uint16(len(segmento)+2))
+
+	jpegOriginal := buffer.Bytes()
+	var resultado []byte
+	resultado = append(resultado, jpegOriginal[:2]...)
+	resultado = append(resultado, cabeçalho...)
+	resultado = append(resultado, segmento...)
+	resultado = append(resultado, jpegOriginal[2:]...)
+	return base64.StdEncoding.EncodeToString(resultado)
+}
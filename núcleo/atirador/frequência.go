@@ -2,6 +2,7 @@ package atirador
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/binary"
@@ -11,6 +12,8 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/comprovante"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/randômico"
 	"golang.org/x/crypto/hkdf"
@@ -19,10 +22,12 @@ import (
 type frequência struct {
 	ID                   int64
 	Controle             int64
+	IDClube              int64
 	CR                   int
 	Calibre              string
 	ArmaUtilizada        string
 	NúmeroSérie          string
+	IDArma               int64
 	GuiaDeTráfego        int
 	QuantidadeMunição    int
 	DataInício           time.Time
@@ -32,6 +37,46 @@ type frequência struct {
 	DataConfirmação      time.Time
 	ImagemNúmeroControle string
 	ImagemConfirmação    string
+	DataCancelamento     time.Time
+	MotivoCancelamento   string
+	Situação             protocolo.FrequênciaSituação
+
+	// IDChaveVerificação identificador da chave utilizada para gerar o código
+	// de verificação. Fica vazio quando a chave legada foi utilizada.
+	IDChaveVerificação string
+
+	// HashImagemConfirmação hash perceptual da imagem de confirmação, utilizado
+	// para identificar a mesma foto enviada em mais de uma frequência.
+	HashImagemConfirmação uint64
+
+	// DataCapturaImagem e ModeloCâmeraImagem são extraídos dos metadados EXIF
+	// da imagem de confirmação, quando disponíveis.
+	DataCapturaImagem  time.Time
+	ModeloCâmeraImagem string
+
+	// SinalizaçãoImagem motivo pelo qual a imagem de confirmação foi aceita com
+	// ressalvas, permitindo que seja priorizada em uma auditoria.
+	SinalizaçãoImagem protocolo.MensagemCódigo
+
+	// MiniaturaImagemConfirmação versão reduzida da imagem de confirmação e
+	// EXIFImagemConfirmação metadados originais da imagem, ambos em base64. A
+	// imagem de confirmação é armazenada sem os metadados.
+	MiniaturaImagemConfirmação string
+	EXIFImagemConfirmação      string
+
+	// DistânciaClube distância em metros entre o local onde a imagem de
+	// confirmação foi capturada e o estande de tiro do clube. Fica indefinida
+	// quando a imagem não possui coordenadas GPS ou o clube não possui
+	// localização cadastrada.
+	DistânciaClube *float64
+
+	// referências identificam as imagens e os metadados no repositório de
+	// objetos, sendo somente elas persistidas no banco de dados. O conteúdo é
+	// mantido nos campos em base64.
+	referênciaImagemNúmeroControle       string
+	referênciaImagemConfirmação          string
+	referênciaMiniaturaImagemConfirmação string
+	referênciaEXIFImagemConfirmação      string
 
 	// revisão utilizado para o controle de versão do objeto na base de dados,
 	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
@@ -42,6 +87,7 @@ type frequência struct {
 func novaFrequência(frequênciaPedidoCompleta protocolo.FrequênciaPedidoCompleta) frequência {
 	return frequência{
 		Controle:          randômico.FonteRandômica.Int63(),
+		IDClube:           frequênciaPedidoCompleta.Clube,
 		CR:                frequênciaPedidoCompleta.CR,
 		Calibre:           frequênciaPedidoCompleta.Calibre,
 		ArmaUtilizada:     frequênciaPedidoCompleta.ArmaUtilizada,
@@ -50,15 +96,101 @@ func novaFrequência(frequênciaPedidoCompleta protocolo.FrequênciaPedidoComple
 		QuantidadeMunição: frequênciaPedidoCompleta.QuantidadeMunição,
 		DataInício:        frequênciaPedidoCompleta.DataInício,
 		DataTérmino:       frequênciaPedidoCompleta.DataTérmino,
+		Situação:          protocolo.FrequênciaSituaçãoPendente,
+	}
+}
+
+// frequênciaTransições define a máquina de estados da frequência, indicando
+// para cada situação as situações que podem ser atingidas a partir dela. As
+// situações sem transições são finais.
+var frequênciaTransições = map[protocolo.FrequênciaSituação][]protocolo.FrequênciaSituação{
+	protocolo.FrequênciaSituaçãoPendente: {
+		protocolo.FrequênciaSituaçãoConfirmada,
+		protocolo.FrequênciaSituaçãoExpirada,
+		protocolo.FrequênciaSituaçãoCancelada,
+	},
+	protocolo.FrequênciaSituaçãoConfirmada: {
+		protocolo.FrequênciaSituaçãoEmAuditoria,
+		protocolo.FrequênciaSituaçãoInvalidada,
+	},
+	protocolo.FrequênciaSituaçãoEmAuditoria: {
+		protocolo.FrequênciaSituaçãoConfirmada,
+		protocolo.FrequênciaSituaçãoInvalidada,
+	},
+}
+
+// transiçãoPermitida verifica se a máquina de estados permite que a frequência
+// passe da situação de origem para a situação de destino.
+func transiçãoPermitida(origem, destino protocolo.FrequênciaSituação) bool {
+	for _, situação := range frequênciaTransições[origem] {
+		if situação == destino {
+			return true
+		}
 	}
+
+	return false
+}
+
+// transitar altera a situação da frequência, garantindo que toda mudança de
+// estado passe pela validação da máquina de estados.
+func (f *frequência) transitar(destino protocolo.FrequênciaSituação) protocolo.Mensagens {
+	if mensagens := validarTransição(*f, destino); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	f.Situação = destino
+	return nil
 }
 
-func (f *frequência) confirmar(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) {
+func (f *frequência) confirmar(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) protocolo.Mensagens {
+	// uma frequência em auditoria retorna para confirmada somente pelo veredito
+	// do auditor, não sendo aceito o envio de uma nova imagem de confirmação
+	if f.Situação == protocolo.FrequênciaSituaçãoEmAuditoria {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
+		)
+	}
+
+	if mensagens := f.transitar(protocolo.FrequênciaSituaçãoConfirmada); len(mensagens) > 0 {
+		return mensagens
+	}
+
 	f.DataConfirmação = time.Now().UTC()
 	f.ImagemConfirmação = frequênciaConfirmaçãoPedidoCompleta.Imagem
+	return nil
+}
+
+func (f *frequência) cancelar(frequênciaCancelamentoPedidoCompleta protocolo.FrequênciaCancelamentoPedidoCompleta) protocolo.Mensagens {
+	if mensagens := f.transitar(protocolo.FrequênciaSituaçãoCancelada); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	f.DataCancelamento = time.Now().UTC()
+	f.MotivoCancelamento = frequênciaCancelamentoPedidoCompleta.Motivo
+	return nil
+}
+
+// expirar marca a frequência como expirada por não ter sido confirmada dentro
+// do prazo. Opcionalmente a imagem do número de controle é descartada, já que
+// não poderá mais ser utilizada.
+func (f *frequência) expirar(removerImagem bool) protocolo.Mensagens {
+	if mensagens := f.transitar(protocolo.FrequênciaSituaçãoExpirada); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	if removerImagem {
+		f.ImagemNúmeroControle = ""
+		f.referênciaImagemNúmeroControle = ""
+	}
+
+	return nil
 }
 
-func (f *frequência) gerarCódigoVerificação(chave string) string {
+// gerarCódigoVerificação calcula o código de verificação com a chave
+// informada. Quando a chave possui identificador, o código é prefixado com o
+// identificador seguido de um ponto, permitindo localizar a chave na
+// verificação mesmo após uma rotação.
+func (f *frequência) gerarCódigoVerificação(idChave, chave string) string {
 	buffer := new(bytes.Buffer)
 
 	// o erro retornado nesta escrita é ignorado, pois o tipo bytes.Buffer não
@@ -82,30 +214,94 @@ func (f *frequência) gerarCódigoVerificação(chave string) string {
 	if tamanho := 44 - len(mensagemCodificada); tamanho > 0 {
 		mensagemCodificada += strings.Repeat("o", tamanho)
 	}
+
+	if idChave != "" {
+		mensagemCodificada = idChave + separadorCódigoVerificação + mensagemCodificada
+	}
 	return mensagemCodificada
 }
 
+// assinarComprovante assina os dados canônicos da frequência, permitindo a
+// verificação do comprovante sem acesso ao servidor. Sem uma chave de
+// assinatura definida o comprovante não é gerado.
+func (f frequência) assinarComprovante(chave ed25519.PrivateKey) (string, error) {
+	if chave == nil {
+		return "", nil
+	}
+
+	comprovanteAssinado, err := comprovante.Assinar(chave, comprovante.Dados{
+		ID:          f.ID,
+		CR:          f.CR,
+		Controle:    f.Controle,
+		Calibre:     f.Calibre,
+		DataInício:  f.DataInício,
+		DataTérmino: f.DataTérmino,
+	})
+
+	return comprovanteAssinado, erros.Novo(err)
+}
+
+// separadorCódigoVerificação separa o identificador da chave do restante do
+// código de verificação. O caractere não faz parte do alfabeto base58.
+const separadorCódigoVerificação = "."
+
+// extrairIDChaveVerificação retorna o identificador da chave contido no
+// código de verificação, ou vazio para os códigos gerados com a chave legada.
+func extrairIDChaveVerificação(códigoVerificação string) string {
+	if i := strings.Index(códigoVerificação, separadorCódigoVerificação); i >= 0 {
+		return códigoVerificação[:i]
+	}
+
+	return ""
+}
+
 func (f frequência) protocolo(códigoVerificação string) protocolo.FrequênciaResposta {
 	return protocolo.FrequênciaResposta{
+		NúmeroControle:     protocolo.NovoNúmeroControle(f.ID, f.Controle),
+		CódigoVerificação:  códigoVerificação,
+		Clube:              f.IDClube,
+		Calibre:            f.Calibre,
+		ArmaUtilizada:      f.ArmaUtilizada,
+		NúmeroSérie:        f.NúmeroSérie,
+		Arma:               f.IDArma,
+		GuiaDeTráfego:      f.GuiaDeTráfego,
+		QuantidadeMunição:  f.QuantidadeMunição,
+		DataInício:         f.DataInício,
+		DataTérmino:        f.DataTérmino,
+		DataCriação:        f.DataCriação,
+		DataConfirmação:    f.DataConfirmação,
+		Imagem:             f.ImagemNúmeroControle,
+		Situação:           f.Situação,
+		DataCancelamento:   f.DataCancelamento,
+		MotivoCancelamento: f.MotivoCancelamento,
+	}
+}
+
+func (f frequência) protocoloListaItem() protocolo.FrequênciaListaItem {
+	return protocolo.FrequênciaListaItem{
 		NúmeroControle:    protocolo.NovoNúmeroControle(f.ID, f.Controle),
-		CódigoVerificação: códigoVerificação,
+		CR:                f.CR,
+		Clube:             f.IDClube,
 		Calibre:           f.Calibre,
 		ArmaUtilizada:     f.ArmaUtilizada,
 		NúmeroSérie:       f.NúmeroSérie,
+		Arma:              f.IDArma,
 		GuiaDeTráfego:     f.GuiaDeTráfego,
 		QuantidadeMunição: f.QuantidadeMunição,
 		DataInício:        f.DataInício,
 		DataTérmino:       f.DataTérmino,
 		DataCriação:       f.DataCriação,
 		DataConfirmação:   f.DataConfirmação,
-		Imagem:            f.ImagemNúmeroControle,
+		Situação:          f.Situação,
+		DataCancelamento:  f.DataCancelamento,
 	}
 }
 
-func (f frequência) protocoloPendente(códigoVerificação string) protocolo.FrequênciaPendenteResposta {
+func (f frequência) protocoloPendente(códigoVerificação, comprovanteAssinado string) protocolo.FrequênciaPendenteResposta {
 	return protocolo.FrequênciaPendenteResposta{
 		NúmeroControle:    protocolo.NovoNúmeroControle(f.ID, f.Controle),
 		CódigoVerificação: códigoVerificação,
+		Comprovante:       comprovanteAssinado,
 		Imagem:            f.ImagemNúmeroControle,
 	}
 }
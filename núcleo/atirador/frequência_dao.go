@@ -2,27 +2,49 @@ package atirador
 
 import (
 	"database/sql"
+	"encoding/base64"
 	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/armazenamento"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
 )
 
 type frequênciaDAO interface {
 	criar(*frequência) error
 	atualizar(*frequência) error
+	cancelar(*frequência) error
+	expirar(*frequência) error
 	resgatar(id int64) (frequência, error)
+	resgatarResumo(id int64) (frequência, error)
+	pendentesExpiradas(dataLimite time.Time, limite int) ([]frequência, error)
+	candidatasAuditoria(início, término time.Time) ([]frequência, error)
+	listar(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error)
+	habitualidadeInsuficiente(início, término time.Time, treinosExigidos int) ([]habitualidade, error)
+	consumoMunição(cr int, início, término time.Time) ([]consumoMunição, error)
+	imagemSemelhante(id int64, hash uint64, distância int) (bool, error)
+	migrarImagens(limite int) (int, error)
+	pendentesPorChave() ([]protocolo.FrequênciaPendênciaChave, error)
 }
 
 var novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
-	return frequênciaDAOImpl{sqlogger: sqlogger}
+	return frequênciaDAOImpl{
+		sqlogger:      sqlogger,
+		armazenamento: armazenamento.Atual,
+	}
 }
 
 type frequênciaDAOImpl struct {
-	sqlogger *bd.SQLogger
+	sqlogger      *bd.SQLogger
+	armazenamento armazenamento.Armazenamento
 }
 
 func (f frequênciaDAOImpl) criar(frequência *frequência) error {
@@ -35,15 +57,19 @@ func (f frequênciaDAOImpl) criar(frequência *frequência) error {
 
 	resultado := f.sqlogger.QueryRow(frequênciaCriaçãoComando,
 		frequência.Controle,
+		frequência.IDClube,
 		frequência.CR,
 		frequência.Calibre,
 		frequência.ArmaUtilizada,
 		frequência.NúmeroSérie,
+		sql.NullInt64{Int64: frequência.IDArma, Valid: frequência.IDArma > 0},
 		frequência.GuiaDeTráfego,
 		frequência.QuantidadeMunição,
 		frequência.DataInício.UTC(),
 		frequência.DataTérmino.UTC(),
 		frequência.DataCriação.UTC(),
+		frequência.IDChaveVerificação,
+		frequência.Situação,
 		frequência.revisão,
 	)
 
@@ -56,10 +82,31 @@ func (f frequênciaDAOImpl) criar(frequência *frequência) error {
 }
 
 func (f frequênciaDAOImpl) atualizar(frequência *frequência) error {
+	return f.atualizarComAção(frequência, bd.AçãoLogAtualização)
+}
+
+// cancelar persiste a data e o motivo do cancelamento da frequência. A
+// frequência é mantida na base de dados, sendo o cancelamento registrado no
+// log com uma ação específica.
+func (f frequênciaDAOImpl) cancelar(frequência *frequência) error {
+	return f.atualizarComAção(frequência, bd.AçãoLogCancelamento)
+}
+
+// expirar persiste a expiração da frequência, registrando no log uma ação
+// específica para diferenciá-la das alterações feitas pelos usuários.
+func (f frequênciaDAOImpl) expirar(frequência *frequência) error {
+	return f.atualizarComAção(frequência, bd.AçãoLogExpiração)
+}
+
+func (f frequênciaDAOImpl) atualizarComAção(frequência *frequência, ação bd.AçãoLog) error {
 	if frequência == nil {
 		return erros.Novo(erros.ObjetoIndefinido)
 	}
 
+	if err := f.armazenarImagens(frequência); err != nil {
+		return erros.Novo(err)
+	}
+
 	frequência.DataAtualização = time.Now().UTC()
 	frequência.revisão++
 
@@ -67,8 +114,18 @@ func (f frequênciaDAOImpl) atualizar(frequência *frequência) error {
 		frequência.DataAtualização.UTC(),
 		frequência.DataConfirmação.UTC(),
 		frequência.revisão,
-		frequência.ImagemNúmeroControle,
-		frequência.ImagemConfirmação,
+		referênciaImagemBD(frequência.referênciaImagemNúmeroControle),
+		referênciaImagemBD(frequência.referênciaImagemConfirmação),
+		referênciaImagemBD(frequência.referênciaMiniaturaImagemConfirmação),
+		referênciaImagemBD(frequência.referênciaEXIFImagemConfirmação),
+		sql.NullInt64{Int64: int64(frequência.HashImagemConfirmação), Valid: frequência.referênciaImagemConfirmação != ""},
+		pq.NullTime{Time: frequência.DataCapturaImagem.UTC(), Valid: !frequência.DataCapturaImagem.IsZero()},
+		frequência.ModeloCâmeraImagem,
+		frequência.SinalizaçãoImagem,
+		distânciaClubeBD(frequência.DistânciaClube),
+		pq.NullTime{Time: frequência.DataCancelamento.UTC(), Valid: !frequência.DataCancelamento.IsZero()},
+		frequência.MotivoCancelamento,
+		frequência.Situação,
 		frequência.ID,
 		frequência.revisão-1,
 	)
@@ -88,23 +145,235 @@ func (f frequênciaDAOImpl) atualizar(frequência *frequência) error {
 	}
 
 	frequênciaLogDAO := novaFrequênciaLogDAO(f.sqlogger)
-	return erros.Novo(frequênciaLogDAO.criar(*frequência, bd.AçãoLogAtualização))
+	return erros.Novo(frequênciaLogDAO.criar(*frequência, ação))
 }
 
+// resgatar retorna a frequência com o conteúdo das imagens, que é obtido do
+// repositório de objetos a partir das referências.
 func (f frequênciaDAOImpl) resgatar(id int64) (frequência, error) {
 	resultado := f.sqlogger.QueryRow(frequênciaResgateComando, id)
 
+	freq, err := interpretarFrequência(resultado)
+	if err != nil {
+		return frequência{}, erros.Novo(err)
+	}
+
+	if err := f.carregarImagens(&freq); err != nil {
+		return frequência{}, erros.Novo(err)
+	}
+
+	return freq, nil
+}
+
+// resgatarResumo retorna a frequência sem as imagens e sem os dados da
+// confirmação, evitando o acesso ao repositório de objetos quando somente os
+// dados do treino são necessários.
+func (f frequênciaDAOImpl) resgatarResumo(id int64) (frequência, error) {
+	resultado := f.sqlogger.QueryRow(frequênciaResgateResumoComando, id)
+
+	var idChaveVerificação string
+	freq, err := interpretarFrequênciaListagem(resultado, &idChaveVerificação)
+	if err != nil {
+		return frequência{}, erros.Novo(err)
+	}
+
+	freq.IDChaveVerificação = idChaveVerificação
+	return freq, nil
+}
+
+// armazenarImagens grava as imagens da frequência no repositório de objetos,
+// atualizando as referências que serão persistidas. As frequências obtidas em
+// listagens não possuem o conteúdo das imagens, mantendo as referências
+// atuais. Para remover uma imagem a sua referência também deve ser removida,
+// porém o objeto é mantido no repositório, pois continua referenciado pelo
+// log.
+func (f frequênciaDAOImpl) armazenarImagens(frequência *frequência) error {
+	var err error
+
+	frequência.referênciaImagemNúmeroControle, err = armazenarImagem(f.armazenamento,
+		frequência.ImagemNúmeroControle, frequência.referênciaImagemNúmeroControle)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	frequência.referênciaImagemConfirmação, err = armazenarImagem(f.armazenamento,
+		frequência.ImagemConfirmação, frequência.referênciaImagemConfirmação)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	frequência.referênciaMiniaturaImagemConfirmação, err = armazenarImagem(f.armazenamento,
+		frequência.MiniaturaImagemConfirmação, frequência.referênciaMiniaturaImagemConfirmação)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	frequência.referênciaEXIFImagemConfirmação, err = armazenarImagem(f.armazenamento,
+		frequência.EXIFImagemConfirmação, frequência.referênciaEXIFImagemConfirmação)
+
+	return erros.Novo(err)
+}
+
+// armazenarImagem grava no repositório de objetos a imagem em base64,
+// retornando a sua referência. Quando a imagem não estiver preenchida a
+// referência atual é mantida.
+func armazenarImagem(a armazenamento.Armazenamento, imagemBase64, referênciaAtual string) (string, error) {
+	if imagemBase64 == "" {
+		return referênciaAtual, nil
+	}
+
+	imagem, err := base64.StdEncoding.DecodeString(imagemBase64)
+	if err != nil {
+		return "", erros.Novo(err)
+	}
+
+	// a imagem não foi alterada desde que foi carregada do repositório
+	if referência := armazenamento.Referência(imagem); referência == referênciaAtual {
+		return referência, nil
+	}
+
+	if a == nil {
+		return "", erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	referência, err := a.Armazenar(imagem)
+	return referência, erros.Novo(err)
+}
+
+// carregarImagens obtém do repositório de objetos o conteúdo das imagens e dos
+// metadados referenciados pela frequência. As imagens ainda não migradas do banco de
+// dados já estão preenchidas e são mantidas.
+func (f frequênciaDAOImpl) carregarImagens(frequência *frequência) error {
+	var err error
+
+	if frequência.ImagemNúmeroControle == "" {
+		frequência.ImagemNúmeroControle, err = f.carregarImagem(frequência.referênciaImagemNúmeroControle)
+		if err != nil {
+			return erros.Novo(err)
+		}
+	}
+
+	if frequência.ImagemConfirmação == "" {
+		frequência.ImagemConfirmação, err = f.carregarImagem(frequência.referênciaImagemConfirmação)
+		if err != nil {
+			return erros.Novo(err)
+		}
+	}
+
+	frequência.MiniaturaImagemConfirmação, err = f.carregarImagem(frequência.referênciaMiniaturaImagemConfirmação)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	frequência.EXIFImagemConfirmação, err = f.carregarImagem(frequência.referênciaEXIFImagemConfirmação)
+	return erros.Novo(err)
+}
+
+func (f frequênciaDAOImpl) carregarImagem(referência string) (string, error) {
+	if referência == "" {
+		return "", nil
+	}
+
+	if f.armazenamento == nil {
+		return "", erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	imagem, err := f.armazenamento.Obter(referência)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		// a ausência da imagem é uma inconsistência do repositório, e não deve
+		// ser confundida com uma frequência inexistente
+		return "", errors.Errorf("imagem “%s” não encontrada no repositório de objetos", referência)
+	} else if err != nil {
+		return "", erros.Novo(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(imagem), nil
+}
+
+// migrarImagens move para o repositório de objetos as imagens que ainda estão
+// armazenadas em base64 nas frequências e no log das frequências, processando
+// no máximo a quantidade de registros informada no limite em cada tabela.
+// Retorna a quantidade total de registros migrados.
+func (f frequênciaDAOImpl) migrarImagens(limite int) (int, error) {
+	quantidade, err := migrarImagensLegadas(f.sqlogger, f.armazenamento, frequênciaTabela, limite)
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	frequênciaLogDAO := novaFrequênciaLogDAO(f.sqlogger)
+	quantidadeLog, err := frequênciaLogDAO.migrarImagens(limite)
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	return quantidade + quantidadeLog, nil
+}
+
+// pendentesExpiradas retorna as frequências ainda pendentes que foram criadas
+// antes da data limite, ou seja, cujo prazo de confirmação já passou. A
+// quantidade de frequências retornadas é restrita pelo limite informado.
+func (f frequênciaDAOImpl) pendentesExpiradas(dataLimite time.Time, limite int) ([]frequência, error) {
+	return f.listarPorComando(frequênciaPendentesExpiradasComando, dataLimite.UTC(), limite)
+}
+
+// candidatasAuditoria retorna as frequências confirmadas com treino iniciado
+// no período, inclusive, que ainda não foram sorteadas em nenhuma amostra de
+// auditoria. As frequências são ordenadas pelo clube e pelo número de
+// identificação, permitindo reproduzir o sorteio.
+func (f frequênciaDAOImpl) candidatasAuditoria(início, término time.Time) ([]frequência, error) {
+	return f.listarPorComando(frequênciaCandidatasAuditoriaComando, início.UTC(), término.UTC())
+}
+
+func (f frequênciaDAOImpl) listarPorComando(comando string, argumentos ...interface{}) ([]frequência, error) {
+	linhas, err := f.sqlogger.Query(comando, argumentos...)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var frequências []frequência
+	for linhas.Next() {
+		freq, err := interpretarFrequência(linhas)
+		if err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		frequências = append(frequências, freq)
+	}
+
+	return frequências, erros.Novo(linhas.Err())
+}
+
+// escaneador representa o resultado de uma consulta, podendo ser uma única
+// linha ou a linha atual de um conjunto de resultados.
+type escaneador interface {
+	Scan(dest ...interface{}) error
+}
+
+// interpretarFrequência converte uma linha com todos os campos do resgate em
+// uma frequência.
+func interpretarFrequência(linha escaneador) (frequência, error) {
 	var freq frequência
-	var dataAtualização, dataConfirmação pq.NullTime
-	var imagemNúmeroControle, imagemConfirmação sql.NullString
+	var idArma, hashImagemConfirmação sql.NullInt64
+	var dataAtualização, dataConfirmação, dataCapturaImagem, dataCancelamento pq.NullTime
+	var referênciaImagemNúmeroControle, referênciaImagemConfirmação sql.NullString
+	var referênciaMiniaturaImagemConfirmação, referênciaEXIFImagemConfirmação sql.NullString
+	var imagemNúmeroControle, imagemConfirmação, modeloCâmeraImagem, sinalizaçãoImagem, motivoCancelamento sql.NullString
+	var distânciaClube sql.NullFloat64
+	var situação string
 
-	err := resultado.Scan(
+	err := linha.Scan(
 		&freq.ID,
 		&freq.Controle,
+		&freq.IDClube,
 		&freq.CR,
 		&freq.Calibre,
 		&freq.ArmaUtilizada,
 		&freq.NúmeroSérie,
+		&idArma,
 		&freq.GuiaDeTráfego,
 		&freq.QuantidadeMunição,
 		&freq.DataInício,
@@ -112,11 +381,30 @@ func (f frequênciaDAOImpl) resgatar(id int64) (frequência, error) {
 		&freq.DataCriação,
 		&dataAtualização,
 		&dataConfirmação,
+		&referênciaImagemNúmeroControle,
+		&referênciaImagemConfirmação,
+		&referênciaMiniaturaImagemConfirmação,
+		&referênciaEXIFImagemConfirmação,
 		&imagemNúmeroControle,
 		&imagemConfirmação,
+		&hashImagemConfirmação,
+		&dataCapturaImagem,
+		&modeloCâmeraImagem,
+		&sinalizaçãoImagem,
+		&distânciaClube,
+		&dataCancelamento,
+		&motivoCancelamento,
+		&freq.IDChaveVerificação,
+		&situação,
 		&freq.revisão,
 	)
 
+	freq.Situação = protocolo.FrequênciaSituação(situação)
+
+	if idArma.Valid {
+		freq.IDArma = idArma.Int64
+	}
+
 	if dataAtualização.Valid {
 		freq.DataAtualização = dataAtualização.Time
 	}
@@ -125,6 +413,24 @@ func (f frequênciaDAOImpl) resgatar(id int64) (frequência, error) {
 		freq.DataConfirmação = dataConfirmação.Time
 	}
 
+	if referênciaImagemNúmeroControle.Valid {
+		freq.referênciaImagemNúmeroControle = referênciaImagemNúmeroControle.String
+	}
+
+	if referênciaImagemConfirmação.Valid {
+		freq.referênciaImagemConfirmação = referênciaImagemConfirmação.String
+	}
+
+	if referênciaMiniaturaImagemConfirmação.Valid {
+		freq.referênciaMiniaturaImagemConfirmação = referênciaMiniaturaImagemConfirmação.String
+	}
+
+	if referênciaEXIFImagemConfirmação.Valid {
+		freq.referênciaEXIFImagemConfirmação = referênciaEXIFImagemConfirmação.String
+	}
+
+	// as imagens em base64 só existem nas frequências anteriores ao repositório
+	// de objetos que ainda não foram migradas
 	if imagemNúmeroControle.Valid {
 		freq.ImagemNúmeroControle = imagemNúmeroControle.String
 	}
@@ -133,24 +439,406 @@ func (f frequênciaDAOImpl) resgatar(id int64) (frequência, error) {
 		freq.ImagemConfirmação = imagemConfirmação.String
 	}
 
+	if hashImagemConfirmação.Valid {
+		freq.HashImagemConfirmação = uint64(hashImagemConfirmação.Int64)
+	}
+
+	if dataCapturaImagem.Valid {
+		freq.DataCapturaImagem = dataCapturaImagem.Time
+	}
+
+	if modeloCâmeraImagem.Valid {
+		freq.ModeloCâmeraImagem = modeloCâmeraImagem.String
+	}
+
+	if sinalizaçãoImagem.Valid {
+		freq.SinalizaçãoImagem = protocolo.MensagemCódigo(sinalizaçãoImagem.String)
+	}
+
+	if distânciaClube.Valid {
+		freq.DistânciaClube = &distânciaClube.Float64
+	}
+
+	if dataCancelamento.Valid {
+		freq.DataCancelamento = dataCancelamento.Time
+	}
+
+	if motivoCancelamento.Valid {
+		freq.MotivoCancelamento = motivoCancelamento.String
+	}
+
 	return freq, erros.Novo(err)
 }
 
+// interpretarFrequênciaListagem converte uma linha com os campos da listagem em
+// uma frequência. Os campos adicionais, consultados após os campos da
+// listagem, são preenchidos nos destinos informados.
+func interpretarFrequênciaListagem(linha escaneador, adicionais ...interface{}) (frequência, error) {
+	var freq frequência
+	var idArma sql.NullInt64
+	var dataAtualização, dataConfirmação, dataCancelamento pq.NullTime
+	var situação string
+
+	destinos := []interface{}{
+		&freq.ID,
+		&freq.Controle,
+		&freq.IDClube,
+		&freq.CR,
+		&freq.Calibre,
+		&freq.ArmaUtilizada,
+		&freq.NúmeroSérie,
+		&idArma,
+		&freq.GuiaDeTráfego,
+		&freq.QuantidadeMunição,
+		&freq.DataInício,
+		&freq.DataTérmino,
+		&freq.DataCriação,
+		&dataAtualização,
+		&dataConfirmação,
+		&dataCancelamento,
+		&situação,
+		&freq.revisão,
+	}
+
+	if err := linha.Scan(append(destinos, adicionais...)...); err != nil {
+		return frequência{}, erros.Novo(err)
+	}
+
+	freq.Situação = protocolo.FrequênciaSituação(situação)
+
+	if idArma.Valid {
+		freq.IDArma = idArma.Int64
+	}
+
+	if dataAtualização.Valid {
+		freq.DataAtualização = dataAtualização.Time
+	}
+
+	if dataConfirmação.Valid {
+		freq.DataConfirmação = dataConfirmação.Time
+	}
+
+	if dataCancelamento.Valid {
+		freq.DataCancelamento = dataCancelamento.Time
+	}
+
+	return freq, nil
+}
+
+// referênciaImagemBD converte a referência de uma imagem para o valor da
+// coluna, que fica nula quando a frequência não possui a imagem.
+func referênciaImagemBD(referência string) sql.NullString {
+	return sql.NullString{String: referência, Valid: referência != ""}
+}
+
+// migrarImagensLegadas move as imagens em base64 das linhas da tabela para o
+// repositório de objetos, substituindo-as pelas referências. As linhas são
+// alteradas sem gerar revisão ou log, pois o conteúdo das imagens não muda.
+// Retorna a quantidade de linhas migradas, que é zero quando não houver mais
+// imagens a migrar.
+func migrarImagensLegadas(sqlogger *bd.SQLogger, a armazenamento.Armazenamento, tabela string, limite int) (int, error) {
+	if a == nil {
+		return 0, erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	linhas, err := sqlogger.Query(fmt.Sprintf(imagensLegadasComando, tabela), limite)
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	type imagensLegadas struct {
+		id                   int64
+		imagemNúmeroControle sql.NullString
+		imagemConfirmação    sql.NullString
+	}
+
+	// as linhas são lidas por completo antes das atualizações, pois não é
+	// possível executar comandos na transação com um resultado aberto
+	var pendentes []imagensLegadas
+	for linhas.Next() {
+		var imagens imagensLegadas
+		if err := linhas.Scan(&imagens.id, &imagens.imagemNúmeroControle, &imagens.imagemConfirmação); err != nil {
+			linhas.Close()
+			return 0, erros.Novo(err)
+		}
+
+		pendentes = append(pendentes, imagens)
+	}
+
+	if err := linhas.Err(); err != nil {
+		linhas.Close()
+		return 0, erros.Novo(err)
+	}
+	linhas.Close()
+
+	for _, imagens := range pendentes {
+		referênciaImagemNúmeroControle, err := armazenarImagem(a, imagens.imagemNúmeroControle.String, "")
+		if err != nil {
+			return 0, erros.Novo(err)
+		}
+
+		referênciaImagemConfirmação, err := armazenarImagem(a, imagens.imagemConfirmação.String, "")
+		if err != nil {
+			return 0, erros.Novo(err)
+		}
+
+		_, err = sqlogger.Exec(fmt.Sprintf(imagensLegadasMigraçãoComando, tabela),
+			referênciaImagemBD(referênciaImagemNúmeroControle),
+			referênciaImagemBD(referênciaImagemConfirmação),
+			imagens.id,
+		)
+
+		if err != nil {
+			return 0, erros.Novo(err)
+		}
+	}
+
+	return len(pendentes), nil
+}
+
+// distânciaClubeBD converte a distância até o clube para o valor da coluna, que
+// fica nula quando a distância não pôde ser calculada.
+func distânciaClubeBD(distância *float64) sql.NullFloat64 {
+	if distância == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *distância, Valid: true}
+}
+
+// listar retorna as frequências que atendem ao filtro, sem as imagens, a
+// partir da posição indicada pelo cursor. O cursor pode ser nulo quando a
+// primeira página é solicitada.
+func (f frequênciaDAOImpl) listar(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+	comando, argumentos := frequênciaListagemComando(filtro, c, limite)
+
+	linhas, err := f.sqlogger.Query(comando, argumentos...)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var frequências []frequência
+	for linhas.Next() {
+		freq, err := interpretarFrequênciaListagem(linhas)
+		if err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		frequências = append(frequências, freq)
+	}
+
+	return frequências, erros.Novo(linhas.Err())
+}
+
+// habitualidadeInsuficiente retorna os atiradores cadastrados que possuem
+// menos treinos confirmados do que o exigido no período. Um treino é
+// considerado confirmado quando possui data de confirmação, e os atiradores
+// sem nenhuma frequência no período também são retornados. Atiradores com o
+// CR cancelado não fazem parte do relatório.
+func (f frequênciaDAOImpl) habitualidadeInsuficiente(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+	linhas, err := f.sqlogger.Query(frequênciaHabitualidadeComando, início.UTC(), término.UTC(), treinosExigidos)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var habitualidades []habitualidade
+	for linhas.Next() {
+		var h habitualidade
+		if err := linhas.Scan(&h.CR, &h.Treinos); err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		habitualidades = append(habitualidades, h)
+	}
+
+	return habitualidades, erros.Novo(linhas.Err())
+}
+
+// consumoMunição soma a munição utilizada pelo atirador em cada calibre nas
+// frequências iniciadas no período, incluindo as que ainda não foram
+// confirmadas e ignorando as canceladas e invalidadas. O término do período
+// não é incluído.
+func (f frequênciaDAOImpl) consumoMunição(cr int, início, término time.Time) ([]consumoMunição, error) {
+	linhas, err := f.sqlogger.Query(frequênciaConsumoMuniçãoComando, cr, início.UTC(), término.UTC())
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var consumos []consumoMunição
+	for linhas.Next() {
+		var c consumoMunição
+		if err := linhas.Scan(&c.Calibre, &c.Classe, &c.Quantidade); err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		consumos = append(consumos, c)
+	}
+
+	return consumos, erros.Novo(linhas.Err())
+}
+
+// pendentesPorChave conta as frequências ainda pendentes agrupadas pela chave
+// utilizada na geração do código de verificação, ordenadas pelo identificador
+// da chave.
+func (f frequênciaDAOImpl) pendentesPorChave() ([]protocolo.FrequênciaPendênciaChave, error) {
+	linhas, err := f.sqlogger.Query(frequênciaPendentesPorChaveComando)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var pendências []protocolo.FrequênciaPendênciaChave
+	for linhas.Next() {
+		var p protocolo.FrequênciaPendênciaChave
+		if err := linhas.Scan(&p.Chave, &p.Pendentes); err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		pendências = append(pendências, p)
+	}
+
+	return pendências, erros.Novo(linhas.Err())
+}
+
+// imagemSemelhante verifica se alguma outra frequência já possui uma imagem de
+// confirmação cujo hash perceptual está a no máximo a distância de Hamming
+// informada do hash da nova imagem.
+func (f frequênciaDAOImpl) imagemSemelhante(id int64, hash uint64, distância int) (bool, error) {
+	candidatos := candidatosBandasHash(hash, distância)
+
+	var existe bool
+	resultado := f.sqlogger.QueryRow(frequênciaImagemSemelhanteComando,
+		candidatos[0], candidatos[1], candidatos[2], candidatos[3], int64(hash), distância, id)
+	if err := resultado.Scan(&existe); err != nil {
+		return false, erros.Novo(err)
+	}
+
+	return existe, nil
+}
+
+// bandasHash quantidade de partes de 16 bits em que o hash perceptual é
+// dividido na busca por imagens semelhantes. Cada parte possui um índice
+// próprio no banco de dados.
+const bandasHash = 4
+
+// candidatosBandasHash retorna, para cada banda do hash, os valores que diferem
+// dela em no máximo distância/bandasHash bits, no formato de array do
+// PostgreSQL. Se dois hashes estão a no máximo a distância informada, ao menos
+// uma das bandas difere nesta quantidade de bits, então basta consultar os
+// índices das bandas pelos candidatos e calcular a distância somente das
+// frequências encontradas. A quantidade de candidatos cresce rapidamente com a
+// distância (17 valores por banda até 7 bits, 137 até 11 bits), tornando a
+// consulta menos seletiva.
+func candidatosBandasHash(hash uint64, distância int) [bandasHash]string {
+	diferençaMáxima := distância / bandasHash
+
+	var candidatos [bandasHash]string
+	for i := range candidatos {
+		banda := uint16(hash >> uint(16*(bandasHash-1-i)))
+
+		var valores []string
+		for valor := 0; valor <= math.MaxUint16; valor++ {
+			if bits.OnesCount16(uint16(valor)^banda) <= diferençaMáxima {
+				valores = append(valores, strconv.Itoa(valor))
+			}
+		}
+
+		candidatos[i] = "{" + strings.Join(valores, ",") + "}"
+	}
+
+	return candidatos
+}
+
+// frequênciaListagemComando monta a consulta da listagem somente com as
+// condições dos campos preenchidos no filtro, que já deve estar normalizado e
+// validado. A paginação compara o par (campo
+// ordenado, id) com os valores do cursor, evitando o custo do OFFSET em
+// tabelas grandes.
+func frequênciaListagemComando(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) (string, []interface{}) {
+	var condições []string
+	var argumentos []interface{}
+
+	adicionarCondição := func(condição string, valores ...interface{}) {
+		marcadores := make([]interface{}, len(valores))
+		for i := range valores {
+			marcadores[i] = fmt.Sprintf("$%d", len(argumentos)+i+1)
+		}
+
+		condições = append(condições, fmt.Sprintf(condição, marcadores...))
+		argumentos = append(argumentos, valores...)
+	}
+
+	if filtro.CR > 0 {
+		adicionarCondição("cr = %s", filtro.CR)
+	}
+
+	if filtro.Clube > 0 {
+		adicionarCondição("id_clube = %s", filtro.Clube)
+	}
+
+	if filtro.Calibre != "" {
+		adicionarCondição("calibre = %s", filtro.Calibre)
+	}
+
+	if filtro.NúmeroSérie != "" {
+		adicionarCondição("numero_serie = %s", filtro.NúmeroSérie)
+	}
+
+	if !filtro.DataInícioDe.IsZero() {
+		adicionarCondição("data_inicio >= %s", filtro.DataInícioDe.UTC())
+	}
+
+	if !filtro.DataInícioAté.IsZero() {
+		adicionarCondição("data_inicio <= %s", filtro.DataInícioAté.UTC())
+	}
+
+	if filtro.Situação != "" {
+		adicionarCondição("situacao = %s", string(filtro.Situação))
+	}
+
+	coluna := frequênciaOrdenaçãoColunas[filtro.Ordenação]
+
+	direção, comparação := "ASC", ">"
+	if filtro.Ordenação.Decrescente() {
+		direção, comparação = "DESC", "<"
+	}
+
+	if c != nil {
+		adicionarCondição("("+coluna+", id) "+comparação+" (%s, %s)", c.valor(), c.ID)
+	}
+
+	comando := fmt.Sprintf(`SELECT %s FROM %s`, frequênciaListagemCamposTexto, frequênciaTabela)
+	if len(condições) > 0 {
+		comando += " WHERE " + strings.Join(condições, " AND ")
+	}
+
+	argumentos = append(argumentos, limite)
+	comando += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", coluna, direção, direção, len(argumentos))
+	return comando, argumentos
+}
+
 var (
 	frequênciaTabela = "frequencia_atirador"
 
 	frequênciaCriaçãoCampos = []string{
 		"id",
 		"controle",
+		"id_clube",
 		"cr",
 		"calibre",
 		"arma_utilizada",
 		"numero_serie",
+		"id_arma",
 		"guia_de_trafego",
 		"quantidade_municao",
 		"data_inicio",
 		"data_termino",
 		"data_criacao",
+		"chave_codigo_verificacao",
+		"situacao",
 		"revisao",
 	}
 	frequênciaCriaçãoCamposTexto = strings.Join(frequênciaCriaçãoCampos, ", ")
@@ -161,17 +849,31 @@ var (
 	data_atualizacao = $1,
 	data_confirmacao = $2,
 	revisao = $3,
-	imagem_numero_controle = $4,
-	imagem_confirmacao = $5
-	WHERE id = $6 AND revisao = $7`, frequênciaTabela)
+	referencia_imagem_numero_controle = $4,
+	referencia_imagem_confirmacao = $5,
+	referencia_miniatura_imagem_confirmacao = $6,
+	referencia_exif_imagem_confirmacao = $7,
+	imagem_numero_controle = NULL,
+	imagem_confirmacao = NULL,
+	hash_imagem_confirmacao = $8,
+	data_captura_imagem = $9,
+	modelo_camera_imagem = $10,
+	sinalizacao_imagem = $11,
+	distancia_clube = $12,
+	data_cancelamento = $13,
+	motivo_cancelamento = $14,
+	situacao = $15
+	WHERE id = $16 AND revisao = $17`, frequênciaTabela)
 
 	frequênciaResgateCampos = []string{
 		"id",
 		"controle",
+		"id_clube",
 		"cr",
 		"calibre",
 		"arma_utilizada",
 		"numero_serie",
+		"id_arma",
 		"guia_de_trafego",
 		"quantidade_municao",
 		"data_inicio",
@@ -179,11 +881,137 @@ var (
 		"data_criacao",
 		"data_atualizacao",
 		"data_confirmacao",
+		"referencia_imagem_numero_controle",
+		"referencia_imagem_confirmacao",
+		"referencia_miniatura_imagem_confirmacao",
+		"referencia_exif_imagem_confirmacao",
 		"imagem_numero_controle",
 		"imagem_confirmacao",
+		"hash_imagem_confirmacao",
+		"data_captura_imagem",
+		"modelo_camera_imagem",
+		"sinalizacao_imagem",
+		"distancia_clube",
+		"data_cancelamento",
+		"motivo_cancelamento",
+		"chave_codigo_verificacao",
+		"situacao",
 		"revisao",
 	}
 	frequênciaResgateCamposTexto = strings.Join(frequênciaResgateCampos, ", ")
 	frequênciaResgateComando     = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
 		frequênciaResgateCamposTexto, frequênciaTabela)
+
+	frequênciaPendentesExpiradasComando = fmt.Sprintf(`SELECT %s FROM %s
+	WHERE situacao = 'pendente' AND data_criacao < $1 ORDER BY id LIMIT $2`,
+		frequênciaResgateCamposTexto, frequênciaTabela)
+
+	frequênciaCandidatasAuditoriaComando = fmt.Sprintf(`SELECT %s FROM %s
+	WHERE situacao = 'confirmada' AND data_inicio BETWEEN $1 AND $2
+	AND id NOT IN (SELECT id_frequencia_atirador FROM auditoria)
+	ORDER BY id_clube, id`,
+		frequênciaResgateCamposTexto, frequênciaTabela)
+
+	// as bandas do hash são comparadas com as mesmas expressões dos índices
+	// da tabela, permitindo que o banco de dados utilize os índices para
+	// selecionar as candidatas; a distância de Hamming, quantidade de bits 1 no
+	// resultado do ou exclusivo entre os hashes, é calculada somente sobre elas
+	frequênciaImagemSemelhanteComando = fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s
+	WHERE (((hash_imagem_confirmacao >> 48) & 65535) = ANY($1::int[])
+	OR ((hash_imagem_confirmacao >> 32) & 65535) = ANY($2::int[])
+	OR ((hash_imagem_confirmacao >> 16) & 65535) = ANY($3::int[])
+	OR (hash_imagem_confirmacao & 65535) = ANY($4::int[]))
+	AND length(replace((hash_imagem_confirmacao # $5)::bit(64)::text, '0', '')) <= $6
+	AND id != $7)`, frequênciaTabela)
+
+	// as colunas imagem_numero_controle e imagem_confirmacao armazenavam as
+	// imagens em base64 antes do repositório de objetos, sendo mantidas somente
+	// até a migração; a tabela é informada na execução, permitindo migrar
+	// também o log das frequências
+	imagensLegadasComando = `SELECT id, imagem_numero_controle, imagem_confirmacao FROM %s
+	WHERE imagem_numero_controle IS NOT NULL OR imagem_confirmacao IS NOT NULL
+	ORDER BY id LIMIT $1`
+
+	imagensLegadasMigraçãoComando = `UPDATE %s SET
+	referencia_imagem_numero_controle = $1,
+	referencia_imagem_confirmacao = $2,
+	imagem_numero_controle = NULL,
+	imagem_confirmacao = NULL
+	WHERE id = $3`
+
+	frequênciaListagemCampos = []string{
+		"id",
+		"controle",
+		"id_clube",
+		"cr",
+		"calibre",
+		"arma_utilizada",
+		"numero_serie",
+		"id_arma",
+		"guia_de_trafego",
+		"quantidade_municao",
+		"data_inicio",
+		"data_termino",
+		"data_criacao",
+		"data_atualizacao",
+		"data_confirmacao",
+		"data_cancelamento",
+		"situacao",
+		"revisao",
+	}
+	frequênciaListagemCamposTexto = strings.Join(frequênciaListagemCampos, ", ")
+
+	// o resumo possui os campos da listagem e a chave do código de verificação,
+	// necessária para validar o acesso à frequência
+	frequênciaResgateResumoCampos = append(append([]string{}, frequênciaListagemCampos...),
+		"chave_codigo_verificacao")
+	frequênciaResgateResumoComando = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
+		strings.Join(frequênciaResgateResumoCampos, ", "), frequênciaTabela)
+
+	frequênciaHabitualidadeCampos = []string{
+		"cr",
+		"treinos",
+	}
+	frequênciaHabitualidadeComando = fmt.Sprintf(`SELECT %s FROM (
+	SELECT atirador.cr, COUNT(frequencia.id) AS treinos
+	FROM %s AS atirador
+	LEFT JOIN %s AS frequencia ON frequencia.cr = atirador.cr
+	AND frequencia.data_confirmacao IS NOT NULL
+	AND frequencia.data_inicio >= $1 AND frequencia.data_inicio <= $2
+	WHERE atirador.situacao != 'cancelado'
+	GROUP BY atirador.cr
+	) AS habitualidade WHERE treinos < $3 ORDER BY cr`,
+		strings.Join(frequênciaHabitualidadeCampos, ", "), atiradorTabela, frequênciaTabela)
+
+	frequênciaConsumoMuniçãoCampos = []string{
+		"calibre",
+		"classe",
+		"quantidade",
+	}
+	frequênciaConsumoMuniçãoComando = fmt.Sprintf(`SELECT %s FROM (
+	SELECT f.calibre, COALESCE(c.classe, '') AS classe, SUM(f.quantidade_municao) AS quantidade
+	FROM %s AS f LEFT JOIN calibre AS c ON c.nome = f.calibre
+	WHERE f.cr = $1 AND f.data_inicio >= $2 AND f.data_inicio < $3 AND f.situacao NOT IN ('cancelada', 'invalidada')
+	GROUP BY f.calibre, c.classe
+	) AS consumo ORDER BY calibre`,
+		strings.Join(frequênciaConsumoMuniçãoCampos, ", "), frequênciaTabela)
+
+	frequênciaPendentesPorChaveCampos = []string{
+		"chave_codigo_verificacao",
+		"pendentes",
+	}
+	frequênciaPendentesPorChaveComando = fmt.Sprintf(`SELECT %s FROM (
+	SELECT chave_codigo_verificacao, COUNT(*) AS pendentes
+	FROM %s WHERE situacao = 'pendente' GROUP BY chave_codigo_verificacao
+	) AS pendencias ORDER BY chave_codigo_verificacao`,
+		strings.Join(frequênciaPendentesPorChaveCampos, ", "), frequênciaTabela)
+
+	frequênciaOrdenaçãoColunas = map[protocolo.FrequênciaOrdenação]string{
+		protocolo.FrequênciaOrdenaçãoDataInício:             "data_inicio",
+		protocolo.FrequênciaOrdenaçãoDataInícioDecrescente:  "data_inicio",
+		protocolo.FrequênciaOrdenaçãoDataCriação:            "data_criacao",
+		protocolo.FrequênciaOrdenaçãoDataCriaçãoDecrescente: "data_criacao",
+		protocolo.FrequênciaOrdenaçãoCR:                     "cr",
+		protocolo.FrequênciaOrdenaçãoCRDecrescente:          "cr",
+	}
 )
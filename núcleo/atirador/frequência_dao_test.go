@@ -3,14 +3,19 @@ package atirador
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/armazenamento"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
 	"github.com/registrobr/gostk/errors"
 )
 
@@ -40,10 +45,12 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			},
 			frequência: &frequência{
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
 				NúmeroSérie:       "ZA785671",
+				IDArma:            3,
 				GuiaDeTráfego:     762556223,
 				QuantidadeMunição: 50,
 				DataInício:        data.Add(-1 * time.Hour),
@@ -53,10 +60,12 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			frequênciaEsperada: frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
 				NúmeroSérie:       "ZA785671",
+				IDArma:            3,
 				GuiaDeTráfego:     762556223,
 				QuantidadeMunição: 50,
 				DataInício:        data.Add(-1 * time.Hour),
@@ -76,6 +85,7 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			},
 			frequência: &frequência{
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -95,6 +105,7 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			},
 			frequência: &frequência{
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -118,6 +129,7 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			},
 			frequência: &frequência{
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -141,6 +153,7 @@ func TestFrequênciaDAOImpl_criar(t *testing.T) {
 			},
 			frequência: &frequência{
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -195,6 +208,7 @@ func TestFrequênciaDAOImpl_atualizar(t *testing.T) {
 	cenários := []struct {
 		descrição          string
 		simulação          func()
+		armazenamento      armazenamento.Armazenamento
 		frequência         *frequência
 		frequênciaEsperada frequência
 		erroEsperado       error
@@ -211,6 +225,7 @@ func TestFrequênciaDAOImpl_atualizar(t *testing.T) {
 			frequência: &frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -227,15 +242,13 @@ dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
 dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
 ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				ImagemConfirmação: `QW5kIGlmIHRoZSBkYXRhIGlzIGEgYml0IGxvbmdlciwgdGhlIGJhc2U2NCBlbmNvZGVkIGRhdGEgd2l
-sbCBzcGFuIG11bHRpcGxlIGxpbmVzLgodGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+sbCBzcGFuIG11bHRpcGxlIGxpbmVzLg==`,
 				revisão: 0,
 			},
 			frequênciaEsperada: frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -253,11 +266,42 @@ dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
 dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
 ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				ImagemConfirmação: `QW5kIGlmIHRoZSBkYXRhIGlzIGEgYml0IGxvbmdlciwgdGhlIGJhc2U2NCBlbmNvZGVkIGRhdGEgd2l
-sbCBzcGFuIG11bHRpcGxlIGxpbmVzLgodGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+sbCBzcGFuIG11bHRpcGxlIGxpbmVzLg==`,
 				revisão: 1,
+
+				referênciaImagemNúmeroControle: "78fe75026c4390ceccc4e9e6a9428ba8ae5968b458e60b5eebecd682cf24bbf2",
+				referênciaImagemConfirmação:    "dce42901af439a6302e0a50e851fdec76c4a840259eb01ee3ea44d98b4466c92",
+			},
+		},
+		{
+			descrição: "deve manter a referência de uma imagem não carregada",
+			simulação: func() {
+				testdb.StubExec(frequênciaAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(frequênciaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			armazenamento: simulador.Armazenamento{
+				SimulaArmazenar: func(conteúdo []byte) (string, error) {
+					t.Error("imagem não alterada foi armazenada novamente")
+					return "", nil
+				},
+			},
+			frequência: &frequência{
+				ID:          1,
+				DataCriação: data.Add(-30 * time.Second),
+				revisão:     0,
+
+				referênciaImagemNúmeroControle: referênciaImagemTeste,
+			},
+			frequênciaEsperada: frequência{
+				ID:              1,
+				DataCriação:     data.Add(-30 * time.Second),
+				DataAtualização: data,
+				revisão:         1,
+
+				referênciaImagemNúmeroControle: referênciaImagemTeste,
 			},
 		},
 		{
@@ -272,6 +316,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			frequência: &frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -288,10 +333,7 @@ dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
 dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
 ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				ImagemConfirmação: `QW5kIGlmIHRoZSBkYXRhIGlzIGEgYml0IGxvbmdlciwgdGhlIGJhc2U2NCBlbmNvZGVkIGRhdGEgd2l
-sbCBzcGFuIG11bHRpcGxlIGxpbmVzLgodGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+sbCBzcGFuIG11bHRpcGxlIGxpbmVzLg==`,
 				revisão: 0,
 			},
 			erroEsperado: errors.Errorf("erro de execução"),
@@ -304,6 +346,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			frequência: &frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -320,10 +363,7 @@ dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
 dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
 ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				ImagemConfirmação: `QW5kIGlmIHRoZSBkYXRhIGlzIGEgYml0IGxvbmdlciwgdGhlIGJhc2U2NCBlbmNvZGVkIGRhdGEgd2l
-sbCBzcGFuIG11bHRpcGxlIGxpbmVzLgodGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+sbCBzcGFuIG11bHRpcGxlIGxpbmVzLg==`,
 				revisão: 0,
 			},
 			erroEsperado: errors.Errorf("erro com o ID"),
@@ -336,6 +376,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			frequência: &frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -352,22 +393,48 @@ dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
 dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
 ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				ImagemConfirmação: `QW5kIGlmIHRoZSBkYXRhIGlzIGEgYml0IGxvbmdlciwgdGhlIGJhc2U2NCBlbmNvZGVkIGRhdGEgd2l
-sbCBzcGFuIG11bHRpcGxlIGxpbmVzLgodGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+sbCBzcGFuIG11bHRpcGxlIGxpbmVzLg==`,
 				revisão: 0,
 			},
 			erroEsperado: erros.NãoAtualizado,
 		},
+		{
+			descrição: "deve detectar um erro ao armazenar a imagem",
+			armazenamento: simulador.Armazenamento{
+				SimulaArmazenar: func(conteúdo []byte) (string, error) {
+					return "", errors.Errorf("erro ao armazenar")
+				},
+			},
+			frequência: &frequência{
+				ID:                   1,
+				DataCriação:          data.Add(-30 * time.Second),
+				ImagemNúmeroControle: base64.StdEncoding.EncodeToString([]byte("imagem de teste")),
+			},
+			frequênciaEsperada: frequência{
+				ID:                   1,
+				DataCriação:          data.Add(-30 * time.Second),
+				ImagemNúmeroControle: base64.StdEncoding.EncodeToString([]byte("imagem de teste")),
+			},
+			erroEsperado: errors.Errorf("erro ao armazenar"),
+		},
 	}
 
+	armazenamentoOriginal := armazenamento.Atual
+	defer func() {
+		armazenamento.Atual = armazenamentoOriginal
+	}()
+
 	for i, cenário := range cenários {
 		testdb.Reset()
 		if cenário.simulação != nil {
 			cenário.simulação()
 		}
 
+		armazenamento.Atual = cenário.armazenamento
+		if armazenamento.Atual == nil {
+			armazenamento.Atual = novoArmazenamentoMemória()
+		}
+
 		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
 		err := dao.atualizar(cenário.frequência)
 
@@ -391,6 +458,122 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 	}
 }
 
+func TestFrequênciaDAOImpl_cancelar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		frequência         *frequência
+		frequênciaEsperada frequência
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve cancelar corretamente a frequência",
+			simulação: func() {
+				testdb.StubExec(frequênciaAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(frequênciaLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			frequência: &frequência{
+				ID:                 1,
+				Controle:           98765,
+				IDClube:            1,
+				CR:                 1234567890,
+				Calibre:            ".380",
+				ArmaUtilizada:      "Arma Clube",
+				QuantidadeMunição:  50,
+				DataInício:         data.Add(-1 * time.Hour),
+				DataTérmino:        data.Add(-10 * time.Minute),
+				DataCriação:        data.Add(-5 * time.Minute),
+				DataCancelamento:   data,
+				MotivoCancelamento: "Registro duplicado",
+				revisão:            0,
+			},
+			frequênciaEsperada: frequência{
+				ID:                 1,
+				Controle:           98765,
+				IDClube:            1,
+				CR:                 1234567890,
+				Calibre:            ".380",
+				ArmaUtilizada:      "Arma Clube",
+				QuantidadeMunição:  50,
+				DataInício:         data.Add(-1 * time.Hour),
+				DataTérmino:        data.Add(-10 * time.Minute),
+				DataCriação:        data.Add(-5 * time.Minute),
+				DataAtualização:    data,
+				DataCancelamento:   data,
+				MotivoCancelamento: "Registro duplicado",
+				revisão:            1,
+			},
+		},
+		{
+			descrição:    "deve detectar quando a frequência não foi definida",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao cancelar a frequência",
+			simulação: func() {
+				testdb.StubExecError(frequênciaAtualizaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			frequência: &frequência{
+				ID:                 1,
+				DataCancelamento:   data,
+				MotivoCancelamento: "Registro duplicado",
+			},
+			frequênciaEsperada: frequência{
+				ID:                 1,
+				DataAtualização:    data,
+				DataCancelamento:   data,
+				MotivoCancelamento: "Registro duplicado",
+				revisão:            1,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.cancelar(cenário.frequência)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		if cenário.frequência == nil {
+			verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+			if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+				t.Error(err)
+			}
+			continue
+		}
+
+		if cenário.frequência.DataAtualização.Before(cenário.frequênciaEsperada.DataAtualização) {
+			t.Errorf("Item %d, “%s”: data de atualização inesperada. Esperava que fosse após “%s”, e foi “%s”",
+				i, cenário.descrição, cenário.frequênciaEsperada.DataAtualização, cenário.frequência.DataAtualização)
+		}
+
+		// a data de atualização é definida no próprio método, por isso ela é
+		// igualada após a comparação para verificar os demais campos
+		cenário.frequênciaEsperada.DataAtualização = cenário.frequência.DataAtualização
+
+		verificadorResultado.DefinirEsperado(&cenário.frequênciaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.frequência, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 	conexão, err := sql.Open("testdb", "")
 	if err != nil {
@@ -402,6 +585,7 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 	cenários := []struct {
 		descrição          string
 		simulação          func()
+		armazenamento      armazenamento.Armazenamento
 		id                 int64
 		frequênciaEsperada frequência
 		erroEsperado       error
@@ -411,26 +595,32 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 			simulação: func() {
 				testdb.StubQuery(frequênciaResgateComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
 					{
-						1, 98765, 1234567890, ".380", "Arma Clube", "ZA785671", 762556223, 50,
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", 3, 762556223, 50,
 						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, time.Time{}, time.Time{},
-						"", "", 0,
+						nil, nil, nil, nil, "", "", nil, nil, nil, nil, nil, data, "Registro duplicado", "2017a", "cancelada", 0,
 					},
 				}))
 			},
 			id: 1,
 			frequênciaEsperada: frequência{
-				ID:                1,
-				Controle:          98765,
-				CR:                1234567890,
-				Calibre:           ".380",
-				ArmaUtilizada:     "Arma Clube",
-				NúmeroSérie:       "ZA785671",
-				GuiaDeTráfego:     762556223,
-				QuantidadeMunição: 50,
-				DataInício:        data.Add(-1 * time.Hour),
-				DataTérmino:       data.Add(-10 * time.Minute),
-				DataCriação:       data,
-				revisão:           0,
+				ID:                 1,
+				Controle:           98765,
+				IDClube:            1,
+				CR:                 1234567890,
+				Calibre:            ".380",
+				ArmaUtilizada:      "Arma Clube",
+				NúmeroSérie:        "ZA785671",
+				IDArma:             3,
+				GuiaDeTráfego:      762556223,
+				QuantidadeMunição:  50,
+				DataInício:         data.Add(-1 * time.Hour),
+				DataTérmino:        data.Add(-10 * time.Minute),
+				DataCriação:        data,
+				DataCancelamento:   data,
+				MotivoCancelamento: "Registro duplicado",
+				IDChaveVerificação: "2017a",
+				Situação:           protocolo.FrequênciaSituaçãoCancelada,
+				revisão:            0,
 			},
 		},
 		{
@@ -438,8 +628,8 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 			simulação: func() {
 				testdb.StubQuery(frequênciaResgateComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
 					{
-						1, 98765, 1234567890, ".380", "Arma Clube", "ZA785671", 762556223, 50,
-						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, nil, nil, nil, nil, 0,
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "pendente", 0,
 					},
 				}))
 			},
@@ -447,6 +637,7 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 			frequênciaEsperada: frequência{
 				ID:                1,
 				Controle:          98765,
+				IDClube:           1,
 				CR:                1234567890,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma Clube",
@@ -456,9 +647,59 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 				DataInício:        data.Add(-1 * time.Hour),
 				DataTérmino:       data.Add(-10 * time.Minute),
 				DataCriação:       data,
+				Situação:          protocolo.FrequênciaSituaçãoPendente,
 				revisão:           0,
 			},
 		},
+		{
+			descrição: "deve resgatar uma frequência obtendo as imagens do repositório de objetos",
+			simulação: func() {
+				testdb.StubQuery(frequênciaResgateComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
+					{
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, nil, nil, referênciaImagemTeste, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "pendente", 0,
+					},
+				}))
+			},
+			id: 1,
+			frequênciaEsperada: frequência{
+				ID:                   1,
+				Controle:             98765,
+				IDClube:              1,
+				CR:                   1234567890,
+				Calibre:              ".380",
+				ArmaUtilizada:        "Arma Clube",
+				NúmeroSérie:          "ZA785671",
+				GuiaDeTráfego:        762556223,
+				QuantidadeMunição:    50,
+				DataInício:           data.Add(-1 * time.Hour),
+				DataTérmino:          data.Add(-10 * time.Minute),
+				DataCriação:          data,
+				ImagemNúmeroControle: base64.StdEncoding.EncodeToString([]byte("imagem de teste")),
+				Situação:             protocolo.FrequênciaSituaçãoPendente,
+				revisão:              0,
+
+				referênciaImagemNúmeroControle: referênciaImagemTeste,
+			},
+		},
+		{
+			descrição: "deve detectar uma imagem ausente no repositório de objetos",
+			simulação: func() {
+				testdb.StubQuery(frequênciaResgateComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
+					{
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, nil, nil, nil, referênciaImagemTeste, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "confirmada", 0,
+					},
+				}))
+			},
+			armazenamento: simulador.Armazenamento{
+				SimulaObter: func(referência string) ([]byte, error) {
+					return nil, erros.NãoEncontrado
+				},
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("imagem “%s” não encontrada no repositório de objetos", referênciaImagemTeste),
+		},
 		{
 			descrição: "deve detectar um erro ao resgatar uma frequência",
 			simulação: func() {
@@ -469,10 +710,20 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 		},
 	}
 
+	armazenamentoOriginal := armazenamento.Atual
+	defer func() {
+		armazenamento.Atual = armazenamentoOriginal
+	}()
+
 	for i, cenário := range cenários {
 		testdb.Reset()
 		cenário.simulação()
 
+		armazenamento.Atual = cenário.armazenamento
+		if armazenamento.Atual == nil {
+			armazenamento.Atual = novoArmazenamentoMemória()
+		}
+
 		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
 		f, err := dao.resgatar(cenário.id)
 
@@ -483,3 +734,865 @@ func TestFrequênciaDAOImpl_resgatar(t *testing.T) {
 		}
 	}
 }
+
+func TestFrequênciaDAOImpl_resgatarResumo(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		id                 int64
+		frequênciaEsperada frequência
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve resgatar corretamente o resumo de uma frequência",
+			simulação: func() {
+				testdb.StubQuery(frequênciaResgateResumoComando, testdb.RowsFromSlice(frequênciaResgateResumoCampos, [][]driver.Value{
+					{
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", 3, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, data, data, nil, "confirmada", 2, "2017a",
+					},
+				}))
+			},
+			id: 1,
+			frequênciaEsperada: frequência{
+				ID:                 1,
+				Controle:           98765,
+				IDClube:            1,
+				CR:                 1234567890,
+				Calibre:            ".380",
+				ArmaUtilizada:      "Arma Clube",
+				NúmeroSérie:        "ZA785671",
+				IDArma:             3,
+				GuiaDeTráfego:      762556223,
+				QuantidadeMunição:  50,
+				DataInício:         data.Add(-1 * time.Hour),
+				DataTérmino:        data.Add(-10 * time.Minute),
+				DataCriação:        data,
+				DataAtualização:    data,
+				DataConfirmação:    data,
+				IDChaveVerificação: "2017a",
+				Situação:           protocolo.FrequênciaSituaçãoConfirmada,
+				revisão:            2,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o resumo de uma frequência",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaResgateResumoComando, fmt.Errorf("erro de execução"))
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	armazenamentoOriginal := armazenamento.Atual
+	defer func() {
+		armazenamento.Atual = armazenamentoOriginal
+	}()
+
+	// o resumo nunca deve acessar o repositório de objetos
+	armazenamento.Atual = simulador.Armazenamento{
+		SimulaObter: func(referência string) ([]byte, error) {
+			t.Errorf("acesso inesperado ao repositório de objetos: %s", referência)
+			return nil, nil
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		f, err := dao.resgatarResumo(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.frequênciaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(f, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_pendentesExpiradas(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição           string
+		simulação           func()
+		dataLimite          time.Time
+		limite              int
+		frequênciasEsperada []frequência
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve retornar corretamente as frequências pendentes fora do prazo",
+			simulação: func() {
+				testdb.StubQuery(frequênciaPendentesExpiradasComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
+					{
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data.Add(-5 * time.Minute), nil, nil, referênciaImagemTeste, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", "pendente", 0,
+					},
+					{
+						2, 98766, 1, 1234567891, ".380", "Arma Clube", "ZA785671", nil, 762556223, 30,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data.Add(-4 * time.Minute), nil, nil, nil, nil, nil, nil, "BBBB", nil, nil, nil, nil, nil, nil, nil, nil, "", "pendente", 0,
+					},
+				}))
+			},
+			dataLimite: data,
+			limite:     10,
+			frequênciasEsperada: []frequência{
+				{
+					ID:                1,
+					Controle:          98765,
+					IDClube:           1,
+					CR:                1234567890,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma Clube",
+					NúmeroSérie:       "ZA785671",
+					GuiaDeTráfego:     762556223,
+					QuantidadeMunição: 50,
+					DataInício:        data.Add(-1 * time.Hour),
+					DataTérmino:       data.Add(-10 * time.Minute),
+					DataCriação:       data.Add(-5 * time.Minute),
+					Situação:          protocolo.FrequênciaSituaçãoPendente,
+
+					referênciaImagemNúmeroControle: referênciaImagemTeste,
+				},
+				{
+					ID:                   2,
+					Controle:             98766,
+					IDClube:              1,
+					CR:                   1234567891,
+					Calibre:              ".380",
+					ArmaUtilizada:        "Arma Clube",
+					NúmeroSérie:          "ZA785671",
+					GuiaDeTráfego:        762556223,
+					QuantidadeMunição:    30,
+					DataInício:           data.Add(-1 * time.Hour),
+					DataTérmino:          data.Add(-10 * time.Minute),
+					DataCriação:          data.Add(-4 * time.Minute),
+					ImagemNúmeroControle: "BBBB",
+					Situação:             protocolo.FrequênciaSituaçãoPendente,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao buscar as frequências",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaPendentesExpiradasComando, fmt.Errorf("erro de execução"))
+			},
+			dataLimite:   data,
+			limite:       10,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		frequências, err := dao.pendentesExpiradas(cenário.dataLimite, cenário.limite)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.frequênciasEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(frequências, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_candidatasAuditoria(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+	distânciaClube := 12.5
+
+	cenários := []struct {
+		descrição           string
+		simulação           func()
+		início              time.Time
+		término             time.Time
+		frequênciasEsperada []frequência
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve retornar corretamente as frequências candidatas à auditoria",
+			simulação: func() {
+				testdb.StubQuery(frequênciaCandidatasAuditoriaComando, testdb.RowsFromSlice(frequênciaResgateCampos, [][]driver.Value{
+					{
+						1, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data.Add(-5 * time.Minute), nil, data.Add(-2 * time.Minute), nil, nil, nil, nil, "AAAA", "BBBB", int64(-1),
+						data.Add(-3 * time.Hour), "Canon EOS 80D", "imagem-fora-periodo-treino", 12.5, nil, nil, "", "confirmada", 1,
+					},
+				}))
+			},
+			início:  data.AddDate(0, -1, 0),
+			término: data,
+			frequênciasEsperada: []frequência{
+				{
+					ID:                    1,
+					Controle:              98765,
+					IDClube:               1,
+					CR:                    1234567890,
+					Calibre:               ".380",
+					ArmaUtilizada:         "Arma Clube",
+					NúmeroSérie:           "ZA785671",
+					GuiaDeTráfego:         762556223,
+					QuantidadeMunição:     50,
+					DataInício:            data.Add(-1 * time.Hour),
+					DataTérmino:           data.Add(-10 * time.Minute),
+					DataCriação:           data.Add(-5 * time.Minute),
+					DataConfirmação:       data.Add(-2 * time.Minute),
+					ImagemNúmeroControle:  "AAAA",
+					ImagemConfirmação:     "BBBB",
+					Situação:              protocolo.FrequênciaSituaçãoConfirmada,
+					HashImagemConfirmação: 1<<64 - 1,
+					DataCapturaImagem:     data.Add(-3 * time.Hour),
+					ModeloCâmeraImagem:    "Canon EOS 80D",
+					SinalizaçãoImagem:     protocolo.MensagemCódigoImagemForaPeríodoTreino,
+					DistânciaClube:        &distânciaClube,
+					revisão:               1,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao buscar as frequências",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaCandidatasAuditoriaComando, fmt.Errorf("erro de execução"))
+			},
+			início:       data.AddDate(0, -1, 0),
+			término:      data,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		frequências, err := dao.candidatasAuditoria(cenário.início, cenário.término)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.frequênciasEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(frequências, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_listar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+	filtro := protocolo.FrequênciaFiltro{
+		CR:        1234567890,
+		Ordenação: protocolo.FrequênciaOrdenaçãoDataInícioDecrescente,
+		Limite:    2,
+	}
+	comando, _ := frequênciaListagemComando(filtro, nil, 3)
+
+	cenários := []struct {
+		descrição           string
+		simulação           func()
+		frequênciasEsperada []frequência
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve listar corretamente as frequências",
+			simulação: func() {
+				testdb.StubQuery(comando, testdb.RowsFromSlice(frequênciaListagemCampos, [][]driver.Value{
+					{
+						2, 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", 3, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, data, data, nil, "confirmada", 1,
+					},
+					{
+						1, 12345, 1, 1234567890, ".40", "Arma Clube", "", nil, 0, 20,
+						data.Add(-2 * time.Hour), data.Add(-90 * time.Minute), data, nil, nil, nil, "pendente", 0,
+					},
+				}))
+			},
+			frequênciasEsperada: []frequência{
+				{
+					ID:                2,
+					Controle:          98765,
+					IDClube:           1,
+					CR:                1234567890,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma Clube",
+					NúmeroSérie:       "ZA785671",
+					IDArma:            3,
+					GuiaDeTráfego:     762556223,
+					QuantidadeMunição: 50,
+					DataInício:        data.Add(-1 * time.Hour),
+					DataTérmino:       data.Add(-10 * time.Minute),
+					DataCriação:       data,
+					DataAtualização:   data,
+					DataConfirmação:   data,
+					Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+					revisão:           1,
+				},
+				{
+					ID:                1,
+					Controle:          12345,
+					IDClube:           1,
+					CR:                1234567890,
+					Calibre:           ".40",
+					ArmaUtilizada:     "Arma Clube",
+					QuantidadeMunição: 20,
+					DataInício:        data.Add(-2 * time.Hour),
+					DataTérmino:       data.Add(-90 * time.Minute),
+					DataCriação:       data,
+					Situação:          protocolo.FrequênciaSituaçãoPendente,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao listar as frequências",
+			simulação: func() {
+				testdb.StubQueryError(comando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao interpretar uma frequência",
+			simulação: func() {
+				testdb.StubQuery(comando, testdb.RowsFromSlice(frequênciaListagemCampos, [][]driver.Value{
+					{
+						"xxx", 98765, 1, 1234567890, ".380", "Arma Clube", "ZA785671", nil, 762556223, 50,
+						data.Add(-1 * time.Hour), data.Add(-10 * time.Minute), data, nil, nil, 0,
+					},
+				}))
+			},
+			erroEsperado: errors.Errorf(`sql: Scan error on column index 0, name "id": converting driver.Value type string ("xxx") to a int64: invalid syntax`),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		frequências, err := dao.listar(filtro, nil, 3)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.frequênciasEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(frequências, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_habitualidadeInsuficiente(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição              string
+		simulação              func()
+		habitualidadesEsperada []habitualidade
+		erroEsperado           error
+	}{
+		{
+			descrição: "deve listar corretamente os atiradores com habitualidade insuficiente",
+			simulação: func() {
+				testdb.StubQuery(frequênciaHabitualidadeComando, testdb.RowsFromSlice(frequênciaHabitualidadeCampos, [][]driver.Value{
+					{380308, 7},
+					{380309, 0},
+				}))
+			},
+			habitualidadesEsperada: []habitualidade{
+				{CR: 380308, Treinos: 7},
+				{CR: 380309, Treinos: 0},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao calcular a habitualidade",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaHabitualidadeComando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao interpretar a habitualidade",
+			simulação: func() {
+				testdb.StubQuery(frequênciaHabitualidadeComando, testdb.RowsFromSlice(frequênciaHabitualidadeCampos, [][]driver.Value{
+					{"xxx", 7},
+				}))
+			},
+			erroEsperado: errors.Errorf(`sql: Scan error on column index 0, name "cr": converting driver.Value type string ("xxx") to a int: invalid syntax`),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		habitualidades, err := dao.habitualidadeInsuficiente(data.AddDate(-1, 0, 0), data, 8)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.habitualidadesEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(habitualidades, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_consumoMunição(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	início := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cenários := []struct {
+		descrição         string
+		simulação         func()
+		consumosEsperados []consumoMunição
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve calcular corretamente o consumo de munição por calibre",
+			simulação: func() {
+				testdb.StubQuery(frequênciaConsumoMuniçãoComando, testdb.RowsFromSlice(frequênciaConsumoMuniçãoCampos, [][]driver.Value{
+					{".380 ACP", "permitido", 150},
+					{"7,62X51MM", "restrito", 40},
+				}))
+			},
+			consumosEsperados: []consumoMunição{
+				{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 150},
+				{Calibre: "7,62X51MM", Classe: protocolo.CalibreClasseRestrito, Quantidade: 40},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao calcular o consumo de munição",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaConsumoMuniçãoComando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao interpretar o consumo de munição",
+			simulação: func() {
+				testdb.StubQuery(frequênciaConsumoMuniçãoComando, testdb.RowsFromSlice(frequênciaConsumoMuniçãoCampos, [][]driver.Value{
+					{".380 ACP", "permitido", "xxx"},
+				}))
+			},
+			erroEsperado: errors.Errorf(`sql: Scan error on column index 2, name "quantidade": converting driver.Value type string ("xxx") to a int: invalid syntax`),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		consumos, err := dao.consumoMunição(380308, início, início.AddDate(1, 0, 0))
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.consumosEsperados, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(consumos, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_pendentesPorChave(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição           string
+		simulação           func()
+		pendênciasEsperadas []protocolo.FrequênciaPendênciaChave
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve contar corretamente as frequências pendentes por chave",
+			simulação: func() {
+				testdb.StubQuery(frequênciaPendentesPorChaveComando, testdb.RowsFromSlice(frequênciaPendentesPorChaveCampos, [][]driver.Value{
+					{"", 3},
+					{"2017a", 12},
+				}))
+			},
+			pendênciasEsperadas: []protocolo.FrequênciaPendênciaChave{
+				{Chave: "", Pendentes: 3},
+				{Chave: "2017a", Pendentes: 12},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao contar as frequências pendentes",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaPendentesPorChaveComando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao interpretar as frequências pendentes",
+			simulação: func() {
+				testdb.StubQuery(frequênciaPendentesPorChaveComando, testdb.RowsFromSlice(frequênciaPendentesPorChaveCampos, [][]driver.Value{
+					{"2017a", "xxx"},
+				}))
+			},
+			erroEsperado: errors.Errorf(`sql: Scan error on column index 1, name "pendentes": converting driver.Value type string ("xxx") to a int: invalid syntax`),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		pendências, err := dao.pendentesPorChave()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.pendênciasEsperadas, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(pendências, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaListagemComando(t *testing.T) {
+	data := time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)
+	campos := strings.Join(frequênciaListagemCampos, ", ")
+
+	cenários := []struct {
+		descrição           string
+		filtro              protocolo.FrequênciaFiltro
+		cursor              *cursor
+		limite              int
+		comandoEsperado     string
+		argumentosEsperados []interface{}
+	}{
+		{
+			descrição: "deve montar a consulta sem condições",
+			filtro: protocolo.FrequênciaFiltro{
+				Ordenação: protocolo.FrequênciaOrdenaçãoDataInícioDecrescente,
+			},
+			limite:              21,
+			comandoEsperado:     "SELECT " + campos + " FROM frequencia_atirador ORDER BY data_inicio DESC, id DESC LIMIT $1",
+			argumentosEsperados: []interface{}{21},
+		},
+		{
+			descrição: "deve montar a consulta com todas as condições",
+			filtro: protocolo.FrequênciaFiltro{
+				CR:            380308,
+				Clube:         1,
+				Calibre:       ".380",
+				NúmeroSérie:   "ZA785671",
+				DataInícioDe:  data,
+				DataInícioAté: data.Add(time.Hour),
+				Situação:      protocolo.FrequênciaSituaçãoPendente,
+				Ordenação:     protocolo.FrequênciaOrdenaçãoCR,
+			},
+			cursor: &cursor{Ordenação: protocolo.FrequênciaOrdenaçãoCR, ID: 10, CR: 380307},
+			limite: 11,
+			comandoEsperado: "SELECT " + campos + " FROM frequencia_atirador WHERE cr = $1 AND id_clube = $2 AND " +
+				"calibre = $3 AND numero_serie = $4 AND data_inicio >= $5 AND data_inicio <= $6 AND " +
+				"situacao = $7 AND (cr, id) > ($8, $9) ORDER BY cr ASC, id ASC LIMIT $10",
+			argumentosEsperados: []interface{}{380308, int64(1), ".380", "ZA785671", data, data.Add(time.Hour), "pendente", 380307, int64(10), 11},
+		},
+		{
+			descrição: "deve montar a consulta de frequências confirmadas a partir de um cursor",
+			filtro: protocolo.FrequênciaFiltro{
+				Situação:  protocolo.FrequênciaSituaçãoConfirmada,
+				Ordenação: protocolo.FrequênciaOrdenaçãoDataCriaçãoDecrescente,
+			},
+			cursor: &cursor{Ordenação: protocolo.FrequênciaOrdenaçãoDataCriaçãoDecrescente, ID: 10, Data: data},
+			limite: 5,
+			comandoEsperado: "SELECT " + campos + " FROM frequencia_atirador WHERE situacao = $1 AND " +
+				"(data_criacao, id) < ($2, $3) ORDER BY data_criacao DESC, id DESC LIMIT $4",
+			argumentosEsperados: []interface{}{"confirmada", data, int64(10), 5},
+		},
+		{
+			descrição: "deve montar a consulta de frequências canceladas",
+			filtro: protocolo.FrequênciaFiltro{
+				Situação:  protocolo.FrequênciaSituaçãoCancelada,
+				Ordenação: protocolo.FrequênciaOrdenaçãoDataInícioDecrescente,
+			},
+			limite: 5,
+			comandoEsperado: "SELECT " + campos + " FROM frequencia_atirador WHERE situacao = $1 " +
+				"ORDER BY data_inicio DESC, id DESC LIMIT $2",
+			argumentosEsperados: []interface{}{"cancelada", 5},
+		},
+	}
+
+	for i, cenário := range cenários {
+		comando, argumentos := frequênciaListagemComando(cenário.filtro, cenário.cursor, cenário.limite)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.comandoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(comando, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.argumentosEsperados, nil)
+		if err := verificadorResultado.VerificaResultado(argumentos, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_imagemSemelhante(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		semelhanteEsperado bool
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve identificar uma imagem semelhante",
+			simulação: func() {
+				testdb.StubQuery(frequênciaImagemSemelhanteComando, testdb.RowsFromSlice([]string{"exists"}, [][]driver.Value{
+					{true},
+				}))
+			},
+			semelhanteEsperado: true,
+		},
+		{
+			descrição: "deve identificar quando não existe imagem semelhante",
+			simulação: func() {
+				testdb.StubQuery(frequênciaImagemSemelhanteComando, testdb.RowsFromSlice([]string{"exists"}, [][]driver.Value{
+					{false},
+				}))
+			},
+			semelhanteEsperado: false,
+		},
+		{
+			descrição: "deve detectar um erro ao buscar imagens semelhantes",
+			simulação: func() {
+				testdb.StubQueryError(frequênciaImagemSemelhanteComando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		semelhante, err := dao.imagemSemelhante(1, 0xf0f0f0f0f0f0f0f0, 5)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.semelhanteEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(semelhante, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCandidatosBandasHash(t *testing.T) {
+	cenários := []struct {
+		descrição           string
+		hash                uint64
+		distância           int
+		candidatosEsperados [bandasHash]string
+	}{
+		{
+			descrição: "deve buscar somente as bandas exatas quando a distância é menor que a quantidade de bandas",
+			hash:      0x0001000200030004,
+			distância: 3,
+			candidatosEsperados: [bandasHash]string{
+				"{1}",
+				"{2}",
+				"{3}",
+				"{4}",
+			},
+		},
+		{
+			descrição: "deve buscar as bandas que diferem em 1 bit",
+			hash:      0x0000ffff00018000,
+			distância: 5,
+			candidatosEsperados: [bandasHash]string{
+				"{0,1,2,4,8,16,32,64,128,256,512,1024,2048,4096,8192,16384,32768}",
+				"{32767,49151,57343,61439,63487,64511,65023,65279,65407,65471,65503,65519,65527,65531,65533,65534,65535}",
+				"{0,1,3,5,9,17,33,65,129,257,513,1025,2049,4097,8193,16385,32769}",
+				"{0,32768,32769,32770,32772,32776,32784,32800,32832,32896,33024,33280,33792,34816,36864,40960,49152}",
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		candidatos := candidatosBandasHash(cenário.hash, cenário.distância)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.candidatosEsperados, nil)
+		if err := verificadorResultado.VerificaResultado(candidatos, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaDAOImpl_migrarImagens(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	imagem := base64.StdEncoding.EncodeToString([]byte("imagem legada"))
+	comandoFrequências := fmt.Sprintf(imagensLegadasComando, frequênciaTabela)
+	comandoLog := fmt.Sprintf(imagensLegadasComando, frequênciaLogTabela)
+	campos := []string{"id", "imagem_numero_controle", "imagem_confirmacao"}
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		armazenamento      armazenamento.Armazenamento
+		limite             int
+		quantidadeEsperada int
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve migrar corretamente as imagens das frequências e do log",
+			simulação: func() {
+				testdb.StubQuery(comandoFrequências, testdb.RowsFromSlice(campos, [][]driver.Value{
+					{1, imagem, nil},
+					{2, imagem, imagem},
+				}))
+				testdb.StubQuery(comandoLog, testdb.RowsFromSlice(campos, [][]driver.Value{
+					{10, nil, imagem},
+				}))
+				testdb.StubExec(fmt.Sprintf(imagensLegadasMigraçãoComando, frequênciaTabela), testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(fmt.Sprintf(imagensLegadasMigraçãoComando, frequênciaLogTabela), testdb.NewResult(1, nil, 1, nil))
+			},
+			limite:             10,
+			quantidadeEsperada: 3,
+		},
+		{
+			descrição: "deve retornar zero quando não existem imagens a migrar",
+			simulação: func() {
+				testdb.StubQuery(comandoFrequências, testdb.RowsFromSlice(campos, [][]driver.Value{}))
+				testdb.StubQuery(comandoLog, testdb.RowsFromSlice(campos, [][]driver.Value{}))
+			},
+			limite: 10,
+		},
+		{
+			descrição: "deve detectar um erro ao buscar as imagens",
+			simulação: func() {
+				testdb.StubQueryError(comandoFrequências, fmt.Errorf("erro de execução"))
+			},
+			limite:       10,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao armazenar uma imagem",
+			simulação: func() {
+				testdb.StubQuery(comandoFrequências, testdb.RowsFromSlice(campos, [][]driver.Value{
+					{1, imagem, nil},
+				}))
+			},
+			armazenamento: simulador.Armazenamento{
+				SimulaArmazenar: func(conteúdo []byte) (string, error) {
+					return "", errors.Errorf("erro ao armazenar")
+				},
+			},
+			limite:       10,
+			erroEsperado: errors.Errorf("erro ao armazenar"),
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar as referências",
+			simulação: func() {
+				testdb.StubQuery(comandoFrequências, testdb.RowsFromSlice(campos, [][]driver.Value{
+					{1, imagem, nil},
+				}))
+				testdb.StubExecError(fmt.Sprintf(imagensLegadasMigraçãoComando, frequênciaTabela), fmt.Errorf("erro de execução"))
+			},
+			limite:       10,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao migrar as imagens do log",
+			simulação: func() {
+				testdb.StubQuery(comandoFrequências, testdb.RowsFromSlice(campos, [][]driver.Value{}))
+				testdb.StubQueryError(comandoLog, fmt.Errorf("erro de execução"))
+			},
+			limite:       10,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	armazenamentoOriginal := armazenamento.Atual
+	defer func() {
+		armazenamento.Atual = armazenamentoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		armazenamento.Atual = cenário.armazenamento
+		if armazenamento.Atual == nil {
+			armazenamento.Atual = novoArmazenamentoMemória()
+		}
+
+		dao := novaFrequênciaDAO(bd.NovoSQLogger(conexão, nil))
+		quantidade, err := dao.migrarImagens(cenário.limite)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.quantidadeEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(quantidade, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// referênciaImagemTeste é a referência do conteúdo "imagem de teste", que está
+// disponível no repositório de objetos em memória.
+const referênciaImagemTeste = "7586cf037e995223a49c28ef4efbe52cab2a56b35ad6072b7d003b317664ea67"
+
+// novoArmazenamentoMemória simula um repositório de objetos que mantém os
+// conteúdos em memória, já contendo o objeto da referência de teste.
+func novoArmazenamentoMemória() simulador.Armazenamento {
+	objetos := map[string][]byte{
+		referênciaImagemTeste: []byte("imagem de teste"),
+	}
+
+	return simulador.Armazenamento{
+		SimulaArmazenar: func(conteúdo []byte) (string, error) {
+			referência := armazenamento.Referência(conteúdo)
+			objetos[referência] = conteúdo
+			return referência, nil
+		},
+		SimulaObter: func(referência string) ([]byte, error) {
+			conteúdo, ok := objetos[referência]
+			if !ok {
+				return nil, erros.NãoEncontrado
+			}
+			return conteúdo, nil
+		},
+	}
+}
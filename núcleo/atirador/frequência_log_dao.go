@@ -1,23 +1,31 @@
 package atirador
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/armazenamento"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 )
 
 type frequênciaLogDAO interface {
 	criar(frequência, bd.AçãoLog) error
+	migrarImagens(limite int) (int, error)
 }
 
 var novaFrequênciaLogDAO = func(sqlogger *bd.SQLogger) frequênciaLogDAO {
-	return frequênciaLogDAOImpl{sqlogger: sqlogger}
+	return frequênciaLogDAOImpl{
+		sqlogger:      sqlogger,
+		armazenamento: armazenamento.Atual,
+	}
 }
 
 type frequênciaLogDAOImpl struct {
-	sqlogger *bd.SQLogger
+	sqlogger      *bd.SQLogger
+	armazenamento armazenamento.Armazenamento
 }
 
 func (f frequênciaLogDAOImpl) criar(frequência frequência, ação bd.AçãoLog) error {
@@ -30,10 +38,12 @@ func (f frequênciaLogDAOImpl) criar(frequência frequência, ação bd.AçãoLo
 		ação,
 		frequência.ID,
 		frequência.Controle,
+		frequência.IDClube,
 		frequência.CR,
 		frequência.Calibre,
 		frequência.ArmaUtilizada,
 		frequência.NúmeroSérie,
+		sql.NullInt64{Int64: frequência.IDArma, Valid: frequência.IDArma > 0},
 		frequência.GuiaDeTráfego,
 		frequência.QuantidadeMunição,
 		frequência.DataInício.UTC(),
@@ -41,14 +51,32 @@ func (f frequênciaLogDAOImpl) criar(frequência frequência, ação bd.AçãoLo
 		frequência.DataCriação.UTC(),
 		frequência.DataAtualização.UTC(),
 		frequência.DataConfirmação.UTC(),
-		frequência.ImagemNúmeroControle,
-		frequência.ImagemConfirmação,
+		referênciaImagemBD(frequência.referênciaImagemNúmeroControle),
+		referênciaImagemBD(frequência.referênciaImagemConfirmação),
+		referênciaImagemBD(frequência.referênciaMiniaturaImagemConfirmação),
+		referênciaImagemBD(frequência.referênciaEXIFImagemConfirmação),
+		sql.NullInt64{Int64: int64(frequência.HashImagemConfirmação), Valid: frequência.referênciaImagemConfirmação != ""},
+		pq.NullTime{Time: frequência.DataCapturaImagem.UTC(), Valid: !frequência.DataCapturaImagem.IsZero()},
+		frequência.ModeloCâmeraImagem,
+		frequência.SinalizaçãoImagem,
+		distânciaClubeBD(frequência.DistânciaClube),
+		pq.NullTime{Time: frequência.DataCancelamento.UTC(), Valid: !frequência.DataCancelamento.IsZero()},
+		frequência.MotivoCancelamento,
+		frequência.IDChaveVerificação,
+		frequência.Situação,
 		frequência.revisão,
 	)
 
 	return erros.Novo(err)
 }
 
+// migrarImagens move para o repositório de objetos as imagens que ainda estão
+// armazenadas em base64 no log, processando no máximo a quantidade de
+// registros informada no limite.
+func (f frequênciaLogDAOImpl) migrarImagens(limite int) (int, error) {
+	return migrarImagensLegadas(f.sqlogger, f.armazenamento, frequênciaLogTabela, limite)
+}
+
 var (
 	frequênciaLogTabela = "frequencia_atirador_log"
 
@@ -58,10 +86,12 @@ var (
 		"acao",
 		"id_frequencia_atirador",
 		"controle",
+		"id_clube",
 		"cr",
 		"calibre",
 		"arma_utilizada",
 		"numero_serie",
+		"id_arma",
 		"guia_de_trafego",
 		"quantidade_municao",
 		"data_inicio",
@@ -69,8 +99,19 @@ var (
 		"data_criacao",
 		"data_atualizacao",
 		"data_confirmacao",
-		"imagem_numero_controle",
-		"imagem_confirmacao",
+		"referencia_imagem_numero_controle",
+		"referencia_imagem_confirmacao",
+		"referencia_miniatura_imagem_confirmacao",
+		"referencia_exif_imagem_confirmacao",
+		"hash_imagem_confirmacao",
+		"data_captura_imagem",
+		"modelo_camera_imagem",
+		"sinalizacao_imagem",
+		"distancia_clube",
+		"data_cancelamento",
+		"motivo_cancelamento",
+		"chave_codigo_verificacao",
+		"situacao",
 		"revisao",
 	}
 	frequênciaLogCriaçãoCamposTexto = strings.Join(frequênciaLogCriaçãoCampos, ", ")
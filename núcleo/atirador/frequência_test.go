@@ -0,0 +1,104 @@
+package atirador
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestFrequência_transitar(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		situação           protocolo.FrequênciaSituação
+		destino            protocolo.FrequênciaSituação
+		situaçãoEsperada   protocolo.FrequênciaSituação
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:        "deve confirmar uma frequência pendente",
+			situação:         protocolo.FrequênciaSituaçãoPendente,
+			destino:          protocolo.FrequênciaSituaçãoConfirmada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoConfirmada,
+		},
+		{
+			descrição:        "deve expirar uma frequência pendente",
+			situação:         protocolo.FrequênciaSituaçãoPendente,
+			destino:          protocolo.FrequênciaSituaçãoExpirada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoExpirada,
+		},
+		{
+			descrição:        "deve enviar uma frequência confirmada para auditoria",
+			situação:         protocolo.FrequênciaSituaçãoConfirmada,
+			destino:          protocolo.FrequênciaSituaçãoEmAuditoria,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoEmAuditoria,
+		},
+		{
+			descrição:        "deve invalidar uma frequência em auditoria",
+			situação:         protocolo.FrequênciaSituaçãoEmAuditoria,
+			destino:          protocolo.FrequênciaSituaçãoInvalidada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoInvalidada,
+		},
+		{
+			descrição:        "deve recusar a confirmação de uma frequência já confirmada",
+			situação:         protocolo.FrequênciaSituaçãoConfirmada,
+			destino:          protocolo.FrequênciaSituaçãoConfirmada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoConfirmada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
+			),
+		},
+		{
+			descrição:        "deve recusar a alteração de uma frequência cancelada",
+			situação:         protocolo.FrequênciaSituaçãoCancelada,
+			destino:          protocolo.FrequênciaSituaçãoConfirmada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoCancelada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaCancelada),
+			),
+		},
+		{
+			descrição:        "deve recusar a confirmação de uma frequência expirada",
+			situação:         protocolo.FrequênciaSituaçãoExpirada,
+			destino:          protocolo.FrequênciaSituaçãoConfirmada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoExpirada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoConfirmaçãoExpirado),
+			),
+		},
+		{
+			descrição:        "deve recusar a auditoria de uma frequência pendente",
+			situação:         protocolo.FrequênciaSituaçãoPendente,
+			destino:          protocolo.FrequênciaSituaçãoEmAuditoria,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoPendente,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoTransiçãoSituaçãoInválida, "pendente"),
+			),
+		},
+		{
+			descrição:        "deve recusar qualquer alteração de uma frequência invalidada",
+			situação:         protocolo.FrequênciaSituaçãoInvalidada,
+			destino:          protocolo.FrequênciaSituaçãoConfirmada,
+			situaçãoEsperada: protocolo.FrequênciaSituaçãoInvalidada,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoTransiçãoSituaçãoInválida, "invalidada"),
+			),
+		},
+	}
+
+	for i, cenário := range cenários {
+		f := frequência{Situação: cenário.situação}
+		mensagens := f.transitar(cenário.destino)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.situaçãoEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(f.Situação, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
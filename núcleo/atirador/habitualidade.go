@@ -0,0 +1,17 @@
+package atirador
+
+import "github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+
+// habitualidade armazena a quantidade de treinos confirmados de um atirador em
+// um determinado período.
+type habitualidade struct {
+	CR      int
+	Treinos int
+}
+
+func (h habitualidade) protocolo() protocolo.HabitualidadeAtirador {
+	return protocolo.HabitualidadeAtirador{
+		CR:      h.CR,
+		Treinos: h.Treinos,
+	}
+}
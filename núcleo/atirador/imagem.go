@@ -11,16 +11,20 @@ import (
 	"strconv"
 
 	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/comprovante"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/registrobr/gostk/errors"
 	qrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
 )
 
 // gerarImagemNúmeroControle gera uma imagem com dados da frequência utilizando
-// uma imagem base.
-func (f *frequência) gerarImagemNúmeroControle(configuração config.Configuração, códigoVerificação string) error {
+// uma imagem base. Quando informado, o comprovante assinado é embutido na URL
+// do QR Code.
+func (f *frequência) gerarImagemNúmeroControle(configuração config.Configuração, códigoVerificação, comprovanteAssinado string) error {
 	fonte := configuração.Atirador.ImagemNúmeroControle.Fonte.Font
 	if fonte == nil {
 		// não é possível gerar a imagem sem uma fonte definida
@@ -31,69 +35,39 @@ func (f *frequência) gerarImagemNúmeroControle(configuração config.Configura
 	imagem := image.NewRGBA(configuração.Atirador.ImagemNúmeroControle.ImagemBase.Bounds())
 	draw.Draw(imagem, imagem.Bounds(), configuração.Atirador.ImagemNúmeroControle.ImagemBase, image.ZP, draw.Src)
 
-	// textos
-	textos := []imagemTexto{
-		{
-			texto:        strconv.Itoa(f.CR),
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{260, 110},
-		},
-		{
-			texto:        f.Calibre,
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{260, 126},
-		},
-		{
-			texto:        f.DataInício.Format("02/01/2006 15:04") + " - " + f.DataTérmino.Format("15:04"),
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{260, 142},
-		},
-		{
-			texto:        f.ArmaUtilizada,
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{260, 157},
-		},
-		{
-			texto:        strconv.Itoa(f.QuantidadeMunição),
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{260, 173},
-		},
-		{
-			texto:        string(protocolo.NovoNúmeroControle(f.ID, f.Controle)),
-			fonteCor:     color.RGBA{0xff, 0x00, 0x00, 0xff},
-			fonteTamanho: 18,
-			posição:      imagemTextoPosição{150, 220},
-		},
-		{
-			texto:        códigoVerificação,
-			fonteCor:     color.RGBA{0x00, 0x00, 0x00, 0xff},
-			fonteTamanho: 11,
-			posição:      imagemTextoPosição{32, 486},
-		},
+	layout := configuração.Atirador.ImagemNúmeroControle.Layout.LayoutImagem
+	if layout.Vazio() {
+		layout = config.LayoutImagemPadrão()
 	}
 
+	// textos
 	camadaTexto := freetype.NewContext()
-	camadaTexto.SetDPI(150)
+	camadaTexto.SetDPI(imagemResolução)
 	camadaTexto.SetClip(imagem.Bounds())
 	camadaTexto.SetDst(imagem)
 	camadaTexto.SetFont(fonte)
 
-	for _, t := range textos {
-		posição := freetype.Pt(int(t.posição.x), int(camadaTexto.PointToFixed(float64(t.fonteTamanho+t.posição.y))>>6))
-		camadaTexto.SetSrc(image.NewUniform(t.fonteCor))
-		camadaTexto.SetFontSize(t.fonteTamanho)
-		camadaTexto.DrawString(t.texto, posição)
+	for _, t := range layout.Textos {
+		face := truetype.NewFace(fonte, &truetype.Options{Size: t.Tamanho, DPI: imagemResolução})
+		texto := truncarTexto(face, f.textoCampoImagem(t.Campo, códigoVerificação), t.LarguraMáxima)
+
+		x := t.X
+		switch t.Alinhamento {
+		case config.AlinhamentoTextoCentro:
+			x -= font.MeasureString(face, texto).Round() / 2
+		case config.AlinhamentoTextoDireita:
+			x -= font.MeasureString(face, texto).Round()
+		}
+
+		camadaTexto.SetSrc(image.NewUniform(t.Cor.RGBA))
+		camadaTexto.SetFontSize(t.Tamanho)
+		if _, err := camadaTexto.DrawString(texto, freetype.Pt(x, t.Y)); err != nil {
+			return erros.Novo(err)
+		}
 	}
 
 	// QR Code
-	qrURL := fmt.Sprintf(configuração.Atirador.ImagemNúmeroControle.URLQRCode, strconv.Itoa(f.CR), protocolo.NovoNúmeroControle(f.ID, f.Controle), códigoVerificação)
-
-	qr, err := qrcode.New(qrURL, qrcode.Highest)
+	qr, err := qrcode.New(f.urlQRCode(configuração, códigoVerificação, comprovanteAssinado), qrcode.Highest)
 	if err != nil {
 		return erros.Novo(err)
 	}
@@ -101,9 +75,9 @@ func (f *frequência) gerarImagemNúmeroControle(configuração config.Configura
 	qr.BackgroundColor = color.Transparent
 	qr.ForegroundColor = color.RGBA{0x00, 0x00, 0x00, 0xff}
 
-	qrPonto := image.Pt(200, 600)
+	qrPonto := image.Pt(layout.QRCode.X, layout.QRCode.Y)
 	qrPosição := imagem.Bounds().Min.Sub(qrPonto)
-	draw.Draw(imagem, imagem.Bounds(), qr.Image(300), qrPosição, draw.Over)
+	draw.Draw(imagem, imagem.Bounds(), qr.Image(layout.QRCode.Tamanho), qrPosição, draw.Over)
 
 	// codifica a imagem
 	var buffer bytes.Buffer
@@ -115,14 +89,156 @@ func (f *frequência) gerarImagemNúmeroControle(configuração config.Configura
 	return nil
 }
 
-type imagemTexto struct {
-	texto        string
-	fonteCor     color.RGBA
-	fonteTamanho float64
-	posição      imagemTextoPosição
+// urlQRCode monta o endereço de verificação embutido no QR Code, incluindo o
+// comprovante assinado quando informado.
+func (f frequência) urlQRCode(configuração config.Configuração, códigoVerificação, comprovanteAssinado string) string {
+	qrURL := fmt.Sprintf(configuração.Atirador.ImagemNúmeroControle.URLQRCode, strconv.Itoa(f.CR), protocolo.NovoNúmeroControle(f.ID, f.Controle), códigoVerificação)
+	return comprovante.URL(qrURL, comprovanteAssinado)
+}
+
+// textoCampoImagem retorna o conteúdo de um dos campos do layout da imagem do
+// número de controle.
+func (f frequência) textoCampoImagem(campo config.CampoImagem, códigoVerificação string) string {
+	switch campo {
+	case config.CampoImagemCR:
+		return strconv.Itoa(f.CR)
+	case config.CampoImagemCalibre:
+		return f.Calibre
+	case config.CampoImagemPeríodo:
+		return f.DataInício.Format("02/01/2006 15:04") + " - " + f.DataTérmino.Format("15:04")
+	case config.CampoImagemArma:
+		return f.ArmaUtilizada
+	case config.CampoImagemMunição:
+		return strconv.Itoa(f.QuantidadeMunição)
+	case config.CampoImagemNúmeroControle:
+		return string(protocolo.NovoNúmeroControle(f.ID, f.Controle))
+	case config.CampoImagemCódigoVerificação:
+		return códigoVerificação
+	}
+
+	return ""
+}
+
+// truncarTexto remove os últimos caracteres do texto, acrescentando
+// reticências, até que ele caiba na largura máxima em pixels. Uma largura zero
+// não limita o texto.
+func truncarTexto(face font.Face, texto string, larguraMáxima int) string {
+	if larguraMáxima <= 0 || font.MeasureString(face, texto).Round() <= larguraMáxima {
+		return texto
+	}
+
+	caracteres := []rune(texto)
+	for len(caracteres) > 0 {
+		caracteres = caracteres[:len(caracteres)-1]
+
+		truncado := string(caracteres) + "…"
+		if font.MeasureString(face, truncado).Round() <= larguraMáxima {
+			return truncado
+		}
+	}
+
+	return ""
 }
 
-type imagemTextoPosição struct {
-	x float64
-	y float64
+// imagemResolução resolução, em DPI, utilizada para escrever os textos na
+// imagem do número de controle.
+const imagemResolução = 150
+
+const (
+	// hashImagemLargura quantidade de colunas da grade utilizada no hash
+	// perceptual. É uma coluna a mais que a quantidade de comparações por linha.
+	hashImagemLargura = 9
+
+	// hashImagemAltura quantidade de linhas da grade utilizada no hash
+	// perceptual.
+	hashImagemAltura = 8
+
+	// hashImagemAmostras quantidade máxima de pixels lidos em cada direção de
+	// uma célula da grade, limitando o custo do cálculo em imagens grandes.
+	hashImagemAmostras = 16
+)
+
+// calcularHashImagem calcula o hash perceptual por diferença (dHash) de uma
+// imagem codificada em base64. A imagem é reduzida para uma grade de 9x8 tons
+// de cinza e cada bit do hash indica se uma célula é mais escura que a sua
+// vizinha da direita. Imagens visualmente semelhantes, mesmo que recomprimidas
+// ou redimensionadas, geram hashes com poucos bits diferentes. A orientação
+// EXIF informada é aplicada antes do cálculo, assim a mesma foto enviada com
+// outra orientação registrada nos metadados, ou já rotacionada nos pixels, gera
+// o mesmo hash.
+func calcularHashImagem(imagemBase64 string, orientação int) (uint64, error) {
+	conteúdo, err := base64.StdEncoding.DecodeString(imagemBase64)
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	imagem, _, err := image.Decode(bytes.NewReader(conteúdo))
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	// a cópia dos pixels só é necessária quando a imagem precisa ser rotacionada
+	// ou espelhada
+	if orientação >= 2 && orientação <= 8 {
+		imagem = orientarImagem(imagem, orientação)
+	}
+
+	limites := imagem.Bounds()
+	if limites.Empty() {
+		return 0, errors.Errorf("imagem sem conteúdo")
+	}
+
+	var grade [hashImagemAltura][hashImagemLargura]float64
+	for linha := 0; linha < hashImagemAltura; linha++ {
+		y0, y1 := intervaloCélula(limites.Min.Y, limites.Dy(), linha, hashImagemAltura)
+
+		for coluna := 0; coluna < hashImagemLargura; coluna++ {
+			x0, x1 := intervaloCélula(limites.Min.X, limites.Dx(), coluna, hashImagemLargura)
+			grade[linha][coluna] = luminânciaMédia(imagem, x0, x1, y0, y1)
+		}
+	}
+
+	var hash uint64
+	for linha := 0; linha < hashImagemAltura; linha++ {
+		for coluna := 0; coluna < hashImagemLargura-1; coluna++ {
+			hash <<= 1
+			if grade[linha][coluna] < grade[linha][coluna+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash, nil
+}
+
+// intervaloCélula determina o intervalo de pixels, em uma das dimensões, que
+// pertence à célula informada da grade. Em imagens menores que a grade uma
+// mesma linha ou coluna de pixels pode pertencer a mais de uma célula.
+func intervaloCélula(início, tamanho, célula, células int) (int, int) {
+	fim := início + (célula+1)*tamanho/células
+	início += célula * tamanho / células
+	if fim <= início {
+		fim = início + 1
+	}
+
+	return início, fim
+}
+
+// luminânciaMédia calcula a média dos tons de cinza da região da imagem. Em
+// regiões grandes somente uma amostra distribuída uniformemente dos pixels é
+// analisada.
+func luminânciaMédia(imagem image.Image, x0, x1, y0, y1 int) float64 {
+	passoX := (x1-x0)/hashImagemAmostras + 1
+	passoY := (y1-y0)/hashImagemAmostras + 1
+
+	var soma float64
+	var quantidade int
+	for y := y0; y < y1; y += passoY {
+		for x := x0; x < x1; x += passoX {
+			soma += float64(color.GrayModel.Convert(imagem.At(x, y)).(color.Gray).Y)
+			quantidade++
+		}
+	}
+
+	return soma / float64(quantidade)
 }
@@ -0,0 +1,232 @@
+package atirador
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// imagemConfirmaçãoNormalizada armazena o resultado da normalização da imagem
+// enviada na confirmação, com todos os conteúdos codificados em base64.
+type imagemConfirmaçãoNormalizada struct {
+	// Imagem foto recodificada em JPEG, já orientada e sem os metadados EXIF.
+	Imagem string
+
+	// Miniatura versão reduzida da foto em JPEG.
+	Miniatura string
+
+	// EXIF conteúdo original dos metadados, mantido como evidência da captura.
+	// Fica vazio quando a imagem enviada não possui metadados EXIF.
+	EXIF string
+}
+
+// validarPixelsImagemConfirmação lê somente o cabeçalho da imagem enviada na
+// confirmação, rejeitando as imagens com mais pixels que o limite configurado
+// antes que sejam decodificadas. Um limite zero desabilita a verificação.
+func validarPixelsImagemConfirmação(imagemBase64 string, pixelsMáximo int) (protocolo.Mensagens, error) {
+	if pixelsMáximo <= 0 {
+		return nil, nil
+	}
+
+	conteúdo, err := base64.StdEncoding.DecodeString(imagemBase64)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	configuraçãoImagem, _, err := image.DecodeConfig(bytes.NewReader(conteúdo))
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	// a multiplicação é feita em 64 bits, evitando que dimensões declaradas
+	// muito grandes estourem o limite do inteiro
+	if int64(configuraçãoImagem.Width)*int64(configuraçãoImagem.Height) > int64(pixelsMáximo) {
+		resolução := fmt.Sprintf("%dx%d", configuraçãoImagem.Width, configuraçãoImagem.Height)
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImagemResoluçãoExcedida, "imagem", resolução),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// normalizarImagemConfirmação decodifica a imagem enviada na confirmação, em
+// qualquer formato suportado pelo image.Decode, e a recodifica em JPEG
+// respeitando a resolução máxima e a qualidade configuradas. Como o JPEG gerado
+// não possui metadados, a orientação registrada no EXIF é aplicada diretamente
+// nos pixels e o EXIF original é retornado separadamente. Imagens acima da
+// quantidade de pixels configurada são rejeitadas com mensagens, sem serem
+// decodificadas.
+func normalizarImagemConfirmação(imagemBase64 string, orientação int, configuração config.Configuração) (imagemConfirmaçãoNormalizada, error) {
+	var normalizada imagemConfirmaçãoNormalizada
+
+	parâmetros := configuração.Atirador.ImagemConfirmação
+	if mensagens, err := validarPixelsImagemConfirmação(imagemBase64, parâmetros.PixelsMáximo); err != nil {
+		return normalizada, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return normalizada, mensagens
+	}
+
+	conteúdo, err := base64.StdEncoding.DecodeString(imagemBase64)
+	if err != nil {
+		return normalizada, erros.Novo(err)
+	}
+
+	// metadados corrompidos não impedem a normalização, somente deixam de ser
+	// mantidos
+	if exif, err := extrairSegmentoEXIF(conteúdo); err == nil && exif != nil {
+		normalizada.EXIF = base64.StdEncoding.EncodeToString(exif)
+	}
+
+	original, _, err := image.Decode(bytes.NewReader(conteúdo))
+	if err != nil {
+		return normalizada, erros.Novo(err)
+	}
+
+	imagem := redimensionarImagem(orientarImagem(original, orientação), parâmetros.ResoluçãoMáxima)
+	if normalizada.Imagem, err = codificarJPEG(imagem, parâmetros.Qualidade); err != nil {
+		return normalizada, erros.Novo(err)
+	}
+
+	// a miniatura é gerada a partir da imagem já reduzida, diminuindo a
+	// quantidade de pixels processados
+	miniatura := redimensionarImagem(imagem, parâmetros.ResoluçãoMiniatura)
+	if normalizada.Miniatura, err = codificarJPEG(miniatura, parâmetros.Qualidade); err != nil {
+		return normalizada, erros.Novo(err)
+	}
+
+	return normalizada, nil
+}
+
+// orientarImagem copia a imagem para o formato RGBA, rotacionando ou
+// espelhando os pixels conforme a orientação EXIF. Nas orientações de 5 a 8 a
+// largura e a altura são trocadas. Orientações desconhecidas mantêm a imagem
+// como foi recebida.
+func orientarImagem(imagem image.Image, orientação int) *image.RGBA {
+	limites := imagem.Bounds()
+	origem := image.NewRGBA(image.Rect(0, 0, limites.Dx(), limites.Dy()))
+
+	// o JPEG não possui transparência, então a imagem é sobreposta a um fundo
+	// branco
+	draw.Draw(origem, origem.Bounds(), image.White, image.ZP, draw.Src)
+	draw.Draw(origem, origem.Bounds(), imagem, limites.Min, draw.Over)
+
+	largura, altura := limites.Dx(), limites.Dy()
+
+	// posição do pixel da origem que corresponde a cada pixel do destino
+	var posição func(x, y int) (int, int)
+
+	switch orientação {
+	case 2: // espelhada horizontalmente
+		posição = func(x, y int) (int, int) { return largura - 1 - x, y }
+	case 3: // rotacionada 180°
+		posição = func(x, y int) (int, int) { return largura - 1 - x, altura - 1 - y }
+	case 4: // espelhada verticalmente
+		posição = func(x, y int) (int, int) { return x, altura - 1 - y }
+	case 5: // transposta
+		posição = func(x, y int) (int, int) { return y, x }
+	case 6: // rotacionada 90° no sentido horário
+		posição = func(x, y int) (int, int) { return y, altura - 1 - x }
+	case 7: // transversa
+		posição = func(x, y int) (int, int) { return largura - 1 - y, altura - 1 - x }
+	case 8: // rotacionada 90° no sentido anti-horário
+		posição = func(x, y int) (int, int) { return largura - 1 - y, x }
+	default:
+		return origem
+	}
+
+	destino := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	if orientação >= 5 {
+		destino = image.NewRGBA(image.Rect(0, 0, altura, largura))
+	}
+
+	limitesDestino := destino.Bounds()
+	for y := 0; y < limitesDestino.Dy(); y++ {
+		for x := 0; x < limitesDestino.Dx(); x++ {
+			origemX, origemY := posição(x, y)
+			i := origem.PixOffset(origemX, origemY)
+			copy(destino.Pix[destino.PixOffset(x, y):], origem.Pix[i:i+4])
+		}
+	}
+
+	return destino
+}
+
+// redimensionarImagem reduz a imagem para que o maior lado possua no máximo a
+// resolução informada, mantendo a proporção. Cada pixel do destino é a média
+// dos pixels da região correspondente na origem. Imagens menores, ou uma
+// resolução não positiva, mantêm a imagem original.
+func redimensionarImagem(origem *image.RGBA, resoluçãoMáxima int) *image.RGBA {
+	limites := origem.Bounds()
+	largura, altura := limites.Dx(), limites.Dy()
+	if resoluçãoMáxima <= 0 || (largura <= resoluçãoMáxima && altura <= resoluçãoMáxima) {
+		return origem
+	}
+
+	if largura >= altura {
+		altura = altura * resoluçãoMáxima / largura
+		largura = resoluçãoMáxima
+	} else {
+		largura = largura * resoluçãoMáxima / altura
+		altura = resoluçãoMáxima
+	}
+
+	if largura < 1 {
+		largura = 1
+	}
+	if altura < 1 {
+		altura = 1
+	}
+
+	destino := image.NewRGBA(image.Rect(0, 0, largura, altura))
+	for y := 0; y < altura; y++ {
+		y0, y1 := intervaloCélula(limites.Min.Y, limites.Dy(), y, altura)
+
+		for x := 0; x < largura; x++ {
+			x0, x1 := intervaloCélula(limites.Min.X, limites.Dx(), x, largura)
+
+			var soma [4]int
+			var quantidade int
+			for origemY := y0; origemY < y1; origemY++ {
+				for origemX := x0; origemX < x1; origemX++ {
+					i := origem.PixOffset(origemX, origemY)
+					for canal := range soma {
+						soma[canal] += int(origem.Pix[i+canal])
+					}
+					quantidade++
+				}
+			}
+
+			i := destino.PixOffset(x, y)
+			for canal := range soma {
+				destino.Pix[i+canal] = uint8(soma[canal] / quantidade)
+			}
+		}
+	}
+
+	return destino
+}
+
+// codificarJPEG codifica a imagem em JPEG com a qualidade informada,
+// retornando o resultado em base64. Uma qualidade fora do intervalo de 1 a 100
+// utiliza a qualidade padrão do codificador.
+func codificarJPEG(imagem image.Image, qualidade int) (string, error) {
+	var opções *jpeg.Options
+	if qualidade >= 1 && qualidade <= 100 {
+		opções = &jpeg.Options{Quality: qualidade}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, imagem, opções); err != nil {
+		return "", erros.Novo(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
@@ -0,0 +1,328 @@
+package atirador
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestNormalizarImagemConfirmação(t *testing.T) {
+	// imagem WebP sem perdas de 75x100 pixels
+	imagemWebP := `UklGRrIBAABXRUJQVlA4TKUBAAAvSsAYAA8w//M///MfeJAkbXvaSG7m8Q3GfYSBJekwQztm/IcZ
+lgwnmWImn2BK7aFmBtnVir6q//8VOkFE/xm4baTIu8c48ArEo6+B3zFKYln3pqClSCKX0begFTAX
+FOLXHSyF8cCNcZEG4OywuA4KVVfJCiArU7GAgJI8+lJP/OKMT/fBAjevg1cYB7YVkFuWga2lyPi5
+I0HFy5YTpWIHg0RZpkniRVW9odHAKOwosWuOGdxIyn2OvaCDvhg/we6TwadPBPbqBV58MsLmMJ8y
+ZnOWk8SRz4N+QoyPL+MnamzMvcE1rHNEr91F9GKZPVUcS9w7PhhH36suB9qPeYb/oLk6cuTiJ0wO
+K3m5h1cKjW6EVZCYMK7dxcKCBdgP9HkKr9gkAO2P8GKZGWVdIAatQa+1IDpt6qyorVwdy01xdW8J
+kfk6xjEXmVQQ+HQdFr6OKhIN34dXWq0+0qr6EJSCeeVLH9+gvGTLyqM65PQ44ihzlTXxQKjKbAvs
+hXgir7Lil9w4L2bvMycmjQcqXaMCO6BlY28i+FOLzbfI1vEqxAhotocAAA==`
+
+	// resultado resume as características das imagens geradas na normalização
+	type resultado struct {
+		Formato          string
+		Largura          int
+		Altura           int
+		FormatoMiniatura string
+		LarguraMiniatura int
+		AlturaMiniatura  int
+		ImagemComEXIF    bool
+		EXIFMantido      bool
+	}
+
+	configuração := func(resoluçãoMáxima, resoluçãoMiniatura int) config.Configuração {
+		var configuração config.Configuração
+		configuração.Atirador.ImagemConfirmação.ResoluçãoMáxima = resoluçãoMáxima
+		configuração.Atirador.ImagemConfirmação.Qualidade = 85
+		configuração.Atirador.ImagemConfirmação.ResoluçãoMiniatura = resoluçãoMiniatura
+		return configuração
+	}
+
+	cenários := []struct {
+		descrição         string
+		imagem            string
+		orientação        int
+		configuração      config.Configuração
+		resultadoEsperado resultado
+		erroEsperado      error
+	}{
+		{
+			descrição:    "deve converter uma imagem WebP para JPEG reduzindo a resolução",
+			imagem:       imagemWebP,
+			configuração: configuração(50, 20),
+			resultadoEsperado: resultado{
+				Formato:          "jpeg",
+				Largura:          37,
+				Altura:           50,
+				FormatoMiniatura: "jpeg",
+				LarguraMiniatura: 14,
+				AlturaMiniatura:  20,
+			},
+		},
+		{
+			descrição:    "deve aplicar a orientação informada nos metadados",
+			imagem:       imagemWebP,
+			orientação:   6,
+			configuração: configuração(50, 20),
+			resultadoEsperado: resultado{
+				Formato:          "jpeg",
+				Largura:          50,
+				Altura:           37,
+				FormatoMiniatura: "jpeg",
+				LarguraMiniatura: 20,
+				AlturaMiniatura:  14,
+			},
+		},
+		{
+			descrição:    "deve remover os metadados EXIF da imagem mantendo-os separadamente",
+			imagem:       gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00", nil),
+			configuração: configuração(1920, 8),
+			resultadoEsperado: resultado{
+				Formato:          "jpeg",
+				Largura:          16,
+				Altura:           16,
+				FormatoMiniatura: "jpeg",
+				LarguraMiniatura: 8,
+				AlturaMiniatura:  8,
+				EXIFMantido:      true,
+			},
+		},
+		{
+			descrição:    "deve manter a resolução original quando não houver limite",
+			imagem:       imagemWebP,
+			configuração: configuração(0, 0),
+			resultadoEsperado: resultado{
+				Formato:          "jpeg",
+				Largura:          75,
+				Altura:           100,
+				FormatoMiniatura: "jpeg",
+				LarguraMiniatura: 75,
+				AlturaMiniatura:  100,
+			},
+		},
+		{
+			descrição: "deve aceitar uma imagem no limite de pixels",
+			imagem:    imagemWebP,
+			configuração: func() config.Configuração {
+				c := configuração(50, 20)
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 75 * 100
+				return c
+			}(),
+			resultadoEsperado: resultado{
+				Formato:          "jpeg",
+				Largura:          37,
+				Altura:           50,
+				FormatoMiniatura: "jpeg",
+				LarguraMiniatura: 14,
+				AlturaMiniatura:  20,
+			},
+		},
+		{
+			descrição: "deve rejeitar uma imagem acima do limite de pixels",
+			imagem:    imagemWebP,
+			configuração: func() config.Configuração {
+				c := configuração(50, 20)
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 75*100 - 1
+				return c
+			}(),
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImagemResoluçãoExcedida, "imagem", "75x100"),
+			),
+		},
+		{
+			descrição:    "deve detectar uma imagem com base64 inválido",
+			imagem:       "%%%",
+			configuração: configuração(50, 20),
+			erroEsperado: errors.Errorf("illegal base64 data at input byte 0"),
+		},
+		{
+			descrição:    "deve detectar uma imagem em formato desconhecido",
+			imagem:       base64.StdEncoding.EncodeToString([]byte("imagem inválida")),
+			configuração: configuração(50, 20),
+			erroEsperado: errors.Errorf("image: unknown format"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		normalizada, err := normalizarImagemConfirmação(cenário.imagem, cenário.orientação, cenário.configuração)
+
+		var r resultado
+		if err == nil {
+			imagem, err := base64.StdEncoding.DecodeString(normalizada.Imagem)
+			if err != nil {
+				t.Fatalf("Item %d, “%s”: imagem em base64 inválido. Detalhes: %s", i, cenário.descrição, err)
+			}
+
+			miniatura, err := base64.StdEncoding.DecodeString(normalizada.Miniatura)
+			if err != nil {
+				t.Fatalf("Item %d, “%s”: miniatura em base64 inválido. Detalhes: %s", i, cenário.descrição, err)
+			}
+
+			configuraçãoImagem, formato, err := image.DecodeConfig(bytes.NewReader(imagem))
+			if err != nil {
+				t.Fatalf("Item %d, “%s”: imagem inválida. Detalhes: %s", i, cenário.descrição, err)
+			}
+
+			configuraçãoMiniatura, formatoMiniatura, err := image.DecodeConfig(bytes.NewReader(miniatura))
+			if err != nil {
+				t.Fatalf("Item %d, “%s”: miniatura inválida. Detalhes: %s", i, cenário.descrição, err)
+			}
+
+			exif, _ := extrairSegmentoEXIF(imagem)
+
+			r = resultado{
+				Formato:          formato,
+				Largura:          configuraçãoImagem.Width,
+				Altura:           configuraçãoImagem.Height,
+				FormatoMiniatura: formatoMiniatura,
+				LarguraMiniatura: configuraçãoMiniatura.Width,
+				AlturaMiniatura:  configuraçãoMiniatura.Height,
+				ImagemComEXIF:    exif != nil,
+				EXIFMantido:      normalizada.EXIF != "",
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.resultadoEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(r, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestOrientarImagem(t *testing.T) {
+	cores := map[byte]color.RGBA{
+		'a': {0xff, 0x00, 0x00, 0xff},
+		'b': {0x00, 0xff, 0x00, 0xff},
+		'c': {0x00, 0x00, 0xff, 0xff},
+		'd': {0xff, 0xff, 0xff, 0xff},
+		'e': {0x00, 0x00, 0x00, 0xff},
+		'f': {0xff, 0xff, 0x00, 0xff},
+	}
+
+	// a imagem é representada por linhas de pixels identificados pelas cores
+	original := []string{
+		"abc",
+		"def",
+	}
+
+	cenários := []struct {
+		descrição      string
+		orientação     int
+		imagemEsperada []string
+	}{
+		{
+			descrição:      "deve manter a imagem na orientação normal",
+			orientação:     1,
+			imagemEsperada: []string{"abc", "def"},
+		},
+		{
+			descrição:      "deve espelhar a imagem horizontalmente",
+			orientação:     2,
+			imagemEsperada: []string{"cba", "fed"},
+		},
+		{
+			descrição:      "deve rotacionar a imagem em 180°",
+			orientação:     3,
+			imagemEsperada: []string{"fed", "cba"},
+		},
+		{
+			descrição:      "deve espelhar a imagem verticalmente",
+			orientação:     4,
+			imagemEsperada: []string{"def", "abc"},
+		},
+		{
+			descrição:      "deve transpor a imagem",
+			orientação:     5,
+			imagemEsperada: []string{"ad", "be", "cf"},
+		},
+		{
+			descrição:      "deve rotacionar a imagem em 90° no sentido horário",
+			orientação:     6,
+			imagemEsperada: []string{"da", "eb", "fc"},
+		},
+		{
+			descrição:      "deve transverter a imagem",
+			orientação:     7,
+			imagemEsperada: []string{"fc", "eb", "da"},
+		},
+		{
+			descrição:      "deve rotacionar a imagem em 90° no sentido anti-horário",
+			orientação:     8,
+			imagemEsperada: []string{"cf", "be", "ad"},
+		},
+		{
+			descrição:      "deve ignorar uma orientação desconhecida",
+			orientação:     9,
+			imagemEsperada: []string{"abc", "def"},
+		},
+	}
+
+	imagem := image.NewRGBA(image.Rect(0, 0, len(original[0]), len(original)))
+	for y, linha := range original {
+		for x := range linha {
+			imagem.Set(x, y, cores[linha[x]])
+		}
+	}
+
+	for i, cenário := range cenários {
+		orientada := orientarImagem(imagem, cenário.orientação)
+
+		var imagemObtida []string
+		for y := 0; y < orientada.Bounds().Dy(); y++ {
+			var linha []byte
+			for x := 0; x < orientada.Bounds().Dx(); x++ {
+				for identificador, cor := range cores {
+					if orientada.RGBAAt(x, y) == cor {
+						linha = append(linha, identificador)
+					}
+				}
+			}
+			imagemObtida = append(imagemObtida, string(linha))
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.imagemEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(imagemObtida, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRedimensionarImagem(t *testing.T) {
+	// imagem de 4x2 com a metade esquerda preta e a direita branca
+	imagem := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x >= 2 {
+				imagem.SetRGBA(x, y, color.RGBA{0xff, 0xff, 0xff, 0xff})
+			} else {
+				imagem.SetRGBA(x, y, color.RGBA{0x00, 0x00, 0x00, 0xff})
+			}
+		}
+	}
+
+	reduzida := redimensionarImagem(imagem, 2)
+	if limites := reduzida.Bounds(); limites.Dx() != 2 || limites.Dy() != 1 {
+		t.Fatalf("Resolução %dx%d inesperada", limites.Dx(), limites.Dy())
+	}
+
+	if cor := reduzida.RGBAAt(0, 0); cor != (color.RGBA{0x00, 0x00, 0x00, 0xff}) {
+		t.Errorf("Cor %v inesperada no pixel da esquerda", cor)
+	}
+
+	if cor := reduzida.RGBAAt(1, 0); cor != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("Cor %v inesperada no pixel da direita", cor)
+	}
+
+	if mesma := redimensionarImagem(imagem, 10); mesma != imagem {
+		t.Errorf("Imagem menor que a resolução máxima não deveria ser alterada")
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"encoding/base64"
 	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/bits"
 	"testing"
 	"time"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"golang.org/x/image/font"
 	"golang.org/x/image/font/gofont/goregular"
 )
 
@@ -44,6 +50,178 @@ func BenchmarkGerarImagemNúmeroControle(b *testing.B) {
 	}
 
 	for i := 0; i < b.N; i++ {
-		f.gerarImagemNúmeroControle(configuração, "abc123")
+		f.gerarImagemNúmeroControle(configuração, "abc123", "")
+	}
+}
+
+func TestCalcularHashImagem(t *testing.T) {
+	// gera uma imagem com regiões claras e escuras alternadas, simulando o
+	// contraste de uma foto
+	gerarImagem := func(largura, altura int, invertida bool) image.Image {
+		imagem := image.NewRGBA(image.Rect(0, 0, largura, altura))
+		for y := 0; y < altura; y++ {
+			for x := 0; x < largura; x++ {
+				tom := uint8((x*255/largura + y*97/altura) % 256)
+				if (x*7/largura)%2 == 0 {
+					tom = 255 - tom
+				}
+				if invertida {
+					tom = 255 - tom
+				}
+				imagem.Set(x, y, color.RGBA{tom, tom / 2, 255 - tom, 0xff})
+			}
+		}
+		return imagem
+	}
+
+	codificarPNG := func(imagem image.Image) string {
+		var buffer bytes.Buffer
+		if err := png.Encode(&buffer, imagem); err != nil {
+			t.Fatalf("erro ao codificar a imagem PNG. Detalhes: %s", err)
+		}
+		return base64.StdEncoding.EncodeToString(buffer.Bytes())
+	}
+
+	codificarJPEG := func(imagem image.Image) string {
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, imagem, &jpeg.Options{Quality: 60}); err != nil {
+			t.Fatalf("erro ao codificar a imagem JPEG. Detalhes: %s", err)
+		}
+		return base64.StdEncoding.EncodeToString(buffer.Bytes())
+	}
+
+	original := codificarPNG(gerarImagem(640, 480, false))
+
+	// a mesma imagem com os pixels rotacionados 90° no sentido anti-horário, que
+	// volta à posição original com a orientação EXIF 6
+	rotacionada := codificarPNG(orientarImagem(gerarImagem(640, 480, false), 8))
+
+	cenários := []struct {
+		descrição       string
+		imagem          string
+		orientação      int
+		distânciaMínima int
+		distânciaMáxima int
+		erroEsperado    bool
+	}{
+		{
+			descrição:       "deve gerar o mesmo hash para a mesma imagem",
+			imagem:          original,
+			distânciaMáxima: 0,
+		},
+		{
+			descrição:       "deve gerar um hash próximo para a imagem recomprimida",
+			imagem:          codificarJPEG(gerarImagem(640, 480, false)),
+			distânciaMáxima: 5,
+		},
+		{
+			descrição:       "deve gerar um hash próximo para a imagem redimensionada",
+			imagem:          codificarPNG(gerarImagem(320, 240, false)),
+			distânciaMáxima: 5,
+		},
+		{
+			descrição:       "deve gerar o mesmo hash para a imagem rotacionada com a orientação informada",
+			imagem:          rotacionada,
+			orientação:      6,
+			distânciaMáxima: 0,
+		},
+		{
+			descrição:       "deve gerar um hash distante para a imagem rotacionada sem orientação",
+			imagem:          rotacionada,
+			distânciaMínima: 20,
+			distânciaMáxima: 64,
+		},
+		{
+			descrição:       "deve gerar um hash distante para uma imagem diferente",
+			imagem:          codificarPNG(gerarImagem(640, 480, true)),
+			distânciaMínima: 20,
+			distânciaMáxima: 64,
+		},
+		{
+			descrição:    "deve detectar um base64 inválido",
+			imagem:       "%%%",
+			erroEsperado: true,
+		},
+		{
+			descrição:    "deve detectar um formato de imagem desconhecido",
+			imagem:       base64.StdEncoding.EncodeToString([]byte("não é uma imagem")),
+			erroEsperado: true,
+		},
+	}
+
+	hashOriginal, err := calcularHashImagem(original, 0)
+	if err != nil {
+		t.Fatalf("erro ao calcular o hash da imagem original. Detalhes: %s", err)
+	}
+
+	for i, cenário := range cenários {
+		hash, err := calcularHashImagem(cenário.imagem, cenário.orientação)
+		if cenário.erroEsperado {
+			if err == nil {
+				t.Errorf("Item %d, “%s”: erro esperado não encontrado", i, cenário.descrição)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Item %d, “%s”: erro inesperado. Detalhes: %s", i, cenário.descrição, err)
+			continue
+		}
+
+		distância := bits.OnesCount64(hashOriginal ^ hash)
+		if distância < cenário.distânciaMínima || distância > cenário.distânciaMáxima {
+			t.Errorf("Item %d, “%s”: distância %d fora do intervalo esperado [%d, %d]",
+				i, cenário.descrição, distância, cenário.distânciaMínima, cenário.distânciaMáxima)
+		}
+	}
+}
+
+func TestTruncarTexto(t *testing.T) {
+	fonte, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Erro ao extrair a fonte de teste. Detalhes: %s", err)
+	}
+
+	face := truetype.NewFace(fonte, &truetype.Options{Size: 11, DPI: imagemResolução})
+	largura := font.MeasureString(face, "Arma do Clube…").Round()
+
+	cenários := []struct {
+		descrição         string
+		texto             string
+		larguraMáxima     int
+		resultadoEsperado string
+	}{
+		{
+			descrição:         "deve manter o texto quando não existe limite de largura",
+			texto:             "Arma do Clube",
+			resultadoEsperado: "Arma do Clube",
+		},
+		{
+			descrição:         "deve manter o texto que cabe na largura máxima",
+			texto:             "Arma do Clube",
+			larguraMáxima:     largura,
+			resultadoEsperado: "Arma do Clube",
+		},
+		{
+			descrição:         "deve truncar o texto maior que a largura máxima",
+			texto:             "Arma do Clube de Tiro",
+			larguraMáxima:     largura,
+			resultadoEsperado: "Arma do Clube…",
+		},
+		{
+			descrição:     "deve descartar o texto quando nem as reticências cabem",
+			texto:         "Arma do Clube",
+			larguraMáxima: 1,
+		},
+	}
+
+	for i, cenário := range cenários {
+		resultado := truncarTexto(face, cenário.texto, cenário.larguraMáxima)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.resultadoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(resultado, nil); err != nil {
+			t.Error(err)
+		}
 	}
 }
@@ -0,0 +1,25 @@
+package atirador
+
+import "github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+
+// consumoMunição armazena a quantidade de munição utilizada por um atirador em
+// um determinado calibre durante um período.
+type consumoMunição struct {
+	Calibre    string
+	Classe     protocolo.CalibreClasse
+	Quantidade int
+}
+
+func (c consumoMunição) protocolo(cotas map[string]int) protocolo.MuniçãoConsumoCalibre {
+	consumo := protocolo.MuniçãoConsumoCalibre{
+		Calibre:    c.Calibre,
+		Classe:     c.Classe,
+		Quantidade: c.Quantidade,
+	}
+
+	if cota, ok := cotas[string(c.Classe)]; ok {
+		consumo.Cota = &cota
+	}
+
+	return consumo
+}
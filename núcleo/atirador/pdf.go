@@ -0,0 +1,130 @@
+package atirador
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// pdfFonte nome da família com que a fonte configurada é registrada no
+	// documento.
+	pdfFonte = "numero-controle"
+
+	// pdfMargem margem, em milímetros, da folha A4.
+	pdfMargem = 20.0
+
+	// pdfColunaValor posição horizontal, em milímetros, dos valores ao lado dos
+	// rótulos.
+	pdfColunaValor = 75.0
+
+	// pdfTamanhoQRCode largura e altura, em milímetros, do QR Code impresso.
+	pdfTamanhoQRCode = 60.0
+)
+
+// gerarPDFNúmeroControle gera a folha do número de controle em um documento
+// PDF no tamanho A4. Diferente da imagem, os textos e o QR Code são vetoriais e
+// a fonte é embutida no documento, mantendo as dimensões em qualquer
+// impressora.
+func (f frequência) gerarPDFNúmeroControle(configuração config.Configuração, códigoVerificação, comprovanteAssinado string) ([]byte, error) {
+	fonte := configuração.Atirador.ImagemNúmeroControle.Fonte.TTF
+	if len(fonte) == 0 {
+		// não é possível gerar o documento sem uma fonte definida
+		return nil, errors.Errorf("fonte do PDF do número de controle indefinida")
+	}
+
+	númeroControle := protocolo.NovoNúmeroControle(f.ID, f.Controle)
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Frequência "+string(númeroControle), true)
+	pdf.SetCreator("Atirador Frequente", true)
+	pdf.SetCreationDate(f.DataCriação)
+	pdf.SetModificationDate(f.DataCriação)
+	pdf.SetMargins(pdfMargem, pdfMargem, pdfMargem)
+	pdf.SetAutoPageBreak(false, pdfMargem)
+	pdf.AddUTF8FontFromBytes(pdfFonte, "", fonte)
+	pdf.AddPage()
+
+	largura, _ := pdf.GetPageSize()
+
+	pdf.SetFont(pdfFonte, "", 18)
+	pdf.Text(pdfMargem, 35, "Comprovante de frequência")
+	pdf.SetLineWidth(0.3)
+	pdf.Line(pdfMargem, 40, largura-pdfMargem, 40)
+
+	campos := []struct {
+		rótulo string
+		valor  string
+	}{
+		{"CR", strconv.Itoa(f.CR)},
+		{"Calibre", f.Calibre},
+		{"Data", f.DataInício.Format("02/01/2006 15:04") + " - " + f.DataTérmino.Format("15:04")},
+		{"Arma utilizada", f.ArmaUtilizada},
+		{"Munição", strconv.Itoa(f.QuantidadeMunição)},
+	}
+
+	pdf.SetFont(pdfFonte, "", 12)
+	for i, campo := range campos {
+		y := 55 + float64(i)*9
+		pdf.SetTextColor(0x60, 0x60, 0x60)
+		pdf.Text(pdfMargem, y, campo.rótulo)
+		pdf.SetTextColor(0x00, 0x00, 0x00)
+		pdf.Text(pdfColunaValor, y, campo.valor)
+	}
+
+	pdf.SetTextColor(0x60, 0x60, 0x60)
+	pdf.Text(pdfMargem, 115, "Número de controle")
+	pdf.SetFont(pdfFonte, "", 28)
+	pdf.SetTextColor(0xff, 0x00, 0x00)
+	pdf.Text(pdfMargem, 128, string(númeroControle))
+
+	pdf.SetFont(pdfFonte, "", 12)
+	pdf.SetTextColor(0x60, 0x60, 0x60)
+	pdf.Text(pdfMargem, 145, "Código de verificação")
+	pdf.SetTextColor(0x00, 0x00, 0x00)
+	pdf.Text(pdfMargem, 153, códigoVerificação)
+
+	// QR Code desenhado módulo a módulo, evitando a perda de nitidez de uma
+	// imagem redimensionada pela impressora
+	qr, err := qrcode.New(f.urlQRCode(configuração, códigoVerificação, comprovanteAssinado), qrcode.Highest)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	módulos := qr.Bitmap()
+	tamanhoMódulo := pdfTamanhoQRCode / float64(len(módulos))
+	qrX := (largura - pdfTamanhoQRCode) / 2
+	qrY := 170.0
+
+	// os módulos consecutivos de uma linha são unidos em um único retângulo,
+	// reduzindo o documento e evitando frestas entre os módulos
+	pdf.SetFillColor(0x00, 0x00, 0x00)
+	for linha := range módulos {
+		for coluna := 0; coluna < len(módulos[linha]); coluna++ {
+			if !módulos[linha][coluna] {
+				continue
+			}
+
+			início := coluna
+			for coluna+1 < len(módulos[linha]) && módulos[linha][coluna+1] {
+				coluna++
+			}
+
+			pdf.Rect(qrX+float64(início)*tamanhoMódulo, qrY+float64(linha)*tamanhoMódulo,
+				float64(coluna-início+1)*tamanhoMódulo, tamanhoMódulo, "F")
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -1,10 +1,17 @@
 package atirador
 
 import (
+	"math"
 	"strconv"
 	"time"
 
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
 )
 
 // validarCR garante que a frequência referente ao ID bate com o CR informado
@@ -55,10 +62,14 @@ func validarTempoMáximoParaCadastro(frequência frequência, tempoMáximaCadast
 }
 
 // validarCódigoVerificação analisa se o código de verificação informado é o
-// correto. Calculamos o código correto utilizando os dados da frequência com
-// uma chave simétrica global.
-func validarCódigoVerificação(frequência frequência, chaveCódigoVerificação, códigoVerificação string) protocolo.Mensagens {
-	if frequência.gerarCódigoVerificação(chaveCódigoVerificação) != códigoVerificação {
+// correto. Calculamos o código correto utilizando os dados da frequência com a
+// chave simétrica referenciada no próprio código, que deve ser a mesma chave
+// utilizada na criação da frequência.
+func validarCódigoVerificação(frequência frequência, configuração config.Configuração, códigoVerificação string) protocolo.Mensagens {
+	idChave := extrairIDChaveVerificação(códigoVerificação)
+	chave, encontrada := configuração.ChaveVerificação(idChave)
+
+	if !encontrada || idChave != frequência.IDChaveVerificação || frequência.gerarCódigoVerificação(idChave, chave) != códigoVerificação {
 		return protocolo.NovasMensagens(
 			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, códigoVerificação),
 		)
@@ -92,13 +103,311 @@ func validarImagemConfirmação(frequência frequência, imagem string) protocol
 	return nil
 }
 
-// validarEstadoFrequência verifica se a frequência já foi confirmada.
-func validarEstadoFrequência(frequência frequência) protocolo.Mensagens {
-	if !frequência.DataConfirmação.IsZero() && frequência.ImagemConfirmação != "" {
+// validarImagemReutilizada evita que a mesma foto, ou uma cópia recomprimida
+// ou levemente alterada, seja utilizada na confirmação de mais de uma
+// frequência. A comparação é feita pela distância de Hamming entre os hashes
+// perceptuais das imagens, sendo desabilitada quando a distância configurada
+// for negativa.
+func validarImagemReutilizada(dao frequênciaDAO, frequência frequência, hash uint64, distância int) (protocolo.Mensagens, error) {
+	if distância < 0 {
+		return nil, nil
+	}
+
+	semelhante, err := dao.imagemSemelhante(frequência.ID, hash, distância)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if semelhante {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoImagemReutilizada),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// validarMetadadosImagem verifica se a data de captura registrada nos metadados
+// da imagem de confirmação está dentro do período do treino, considerando a
+// tolerância configurada. Quando a verificação falha a política define se a
+// confirmação é rejeitada, retornando as mensagens, ou se é aceita com uma
+// sinalização, retornando o código que deve ser armazenado na frequência.
+func validarMetadadosImagem(frequência frequência, metadados metadadosImagem,
+	tolerância time.Duration, políticaData, políticaSemMetadados config.PolíticaImagem) (protocolo.Mensagens, protocolo.MensagemCódigo) {
+
+	var mensagem protocolo.Mensagem
+	var política config.PolíticaImagem
+
+	if metadados.DataCaptura.IsZero() {
+		mensagem = protocolo.NovaMensagem(protocolo.MensagemCódigoImagemSemMetadados)
+		política = políticaSemMetadados
+
+	} else if metadados.DataCaptura.Before(frequência.DataInício.Add(-tolerância)) ||
+		metadados.DataCaptura.After(frequência.DataTérmino.Add(tolerância)) {
+
+		mensagem = protocolo.NovaMensagemComValor(protocolo.MensagemCódigoImagemForaPeríodoTreino,
+			metadados.DataCaptura.Format(time.RFC3339))
+		política = políticaData
+
+	} else {
+		return nil, ""
+	}
+
+	switch política {
+	case config.PolíticaImagemRejeitar:
+		return protocolo.NovasMensagens(mensagem), ""
+	case config.PolíticaImagemSinalizar:
+		return nil, mensagem.Código
+	}
+
+	return nil, ""
+}
+
+// verificarLocalizaçãoImagem calcula a distância entre o local onde a imagem de
+// confirmação foi capturada e o estande de tiro do clube. Quando a distância
+// excede o raio configurado retorna o código de sinalização que deve ser
+// armazenado na frequência. A distância fica indefinida quando uma das
+// localizações não é conhecida.
+func verificarLocalizaçãoImagem(localizaçãoImagem, localizaçãoClube *protocolo.Coordenadas, raio int) (*float64, protocolo.MensagemCódigo) {
+	if localizaçãoImagem == nil || localizaçãoClube == nil {
+		return nil, ""
+	}
+
+	distância := localizaçãoImagem.Distância(*localizaçãoClube)
+	if raio > 0 && distância > float64(raio) {
+		return &distância, protocolo.MensagemCódigoImagemForaClube
+	}
+
+	return &distância, ""
+}
+
+// validarTransição verifica se a situação atual da frequência permite a
+// transição para a situação de destino. As situações mais comuns possuem
+// mensagens específicas, facilitando o entendimento do cliente.
+func validarTransição(frequência frequência, destino protocolo.FrequênciaSituação) protocolo.Mensagens {
+	if transiçãoPermitida(frequência.Situação, destino) {
+		return nil
+	}
+
+	var mensagem protocolo.Mensagem
+
+	switch frequência.Situação {
+	case protocolo.FrequênciaSituaçãoConfirmada, protocolo.FrequênciaSituaçãoEmAuditoria:
+		mensagem = protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada)
+	case protocolo.FrequênciaSituaçãoCancelada:
+		mensagem = protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaCancelada)
+	case protocolo.FrequênciaSituaçãoExpirada:
+		mensagem = protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoConfirmaçãoExpirado)
+	default:
+		mensagem = protocolo.NovaMensagemComValor(protocolo.MensagemCódigoTransiçãoSituaçãoInválida, string(frequência.Situação))
+	}
+
+	return protocolo.NovasMensagens(mensagem)
+}
+
+// validarPrazoCancelamento verifica se o prazo máximo para o cancelamento da
+// frequência, contado a partir da sua criação, já expirou.
+func validarPrazoCancelamento(frequência frequência, prazoCancelamento time.Duration) protocolo.Mensagens {
+	if data := frequência.DataCriação.Add(prazoCancelamento); data.Before(time.Now()) {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoCancelamentoExpirado),
+		)
+	}
+
+	return nil
+}
+
+// validarClube garante que o Clube de Tiro informado na frequência existe e
+// está ativo.
+func validarClube(serviçoClube clube.Serviço, idClube int64) (protocolo.Mensagens, error) {
+	c, err := serviçoClube.ObterClube(idClube)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInválido, strconv.FormatInt(idClube, 10)),
+		), nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if c.Situação != protocolo.ClubeSituaçãoAtivo {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInativo, strconv.FormatInt(idClube, 10)),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// validarCalibre garante que o calibre informado na frequência consta no
+// catálogo de calibres. Retorna o calibre do catálogo para que o nome canônico
+// seja armazenado na frequência, permitindo agregar as frequências pelo
+// calibre.
+func validarCalibre(serviçoCalibre calibre.Serviço, nome string) (protocolo.CalibreResposta, protocolo.Mensagens, error) {
+	c, err := serviçoCalibre.ResolverCalibre(nome)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return protocolo.CalibreResposta{}, protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, nome),
+		), nil
+	} else if err != nil {
+		return protocolo.CalibreResposta{}, nil, erros.Novo(err)
+	}
+
+	return c, nil, nil
+}
+
+// validarCotaMunição garante que a munição informada na frequência, somada ao
+// que o atirador já consumiu no calibre durante o ano do treino, não ultrapassa
+// a cota anual definida para a classe do calibre. Quando não existe cota para
+// a classe o consumo não é verificado.
+func validarCotaMunição(dao frequênciaDAO, frequência frequência, classe protocolo.CalibreClasse, cotas map[string]int) (protocolo.Mensagens, error) {
+	cota, ok := cotas[string(classe)]
+	if !ok {
+		return nil, nil
+	}
+
+	início, término := anoCivil(frequência.DataInício.UTC().Year())
+	consumos, err := dao.consumoMunição(frequência.CR, início, término)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	quantidade := frequência.QuantidadeMunição
+	for _, consumo := range consumos {
+		if consumo.Calibre == frequência.Calibre {
+			quantidade += consumo.Quantidade
+		}
+	}
+
+	if quantidade > cota {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCotaMuniçãoExcedida, frequência.Calibre),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// anoCivil retorna o período que compreende o ano informado, sendo o término
+// o início do ano seguinte.
+func anoCivil(ano int) (início, término time.Time) {
+	início = time.Date(ano, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return início, início.AddDate(1, 0, 0)
+}
+
+// validarArma garante que a arma informada na frequência está cadastrada no
+// acervo, possui o mesmo calibre informado e pertence ao atirador ou ao clube
+// que está reportando a frequência. Retorna o número de identificação da arma
+// para que seja armazenado na frequência.
+func validarArma(serviçoArma arma.Serviço, frequência frequência) (int64, protocolo.Mensagens, error) {
+	a, err := serviçoArma.ObterArmaPorNúmeroSérie(frequência.NúmeroSérie)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return 0, protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaNãoCadastrada, frequência.NúmeroSérie),
+		), nil
+	} else if err != nil {
+		return 0, nil, erros.Novo(err)
+	}
+
+	var mensagens protocolo.Mensagens
+
+	if a.Calibre != frequência.Calibre {
+		mensagens = append(mensagens, protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaCalibreDivergente, frequência.Calibre))
+	}
+
+	doAtirador := a.CR > 0 && a.CR == frequência.CR
+	doClube := a.Clube > 0 && a.Clube == frequência.IDClube
+	if !doAtirador && !doClube {
+		mensagens = append(mensagens, protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaProprietárioDivergente, frequência.NúmeroSérie))
+	}
+
+	return a.ID, mensagens, nil
+}
+
+// validarNívelHabitualidade garante que existe uma quantidade mínima de treinos
+// configurada para o nível de atividade informado.
+func validarNívelHabitualidade(nível int, habitualidade map[int]int) protocolo.Mensagens {
+	if _, ok := habitualidade[nível]; !ok {
 		return protocolo.NovasMensagens(
-			protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNívelInválido, strconv.Itoa(nível)),
 		)
 	}
 
 	return nil
 }
+
+// calcularTreinosExigidos determina a quantidade mínima de treinos no período,
+// proporcional à quantidade anual exigida. Cada ano civil completo exige a
+// quantidade anual, e a fração restante é calculada sobre a duração real do
+// ano seguinte (considerando anos bissextos) e arredondada para cima, de forma
+// que um período de um ano inteiro até o último segundo exija todos os treinos.
+func calcularTreinosExigidos(treinosAnuais int, início, término time.Time) int {
+	if !término.After(início) {
+		return 0
+	}
+
+	var anos int
+	cursor := início
+	for !cursor.AddDate(1, 0, 0).After(término) {
+		cursor = cursor.AddDate(1, 0, 0)
+		anos++
+	}
+
+	ano := cursor.AddDate(1, 0, 0).Sub(cursor)
+	fração := math.Ceil(float64(treinosAnuais) * float64(término.Sub(cursor)) / float64(ano))
+	return anos*treinosAnuais + int(fração)
+}
+
+// validarAtirador garante que o CR informado na frequência pertence a um
+// atirador cadastrado e que o CR estava regular na data do treino.
+func validarAtirador(dao atiradorDAO, frequência frequência) (protocolo.Mensagens, error) {
+	cr := strconv.Itoa(frequência.CR)
+
+	a, err := dao.resgatarPorCR(frequência.CR)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRNãoCadastrado, cr),
+		), nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	switch a.Situação {
+	case protocolo.AtiradorSituaçãoSuspenso:
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRSuspenso, cr),
+		), nil
+
+	case protocolo.AtiradorSituaçãoCancelado:
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRCancelado, cr),
+		), nil
+	}
+
+	if a.DataValidade.Before(frequência.DataInício) {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRExpirado, cr),
+		), nil
+	}
+
+	return nil, nil
+}
+
+// validarCRDisponível garante que não existe outro atirador cadastrado com o
+// mesmo CR. O número de identificação informado é ignorado na busca,
+// permitindo que um atirador seja atualizado mantendo o seu próprio CR.
+func validarCRDisponível(dao atiradorDAO, id int64, cr int) (protocolo.Mensagens, error) {
+	a, err := dao.resgatarPorCR(cr)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return nil, nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if a.ID != id {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, strconv.Itoa(cr)),
+		), nil
+	}
+
+	return nil, nil
+}
@@ -1,11 +1,17 @@
 package atirador
 
 import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
 )
 
 // Serviço disponibiliza as ações que podem ser feitas relacionadas ao Atirador.
@@ -13,7 +19,11 @@ type Serviço interface {
 	// CadastrarFrequência persiste em banco de dados as informações básicas
 	// relacionados a visita do Atirador a um Clube de Tiro. Esta ação será
 	// responsável por gerar o número de controle utilizado na confirmação da
-	// frequência.
+	// frequência. O calibre deve constar no catálogo de calibres, sendo
+	// armazenado com o seu nome canônico. Quando o número de série é
+	// informado, a arma deve constar no acervo do atirador ou do clube. A
+	// munição informada não pode ultrapassar a cota anual do atirador no
+	// calibre.
 	CadastrarFrequência(protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error)
 
 	// ObterFrequência retorna a frequência relacionada ao CR e número de controle
@@ -21,9 +31,110 @@ type Serviço interface {
 	// da criação para que a informação seja liberada.
 	ObterFrequência(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error)
 
+	// ObterResumoFrequência retorna os dados do treino da frequência, sem as
+	// imagens e sem os dados da confirmação, com as mesmas validações da
+	// consulta. Destinado às consultas públicas, não acessa o repositório de
+	// objetos.
+	ObterResumoFrequência(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error)
+
+	// PDFNúmeroControle gera a folha do número de controle em PDF no tamanho
+	// A4, com os mesmos dados da imagem, para impressão pelo clube. Assim como
+	// na consulta, o código de verificação deve bater com o informado no
+	// momento da criação.
+	PDFNúmeroControle(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error)
+
 	// ConfirmarFrequência finaliza o cadastro da frequência, confirmando atraves
 	// de uma imagem que o Atirador esta presente no Clube de Tiro.
 	ConfirmarFrequência(protocolo.FrequênciaConfirmaçãoPedidoCompleta) error
+
+	// CancelarFrequência desfaz uma frequência registrada por engano. Somente
+	// o clube que reportou a frequência pode cancelá-la, desde que ainda não
+	// tenha sido confirmada e dentro do prazo de cancelamento. A frequência é
+	// mantida com a data e o motivo do cancelamento.
+	CancelarFrequência(protocolo.FrequênciaCancelamentoPedidoCompleta) error
+
+	// ExpirarFrequências marca como expiradas as frequências pendentes cujo
+	// prazo de confirmação já passou, processando no máximo a quantidade de
+	// frequências informada no limite. As frequências alteradas por outra
+	// transação durante a execução são ignoradas, sem impedir a expiração das
+	// demais. Retorna a quantidade de frequências expiradas.
+	ExpirarFrequências(limite int) (int, error)
+
+	// MigrarImagens move para o repositório de objetos as imagens das
+	// frequências e do log que ainda estão armazenadas em base64 no banco de
+	// dados, processando no máximo a quantidade de registros informada no
+	// limite. Retorna a quantidade de registros migrados, que é zero quando não
+	// houver mais imagens a migrar.
+	MigrarImagens(limite int) (int, error)
+
+	// ListarFrequências retorna uma página das frequências que atendem ao
+	// filtro, sem as imagens. Quando existirem mais frequências, a resposta
+	// contém o cursor para obter a próxima página.
+	ListarFrequências(protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error)
+
+	// ListarFrequênciasAtirador retorna uma página das frequências registradas
+	// no CR informado, permitindo que o próprio atirador identifique registros
+	// feitos em seu nome. O CR do filtro é ignorado, prevalecendo o CR
+	// informado.
+	ListarFrequênciasAtirador(cr int, filtro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error)
+
+	// RelatórioHabitualidade identifica os atiradores que não atingiram a
+	// quantidade mínima de treinos confirmados no período, conforme o nível de
+	// atividade informado no filtro.
+	RelatórioHabitualidade(protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error)
+
+	// ConsumoMunição retorna a quantidade de munição consumida pelo atirador em
+	// cada calibre durante o ano civil informado, junto com a cota anual de
+	// cada calibre, permitindo que o clube verifique o saldo antes do treino.
+	ConsumoMunição(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error)
+
+	// PendênciasChavesAposentadas retorna a quantidade de frequências ainda
+	// pendentes cujo código de verificação foi gerado com uma chave diferente
+	// da chave ativa, indicando quando uma chave aposentada pode ser removida
+	// da configuração.
+	PendênciasChavesAposentadas() ([]protocolo.FrequênciaPendênciaChave, error)
+
+	// SortearAmostraAuditoria sorteia as frequências confirmadas de cada clube
+	// no período que serão auditadas, conforme o percentual ou a quantidade por
+	// clube informados. O sorteio é reproduzível a partir da semente armazenada
+	// na amostra. As frequências sorteadas permanecem em auditoria até que o
+	// auditor registre um veredito definitivo.
+	SortearAmostraAuditoria(protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error)
+
+	// ObterAmostraAuditoria retorna os critérios de uma amostra de auditoria
+	// junto com a lista das auditorias geradas no sorteio.
+	ObterAmostraAuditoria(id int64) (protocolo.AmostraAuditoriaResposta, error)
+
+	// ObterAuditoria retorna uma auditoria com os dados da frequência sorteada,
+	// incluindo a imagem do número de controle e a foto da confirmação para que
+	// o auditor possa compará-las.
+	ObterAuditoria(id int64) (protocolo.AuditoriaResposta, error)
+
+	// RegistrarVeredito armazena a análise do auditor. Uma frequência aprovada
+	// volta a ser considerada confirmada e uma frequência fraudulenta é
+	// invalidada. Após um veredito definitivo a auditoria não pode mais ser
+	// alterada.
+	RegistrarVeredito(protocolo.AuditoriaVereditoPedidoCompleta) error
+
+	// CadastrarAtirador persiste em banco de dados um novo Atirador. Não é
+	// permitido cadastrar dois atiradores com o mesmo CR.
+	CadastrarAtirador(protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error)
+
+	// ObterAtirador retorna os dados do Atirador a partir do seu CR.
+	ObterAtirador(cr int) (protocolo.AtiradorResposta, error)
+
+	// AtualizarAtirador substitui os dados do Atirador pelos dados informados.
+	// É através desta ação que o CR de um atirador pode ser suspenso ou
+	// cancelado.
+	AtualizarAtirador(protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error)
+
+	// RemoverAtirador apaga o Atirador da base de dados. As frequências já
+	// registradas para o CR são mantidas.
+	RemoverAtirador(cr int) error
+
+	// ImportarAtiradores cadastra ou atualiza um lote de atiradores, utilizando
+	// o CR para identificar os atiradores já existentes.
+	ImportarAtiradores(protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error)
 }
 
 // NovoServiço inicializa um serviço concreto do Atirador. Pode ser substituído
@@ -45,6 +156,42 @@ type serviço struct {
 func (s serviço) CadastrarFrequência(frequênciaPedidoCompleta protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error) {
 	f := novaFrequência(frequênciaPedidoCompleta)
 
+	serviçoClube := clube.NovoServiço(s.sqlogger, s.logger, s.configuração)
+	if mensagens, err := validarClube(serviçoClube, f.IDClube); err != nil {
+		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.FrequênciaPendenteResposta{}, mensagens
+	}
+
+	if mensagens, err := validarAtirador(novoAtiradorDAO(s.sqlogger), f); err != nil {
+		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.FrequênciaPendenteResposta{}, mensagens
+	}
+
+	serviçoCalibre := calibre.NovoServiço(s.sqlogger, s.logger, s.configuração)
+	calibreResposta, mensagens, err := validarCalibre(serviçoCalibre, f.Calibre)
+	if err != nil {
+		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.FrequênciaPendenteResposta{}, mensagens
+	}
+	f.Calibre = calibreResposta.Nome
+
+	// o número de série é opcional, pois o atirador pode utilizar uma arma do
+	// clube sem identificá-la; quando informado a arma deve constar no acervo
+	if f.NúmeroSérie != "" {
+		serviçoArma := arma.NovoServiço(s.sqlogger, s.logger, s.configuração)
+		idArma, mensagens, err := validarArma(serviçoArma, f)
+		if err != nil {
+			return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+		} else if len(mensagens) > 0 {
+			return protocolo.FrequênciaPendenteResposta{}, mensagens
+		}
+
+		f.IDArma = idArma
+	}
+
 	if mensagens := protocolo.JuntarMensagens(
 		validarTempoMáximoParaCadastro(f, s.configuração.Atirador.TempoMáximoCadastro),
 		validarDuraçãoTreino(f, s.configuração.Atirador.DuraçãoMáximaTreino),
@@ -53,13 +200,33 @@ func (s serviço) CadastrarFrequência(frequênciaPedidoCompleta protocolo.Frequ
 	}
 
 	dao := novaFrequênciaDAO(s.sqlogger)
+	if mensagens, err := validarCotaMunição(dao, f, calibreResposta.Classe, s.configuração.Atirador.CotaMunição); err != nil {
+		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.FrequênciaPendenteResposta{}, mensagens
+	}
+
+	// os novos códigos de verificação sempre utilizam a chave ativa, e o
+	// identificador é persistido para acompanhar as frequências que ainda
+	// dependem das chaves aposentadas
+	f.IDChaveVerificação = s.configuração.Atirador.ChaveCódigoVerificaçãoAtiva
+	chave, encontrada := s.configuração.ChaveVerificação(f.IDChaveVerificação)
+	if !encontrada {
+		return protocolo.FrequênciaPendenteResposta{}, errors.Errorf("chave do código de verificação “%s” não encontrada", f.IDChaveVerificação)
+	}
+
 	if err := dao.criar(&f); err != nil {
 		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
 	}
 
-	códigoVerificação := f.gerarCódigoVerificação(s.configuração.Atirador.ChaveCódigoVerificação)
+	códigoVerificação := f.gerarCódigoVerificação(f.IDChaveVerificação, chave)
+
+	comprovanteAssinado, err := f.assinarComprovante(s.configuração.Atirador.ChaveAssinatura.PrivateKey)
+	if err != nil {
+		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
+	}
 
-	if err := f.gerarImagemNúmeroControle(s.configuração, códigoVerificação); err != nil {
+	if err := f.gerarImagemNúmeroControle(s.configuração, códigoVerificação, comprovanteAssinado); err != nil {
 		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
 	}
 
@@ -67,7 +234,7 @@ func (s serviço) CadastrarFrequência(frequênciaPedidoCompleta protocolo.Frequ
 		return protocolo.FrequênciaPendenteResposta{}, erros.Novo(err)
 	}
 
-	return f.protocoloPendente(códigoVerificação), nil
+	return f.protocoloPendente(códigoVerificação, comprovanteAssinado), nil
 }
 
 func (s serviço) ObterFrequência(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
@@ -80,7 +247,7 @@ func (s serviço) ObterFrequência(cr int, númeroControle protocolo.NúmeroCont
 	if mensagens := protocolo.JuntarMensagens(
 		validarCR(cr, f),
 		validarNúmeroControle(númeroControle, f),
-		validarCódigoVerificação(f, s.configuração.Atirador.ChaveCódigoVerificação, códigoVerificação),
+		validarCódigoVerificação(f, s.configuração, códigoVerificação),
 	); len(mensagens) > 0 {
 		return protocolo.FrequênciaResposta{}, mensagens
 	}
@@ -88,6 +255,50 @@ func (s serviço) ObterFrequência(cr int, númeroControle protocolo.NúmeroCont
 	return f.protocolo(códigoVerificação), nil
 }
 
+func (s serviço) ObterResumoFrequência(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+	dao := novaFrequênciaDAO(s.sqlogger)
+	f, err := dao.resgatarResumo(númeroControle.ID())
+	if err != nil {
+		return protocolo.FrequênciaResposta{}, erros.Novo(err)
+	}
+
+	if mensagens := protocolo.JuntarMensagens(
+		validarCR(cr, f),
+		validarNúmeroControle(númeroControle, f),
+		validarCódigoVerificação(f, s.configuração, códigoVerificação),
+	); len(mensagens) > 0 {
+		return protocolo.FrequênciaResposta{}, mensagens
+	}
+
+	return f.protocolo(códigoVerificação), nil
+}
+
+func (s serviço) PDFNúmeroControle(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+	dao := novaFrequênciaDAO(s.sqlogger)
+	f, err := dao.resgatar(númeroControle.ID())
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if mensagens := protocolo.JuntarMensagens(
+		validarCR(cr, f),
+		validarNúmeroControle(númeroControle, f),
+		validarCódigoVerificação(f, s.configuração, códigoVerificação),
+	); len(mensagens) > 0 {
+		return nil, mensagens
+	}
+
+	// a assinatura Ed25519 é determinística, reproduzindo o mesmo comprovante
+	// gerado no cadastro da frequência
+	comprovanteAssinado, err := f.assinarComprovante(s.configuração.Atirador.ChaveAssinatura.PrivateKey)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	pdf, err := f.gerarPDFNúmeroControle(s.configuração, códigoVerificação, comprovanteAssinado)
+	return pdf, erros.Novo(err)
+}
+
 func (s serviço) ConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) error {
 	dao := novaFrequênciaDAO(s.sqlogger)
 	f, err := dao.resgatar(frequênciaConfirmaçãoPedidoCompleta.NúmeroControle.ID())
@@ -98,14 +309,463 @@ func (s serviço) ConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta pr
 	if mensagens := protocolo.JuntarMensagens(
 		validarCR(frequênciaConfirmaçãoPedidoCompleta.CR, f),
 		validarNúmeroControle(frequênciaConfirmaçãoPedidoCompleta.NúmeroControle, f),
-		validarCódigoVerificação(f, s.configuração.Atirador.ChaveCódigoVerificação, frequênciaConfirmaçãoPedidoCompleta.CódigoVerificação),
+		validarCódigoVerificação(f, s.configuração, frequênciaConfirmaçãoPedidoCompleta.CódigoVerificação),
 		validarIntervaloMáximoConfirmação(f, s.configuração.Atirador.PrazoConfirmação),
 		validarImagemConfirmação(f, frequênciaConfirmaçãoPedidoCompleta.Imagem),
-		validarEstadoFrequência(f),
+		validarTransição(f, protocolo.FrequênciaSituaçãoConfirmada),
 	); len(mensagens) > 0 {
 		return mensagens
 	}
 
-	f.confirmar(frequênciaConfirmaçãoPedidoCompleta)
+	// as dimensões são verificadas antes do cálculo do hash, que decodifica
+	// todos os pixels da imagem
+	if mensagens, err := validarPixelsImagemConfirmação(frequênciaConfirmaçãoPedidoCompleta.Imagem,
+		s.configuração.Atirador.ImagemConfirmação.PixelsMáximo); err != nil {
+		return erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return mensagens
+	}
+
+	metadados, err := extrairMetadadosImagem(frequênciaConfirmaçãoPedidoCompleta.Imagem,
+		s.configuração.Atirador.FusoHorárioImagem.Location)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	// o hash é calculado sobre a imagem já orientada, da mesma forma que ela é
+	// armazenada, para que a orientação registrada no EXIF não altere o resultado
+	hash, err := calcularHashImagem(frequênciaConfirmaçãoPedidoCompleta.Imagem, metadados.Orientação)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if mensagens, err := validarImagemReutilizada(dao, f, hash, s.configuração.Atirador.DistânciaHashImagem); err != nil {
+		return erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return mensagens
+	}
+
+	mensagens, sinalização := validarMetadadosImagem(f, metadados,
+		s.configuração.Atirador.TolerânciaDataImagem,
+		s.configuração.Atirador.PolíticaDataImagem,
+		s.configuração.Atirador.PolíticaImagemSemEXIF)
+
+	if len(mensagens) > 0 {
+		return mensagens
+	}
+
+	// a localização do clube só é necessária quando a imagem possui coordenadas
+	var localizaçãoClube *protocolo.Coordenadas
+	if metadados.Localização != nil {
+		serviçoClube := clube.NovoServiço(s.sqlogger, s.logger, s.configuração)
+		c, err := serviçoClube.ObterClube(f.IDClube)
+		if err != nil {
+			return erros.Novo(err)
+		}
+		localizaçãoClube = c.Localização
+	}
+
+	distânciaClube, sinalizaçãoLocalização := verificarLocalizaçãoImagem(metadados.Localização,
+		localizaçãoClube, s.configuração.Atirador.RaioClube)
+
+	// somente uma sinalização é armazenada, prevalecendo a primeira detectada
+	if sinalização == "" {
+		sinalização = sinalizaçãoLocalização
+	}
+
+	imagem, err := normalizarImagemConfirmação(frequênciaConfirmaçãoPedidoCompleta.Imagem,
+		metadados.Orientação, s.configuração)
+
+	if mensagens, ok := err.(protocolo.Mensagens); ok {
+		return mensagens
+	} else if err != nil {
+		return erros.Novo(err)
+	}
+
+	// somente a imagem normalizada é armazenada, os metadados originais são
+	// mantidos separadamente
+	frequênciaConfirmaçãoPedidoCompleta.Imagem = imagem.Imagem
+
+	if mensagens := f.confirmar(frequênciaConfirmaçãoPedidoCompleta); len(mensagens) > 0 {
+		return mensagens
+	}
+	f.MiniaturaImagemConfirmação = imagem.Miniatura
+	f.EXIFImagemConfirmação = imagem.EXIF
+	f.HashImagemConfirmação = hash
+	f.DataCapturaImagem = metadados.DataCaptura
+	f.ModeloCâmeraImagem = metadados.ModeloCâmera
+	f.SinalizaçãoImagem = sinalização
+	f.DistânciaClube = distânciaClube
+
 	return erros.Novo(dao.atualizar(&f))
 }
+
+func (s serviço) CancelarFrequência(frequênciaCancelamentoPedidoCompleta protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+	dao := novaFrequênciaDAO(s.sqlogger)
+	f, err := dao.resgatar(frequênciaCancelamentoPedidoCompleta.NúmeroControle.ID())
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if mensagens := protocolo.JuntarMensagens(
+		validarCR(frequênciaCancelamentoPedidoCompleta.CR, f),
+		validarNúmeroControle(frequênciaCancelamentoPedidoCompleta.NúmeroControle, f),
+	); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	if !frequênciaCancelamentoPedidoCompleta.Identidade.PodeReportarClube(f.IDClube) {
+		return erros.AcessoNegado
+	}
+
+	if mensagens := protocolo.JuntarMensagens(
+		validarTransição(f, protocolo.FrequênciaSituaçãoCancelada),
+		validarPrazoCancelamento(f, s.configuração.Atirador.PrazoCancelamento),
+	); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	if mensagens := f.cancelar(frequênciaCancelamentoPedidoCompleta); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	return erros.Novo(dao.cancelar(&f))
+}
+
+func (s serviço) ExpirarFrequências(limite int) (int, error) {
+	dataLimite := time.Now().UTC().Add(-s.configuração.Atirador.PrazoConfirmação)
+
+	dao := novaFrequênciaDAO(s.sqlogger)
+	frequências, err := dao.pendentesExpiradas(dataLimite, limite)
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	var expiradas int
+	for _, f := range frequências {
+		// a frequência pode ter sido confirmada ou cancelada após a consulta; a
+		// atualização sem efeito não invalida a transação, então as demais
+		// frequências continuam sendo expiradas
+		if mensagens := f.expirar(s.configuração.Atirador.RemoverImagemExpirada); len(mensagens) > 0 {
+			s.logger.Infof("Frequência %d ignorada na expiração. Detalhes: %s", f.ID, mensagens)
+			continue
+		}
+
+		if err := dao.expirar(&f); errors.Equal(err, erros.NãoAtualizado) {
+			s.logger.Infof("Frequência %d ignorada na expiração. Detalhes: %s", f.ID, err)
+			continue
+		} else if err != nil {
+			return expiradas, erros.Novo(err)
+		}
+
+		expiradas++
+	}
+
+	return expiradas, nil
+}
+
+func (s serviço) MigrarImagens(limite int) (int, error) {
+	dao := novaFrequênciaDAO(s.sqlogger)
+	quantidade, err := dao.migrarImagens(limite)
+	return quantidade, erros.Novo(err)
+}
+
+func (s serviço) ListarFrequências(filtro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+	filtro.Normalizar()
+	if mensagens := filtro.Validar(); len(mensagens) > 0 {
+		return protocolo.FrequênciaListaResposta{}, mensagens
+	}
+
+	c, mensagens := interpretarCursor(filtro.Cursor, filtro.Ordenação)
+	if len(mensagens) > 0 {
+		return protocolo.FrequênciaListaResposta{}, mensagens
+	}
+
+	// é solicitada uma frequência a mais do que o limite para identificar se
+	// existe uma próxima página
+	dao := novaFrequênciaDAO(s.sqlogger)
+	frequências, err := dao.listar(filtro, c, filtro.Limite+1)
+	if err != nil {
+		return protocolo.FrequênciaListaResposta{}, erros.Novo(err)
+	}
+
+	var resposta protocolo.FrequênciaListaResposta
+	if len(frequências) > filtro.Limite {
+		frequências = frequências[:filtro.Limite]
+		resposta.PróximoCursor = novoCursor(frequências[len(frequências)-1], filtro.Ordenação).String()
+	}
+
+	resposta.Frequências = make([]protocolo.FrequênciaListaItem, 0, len(frequências))
+	for _, f := range frequências {
+		resposta.Frequências = append(resposta.Frequências, f.protocoloListaItem())
+	}
+
+	return resposta, nil
+}
+
+func (s serviço) ListarFrequênciasAtirador(cr int, filtro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+	if _, err := novoAtiradorDAO(s.sqlogger).resgatarPorCR(cr); err != nil {
+		return protocolo.FrequênciaListaResposta{}, erros.Novo(err)
+	}
+
+	filtro.CR = cr
+	return s.ListarFrequências(filtro)
+}
+
+func (s serviço) RelatórioHabitualidade(filtro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+	filtro.Normalizar()
+	if mensagens := filtro.Validar(); len(mensagens) > 0 {
+		return protocolo.HabitualidadeResposta{}, mensagens
+	}
+
+	if mensagens := validarNívelHabitualidade(filtro.Nível, s.configuração.Atirador.Habitualidade); len(mensagens) > 0 {
+		return protocolo.HabitualidadeResposta{}, mensagens
+	}
+
+	treinosAnuais := s.configuração.Atirador.Habitualidade[filtro.Nível]
+
+	resposta := protocolo.HabitualidadeResposta{
+		DataInício:      filtro.DataInício,
+		DataTérmino:     filtro.DataTérmino,
+		Nível:           filtro.Nível,
+		TreinosExigidos: calcularTreinosExigidos(treinosAnuais, filtro.DataInício, filtro.DataTérmino),
+	}
+
+	dao := novaFrequênciaDAO(s.sqlogger)
+	habitualidades, err := dao.habitualidadeInsuficiente(filtro.DataInício, filtro.DataTérmino, resposta.TreinosExigidos)
+	if err != nil {
+		return protocolo.HabitualidadeResposta{}, erros.Novo(err)
+	}
+
+	resposta.Atiradores = make([]protocolo.HabitualidadeAtirador, 0, len(habitualidades))
+	for _, h := range habitualidades {
+		resposta.Atiradores = append(resposta.Atiradores, h.protocolo())
+	}
+
+	return resposta, nil
+}
+
+func (s serviço) ConsumoMunição(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+	if _, err := novoAtiradorDAO(s.sqlogger).resgatarPorCR(cr); err != nil {
+		return protocolo.MuniçãoConsumoResposta{}, erros.Novo(err)
+	}
+
+	if ano == 0 {
+		ano = time.Now().UTC().Year()
+	}
+
+	início, término := anoCivil(ano)
+
+	dao := novaFrequênciaDAO(s.sqlogger)
+	consumos, err := dao.consumoMunição(cr, início, término)
+	if err != nil {
+		return protocolo.MuniçãoConsumoResposta{}, erros.Novo(err)
+	}
+
+	resposta := protocolo.MuniçãoConsumoResposta{
+		CR:       cr,
+		Ano:      ano,
+		Calibres: make([]protocolo.MuniçãoConsumoCalibre, 0, len(consumos)),
+	}
+
+	for _, consumo := range consumos {
+		resposta.Calibres = append(resposta.Calibres, consumo.protocolo(s.configuração.Atirador.CotaMunição))
+	}
+
+	return resposta, nil
+}
+
+func (s serviço) PendênciasChavesAposentadas() ([]protocolo.FrequênciaPendênciaChave, error) {
+	dao := novaFrequênciaDAO(s.sqlogger)
+	pendências, err := dao.pendentesPorChave()
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	aposentadas := make([]protocolo.FrequênciaPendênciaChave, 0, len(pendências))
+	for _, pendência := range pendências {
+		if pendência.Chave != s.configuração.Atirador.ChaveCódigoVerificaçãoAtiva {
+			aposentadas = append(aposentadas, pendência)
+		}
+	}
+
+	return aposentadas, nil
+}
+
+func (s serviço) SortearAmostraAuditoria(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+	amostra := novaAmostraAuditoria(amostraAuditoriaPedido)
+
+	frequênciaDAO := novaFrequênciaDAO(s.sqlogger)
+	candidatas, err := frequênciaDAO.candidatasAuditoria(amostra.DataInício, amostra.DataTérmino)
+	if err != nil {
+		return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+	}
+
+	if err := novaAmostraAuditoriaDAO(s.sqlogger).criar(&amostra); err != nil {
+		return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+	}
+
+	auditoriaDAO := novaAuditoriaDAO(s.sqlogger)
+
+	var auditorias []auditoria
+	for _, f := range amostra.sortear(candidatas) {
+		if mensagens := f.transitar(protocolo.FrequênciaSituaçãoEmAuditoria); len(mensagens) > 0 {
+			return protocolo.AmostraAuditoriaResposta{}, mensagens
+		}
+
+		if err := frequênciaDAO.atualizar(&f); err != nil {
+			return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+		}
+
+		a := novaAuditoria(amostra.ID, f)
+		if err := auditoriaDAO.criar(&a); err != nil {
+			return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+		}
+
+		auditorias = append(auditorias, a)
+	}
+
+	return amostra.protocolo(auditorias), nil
+}
+
+func (s serviço) ObterAmostraAuditoria(id int64) (protocolo.AmostraAuditoriaResposta, error) {
+	amostra, err := novaAmostraAuditoriaDAO(s.sqlogger).resgatar(id)
+	if err != nil {
+		return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+	}
+
+	auditorias, err := novaAuditoriaDAO(s.sqlogger).listarPorAmostra(amostra.ID)
+	if err != nil {
+		return protocolo.AmostraAuditoriaResposta{}, erros.Novo(err)
+	}
+
+	return amostra.protocolo(auditorias), nil
+}
+
+func (s serviço) ObterAuditoria(id int64) (protocolo.AuditoriaResposta, error) {
+	a, err := novaAuditoriaDAO(s.sqlogger).resgatar(id)
+	if err != nil {
+		return protocolo.AuditoriaResposta{}, erros.Novo(err)
+	}
+
+	if a.frequência, err = novaFrequênciaDAO(s.sqlogger).resgatar(a.IDFrequência); err != nil {
+		return protocolo.AuditoriaResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) RegistrarVeredito(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+	auditoriaDAO := novaAuditoriaDAO(s.sqlogger)
+	a, err := auditoriaDAO.resgatar(auditoriaVereditoPedidoCompleta.ID)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	frequênciaDAO := novaFrequênciaDAO(s.sqlogger)
+	if a.frequência, err = frequênciaDAO.resgatar(a.IDFrequência); err != nil {
+		return erros.Novo(err)
+	}
+
+	situaçãoAnterior := a.frequência.Situação
+	if mensagens := a.registrarVeredito(auditoriaVereditoPedidoCompleta); len(mensagens) > 0 {
+		return mensagens
+	}
+
+	if a.frequência.Situação != situaçãoAnterior {
+		if err := frequênciaDAO.atualizar(&a.frequência); err != nil {
+			return erros.Novo(err)
+		}
+	}
+
+	return erros.Novo(auditoriaDAO.atualizar(&a))
+}
+
+func (s serviço) CadastrarAtirador(atiradorPedido protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+	dao := novoAtiradorDAO(s.sqlogger)
+
+	if mensagens, err := validarCRDisponível(dao, 0, atiradorPedido.CR); err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.AtiradorResposta{}, mensagens
+	}
+
+	a := novoAtirador(atiradorPedido)
+	if err := dao.criar(&a); err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) ObterAtirador(cr int) (protocolo.AtiradorResposta, error) {
+	dao := novoAtiradorDAO(s.sqlogger)
+	a, err := dao.resgatarPorCR(cr)
+	if err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) AtualizarAtirador(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+	dao := novoAtiradorDAO(s.sqlogger)
+	a, err := dao.resgatarPorCR(atiradorPedidoCompleto.CRAtual)
+	if err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	}
+
+	if mensagens, err := validarCRDisponível(dao, a.ID, atiradorPedidoCompleto.CR); err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.AtiradorResposta{}, mensagens
+	}
+
+	a.preencher(atiradorPedidoCompleto.AtiradorPedido)
+	if err := dao.atualizar(&a); err != nil {
+		return protocolo.AtiradorResposta{}, erros.Novo(err)
+	}
+
+	return a.protocolo(), nil
+}
+
+func (s serviço) RemoverAtirador(cr int) error {
+	dao := novoAtiradorDAO(s.sqlogger)
+	a, err := dao.resgatarPorCR(cr)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	return erros.Novo(dao.remover(&a))
+}
+
+func (s serviço) ImportarAtiradores(atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error) {
+	dao := novoAtiradorDAO(s.sqlogger)
+
+	var resposta protocolo.AtiradorImportaçãoResposta
+	for _, atiradorPedido := range atiradorImportaçãoPedido.Atiradores {
+		a, err := dao.resgatarPorCR(atiradorPedido.CR)
+
+		if errors.Equal(err, erros.NãoEncontrado) {
+			a = novoAtirador(atiradorPedido)
+			if err := dao.criar(&a); err != nil {
+				return protocolo.AtiradorImportaçãoResposta{}, erros.Novo(err)
+			}
+
+			resposta.Cadastrados++
+			continue
+
+		} else if err != nil {
+			return protocolo.AtiradorImportaçãoResposta{}, erros.Novo(err)
+		}
+
+		a.preencher(atiradorPedido)
+		if err := dao.atualizar(&a); err != nil {
+			return protocolo.AtiradorImportaçãoResposta{}, erros.Novo(err)
+		}
+
+		resposta.Atualizados++
+	}
+
+	return resposta, nil
+}
@@ -3,19 +3,29 @@ package atirador
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
+	"fmt"
 	"image"
+	"image/color"
+	"image/jpeg"
 	_ "image/png"
+	"math/bits"
 	"strings"
 	"testing"
 	"testing/quick"
 	"time"
 
 	"github.com/golang/freetype/truetype"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
 	"github.com/registrobr/gostk/errors"
 	"golang.org/x/image/font/gofont/goregular"
 )
@@ -37,10 +47,57 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 
 	imagemBaseInválida := image.NewNRGBA(image.Rect(0, 0, 0, 0))
 
+	serviçoClubeAtivo := simulador.ServiçoClube{
+		SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+			return protocolo.ClubeResposta{
+				ID:       id,
+				Situação: protocolo.ClubeSituaçãoAtivo,
+			}, nil
+		},
+	}
+
+	atiradorDAOAtivo := simulaAtiradorDAO{
+		simulaResgatarPorCR: func(cr int) (atirador, error) {
+			return atirador{
+				ID:           1,
+				CR:           cr,
+				DataValidade: data.AddDate(1, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+			}, nil
+		},
+	}
+
+	serviçoCalibreConhecido := simulador.ServiçoCalibre{
+		SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+			switch nome {
+			case ".380":
+				return protocolo.CalibreResposta{ID: 1, Nome: ".380 ACP", Classe: protocolo.CalibreClassePermitido}, nil
+			case ".40":
+				return protocolo.CalibreResposta{ID: 2, Nome: ".40 S&W", Classe: protocolo.CalibreClassePermitido}, nil
+			}
+			return protocolo.CalibreResposta{}, erros.NãoEncontrado
+		},
+	}
+
+	serviçoArmaDoAtirador := simulador.ServiçoArma{
+		SimulaObterArmaPorNúmeroSérie: func(númeroSérie string) (protocolo.ArmaResposta, error) {
+			return protocolo.ArmaResposta{
+				ID:          7,
+				NúmeroSérie: númeroSérie,
+				Calibre:     ".380 ACP",
+				CR:          1234,
+			}, nil
+		},
+	}
+
 	cenários := []struct {
 		descrição                string
 		configuração             config.Configuração
 		frequênciaPedidoCompleta protocolo.FrequênciaPedidoCompleta
+		serviçoClube             clube.Serviço
+		atiradorDAO              atiradorDAO
+		serviçoCalibre           calibre.Serviço
+		serviçoArma              arma.Serviço
 		frequênciaDAO            frequênciaDAO
 		esperado                 protocolo.FrequênciaPendenteResposta
 		erroEsperado             error
@@ -64,6 +121,7 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -71,6 +129,9 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -95,6 +156,366 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 				Imagem:            strings.Replace(imagemNúmeroControlePNG, "\n", "", -1),
 			},
 		},
+		{
+			descrição: "deve detectar quando o clube não existe",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             2,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInválido, "2"),
+			},
+		},
+		{
+			descrição: "deve detectar quando o clube está inativo",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             3,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{
+						ID:       id,
+						Situação: protocolo.ClubeSituaçãoInativo,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeInativo, "3"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o clube",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro de resgate do clube")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate do clube"),
+		},
+		{
+			descrição: "deve detectar quando o CR não está cadastrado",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRNãoCadastrado, "123456789"),
+			},
+		},
+		{
+			descrição: "deve detectar quando o CR está expirado",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{
+						ID:           1,
+						CR:           cr,
+						DataValidade: data.Add(-time.Minute),
+						Situação:     protocolo.AtiradorSituaçãoAtivo,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRExpirado, "123456789"),
+			},
+		},
+		{
+			descrição: "deve detectar quando o CR está suspenso",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{
+						ID:           1,
+						CR:           cr,
+						DataValidade: data.AddDate(1, 0, 0),
+						Situação:     protocolo.AtiradorSituaçãoSuspenso,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRSuspenso, "123456789"),
+			},
+		},
+		{
+			descrição: "deve detectar quando o CR está cancelado",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{
+						ID:           1,
+						CR:           cr,
+						DataValidade: data.AddDate(1, 0, 0),
+						Situação:     protocolo.AtiradorSituaçãoCancelado,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRCancelado, "123456789"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o atirador",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, errors.Errorf("erro de resgate do atirador")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate do atirador"),
+		},
+		{
+			descrição: "deve detectar quando o calibre é desconhecido",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".999",
+					ArmaUtilizada:     "Arma do Atirador",
+					NúmeroSérie:       "XZ23456",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, ".999"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resolver o calibre",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Atirador",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube: serviçoClubeAtivo,
+			atiradorDAO:  atiradorDAOAtivo,
+			serviçoCalibre: simulador.ServiçoCalibre{
+				SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+					return protocolo.CalibreResposta{}, errors.Errorf("erro ao resolver o calibre")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao resolver o calibre"),
+		},
+		{
+			descrição: "deve detectar quando a arma não está cadastrada no acervo",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Atirador",
+					NúmeroSérie:       "XZ23456",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArmaPorNúmeroSérie: func(númeroSérie string) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaNãoCadastrada, "XZ23456"),
+			},
+		},
+		{
+			descrição: "deve detectar quando a arma possui outro calibre e pertence a terceiros",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 5678,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".40",
+					ArmaUtilizada:     "Arma do Atirador",
+					NúmeroSérie:       "XZ23456",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma:    serviçoArmaDoAtirador,
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaCalibreDivergente, ".40 S&W"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaProprietárioDivergente, "XZ23456"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a arma",
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Atirador",
+					NúmeroSérie:       "XZ23456",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArmaPorNúmeroSérie: func(númeroSérie string) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, errors.Errorf("erro de resgate da arma")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate da arma"),
+		},
+		{
+			descrição: "deve associar a arma do clube à frequência",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
+				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 5678,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					NúmeroSérie:       "XZ23456",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArmaPorNúmeroSérie: func(númeroSérie string) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{
+						ID:          8,
+						NúmeroSérie: númeroSérie,
+						Calibre:     ".380 ACP",
+						Clube:       1,
+					}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCriar: func(frequência *frequência) error {
+					if frequência.IDArma != 8 {
+						t.Errorf("Arma do acervo não associada à frequência")
+					}
+
+					if frequência.Calibre != ".380 ACP" {
+						t.Errorf("Calibre não armazenado com o nome canônico")
+					}
+
+					return errors.Errorf("erro de persistência")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de persistência"),
+		},
 		{
 			descrição: "deve detectar quando o prazo de cadastro do treino já passou",
 			configuração: func() config.Configuração {
@@ -107,7 +528,8 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 1234,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
-					Calibre:           "",
+					Clube:             1,
+					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					NúmeroSérie:       "XZ23456",
 					GuiaDeTráfego:     8734500,
@@ -116,6 +538,10 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(-12 * time.Hour),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma:    serviçoArmaDoAtirador,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -157,7 +583,8 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 1234,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
-					Calibre:           "",
+					Clube:             1,
+					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					NúmeroSérie:       "XZ23456",
 					GuiaDeTráfego:     8734500,
@@ -166,6 +593,10 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data,
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			serviçoArma:    serviçoArmaDoAtirador,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -189,24 +620,18 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			},
 		},
 		{
-			descrição: "deve detectar um erro ao persistir uma nova frequência",
+			descrição: "deve detectar quando a chave ativa do código de verificação não existe",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
 				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-				configuração.Atirador.ChaveCódigoVerificação = "abc123"
-				configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = imagemBase
-				configuração.Atirador.ImagemNúmeroControle.Fonte.Font, err = truetype.Parse(goregular.TTF)
-
-				if err != nil {
-					t.Fatalf("Erro ao extrair a fonte de teste. Detalhes: %s", err)
-				}
-
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017a"
 				return configuração
 			}(),
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -214,21 +639,24 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
-					return errors.Errorf("erro de criação")
+					return errors.Errorf("frequência não deveria ser criada")
 				},
 			},
-			erroEsperado: errors.Errorf("erro de criação"),
+			erroEsperado: errors.Errorf("chave do código de verificação “2017a” não encontrada"),
 		},
 		{
-			descrição: "deve detectar um erro ao gerar a imagem PNG",
+			descrição: "deve detectar um erro ao persistir uma nova frequência",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
 				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
 				configuração.Atirador.ChaveCódigoVerificação = "abc123"
-				configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = imagemBaseInválida
+				configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = imagemBase
 				configuração.Atirador.ImagemNúmeroControle.Fonte.Font, err = truetype.Parse(goregular.TTF)
 
 				if err != nil {
@@ -240,6 +668,7 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -247,6 +676,46 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCriar: func(frequência *frequência) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+		{
+			descrição: "deve detectar um erro ao gerar a imagem PNG",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
+				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = imagemBaseInválida
+				configuração.Atirador.ImagemNúmeroControle.Fonte.Font, err = truetype.Parse(goregular.TTF)
+
+				if err != nil {
+					t.Fatalf("Erro ao extrair a fonte de teste. Detalhes: %s", err)
+				}
+
+				return configuração
+			}(),
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 123456789,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -273,6 +742,7 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -280,6 +750,9 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -313,6 +786,7 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -320,6 +794,9 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					if frequência.Controle == 0 {
@@ -352,6 +829,7 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
 				CR: 123456789,
 				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
 					Calibre:           ".380",
 					ArmaUtilizada:     "Arma do Clube",
 					QuantidadeMunição: 50,
@@ -359,6 +837,9 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 					DataTérmino:       data.Add(30 * time.Minute),
 				},
 			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaCriar: func(frequência *frequência) error {
 					frequência.ID = 1
@@ -371,11 +852,88 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			},
 			erroEsperado: errors.Errorf("erro de atualização"),
 		},
+		{
+			descrição: "deve detectar quando a munição ultrapassa a cota anual do calibre",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
+				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+				configuração.Atirador.CotaMunição = map[string]int{"permitido": 100}
+				return configuração
+			}(),
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaConsumoMunição: func(cr int, início, término time.Time) ([]consumoMunição, error) {
+					if início.Year() != data.UTC().Year() || !término.Equal(início.AddDate(1, 0, 0)) {
+						return nil, errors.Errorf("período inesperado")
+					}
+
+					return []consumoMunição{
+						{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 60},
+						{Calibre: ".40 S&W", Classe: protocolo.CalibreClassePermitido, Quantidade: 20},
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCotaMuniçãoExcedida, ".380 ACP"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao calcular o consumo de munição",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
+				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+				configuração.Atirador.CotaMunição = map[string]int{"permitido": 100}
+				return configuração
+			}(),
+			frequênciaPedidoCompleta: protocolo.FrequênciaPedidoCompleta{
+				CR: 1234,
+				FrequênciaPedido: protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "Arma do Clube",
+					QuantidadeMunição: 50,
+					DataInício:        data,
+					DataTérmino:       data.Add(30 * time.Minute),
+				},
+			},
+			serviçoClube:   serviçoClubeAtivo,
+			atiradorDAO:    atiradorDAOAtivo,
+			serviçoCalibre: serviçoCalibreConhecido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaConsumoMunição: func(cr int, início, término time.Time) ([]consumoMunição, error) {
+					return nil, errors.Errorf("erro ao calcular o consumo")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao calcular o consumo"),
+		},
 	}
 
 	daoOriginal := novaFrequênciaDAO
+	atiradorDAOOriginal := novoAtiradorDAO
+	serviçoClubeOriginal := clube.NovoServiço
+	serviçoArmaOriginal := arma.NovoServiço
+	serviçoCalibreOriginal := calibre.NovoServiço
 	defer func() {
 		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = atiradorDAOOriginal
+		clube.NovoServiço = serviçoClubeOriginal
+		arma.NovoServiço = serviçoArmaOriginal
+		calibre.NovoServiço = serviçoCalibreOriginal
 	}()
 
 	for i, cenário := range cenários {
@@ -383,6 +941,22 @@ func TestServiço_CadastrarFrequência(t *testing.T) {
 			return cenário.frequênciaDAO
 		}
 
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		clube.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		arma.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) arma.Serviço {
+			return cenário.serviçoArma
+		}
+
+		calibre.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) calibre.Serviço {
+			return cenário.serviçoCalibre
+		}
+
 		serviço := NovoServiço(nil, nil, cenário.configuração)
 		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
 		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
@@ -407,10 +981,58 @@ func TestServiço_CadastrarFrequência_valoresAleatórios(t *testing.T) {
 	}
 
 	daoOriginal := novaFrequênciaDAO
+	atiradorDAOOriginal := novoAtiradorDAO
+	serviçoClubeOriginal := clube.NovoServiço
+	serviçoArmaOriginal := arma.NovoServiço
+	serviçoCalibreOriginal := calibre.NovoServiço
 	defer func() {
 		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = atiradorDAOOriginal
+		clube.NovoServiço = serviçoClubeOriginal
+		arma.NovoServiço = serviçoArmaOriginal
+		calibre.NovoServiço = serviçoCalibreOriginal
 	}()
 
+	calibre.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) calibre.Serviço {
+		return simulador.ServiçoCalibre{
+			SimulaResolverCalibre: func(nome string) (protocolo.CalibreResposta, error) {
+				return protocolo.CalibreResposta{Nome: nome}, nil
+			},
+		}
+	}
+
+	arma.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) arma.Serviço {
+		return simulador.ServiçoArma{
+			SimulaObterArmaPorNúmeroSérie: func(númeroSérie string) (protocolo.ArmaResposta, error) {
+				return protocolo.ArmaResposta{}, erros.NãoEncontrado
+			},
+		}
+	}
+
+	clube.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) clube.Serviço {
+		return simulador.ServiçoClube{
+			SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+				return protocolo.ClubeResposta{
+					ID:       id,
+					Situação: protocolo.ClubeSituaçãoAtivo,
+				}, nil
+			},
+		}
+	}
+
+	novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+		return simulaAtiradorDAO{
+			simulaResgatarPorCR: func(cr int) (atirador, error) {
+				return atirador{
+					ID:           1,
+					CR:           cr,
+					DataValidade: time.Now().AddDate(100, 0, 0),
+					Situação:     protocolo.AtiradorSituaçãoAtivo,
+				}, nil
+			},
+		}
+	}
+
 	novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
 		return simulaFrequênciaDAO{
 			simulaCriar: func(frequência *frequência) error {
@@ -426,7 +1048,7 @@ func TestServiço_CadastrarFrequência_valoresAleatórios(t *testing.T) {
 
 	// não utilizamos diretamente o objeto do protocolo, pois a biblioteca padrão
 	// não sabe preencher corretamente o tipo time.Time.
-	f := func(cr int, calibre, armaUtilizada, númeroSérie string, guiaDeTráfego, quantidadeMunição int, dataInício, dataTérmino int64) bool {
+	f := func(cr int, idClube int64, calibre, armaUtilizada, númeroSérie string, guiaDeTráfego, quantidadeMunição int, dataInício, dataTérmino int64) bool {
 		var configuração config.Configuração
 		configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
 		configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
@@ -441,6 +1063,7 @@ func TestServiço_CadastrarFrequência_valoresAleatórios(t *testing.T) {
 		frequênciaPedidoCompleta := protocolo.FrequênciaPedidoCompleta{
 			CR: cr,
 			FrequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             idClube,
 				Calibre:           calibre,
 				ArmaUtilizada:     armaUtilizada,
 				NúmeroSérie:       númeroSérie,
@@ -481,11 +1104,15 @@ func TestServiço_ObterFrequência(t *testing.T) {
 		erroEsperado      error
 	}{
 		{
-			descrição:         "deve obter uma frequência corretamente",
-			configuração:      config.Configuração{},
+			descrição: "deve obter uma frequência corretamente",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
 			cr:                123456789,
 			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaResgatar: func(id int64) (frequência, error) {
 					if id != 7654 {
@@ -504,6 +1131,7 @@ func TestServiço_ObterFrequência(t *testing.T) {
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -514,7 +1142,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			},
 			esperado: protocolo.FrequênciaResposta{
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma do Clube",
 				NúmeroSérie:       "ZA785671",
@@ -523,6 +1151,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				DataInício:        data.Add(-40 * time.Minute),
 				DataTérmino:       data.Add(-10 * time.Minute),
 				DataCriação:       data.Add(-5 * time.Minute),
+				Situação:          protocolo.FrequênciaSituaçãoPendente,
 				Imagem: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -531,11 +1160,15 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			},
 		},
 		{
-			descrição:         "deve identificar uma frequência que não existe",
-			configuração:      config.Configuração{},
+			descrição: "deve identificar uma frequência que não existe",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
 			cr:                123456789,
 			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaResgatar: func(id int64) (frequência, error) {
 					if id != 7654 {
@@ -548,11 +1181,15 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			erroEsperado: erros.NãoEncontrado,
 		},
 		{
-			descrição:         "deve identificar quando o CR não confere",
-			configuração:      config.Configuração{},
+			descrição: "deve identificar quando o CR não confere",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
 			cr:                123456780,
 			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaResgatar: func(id int64) (frequência, error) {
 					if id != 7654 {
@@ -584,11 +1221,15 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			),
 		},
 		{
-			descrição:         "deve identificar quando o número de controle não confere",
-			configuração:      config.Configuração{},
+			descrição: "deve identificar quando o número de controle não confere",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
 			cr:                123456789,
 			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273646),
-			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaResgatar: func(id int64) (frequência, error) {
 					if id != 7654 {
@@ -620,11 +1261,15 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			),
 		},
 		{
-			descrição:         "deve identificar quando o código de verificação não confere",
-			configuração:      config.Configuração{},
+			descrição: "deve identificar quando o código de verificação não confere",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
 			cr:                123456789,
 			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4q",
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5Jx",
 			frequênciaDAO: simulaFrequênciaDAO{
 				simulaResgatar: func(id int64) (frequência, error) {
 					if id != 7654 {
@@ -652,7 +1297,111 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				},
 			},
 			erroEsperado: protocolo.NovasMensagens(
-				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4q"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5Jx"),
+			),
+		},
+		{
+			descrição: "deve obter uma frequência com o código gerado por uma chave aposentada",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave1", "2017b": "chave2"}
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                 7654,
+						Controle:           918273645,
+						CR:                 123456789,
+						Calibre:            ".380",
+						ArmaUtilizada:      "Arma do Clube",
+						QuantidadeMunição:  50,
+						DataInício:         data.Add(-40 * time.Minute),
+						DataTérmino:        data.Add(-10 * time.Minute),
+						DataCriação:        data.Add(-5 * time.Minute),
+						Situação:           protocolo.FrequênciaSituaçãoPendente,
+						IDChaveVerificação: "2017a",
+					}, nil
+				},
+			},
+			esperado: protocolo.FrequênciaResposta{
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr",
+				Calibre:           ".380",
+				ArmaUtilizada:     "Arma do Clube",
+				QuantidadeMunição: 50,
+				DataInício:        data.Add(-40 * time.Minute),
+				DataTérmino:       data.Add(-10 * time.Minute),
+				DataCriação:       data.Add(-5 * time.Minute),
+				Situação:          protocolo.FrequênciaSituaçãoPendente,
+			},
+		},
+		{
+			descrição: "deve identificar quando a chave do código de verificação não existe mais",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017b": "chave2"}
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                 7654,
+						Controle:           918273645,
+						CR:                 123456789,
+						Calibre:            ".380",
+						ArmaUtilizada:      "Arma do Clube",
+						QuantidadeMunição:  50,
+						DataInício:         data.Add(-40 * time.Minute),
+						DataTérmino:        data.Add(-10 * time.Minute),
+						DataCriação:        data.Add(-5 * time.Minute),
+						Situação:           protocolo.FrequênciaSituaçãoPendente,
+						IDChaveVerificação: "2017a",
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr"),
+			),
+		},
+		{
+			descrição: "deve identificar quando a chave do código de verificação não é a utilizada na frequência",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave1", "2017b": "chave2"}
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                 7654,
+						Controle:           918273645,
+						CR:                 123456789,
+						Calibre:            ".380",
+						ArmaUtilizada:      "Arma do Clube",
+						QuantidadeMunição:  50,
+						DataInício:         data.Add(-40 * time.Minute),
+						DataTérmino:        data.Add(-10 * time.Minute),
+						DataCriação:        data.Add(-5 * time.Minute),
+						Situação:           protocolo.FrequênciaSituaçãoPendente,
+						IDChaveVerificação: "2017b",
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "2017a.HnFecmsWn3iUsNQMJFVMGjWZQFpWxtvRG6MpZ4H8jrGr"),
 			),
 		},
 	}
@@ -677,80 +1426,356 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 	}
 }
 
-func TestServiço_ObterFrequência_valoresAleatórios(t *testing.T) {
-	daoOriginal := novaFrequênciaDAO
-	defer func() {
-		novaFrequênciaDAO = daoOriginal
-	}()
+func TestServiço_ObterResumoFrequência(t *testing.T) {
+	data := time.Now()
+
+	var configuração config.Configuração
+	configuração.Atirador.ChaveCódigoVerificação = "abc123"
+
+	cenários := []struct {
+		descrição         string
+		cr                int
+		númeroControle    protocolo.NúmeroControle
+		códigoVerificação string
+		frequênciaDAO     frequênciaDAO
+		esperado          protocolo.FrequênciaResposta
+		erroEsperado      error
+	}{
+		{
+			descrição:         "deve obter o resumo de uma frequência sem carregar as imagens",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatarResumo: func(id int64) (frequência, error) {
+					if id != 7654 {
+						t.Errorf("ID %d inesperado", id)
+					}
 
-	f := func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) bool {
-		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
-			return simulaFrequênciaDAO{
-				simulaResgatar: func(id int64) (frequência, error) {
 					return frequência{
-						ID:                númeroControle.ID(),
-						Controle:          númeroControle.Controle(),
-						CR:                cr,
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
 						Calibre:           ".380",
 						ArmaUtilizada:     "Arma do Clube",
 						NúmeroSérie:       "ZA785671",
 						GuiaDeTráfego:     762556223,
 						QuantidadeMunição: 50,
-						DataInício:        time.Now().Add(-40 * time.Minute),
-						DataTérmino:       time.Now().Add(-10 * time.Minute),
-						DataCriação:       time.Now().Add(-5 * time.Minute),
-						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
-IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						DataConfirmação:   data.Add(-2 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoConfirmada,
 					}, nil
 				},
-			}
-		}
-
-		var configuração config.Configuração
-		configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
-		configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-		configuração.Atirador.ChaveCódigoVerificação = "abc123"
-
-		serviço := NovoServiço(nil, nil, configuração)
-		if _, err := serviço.ObterFrequência(cr, númeroControle, códigoVerificação); err != nil {
-			if _, ok := err.(protocolo.Mensagens); !ok {
-				t.Log(err)
-				return false
-			}
-		}
-
-		return true
-	}
-
-	if err := quick.Check(f, &quick.Config{MaxCount: 5}); err != nil {
-		t.Error(err)
-	}
-}
-
+			},
+			esperado: protocolo.FrequênciaResposta{
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				Calibre:           ".380",
+				ArmaUtilizada:     "Arma do Clube",
+				NúmeroSérie:       "ZA785671",
+				GuiaDeTráfego:     762556223,
+				QuantidadeMunição: 50,
+				DataInício:        data.Add(-40 * time.Minute),
+				DataTérmino:       data.Add(-10 * time.Minute),
+				DataCriação:       data.Add(-5 * time.Minute),
+				DataConfirmação:   data.Add(-2 * time.Minute),
+				Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+			},
+		},
+		{
+			descrição:         "deve identificar uma frequência que não existe",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatarResumo: func(id int64) (frequência, error) {
+					return frequência{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição:         "deve identificar quando o código de verificação não confere",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5Jx",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatarResumo: func(id int64) (frequência, error) {
+					return frequência{
+						ID:       7654,
+						Controle: 918273645,
+						CR:       123456789,
+						Situação: protocolo.FrequênciaSituaçãoPendente,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5Jx"),
+			),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ObterResumoFrequência(cenário.cr, cenário.númeroControle, cenário.códigoVerificação)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterFrequência_valoresAleatórios(t *testing.T) {
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	f := func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) bool {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                númeroControle.ID(),
+						Controle:          númeroControle.Controle(),
+						CR:                cr,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        time.Now().Add(-40 * time.Minute),
+						DataTérmino:       time.Now().Add(-10 * time.Minute),
+						DataCriação:       time.Now().Add(-5 * time.Minute),
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			}
+		}
+
+		var configuração config.Configuração
+		configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
+		configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+		configuração.Atirador.ChaveCódigoVerificação = "abc123"
+
+		serviço := NovoServiço(nil, nil, configuração)
+		if _, err := serviço.ObterFrequência(cr, númeroControle, códigoVerificação); err != nil {
+			if _, ok := err.(protocolo.Mensagens); !ok {
+				t.Log(err)
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 5}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServiço_PDFNúmeroControle(t *testing.T) {
+	data := time.Now()
+
+	frequênciaSimulada := frequência{
+		ID:                7654,
+		Controle:          918273645,
+		CR:                123456789,
+		Calibre:           ".380",
+		ArmaUtilizada:     "Arma do Clube",
+		QuantidadeMunição: 50,
+		DataInício:        data.Add(-40 * time.Minute),
+		DataTérmino:       data.Add(-10 * time.Minute),
+		DataCriação:       data.Add(-5 * time.Minute),
+		Situação:          protocolo.FrequênciaSituaçãoPendente,
+	}
+
+	cenários := []struct {
+		descrição         string
+		configuração      config.Configuração
+		cr                int
+		númeroControle    protocolo.NúmeroControle
+		códigoVerificação string
+		frequênciaDAO     frequênciaDAO
+		esperado          bool
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve gerar corretamente o PDF do número de controle",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.ImagemNúmeroControle.Fonte.TTF = goregular.TTF
+				configuração.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					if id != 7654 {
+						t.Errorf("ID %d inesperado", id)
+					}
+
+					return frequênciaSimulada, nil
+				},
+			},
+			esperado: true,
+		},
+		{
+			descrição: "deve identificar quando o código de verificação não confere",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.ImagemNúmeroControle.Fonte.TTF = goregular.TTF
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "abc",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaSimulada, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "abc"),
+			),
+		},
+		{
+			descrição: "deve identificar uma frequência que não existe",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar quando a fonte não foi definida",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				return configuração
+			}(),
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaSimulada, nil
+				},
+			},
+			erroEsperado: errors.Errorf("fonte do PDF do número de controle indefinida"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, cenário.configuração)
+		pdf, err := serviço.PDFNúmeroControle(cenário.cr, cenário.númeroControle, cenário.códigoVerificação)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(bytes.HasPrefix(pdf, []byte("%PDF-")), err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestServiço_ConfirmarFrequência(t *testing.T) {
 	data := time.Now()
 
+	// imagem aceita em uma confirmação anterior e uma cópia com os pixels
+	// rotacionados 90° no sentido anti-horário e a orientação EXIF 6, que é
+	// exibida exatamente como a imagem aceita
+	imagemAceita := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			tom := uint8((x*255/64 + y*97/48) % 256)
+			if (x*7/64)%2 == 0 {
+				tom = 255 - tom
+			}
+			imagemAceita.Set(x, y, color.RGBA{tom, tom / 2, 255 - tom, 0xff})
+		}
+	}
+
+	var imagemAceitaBuffer bytes.Buffer
+	if err := jpeg.Encode(&imagemAceitaBuffer, imagemAceita, nil); err != nil {
+		t.Fatalf("Erro ao codificar a imagem aceita. Detalhes: %s", err)
+	}
+
+	hashImagemAceita, err := calcularHashImagem(base64.StdEncoding.EncodeToString(imagemAceitaBuffer.Bytes()), 0)
+	if err != nil {
+		t.Fatalf("Erro ao calcular o hash da imagem aceita. Detalhes: %s", err)
+	}
+
+	var imagemRotacionadaBuffer bytes.Buffer
+	if err := jpeg.Encode(&imagemRotacionadaBuffer, orientarImagem(imagemAceita, 8), nil); err != nil {
+		t.Fatalf("Erro ao codificar a imagem rotacionada. Detalhes: %s", err)
+	}
+
+	segmentoOrientação := "\xff\xe1\x00\x22Exif\x00\x00" +
+		"MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00"
+
+	imagemRotacionada := imagemRotacionadaBuffer.Bytes()
+	imagemOrientada := base64.StdEncoding.EncodeToString(
+		append(append(append([]byte{}, imagemRotacionada[:2]...), segmentoOrientação...), imagemRotacionada[2:]...),
+	)
+
 	cenários := []struct {
 		descrição                           string
 		configuração                        config.Configuração
 		frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta
 		frequênciaDAO                       frequênciaDAO
+		serviçoClube                        clube.Serviço
 		erroEsperado                        error
 	}{
 		{
 			descrição: "deve confirmar corretamente uma frequência",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -759,6 +1784,9 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 				},
 			},
 			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
 				simulaAtualizar: func(frequência *frequência) error {
 					if frequência.DataConfirmação.Before(data) {
 						t.Errorf("Data de confirmação não definida corretamente")
@@ -787,6 +1815,7 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -800,13 +1829,14 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			descrição: "deve detectar um erro ao resgatar a frequência",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -825,13 +1855,14 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 			descrição: "deve detectar quando o CR e o número de controle não conferem",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456781,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273640),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -853,6 +1884,7 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -870,13 +1902,14 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			descrição: "deve detectar quando o prazo de confirmação expirar",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -898,6 +1931,7 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 						DataInício:        data.Add(-60 * time.Minute),
 						DataTérmino:       data.Add(-30 * time.Minute),
 						DataCriação:       data.Add(-21 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -914,13 +1948,14 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			descrição: "deve detectar quando a imagem de confirmação for igual a imagem do número de controle",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
@@ -943,6 +1978,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -964,13 +2000,14 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`),
 			descrição: "deve detectar quando a frequência já foi confirmada",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -996,6 +2033,7 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoConfirmada,
 						DataConfirmação:   data.Add(-2 * time.Minute),
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
@@ -1013,17 +2051,66 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
 			),
 		},
+		{
+			descrição: "deve detectar quando a frequência foi cancelada",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                 7654,
+						Controle:           918273645,
+						CR:                 123456789,
+						Calibre:            ".380",
+						ArmaUtilizada:      "Arma do Clube",
+						NúmeroSérie:        "ZA785671",
+						GuiaDeTráfego:      762556223,
+						QuantidadeMunição:  50,
+						DataInício:         data.Add(-40 * time.Minute),
+						DataTérmino:        data.Add(-10 * time.Minute),
+						DataCriação:        data.Add(-5 * time.Minute),
+						Situação:           protocolo.FrequênciaSituaçãoCancelada,
+						DataCancelamento:   data.Add(-2 * time.Minute),
+						MotivoCancelamento: "Registro duplicado",
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaCancelada),
+			),
+		},
 		{
 			descrição: "deve detectar um erro ao persistir a frequência existente",
 			configuração: func() config.Configuração {
 				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
 				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
 				return configuração
 			}(),
 			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
 				CR:                123456789,
 				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
-				CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
 				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
 					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
 aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
@@ -1032,6 +2119,9 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 				},
 			},
 			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
 				simulaAtualizar: func(*frequência) error {
 					return errors.Errorf("erro ao atualizar")
 				},
@@ -1048,6 +2138,7 @@ QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
 						DataInício:        data.Add(-40 * time.Minute),
 						DataTérmino:       data.Add(-10 * time.Minute),
 						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
 						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
 IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
 dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
@@ -1058,87 +2149,3033 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			},
 			erroEsperado: errors.Errorf("erro ao atualizar"),
 		},
+		{
+			descrição: "deve rejeitar uma imagem de confirmação acima do limite de pixels",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = 5
+				configuração.Atirador.ImagemConfirmação.PixelsMáximo = 99
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					t.Error("imagem semelhante não deveria ser consultada")
+					return false, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImagemResoluçãoExcedida, "imagem", "10x10"),
+			),
+		},
+		{
+			descrição: "deve detectar quando a imagem de confirmação já foi utilizada em outra frequência",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = 5
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					if id != 7654 {
+						t.Errorf("ID %d inesperado", id)
+					}
+
+					if distância != 5 {
+						t.Errorf("distância %d inesperada", distância)
+					}
+
+					return true, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoImagemReutilizada),
+			),
+		},
+		{
+			descrição: "deve detectar a imagem reutilizada mesmo quando enviada com outra orientação",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = 5
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: imagemOrientada,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return bits.OnesCount64(hash^hashImagemAceita) <= distância, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoImagemReutilizada),
+			),
+		},
+		{
+			descrição: "deve ignorar a verificação de imagem reutilizada quando estiver desabilitada",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = -1
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaAtualizar: func(frequência *frequência) error {
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: nil,
+		},
+		{
+			descrição: "deve detectar um erro ao verificar se a imagem já foi utilizada",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = 5
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, errors.Errorf("erro ao consultar")
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao consultar"),
+		},
+		{
+			descrição: "deve rejeitar uma imagem de confirmação sem metadados quando configurado",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.PolíticaImagemSemEXIF = config.PolíticaImagemRejeitar
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: `iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX///8AezAAzhcIziD//5sA
+aygIzos5zoPGpQAArQAArSj/zpsxzkkAWgBCnAAAlBcAvQAApTi1zgApjACMYwCTUqAuAAAAT0lE
+QVQImR2MyQ3AMAzDpNjO3bv7z1o1ehEiQABIGv6d/SC3vgu7uTEzZC93HyxRkWK9ozShLJObcMuR
+7fZZAWOx4ZMqPIxik+8q19Zk8QFkhgHrQUAyGgAAAABJRU5ErkJggg==`,
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoImagemSemMetadados),
+			),
+		},
+		{
+			descrição: "deve rejeitar uma imagem de confirmação capturada fora do período do treino quando configurado",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.TolerânciaDataImagem = time.Hour
+				configuração.Atirador.PolíticaDataImagem = config.PolíticaImagemRejeitar
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00", nil),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoImagemForaPeríodoTreino, "2017-03-10T17:30:00Z"),
+			),
+		},
+		{
+			descrição: "deve sinalizar uma imagem de confirmação capturada fora do período do treino quando configurado",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.TolerânciaDataImagem = time.Hour
+				configuração.Atirador.PolíticaDataImagem = config.PolíticaImagemSinalizar
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00", nil),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					if frequência.SinalizaçãoImagem != protocolo.MensagemCódigoImagemForaPeríodoTreino {
+						t.Errorf("Sinalização da imagem “%s” inesperada", frequência.SinalizaçãoImagem)
+					}
+
+					if frequência.ModeloCâmeraImagem != "Canon EOS 80D" {
+						t.Errorf("Modelo da câmera “%s” inesperado", frequência.ModeloCâmeraImagem)
+					}
+
+					if !frequência.DataCapturaImagem.Equal(time.Date(2017, 3, 10, 17, 30, 0, 0, time.UTC)) {
+						t.Errorf("Data de captura %s inesperada", frequência.DataCapturaImagem)
+					}
+
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: nil,
+		},
+		{
+			descrição: "deve normalizar a imagem de confirmação mantendo os metadados separadamente",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.PolíticaDataImagem = config.PolíticaImagemPermitir
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMáxima = 8
+				configuração.Atirador.ImagemConfirmação.Qualidade = 90
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 4
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00", nil),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					verificarImagem := func(nome, imagemBase64 string, resolução int) {
+						imagem, err := base64.StdEncoding.DecodeString(imagemBase64)
+						if err != nil {
+							t.Fatalf("%s em base64 inválido. Detalhes: %s", nome, err)
+						}
+
+						if exif, _ := extrairSegmentoEXIF(imagem); exif != nil {
+							t.Errorf("%s armazenada com os metadados EXIF", nome)
+						}
+
+						configuraçãoImagem, formato, err := image.DecodeConfig(bytes.NewReader(imagem))
+						if err != nil {
+							t.Fatalf("%s inválida. Detalhes: %s", nome, err)
+						}
+
+						if formato != "jpeg" || configuraçãoImagem.Width != resolução || configuraçãoImagem.Height != resolução {
+							t.Errorf("%s inesperada: %s %dx%d", nome, formato,
+								configuraçãoImagem.Width, configuraçãoImagem.Height)
+						}
+					}
+
+					verificarImagem("Imagem de confirmação", frequência.ImagemConfirmação, 8)
+					verificarImagem("Miniatura da imagem de confirmação", frequência.MiniaturaImagemConfirmação, 4)
+
+					if frequência.EXIFImagemConfirmação == "" {
+						t.Errorf("Metadados EXIF da imagem de confirmação não mantidos")
+					}
+
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: nil,
+		},
+		{
+			descrição: "deve sinalizar uma imagem de confirmação capturada longe do clube",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.RaioClube = 1000
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00",
+						&protocolo.Coordenadas{Latitude: -23.5505, Longitude: -46.6333}),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					if frequência.SinalizaçãoImagem != protocolo.MensagemCódigoImagemForaClube {
+						t.Errorf("Sinalização da imagem “%s” inesperada", frequência.SinalizaçãoImagem)
+					}
+
+					if frequência.DistânciaClube == nil || *frequência.DistânciaClube < 350000 {
+						t.Errorf("Distância até o clube não definida corretamente")
+					}
+
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						IDClube:           3,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					if id != 3 {
+						t.Errorf("ID do clube %d inesperado", id)
+					}
+
+					return protocolo.ClubeResposta{
+						ID:          id,
+						Situação:    protocolo.ClubeSituaçãoAtivo,
+						Localização: &protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729},
+					}, nil
+				},
+			},
+			erroEsperado: nil,
+		},
+		{
+			descrição: "deve registrar a distância de uma imagem de confirmação capturada no clube",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.RaioClube = 1000
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00",
+						&protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729}),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					if frequência.SinalizaçãoImagem != "" {
+						t.Errorf("Sinalização da imagem “%s” inesperada", frequência.SinalizaçãoImagem)
+					}
+
+					if frequência.DistânciaClube == nil || *frequência.DistânciaClube > 10 {
+						t.Errorf("Distância até o clube não definida corretamente")
+					}
+
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						IDClube:           3,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					if id != 3 {
+						t.Errorf("ID do clube %d inesperado", id)
+					}
+
+					return protocolo.ClubeResposta{
+						ID:          id,
+						Situação:    protocolo.ClubeSituaçãoAtivo,
+						Localização: &protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729},
+					}, nil
+				},
+			},
+			erroEsperado: nil,
+		},
+		{
+			descrição: "deve detectar um erro ao obter a localização do clube",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.RaioClube = 1000
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: gerarImagemEXIF(t, binary.BigEndian, "Canon EOS 80D", "2017:03:10 14:30:00", "-03:00",
+						&protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729}),
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaImagemSemelhante: func(id int64, hash uint64, distância int) (bool, error) {
+					return false, nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						IDClube:           3,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro ao obter o clube")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao obter o clube"),
+		},
+		{
+			descrição: "deve detectar uma imagem de confirmação que não pode ser interpretada",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+				configuração.Atirador.DistânciaHashImagem = 5
+				return configuração
+			}(),
+			frequênciaConfirmaçãoPedidoCompleta: protocolo.FrequênciaConfirmaçãoPedidoCompleta{
+				CR:                123456789,
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "Gcn49YnkH1qmKkEvkYDtVRoRPNHQXHPUy5g61T3BQ5JX",
+				FrequênciaConfirmaçãoPedido: protocolo.FrequênciaConfirmaçãoPedido{
+					Imagem: "AAAA",
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                7654,
+						Controle:          918273645,
+						CR:                123456789,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			},
+			erroEsperado: errors.Errorf("image: unknown format"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	serviçoClubeOriginal := clube.NovoServiço
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+		clube.NovoServiço = serviçoClubeOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		clube.NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		serviço := NovoServiço(nil, nil, cenário.configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+
+		err := serviço.ConfirmarFrequência(cenário.frequênciaConfirmaçãoPedidoCompleta)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ConfirmarFrequência_valoresAleatórios(t *testing.T) {
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	f := func(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) bool {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return simulaFrequênciaDAO{
+				simulaAtualizar: func(frequência *frequência) error {
+					return nil
+				},
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                frequênciaConfirmaçãoPedidoCompleta.NúmeroControle.ID(),
+						Controle:          frequênciaConfirmaçãoPedidoCompleta.NúmeroControle.Controle(),
+						CR:                frequênciaConfirmaçãoPedidoCompleta.CR,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						GuiaDeTráfego:     762556223,
+						QuantidadeMunição: 50,
+						DataInício:        time.Now().Add(-40 * time.Minute),
+						DataTérmino:       time.Now().Add(-10 * time.Minute),
+						DataCriação:       time.Now().Add(-5 * time.Minute),
+						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
+IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
+dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
+dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
+ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
+					}, nil
+				},
+			}
+		}
+
+		var configuração config.Configuração
+		configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+
+		serviço := NovoServiço(nil, nil, configuração)
+		if err := serviço.ConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta); err != nil {
+			if _, ok := err.(protocolo.Mensagens); !ok {
+				t.Log(err)
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 5}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServiço_CancelarFrequência(t *testing.T) {
+	data := time.Now()
+
+	frequênciaPendente := func() frequência {
+		return frequência{
+			ID:                7654,
+			Controle:          918273645,
+			IDClube:           1,
+			CR:                123456789,
+			Calibre:           ".380",
+			ArmaUtilizada:     "Arma do Clube",
+			QuantidadeMunição: 50,
+			DataInício:        data.Add(-40 * time.Minute),
+			DataTérmino:       data.Add(-10 * time.Minute),
+			DataCriação:       data.Add(-5 * time.Minute),
+			Situação:          protocolo.FrequênciaSituaçãoPendente,
+		}
+	}
+
+	configuração := func() config.Configuração {
+		var configuração config.Configuração
+		configuração.Atirador.PrazoCancelamento = 20 * time.Minute
+		return configuração
+	}()
+
+	pedido := protocolo.FrequênciaCancelamentoPedidoCompleta{
+		CR:             123456789,
+		NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+		Identidade: protocolo.Identidade{
+			Papel:   protocolo.PapelClube,
+			IDClube: 1,
+		},
+		FrequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+			Motivo: "Registro duplicado",
+		},
+	}
+
+	cenários := []struct {
+		descrição                            string
+		configuração                         config.Configuração
+		frequênciaCancelamentoPedidoCompleta protocolo.FrequênciaCancelamentoPedidoCompleta
+		frequênciaDAO                        frequênciaDAO
+		erroEsperado                         error
+	}{
+		{
+			descrição:                            "deve cancelar corretamente uma frequência",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					if id != 7654 {
+						t.Errorf("ID %d inesperado", id)
+					}
+
+					return frequênciaPendente(), nil
+				},
+				simulaCancelar: func(frequência *frequência) error {
+					if frequência.DataCancelamento.Before(data) {
+						t.Errorf("Data de cancelamento não definida corretamente")
+					}
+
+					if frequência.MotivoCancelamento != "Registro duplicado" {
+						t.Errorf("Motivo de cancelamento “%s” inesperado", frequência.MotivoCancelamento)
+					}
+
+					return nil
+				},
+			},
+		},
+		{
+			descrição:    "deve permitir que o administrador cancele a frequência de qualquer clube",
+			configuração: configuração,
+			frequênciaCancelamentoPedidoCompleta: func() protocolo.FrequênciaCancelamentoPedidoCompleta {
+				p := pedido
+				p.Identidade = protocolo.Identidade{Papel: protocolo.PapelAdministrador}
+				return p
+			}(),
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaPendente(), nil
+				},
+				simulaCancelar: func(frequência *frequência) error {
+					return nil
+				},
+			},
+		},
+		{
+			descrição:                            "deve detectar um erro ao resgatar a frequência",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição:    "deve detectar quando o CR e o número de controle não conferem",
+			configuração: configuração,
+			frequênciaCancelamentoPedidoCompleta: func() protocolo.FrequênciaCancelamentoPedidoCompleta {
+				p := pedido
+				p.CR = 123456781
+				p.NúmeroControle = protocolo.NovoNúmeroControle(7654, 918273640)
+				return p
+			}(),
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaPendente(), nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRInválido, "123456781"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNúmeroControleInválido, "7654-918273640"),
+			),
+		},
+		{
+			descrição:    "deve impedir que outro clube cancele a frequência",
+			configuração: configuração,
+			frequênciaCancelamentoPedidoCompleta: func() protocolo.FrequênciaCancelamentoPedidoCompleta {
+				p := pedido
+				p.Identidade.IDClube = 2
+				return p
+			}(),
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaPendente(), nil
+				},
+			},
+			erroEsperado: erros.AcessoNegado,
+		},
+		{
+			descrição:                            "deve detectar quando a frequência já foi confirmada",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					f := frequênciaPendente()
+					f.DataConfirmação = data.Add(-2 * time.Minute)
+					f.ImagemConfirmação = "iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAMAAAC67D+PAAAAP1BMVEX"
+					f.Situação = protocolo.FrequênciaSituaçãoConfirmada
+					return f, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
+			),
+		},
+		{
+			descrição:                            "deve detectar quando a frequência já foi cancelada",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					f := frequênciaPendente()
+					f.DataCancelamento = data.Add(-2 * time.Minute)
+					f.MotivoCancelamento = "Registro duplicado"
+					f.Situação = protocolo.FrequênciaSituaçãoCancelada
+					return f, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaCancelada),
+			),
+		},
+		{
+			descrição:                            "deve detectar quando o prazo de cancelamento expirar",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					f := frequênciaPendente()
+					f.DataCriação = data.Add(-21 * time.Minute)
+					return f, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoCancelamentoExpirado),
+			),
+		},
+		{
+			descrição:                            "deve detectar um erro ao persistir o cancelamento",
+			configuração:                         configuração,
+			frequênciaCancelamentoPedidoCompleta: pedido,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequênciaPendente(), nil
+				},
+				simulaCancelar: func(*frequência) error {
+					return errors.Errorf("erro ao cancelar")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao cancelar"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, cenário.configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+
+		err := serviço.CancelarFrequência(cenário.frequênciaCancelamentoPedidoCompleta)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ExpirarFrequências(t *testing.T) {
+	var configuração config.Configuração
+	configuração.Atirador.PrazoConfirmação = 10 * time.Minute
+
+	configuraçãoRemoverImagem := configuração
+	configuraçãoRemoverImagem.Atirador.RemoverImagemExpirada = true
+
+	cenários := []struct {
+		descrição     string
+		configuração  config.Configuração
+		limite        int
+		frequênciaDAO frequênciaDAO
+		logger        log.Serviço
+		esperado      int
+		erroEsperado  error
+	}{
+		{
+			descrição:    "deve expirar as frequências pendentes fora do prazo",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					if time.Now().UTC().Add(-10*time.Minute).Sub(dataLimite) > time.Minute {
+						t.Errorf("data limite inesperada: %s", dataLimite)
+					}
+
+					if limite != 10 {
+						t.Errorf("limite inesperado: %d", limite)
+					}
+
+					return []frequência{
+						{ID: 1, Situação: protocolo.FrequênciaSituaçãoPendente, ImagemNúmeroControle: "AAAA"},
+						{ID: 2, Situação: protocolo.FrequênciaSituaçãoPendente, ImagemNúmeroControle: "BBBB"},
+					}, nil
+				},
+				simulaExpirar: func(frequência *frequência) error {
+					if frequência.Situação != protocolo.FrequênciaSituaçãoExpirada {
+						t.Errorf("situação inesperada: %s", frequência.Situação)
+					}
+
+					if frequência.ImagemNúmeroControle == "" {
+						t.Errorf("imagem do número de controle removida indevidamente")
+					}
+
+					return nil
+				},
+			},
+			esperado: 2,
+		},
+		{
+			descrição:    "deve remover a imagem do número de controle das frequências expiradas",
+			configuração: configuraçãoRemoverImagem,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, Situação: protocolo.FrequênciaSituaçãoPendente, ImagemNúmeroControle: "AAAA"},
+					}, nil
+				},
+				simulaExpirar: func(frequência *frequência) error {
+					if frequência.ImagemNúmeroControle != "" {
+						t.Errorf("imagem do número de controle não removida")
+					}
+
+					return nil
+				},
+			},
+			esperado: 1,
+		},
+		{
+			descrição:    "deve ignorar quando não existem frequências fora do prazo",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return nil, nil
+				},
+			},
+			esperado: 0,
+		},
+		{
+			descrição:    "deve ignorar uma frequência que não pode ser expirada",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, Situação: protocolo.FrequênciaSituaçãoConfirmada},
+						{ID: 2, Situação: protocolo.FrequênciaSituaçãoPendente},
+					}, nil
+				},
+				simulaExpirar: func(frequência *frequência) error {
+					if frequência.ID != 2 {
+						t.Errorf("frequência %d expirada indevidamente", frequência.ID)
+					}
+
+					return nil
+				},
+			},
+			logger: simulador.Logger{
+				SimulaInfof: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if !strings.HasPrefix(mensagem, "Frequência 1 ignorada na expiração. Detalhes: ") {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			esperado: 1,
+		},
+		{
+			descrição:    "deve ignorar uma frequência alterada por outra transação",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, Situação: protocolo.FrequênciaSituaçãoPendente},
+						{ID: 2, Situação: protocolo.FrequênciaSituaçãoPendente},
+					}, nil
+				},
+				simulaExpirar: func(frequência *frequência) error {
+					if frequência.ID == 1 {
+						return erros.NãoAtualizado
+					}
+
+					return nil
+				},
+			},
+			logger: simulador.Logger{
+				SimulaInfof: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if !strings.HasPrefix(mensagem, "Frequência 1 ignorada na expiração. Detalhes: ") {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			esperado: 1,
+		},
+		{
+			descrição:    "deve detectar um erro ao buscar as frequências fora do prazo",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return nil, errors.Errorf("erro de consulta")
+				},
+			},
+			esperado:     0,
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição:    "deve detectar um erro ao expirar uma frequência",
+			configuração: configuração,
+			limite:       10,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesExpiradas: func(dataLimite time.Time, limite int) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, Situação: protocolo.FrequênciaSituaçãoPendente},
+						{ID: 2, Situação: protocolo.FrequênciaSituaçãoPendente},
+					}, nil
+				},
+				simulaExpirar: func(frequência *frequência) error {
+					if frequência.ID == 2 {
+						return errors.Errorf("erro de atualização")
+					}
+
+					return nil
+				},
+			},
+			esperado:     1,
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, cenário.logger, cenário.configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ExpirarFrequências(cenário.limite)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ListarFrequências(t *testing.T) {
+	data := time.Now()
+
+	frequências := []frequência{
+		{
+			ID:                3,
+			Controle:          918273645,
+			IDClube:           1,
+			CR:                380308,
+			Calibre:           ".380",
+			ArmaUtilizada:     "ARMA DO CLUBE",
+			QuantidadeMunição: 50,
+			DataInício:        data.Add(-1 * time.Hour),
+			DataTérmino:       data.Add(-30 * time.Minute),
+			DataCriação:       data.Add(-20 * time.Minute),
+			DataConfirmação:   data.Add(-10 * time.Minute),
+			Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+		},
+		{
+			ID:                2,
+			Controle:          7654,
+			IDClube:           1,
+			CR:                380308,
+			Calibre:           ".40",
+			ArmaUtilizada:     "IMBEL MD2",
+			NúmeroSérie:       "DL28461184",
+			GuiaDeTráfego:     102483466,
+			QuantidadeMunição: 100,
+			DataInício:        data.Add(-3 * time.Hour),
+			DataTérmino:       data.Add(-2 * time.Hour),
+			DataCriação:       data.Add(-2 * time.Hour),
+			Situação:          protocolo.FrequênciaSituaçãoPendente,
+		},
+	}
+
+	cenários := []struct {
+		descrição        string
+		frequênciaFiltro protocolo.FrequênciaFiltro
+		frequênciaDAO    frequênciaDAO
+		esperado         protocolo.FrequênciaListaResposta
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve listar corretamente a última página",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				CR:       380308,
+				Calibre:  " .380 ",
+				Situação: protocolo.FrequênciaSituaçãoConfirmada,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaListar: func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+					if filtro.Calibre != ".380" || filtro.Ordenação != protocolo.FrequênciaOrdenaçãoDataInícioDecrescente {
+						t.Errorf("filtro não normalizado: %#v", filtro)
+					}
+
+					if c != nil {
+						t.Errorf("cursor inesperado: %#v", c)
+					}
+
+					if limite != protocolo.FrequênciaListaLimitePadrão+1 {
+						t.Errorf("limite inesperado: %d", limite)
+					}
+
+					return frequências[:1], nil
+				},
+			},
+			esperado: protocolo.FrequênciaListaResposta{
+				Frequências: []protocolo.FrequênciaListaItem{
+					{
+						NúmeroControle:    protocolo.NovoNúmeroControle(3, 918273645),
+						CR:                380308,
+						Clube:             1,
+						Calibre:           ".380",
+						ArmaUtilizada:     "ARMA DO CLUBE",
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-1 * time.Hour),
+						DataTérmino:       data.Add(-30 * time.Minute),
+						DataCriação:       data.Add(-20 * time.Minute),
+						DataConfirmação:   data.Add(-10 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+					},
+				},
+			},
+		},
+		{
+			descrição: "deve gerar o cursor quando existirem mais páginas",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Ordenação: protocolo.FrequênciaOrdenaçãoCR,
+				Cursor:    cursor{Ordenação: protocolo.FrequênciaOrdenaçãoCR, ID: 4, CR: 380307}.String(),
+				Limite:    1,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaListar: func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+					if c == nil || c.ID != 4 || c.CR != 380307 {
+						t.Errorf("cursor inesperado: %#v", c)
+					}
+
+					if limite != 2 {
+						t.Errorf("limite inesperado: %d", limite)
+					}
+
+					return frequências, nil
+				},
+			},
+			esperado: protocolo.FrequênciaListaResposta{
+				Frequências: []protocolo.FrequênciaListaItem{
+					{
+						NúmeroControle:    protocolo.NovoNúmeroControle(3, 918273645),
+						CR:                380308,
+						Clube:             1,
+						Calibre:           ".380",
+						ArmaUtilizada:     "ARMA DO CLUBE",
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-1 * time.Hour),
+						DataTérmino:       data.Add(-30 * time.Minute),
+						DataCriação:       data.Add(-20 * time.Minute),
+						DataConfirmação:   data.Add(-10 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+					},
+				},
+				PróximoCursor: cursor{Ordenação: protocolo.FrequênciaOrdenaçãoCR, ID: 3, CR: 380308}.String(),
+			},
+		},
+		{
+			descrição: "deve retornar uma lista vazia quando nenhuma frequência for encontrada",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaListar: func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+					return nil, nil
+				},
+			},
+			esperado: protocolo.FrequênciaListaResposta{
+				Frequências: []protocolo.FrequênciaListaItem{},
+			},
+		},
+		{
+			descrição: "deve detectar um filtro inválido",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Ordenação: "calibre",
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoOrdenaçãoInválida, "calibre"),
+			},
+		},
+		{
+			descrição: "deve detectar um cursor gerado para outra ordenação",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Ordenação: protocolo.FrequênciaOrdenaçãoDataCriação,
+				Cursor:    "eyJvIjoiY3IiLCJpIjo0LCJjIjozODAzMDd9",
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCursorInválido, "eyJvIjoiY3IiLCJpIjo0LCJjIjozODAzMDd9"),
+			},
+		},
+		{
+			descrição: "deve detectar um cursor mal formatado",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Cursor: "@@@",
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCursorInválido, "@@@"),
+			},
+		},
+		{
+			descrição: "deve detectar um cursor com conteúdo que não é JSON",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Cursor: "bmFvIGUganNvbg",
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCursorInválido, "bmFvIGUganNvbg"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao listar as frequências",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaListar: func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+					return nil, errors.Errorf("erro de listagem")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de listagem"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ListarFrequências(cenário.frequênciaFiltro)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ListarFrequênciasAtirador(t *testing.T) {
+	data := time.Now()
+
+	atiradorDAOExistente := simulaAtiradorDAO{
+		simulaResgatarPorCR: func(cr int) (atirador, error) {
+			return atirador{ID: 1, CR: cr}, nil
+		},
+	}
+
+	cenários := []struct {
+		descrição        string
+		cr               int
+		frequênciaFiltro protocolo.FrequênciaFiltro
+		atiradorDAO      atiradorDAO
+		frequênciaDAO    frequênciaDAO
+		esperado         protocolo.FrequênciaListaResposta
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve listar somente as frequências do CR informado",
+			cr:        380308,
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				CR:           380309,
+				DataInícioDe: data.Add(-24 * time.Hour),
+			},
+			atiradorDAO: atiradorDAOExistente,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaListar: func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+					if filtro.CR != 380308 || !filtro.DataInícioDe.Equal(data.Add(-24*time.Hour)) {
+						t.Errorf("filtro inesperado: %#v", filtro)
+					}
+
+					return []frequência{
+						{
+							ID:                3,
+							Controle:          918273645,
+							IDClube:           1,
+							CR:                380308,
+							Calibre:           ".380",
+							ArmaUtilizada:     "ARMA DO CLUBE",
+							QuantidadeMunição: 50,
+							DataInício:        data.Add(-1 * time.Hour),
+							DataTérmino:       data.Add(-30 * time.Minute),
+							DataCriação:       data.Add(-20 * time.Minute),
+							Situação:          protocolo.FrequênciaSituaçãoPendente,
+						},
+					}, nil
+				},
+			},
+			esperado: protocolo.FrequênciaListaResposta{
+				Frequências: []protocolo.FrequênciaListaItem{
+					{
+						NúmeroControle:    protocolo.NovoNúmeroControle(3, 918273645),
+						CR:                380308,
+						Clube:             1,
+						Calibre:           ".380",
+						ArmaUtilizada:     "ARMA DO CLUBE",
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-1 * time.Hour),
+						DataTérmino:       data.Add(-30 * time.Minute),
+						DataCriação:       data.Add(-20 * time.Minute),
+						Situação:          protocolo.FrequênciaSituaçãoPendente,
+					},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar um filtro inválido",
+			cr:        380308,
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				Limite: protocolo.FrequênciaListaLimiteMáximo + 1,
+			},
+			atiradorDAO: atiradorDAOExistente,
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "limite", "101"),
+			},
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	atiradorDAOOriginal := novoAtiradorDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = atiradorDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ListarFrequênciasAtirador(cenário.cr, cenário.frequênciaFiltro)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_RelatórioHabitualidade(t *testing.T) {
+	data := time.Now()
+
+	var configuração config.Configuração
+	configuração.Atirador.Habitualidade = map[int]int{1: 8, 2: 12}
+
+	cenários := []struct {
+		descrição           string
+		habitualidadeFiltro protocolo.HabitualidadeFiltro
+		frequênciaDAO       frequênciaDAO
+		esperado            protocolo.HabitualidadeResposta
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve identificar os atiradores com habitualidade insuficiente no ano",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataTérmino: data,
+				Nível:       2,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					if !início.Equal(data.AddDate(-1, 0, 0)) || !término.Equal(data) {
+						t.Errorf("período inesperado: %s - %s", início, término)
+					}
+
+					if treinosExigidos != 12 {
+						t.Errorf("quantidade de treinos exigidos inesperada: %d", treinosExigidos)
+					}
+
+					return []habitualidade{
+						{CR: 380308, Treinos: 11},
+						{CR: 380309, Treinos: 0},
+					}, nil
+				},
+			},
+			esperado: protocolo.HabitualidadeResposta{
+				DataInício:      data.AddDate(-1, 0, 0),
+				DataTérmino:     data,
+				Nível:           2,
+				TreinosExigidos: 12,
+				Atiradores: []protocolo.HabitualidadeAtirador{
+					{CR: 380308, Treinos: 11},
+					{CR: 380309, Treinos: 0},
+				},
+			},
+		},
+		{
+			descrição: "deve calcular os treinos exigidos proporcionalmente ao período",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  data.Add(-365 * 24 * time.Hour / 2),
+				DataTérmino: data,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					if treinosExigidos != 4 {
+						t.Errorf("quantidade de treinos exigidos inesperada: %d", treinosExigidos)
+					}
+
+					return nil, nil
+				},
+			},
+			esperado: protocolo.HabitualidadeResposta{
+				DataInício:      data.Add(-365 * 24 * time.Hour / 2),
+				DataTérmino:     data,
+				Nível:           protocolo.HabitualidadeNívelPadrão,
+				TreinosExigidos: 4,
+				Atiradores:      []protocolo.HabitualidadeAtirador{},
+			},
+		},
+		{
+			descrição: "deve exigir todos os treinos em um ano civil completo",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino: time.Date(2017, 12, 31, 23, 59, 59, 0, time.UTC),
+				Nível:       2,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					if treinosExigidos != 12 {
+						t.Errorf("quantidade de treinos exigidos inesperada: %d", treinosExigidos)
+					}
+
+					return nil, nil
+				},
+			},
+			esperado: protocolo.HabitualidadeResposta{
+				DataInício:      time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino:     time.Date(2017, 12, 31, 23, 59, 59, 0, time.UTC),
+				Nível:           2,
+				TreinosExigidos: 12,
+				Atiradores:      []protocolo.HabitualidadeAtirador{},
+			},
+		},
+		{
+			descrição: "deve exigir todos os treinos em um ano bissexto completo",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino: time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC),
+				Nível:       2,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					if treinosExigidos != 12 {
+						t.Errorf("quantidade de treinos exigidos inesperada: %d", treinosExigidos)
+					}
+
+					return nil, nil
+				},
+			},
+			esperado: protocolo.HabitualidadeResposta{
+				DataInício:      time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino:     time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC),
+				Nível:           2,
+				TreinosExigidos: 12,
+				Atiradores:      []protocolo.HabitualidadeAtirador{},
+			},
+		},
+		{
+			descrição: "deve somar os anos completos e arredondar a fração restante para cima",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino: time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+				Nível:       2,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					if treinosExigidos != 16 {
+						t.Errorf("quantidade de treinos exigidos inesperada: %d", treinosExigidos)
+					}
+
+					return nil, nil
+				},
+			},
+			esperado: protocolo.HabitualidadeResposta{
+				DataInício:      time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC),
+				DataTérmino:     time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC),
+				Nível:           2,
+				TreinosExigidos: 16,
+				Atiradores:      []protocolo.HabitualidadeAtirador{},
+			},
+		},
+		{
+			descrição: "deve detectar um filtro inválido",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  data,
+				DataTérmino: data.Add(-1 * time.Hour),
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
+			},
+		},
+		{
+			descrição: "deve detectar um nível sem quantidade mínima de treinos configurada",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				Nível: 3,
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNívelInválido, "3"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao calcular a habitualidade",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaHabitualidadeInsuficiente: func(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+					return nil, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.RelatórioHabitualidade(cenário.habitualidadeFiltro)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ConsumoMunição(t *testing.T) {
+	atiradorDAOExistente := simulaAtiradorDAO{
+		simulaResgatarPorCR: func(cr int) (atirador, error) {
+			return atirador{ID: 1, CR: cr}, nil
+		},
+	}
+
+	cotaPermitido := 1000
+
+	cenários := []struct {
+		descrição     string
+		cr            int
+		ano           int
+		configuração  config.Configuração
+		atiradorDAO   atiradorDAO
+		frequênciaDAO frequênciaDAO
+		esperado      protocolo.MuniçãoConsumoResposta
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve calcular corretamente o consumo de munição do atirador",
+			cr:        380308,
+			ano:       2016,
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.CotaMunição = map[string]int{"permitido": 1000}
+				return configuração
+			}(),
+			atiradorDAO: atiradorDAOExistente,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaConsumoMunição: func(cr int, início, término time.Time) ([]consumoMunição, error) {
+					if !início.Equal(time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)) ||
+						!término.Equal(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+						return nil, errors.Errorf("período inesperado")
+					}
+
+					return []consumoMunição{
+						{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 150},
+						{Calibre: "7,62X51MM", Classe: protocolo.CalibreClasseRestrito, Quantidade: 40},
+					}, nil
+				},
+			},
+			esperado: protocolo.MuniçãoConsumoResposta{
+				CR:  380308,
+				Ano: 2016,
+				Calibres: []protocolo.MuniçãoConsumoCalibre{
+					{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 150, Cota: &cotaPermitido},
+					{Calibre: "7,62X51MM", Classe: protocolo.CalibreClasseRestrito, Quantidade: 40},
+				},
+			},
+		},
+		{
+			descrição:   "deve utilizar o ano atual quando o ano não é informado",
+			cr:          380308,
+			atiradorDAO: atiradorDAOExistente,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaConsumoMunição: func(cr int, início, término time.Time) ([]consumoMunição, error) {
+					return nil, nil
+				},
+			},
+			esperado: protocolo.MuniçãoConsumoResposta{
+				CR:       380308,
+				Ano:      time.Now().UTC().Year(),
+				Calibres: []protocolo.MuniçãoConsumoCalibre{},
+			},
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			cr:        380308,
+			ano:       2016,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição:   "deve detectar um erro ao calcular o consumo de munição",
+			cr:          380308,
+			ano:         2016,
+			atiradorDAO: atiradorDAOExistente,
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaConsumoMunição: func(cr int, início, término time.Time) ([]consumoMunição, error) {
+					return nil, errors.Errorf("erro ao calcular o consumo")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao calcular o consumo"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	atiradorDAOOriginal := novoAtiradorDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = atiradorDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		serviço := NovoServiço(nil, nil, cenário.configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ConsumoMunição(cenário.cr, cenário.ano)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_PendênciasChavesAposentadas(t *testing.T) {
+	cenários := []struct {
+		descrição     string
+		configuração  config.Configuração
+		frequênciaDAO frequênciaDAO
+		esperado      []protocolo.FrequênciaPendênciaChave
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve ignorar as frequências pendentes da chave ativa",
+			configuração: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				return configuração
+			}(),
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesPorChave: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return []protocolo.FrequênciaPendênciaChave{
+						{Chave: "", Pendentes: 3},
+						{Chave: "2017a", Pendentes: 5},
+						{Chave: "2017b", Pendentes: 40},
+					}, nil
+				},
+			},
+			esperado: []protocolo.FrequênciaPendênciaChave{
+				{Chave: "", Pendentes: 3},
+				{Chave: "2017a", Pendentes: 5},
+			},
+		},
+		{
+			descrição: "deve ignorar as frequências pendentes da chave legada quando não há chave ativa",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesPorChave: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return []protocolo.FrequênciaPendênciaChave{
+						{Chave: "", Pendentes: 3},
+					}, nil
+				},
+			},
+			esperado: []protocolo.FrequênciaPendênciaChave{},
+		},
+		{
+			descrição: "deve detectar um erro ao contar as frequências pendentes",
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaPendentesPorChave: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return nil, errors.Errorf("erro ao contar as pendências")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao contar as pendências"),
+		},
+	}
+
+	daoOriginal := novaFrequênciaDAO
+	defer func() {
+		novaFrequênciaDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, cenário.configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.PendênciasChavesAposentadas()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_SortearAmostraAuditoria(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição                string
+		amostraAuditoriaPedido   protocolo.AmostraAuditoriaPedido
+		frequênciaDAO            frequênciaDAO
+		amostraAuditoriaDAO      amostraAuditoriaDAO
+		auditoriaDAO             auditoriaDAO
+		amostraAuditoriaEsperada protocolo.AmostraAuditoriaResposta
+		erroEsperado             error
+	}{
+		{
+			descrição: "deve sortear corretamente uma amostra de auditoria",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 1,
+				Semente:            42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, Controle: 98765, CR: 380308, IDClube: 1, Situação: protocolo.FrequênciaSituaçãoConfirmada},
+						{ID: 2, Controle: 98766, CR: 380309, IDClube: 2, Situação: protocolo.FrequênciaSituaçãoConfirmada},
+					}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					if frequência.Situação != protocolo.FrequênciaSituaçãoEmAuditoria {
+						t.Errorf("situação inesperada: %s", frequência.Situação)
+					}
+
+					return nil
+				},
+			},
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaCriar: func(amostraAuditoria *amostraAuditoria) error {
+					amostraAuditoria.ID = 3
+					amostraAuditoria.DataCriação = data
+					return nil
+				},
+			},
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaCriar: func(auditoria *auditoria) error {
+					if auditoria.IDAmostra != 3 {
+						t.Errorf("amostra inesperada: %d", auditoria.IDAmostra)
+					}
+
+					auditoria.ID = auditoria.IDFrequência + 10
+					return nil
+				},
+			},
+			amostraAuditoriaEsperada: protocolo.AmostraAuditoriaResposta{
+				ID:                 3,
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 1,
+				Semente:            42,
+				DataCriação:        data,
+				Auditorias: []protocolo.AuditoriaListaItem{
+					{ID: 11, NúmeroControle: protocolo.NovoNúmeroControle(1, 98765), CR: 380308, Clube: 1},
+					{ID: 12, NúmeroControle: protocolo.NovoNúmeroControle(2, 98766), CR: 380309, Clube: 2},
+				},
+			},
+		},
+		{
+			descrição: "deve criar uma amostra vazia quando não existem candidatas",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return nil, nil
+				},
+			},
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaCriar: func(amostraAuditoria *amostraAuditoria) error {
+					amostraAuditoria.ID = 3
+					amostraAuditoria.DataCriação = data
+					return nil
+				},
+			},
+			amostraAuditoriaEsperada: protocolo.AmostraAuditoriaResposta{
+				ID:          3,
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+				DataCriação: data,
+				Auditorias:  []protocolo.AuditoriaListaItem{},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao buscar as frequências candidatas",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				Percentual: 10,
+				Semente:    42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return nil, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar a amostra",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				Percentual: 10,
+				Semente:    42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return nil, nil
+				},
+			},
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaCriar: func(amostraAuditoria *amostraAuditoria) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a frequência sorteada",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				Percentual: 100,
+				Semente:    42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, IDClube: 1, Situação: protocolo.FrequênciaSituaçãoConfirmada},
+					}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaCriar: func(amostraAuditoria *amostraAuditoria) error {
+					return nil
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar a auditoria",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				Percentual: 100,
+				Semente:    42,
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaCandidatasAuditoria: func(início, término time.Time) ([]frequência, error) {
+					return []frequência{
+						{ID: 1, IDClube: 1, Situação: protocolo.FrequênciaSituaçãoConfirmada},
+					}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					return nil
+				},
+			},
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaCriar: func(amostraAuditoria *amostraAuditoria) error {
+					return nil
+				},
+			},
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaCriar: func(auditoria *auditoria) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+	}
+
+	frequênciaDAOOriginal := novaFrequênciaDAO
+	amostraAuditoriaDAOOriginal := novaAmostraAuditoriaDAO
+	auditoriaDAOOriginal := novaAuditoriaDAO
+	defer func() {
+		novaFrequênciaDAO = frequênciaDAOOriginal
+		novaAmostraAuditoriaDAO = amostraAuditoriaDAOOriginal
+		novaAuditoriaDAO = auditoriaDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		novaAmostraAuditoriaDAO = func(sqlogger *bd.SQLogger) amostraAuditoriaDAO {
+			return cenário.amostraAuditoriaDAO
+		}
+
+		novaAuditoriaDAO = func(sqlogger *bd.SQLogger) auditoriaDAO {
+			return cenário.auditoriaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		amostraAuditoriaResposta, err := serviço.SortearAmostraAuditoria(cenário.amostraAuditoriaPedido)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.amostraAuditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(amostraAuditoriaResposta, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterAmostraAuditoria(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição                string
+		id                       int64
+		amostraAuditoriaDAO      amostraAuditoriaDAO
+		auditoriaDAO             auditoriaDAO
+		amostraAuditoriaEsperada protocolo.AmostraAuditoriaResposta
+		erroEsperado             error
+	}{
+		{
+			descrição: "deve obter corretamente uma amostra de auditoria",
+			id:        3,
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaResgatar: func(id int64) (amostraAuditoria, error) {
+					return amostraAuditoria{
+						ID:          id,
+						DataInício:  data.AddDate(0, -1, 0),
+						DataTérmino: data,
+						Percentual:  10,
+						Semente:     42,
+						DataCriação: data,
+					}, nil
+				},
+			},
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaListarPorAmostra: func(idAmostra int64) ([]auditoria, error) {
+					return []auditoria{
+						{
+							ID:           11,
+							IDAmostra:    idAmostra,
+							IDFrequência: 1,
+							Veredito:     protocolo.AuditoriaVereditoSuspeita,
+							frequência:   frequência{ID: 1, Controle: 98765, CR: 380308, IDClube: 1},
+						},
+					}, nil
+				},
+			},
+			amostraAuditoriaEsperada: protocolo.AmostraAuditoriaResposta{
+				ID:          3,
+				DataInício:  data.AddDate(0, -1, 0),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+				DataCriação: data,
+				Auditorias: []protocolo.AuditoriaListaItem{
+					{
+						ID:             11,
+						NúmeroControle: protocolo.NovoNúmeroControle(1, 98765),
+						CR:             380308,
+						Clube:          1,
+						Veredito:       protocolo.AuditoriaVereditoSuspeita,
+					},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a amostra",
+			id:        3,
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaResgatar: func(id int64) (amostraAuditoria, error) {
+					return amostraAuditoria{}, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição: "deve detectar um erro ao listar as auditorias da amostra",
+			id:        3,
+			amostraAuditoriaDAO: simulaAmostraAuditoriaDAO{
+				simulaResgatar: func(id int64) (amostraAuditoria, error) {
+					return amostraAuditoria{ID: id}, nil
+				},
+			},
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaListarPorAmostra: func(idAmostra int64) ([]auditoria, error) {
+					return nil, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+	}
+
+	amostraAuditoriaDAOOriginal := novaAmostraAuditoriaDAO
+	auditoriaDAOOriginal := novaAuditoriaDAO
+	defer func() {
+		novaAmostraAuditoriaDAO = amostraAuditoriaDAOOriginal
+		novaAuditoriaDAO = auditoriaDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaAmostraAuditoriaDAO = func(sqlogger *bd.SQLogger) amostraAuditoriaDAO {
+			return cenário.amostraAuditoriaDAO
+		}
+
+		novaAuditoriaDAO = func(sqlogger *bd.SQLogger) auditoriaDAO {
+			return cenário.auditoriaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		amostraAuditoriaResposta, err := serviço.ObterAmostraAuditoria(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.amostraAuditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(amostraAuditoriaResposta, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterAuditoria(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição         string
+		id                int64
+		frequênciaDAO     frequênciaDAO
+		auditoriaDAO      auditoriaDAO
+		auditoriaEsperada protocolo.AuditoriaResposta
+		erroEsperado      error
+	}{
+		{
+			descrição: "deve obter corretamente uma auditoria com as imagens da frequência",
+			id:        11,
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{
+						ID:           id,
+						IDAmostra:    3,
+						IDFrequência: 1,
+					}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{
+						ID:                   id,
+						Controle:             98765,
+						IDClube:              1,
+						CR:                   380308,
+						Calibre:              ".380",
+						ArmaUtilizada:        "Arma do Clube",
+						QuantidadeMunição:    50,
+						DataInício:           data.Add(-1 * time.Hour),
+						DataTérmino:          data.Add(-30 * time.Minute),
+						DataConfirmação:      data.Add(-20 * time.Minute),
+						ImagemNúmeroControle: "AAAA",
+						ImagemConfirmação:    "BBBB",
+						Situação:             protocolo.FrequênciaSituaçãoEmAuditoria,
+					}, nil
+				},
+			},
+			auditoriaEsperada: protocolo.AuditoriaResposta{
+				ID:                   11,
+				Amostra:              3,
+				NúmeroControle:       protocolo.NovoNúmeroControle(1, 98765),
+				CR:                   380308,
+				Clube:                1,
+				Calibre:              ".380",
+				ArmaUtilizada:        "Arma do Clube",
+				QuantidadeMunição:    50,
+				DataInício:           data.Add(-1 * time.Hour),
+				DataTérmino:          data.Add(-30 * time.Minute),
+				DataConfirmação:      data.Add(-20 * time.Minute),
+				Situação:             protocolo.FrequênciaSituaçãoEmAuditoria,
+				ImagemNúmeroControle: "AAAA",
+				ImagemConfirmação:    "BBBB",
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a auditoria",
+			id:        11,
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{}, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a frequência auditada",
+			id:        11,
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDFrequência: 1}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{}, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+	}
+
+	frequênciaDAOOriginal := novaFrequênciaDAO
+	auditoriaDAOOriginal := novaAuditoriaDAO
+	defer func() {
+		novaFrequênciaDAO = frequênciaDAOOriginal
+		novaAuditoriaDAO = auditoriaDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		novaAuditoriaDAO = func(sqlogger *bd.SQLogger) auditoriaDAO {
+			return cenário.auditoriaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		auditoriaResposta, err := serviço.ObterAuditoria(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.auditoriaEsperada, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(auditoriaResposta, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_RegistrarVeredito(t *testing.T) {
+	identidade := protocolo.Identidade{
+		IDUsuário: 7,
+		Usuário:   "auditor",
+		Papel:     protocolo.PapelAuditor,
+	}
+
+	cenários := []struct {
+		descrição                       string
+		auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta
+		frequênciaDAO                   frequênciaDAO
+		auditoriaDAO                    auditoriaDAO
+		erroEsperado                    error
+	}{
+		{
+			descrição: "deve invalidar a frequência quando o veredito for fraudulenta",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito:    protocolo.AuditoriaVereditoFraudulenta,
+				Observações: "Foto de outra pessoa",
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDAmostra: 3, IDFrequência: 1}, nil
+				},
+				simulaAtualizar: func(auditoria *auditoria) error {
+					if auditoria.Veredito != protocolo.AuditoriaVereditoFraudulenta {
+						t.Errorf("veredito inesperado: %s", auditoria.Veredito)
+					}
+
+					if auditoria.IDUsuário != 7 {
+						t.Errorf("usuário inesperado: %d", auditoria.IDUsuário)
+					}
+
+					if auditoria.DataVeredito.IsZero() {
+						t.Errorf("data do veredito não definida")
+					}
+
+					return nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{ID: id, Situação: protocolo.FrequênciaSituaçãoEmAuditoria}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					if frequência.Situação != protocolo.FrequênciaSituaçãoInvalidada {
+						t.Errorf("situação inesperada: %s", frequência.Situação)
+					}
+
+					return nil
+				},
+			},
+		},
+		{
+			descrição: "deve manter a frequência em auditoria quando o veredito for suspeita",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito:    protocolo.AuditoriaVereditoSuspeita,
+				Observações: "Rosto parcialmente coberto",
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDAmostra: 3, IDFrequência: 1}, nil
+				},
+				simulaAtualizar: func(auditoria *auditoria) error {
+					return nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{ID: id, Situação: protocolo.FrequênciaSituaçãoEmAuditoria}, nil
+				},
+			},
+		},
+		{
+			descrição: "deve detectar uma auditoria já concluída",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDFrequência: 1, Veredito: protocolo.AuditoriaVereditoAprovada}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{ID: id, Situação: protocolo.FrequênciaSituaçãoConfirmada}, nil
+				},
+			},
+			erroEsperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAuditoriaConcluída),
+			),
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a auditoria",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{}, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar a frequência auditada",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDFrequência: 1}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{}, errors.Errorf("erro de consulta")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de consulta"),
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a frequência auditada",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDFrequência: 1}, nil
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{ID: id, Situação: protocolo.FrequênciaSituaçãoEmAuditoria}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar a auditoria",
+			auditoriaVereditoPedidoCompleta: protocolo.NovaAuditoriaVereditoPedidoCompleta(11, identidade, protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			}),
+			auditoriaDAO: simulaAuditoriaDAO{
+				simulaResgatar: func(id int64) (auditoria, error) {
+					return auditoria{ID: id, IDFrequência: 1}, nil
+				},
+				simulaAtualizar: func(auditoria *auditoria) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			frequênciaDAO: simulaFrequênciaDAO{
+				simulaResgatar: func(id int64) (frequência, error) {
+					return frequência{ID: id, Situação: protocolo.FrequênciaSituaçãoEmAuditoria}, nil
+				},
+				simulaAtualizar: func(frequência *frequência) error {
+					return nil
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+	}
+
+	frequênciaDAOOriginal := novaFrequênciaDAO
+	auditoriaDAOOriginal := novaAuditoriaDAO
+	defer func() {
+		novaFrequênciaDAO = frequênciaDAOOriginal
+		novaAuditoriaDAO = auditoriaDAOOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
+			return cenário.frequênciaDAO
+		}
+
+		novaAuditoriaDAO = func(sqlogger *bd.SQLogger) auditoriaDAO {
+			return cenário.auditoriaDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		err := serviço.RegistrarVeredito(cenário.auditoriaVereditoPedidoCompleta)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_CadastrarAtirador(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição      string
+		atiradorPedido protocolo.AtiradorPedido
+		atiradorDAO    atiradorDAO
+		esperado       protocolo.AtiradorResposta
+		erroEsperado   error
+	}{
+		{
+			descrição: "deve cadastrar corretamente um atirador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *atirador) error {
+					a.ID = 1
+					a.DataCriação = data
+					return nil
+				},
+			},
+			esperado: protocolo.AtiradorResposta{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o CR já está cadastrado",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{ID: 2, CR: cr}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380308"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao verificar o CR",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar o atirador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *atirador) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+	}
+
+	daoOriginal := novoAtiradorDAO
+	defer func() {
+		novoAtiradorDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.CadastrarAtirador(cenário.atiradorPedido)); err != nil {
+			t.Error(err)
+		}
 	}
+}
 
-	daoOriginal := novaFrequênciaDAO
+func TestServiço_ObterAtirador(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		cr           int
+		atiradorDAO  atiradorDAO
+		esperado     protocolo.AtiradorResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve obter corretamente um atirador",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{
+						ID:           1,
+						CR:           cr,
+						Nome:         "João da Silva",
+						CPF:          "52998224725",
+						DataEmissão:  data.AddDate(-1, 0, 0),
+						DataValidade: data.AddDate(2, 0, 0),
+						Situação:     protocolo.AtiradorSituaçãoSuspenso,
+						DataCriação:  data,
+					}, nil
+				},
+			},
+			esperado: protocolo.AtiradorResposta{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoSuspenso,
+				DataCriação:  data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+	}
+
+	daoOriginal := novoAtiradorDAO
 	defer func() {
-		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = daoOriginal
 	}()
 
 	for i, cenário := range cenários {
-		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
-			return cenário.frequênciaDAO
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
 		}
 
-		serviço := NovoServiço(nil, nil, cenário.configuração)
+		serviço := NovoServiço(nil, nil, config.Configuração{})
 		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
-		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
 
-		err := serviço.ConfirmarFrequência(cenário.frequênciaConfirmaçãoPedidoCompleta)
-		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+		if err := verificadorResultado.VerificaResultado(serviço.ObterAtirador(cenário.cr)); err != nil {
 			t.Error(err)
 		}
 	}
 }
 
-func TestServiço_ConfirmarFrequência_valoresAleatórios(t *testing.T) {
-	daoOriginal := novaFrequênciaDAO
+func TestServiço_AtualizarAtirador(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição              string
+		atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto
+		atiradorDAO            atiradorDAO
+		esperado               protocolo.AtiradorResposta
+		erroEsperado           error
+	}{
+		{
+			descrição: "deve atualizar corretamente um atirador",
+			atiradorPedidoCompleto: protocolo.NovoAtiradorPedidoCompleto(380308, protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoCancelado,
+			}),
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{
+						ID:          1,
+						CR:          cr,
+						Situação:    protocolo.AtiradorSituaçãoAtivo,
+						DataCriação: data.Add(-time.Hour),
+					}, nil
+				},
+				simulaAtualizar: func(a *atirador) error {
+					a.DataAtualização = data
+					return nil
+				},
+			},
+			esperado: protocolo.AtiradorResposta{
+				CR:              380308,
+				Nome:            "João da Silva",
+				CPF:             "52998224725",
+				DataEmissão:     data.AddDate(-1, 0, 0),
+				DataValidade:    data.AddDate(2, 0, 0),
+				Situação:        protocolo.AtiradorSituaçãoCancelado,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			atiradorPedidoCompleto: protocolo.NovoAtiradorPedidoCompleto(380308, protocolo.AtiradorPedido{
+				CR: 380308,
+			}),
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar quando o novo CR pertence a outro atirador",
+			atiradorPedidoCompleto: protocolo.NovoAtiradorPedidoCompleto(380308, protocolo.AtiradorPedido{
+				CR: 380309,
+			}),
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					if cr == 380308 {
+						return atirador{ID: 1, CR: cr}, nil
+					}
+					return atirador{ID: 2, CR: cr}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380309"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar o atirador",
+			atiradorPedidoCompleto: protocolo.NovoAtiradorPedidoCompleto(380308, protocolo.AtiradorPedido{
+				CR: 380308,
+			}),
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{ID: 1, CR: cr}, nil
+				},
+				simulaAtualizar: func(a *atirador) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+	}
+
+	daoOriginal := novoAtiradorDAO
 	defer func() {
-		novaFrequênciaDAO = daoOriginal
+		novoAtiradorDAO = daoOriginal
 	}()
 
-	f := func(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) bool {
-		novaFrequênciaDAO = func(sqlogger *bd.SQLogger) frequênciaDAO {
-			return simulaFrequênciaDAO{
-				simulaAtualizar: func(frequência *frequência) error {
+	for i, cenário := range cenários {
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.AtualizarAtirador(cenário.atiradorPedidoCompleto)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_RemoverAtirador(t *testing.T) {
+	cenários := []struct {
+		descrição    string
+		cr           int
+		atiradorDAO  atiradorDAO
+		erroEsperado error
+	}{
+		{
+			descrição: "deve remover corretamente um atirador",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{ID: 1, CR: cr}, nil
+				},
+				simulaRemover: func(a *atirador) error {
+					if a.ID != 1 {
+						t.Errorf("Atirador inesperado na remoção: %d", a.ID)
+					}
 					return nil
 				},
-				simulaResgatar: func(id int64) (frequência, error) {
-					return frequência{
-						ID:                frequênciaConfirmaçãoPedidoCompleta.NúmeroControle.ID(),
-						Controle:          frequênciaConfirmaçãoPedidoCompleta.NúmeroControle.Controle(),
-						CR:                frequênciaConfirmaçãoPedidoCompleta.CR,
-						Calibre:           ".380",
-						ArmaUtilizada:     "Arma do Clube",
-						NúmeroSérie:       "ZA785671",
-						GuiaDeTráfego:     762556223,
-						QuantidadeMunição: 50,
-						DataInício:        time.Now().Add(-40 * time.Minute),
-						DataTérmino:       time.Now().Add(-10 * time.Minute),
-						DataCriação:       time.Now().Add(-5 * time.Minute),
-						ImagemNúmeroControle: `TWFuIGlzIGRpc3Rpbmd1aXNoZWQsIG5vdCBvbmx5IGJ5IGhpcyByZWFzb24sIGJ1dCBieSB0aGlz
-IHNpbmd1bGFyIHBhc3Npb24gZnJvbSBvdGhlciBhbmltYWxzLCB3aGljaCBpcyBhIGx1c3Qgb2Yg
-dGhlIG1pbmQsIHRoYXQgYnkgYSBwZXJzZXZlcmFuY2Ugb2YgZGVsaWdodCBpbiB0aGUgY29udGlu
-dWVkIGFuZCBpbmRlZmF0aWdhYmxlIGdlbmVyYXRpb24gb2Yga25vd2xlZGdlLCBleGNlZWRzIHRo
-ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
-					}, nil
+			},
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
 				},
-			}
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar um erro ao remover o atirador",
+			cr:        380308,
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{ID: 1, CR: cr}, nil
+				},
+				simulaRemover: func(a *atirador) error {
+					return errors.Errorf("erro de remoção")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de remoção"),
+		},
+	}
+
+	daoOriginal := novoAtiradorDAO
+	defer func() {
+		novoAtiradorDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
 		}
 
-		var configuração config.Configuração
-		configuração.Atirador.PrazoConfirmação = 20 * time.Minute
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
 
-		serviço := NovoServiço(nil, nil, configuração)
-		if err := serviço.ConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta); err != nil {
-			if _, ok := err.(protocolo.Mensagens); !ok {
-				t.Log(err)
-				return false
-			}
+		if err := verificadorResultado.VerificaResultado(nil, serviço.RemoverAtirador(cenário.cr)); err != nil {
+			t.Error(err)
 		}
+	}
+}
 
-		return true
+func TestServiço_ImportarAtiradores(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição                string
+		atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido
+		atiradorDAO              atiradorDAO
+		esperado                 protocolo.AtiradorImportaçãoResposta
+		erroEsperado             error
+	}{
+		{
+			descrição: "deve cadastrar os atiradores novos e atualizar os existentes",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308, Nome: "João da Silva", DataValidade: data.AddDate(2, 0, 0)},
+					{CR: 380309, Nome: "Maria da Silva", DataValidade: data.AddDate(2, 0, 0)},
+					{CR: 380310, Nome: "José da Silva", DataValidade: data.AddDate(2, 0, 0)},
+				},
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					if cr == 380309 {
+						return atirador{ID: 2, CR: cr}, nil
+					}
+					return atirador{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *atirador) error {
+					if a.Situação != protocolo.AtiradorSituaçãoAtivo {
+						t.Errorf("Situação inesperada para o atirador %d: %s", a.CR, a.Situação)
+					}
+					return nil
+				},
+				simulaAtualizar: func(a *atirador) error {
+					if a.ID != 2 || a.Nome != "Maria da Silva" {
+						t.Errorf("Atirador inesperado na atualização: %#v", a)
+					}
+					return nil
+				},
+			},
+			esperado: protocolo.AtiradorImportaçãoResposta{
+				Cadastrados: 2,
+				Atualizados: 1,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o atirador",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308},
+				},
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar o atirador",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308},
+				},
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(a *atirador) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar o atirador",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308},
+				},
+			},
+			atiradorDAO: simulaAtiradorDAO{
+				simulaResgatarPorCR: func(cr int) (atirador, error) {
+					return atirador{ID: 1, CR: cr}, nil
+				},
+				simulaAtualizar: func(a *atirador) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
 	}
 
-	if err := quick.Check(f, &quick.Config{MaxCount: 5}); err != nil {
-		t.Error(err)
+	daoOriginal := novoAtiradorDAO
+	defer func() {
+		novoAtiradorDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoAtiradorDAO = func(sqlogger *bd.SQLogger) atiradorDAO {
+			return cenário.atiradorDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ImportarAtiradores(cenário.atiradorImportaçãoPedido)); err != nil {
+			t.Error(err)
+		}
 	}
 }
 
 type simulaFrequênciaDAO struct {
 	simulaCriar     func(*frequência) error
 	simulaAtualizar func(*frequência) error
+	simulaCancelar  func(*frequência) error
+	simulaExpirar   func(*frequência) error
 	simulaResgatar  func(id int64) (frequência, error)
+	simulaListar    func(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error)
+
+	simulaResgatarResumo func(id int64) (frequência, error)
+
+	simulaPendentesExpiradas  func(dataLimite time.Time, limite int) ([]frequência, error)
+	simulaCandidatasAuditoria func(início, término time.Time) ([]frequência, error)
+
+	simulaHabitualidadeInsuficiente func(início, término time.Time, treinosExigidos int) ([]habitualidade, error)
+	simulaConsumoMunição            func(cr int, início, término time.Time) ([]consumoMunição, error)
+	simulaImagemSemelhante          func(id int64, hash uint64, distância int) (bool, error)
+	simulaMigrarImagens             func(limite int) (int, error)
+	simulaPendentesPorChave         func() ([]protocolo.FrequênciaPendênciaChave, error)
 }
 
 func (s simulaFrequênciaDAO) criar(frequência *frequência) error {
@@ -1149,10 +5186,77 @@ func (s simulaFrequênciaDAO) atualizar(frequência *frequência) error {
 	return s.simulaAtualizar(frequência)
 }
 
+func (s simulaFrequênciaDAO) cancelar(frequência *frequência) error {
+	return s.simulaCancelar(frequência)
+}
+
+func (s simulaFrequênciaDAO) expirar(frequência *frequência) error {
+	return s.simulaExpirar(frequência)
+}
+
 func (s simulaFrequênciaDAO) resgatar(id int64) (frequência, error) {
 	return s.simulaResgatar(id)
 }
 
+func (s simulaFrequênciaDAO) resgatarResumo(id int64) (frequência, error) {
+	return s.simulaResgatarResumo(id)
+}
+
+func (s simulaFrequênciaDAO) pendentesExpiradas(dataLimite time.Time, limite int) ([]frequência, error) {
+	return s.simulaPendentesExpiradas(dataLimite, limite)
+}
+
+func (s simulaFrequênciaDAO) candidatasAuditoria(início, término time.Time) ([]frequência, error) {
+	return s.simulaCandidatasAuditoria(início, término)
+}
+
+func (s simulaFrequênciaDAO) imagemSemelhante(id int64, hash uint64, distância int) (bool, error) {
+	return s.simulaImagemSemelhante(id, hash, distância)
+}
+
+func (s simulaFrequênciaDAO) migrarImagens(limite int) (int, error) {
+	return s.simulaMigrarImagens(limite)
+}
+
+func (s simulaFrequênciaDAO) listar(filtro protocolo.FrequênciaFiltro, c *cursor, limite int) ([]frequência, error) {
+	return s.simulaListar(filtro, c, limite)
+}
+
+func (s simulaFrequênciaDAO) habitualidadeInsuficiente(início, término time.Time, treinosExigidos int) ([]habitualidade, error) {
+	return s.simulaHabitualidadeInsuficiente(início, término, treinosExigidos)
+}
+
+func (s simulaFrequênciaDAO) consumoMunição(cr int, início, término time.Time) ([]consumoMunição, error) {
+	return s.simulaConsumoMunição(cr, início, término)
+}
+
+func (s simulaFrequênciaDAO) pendentesPorChave() ([]protocolo.FrequênciaPendênciaChave, error) {
+	return s.simulaPendentesPorChave()
+}
+
+type simulaAtiradorDAO struct {
+	simulaCriar         func(*atirador) error
+	simulaAtualizar     func(*atirador) error
+	simulaRemover       func(*atirador) error
+	simulaResgatarPorCR func(cr int) (atirador, error)
+}
+
+func (s simulaAtiradorDAO) criar(atirador *atirador) error {
+	return s.simulaCriar(atirador)
+}
+
+func (s simulaAtiradorDAO) atualizar(atirador *atirador) error {
+	return s.simulaAtualizar(atirador)
+}
+
+func (s simulaAtiradorDAO) remover(atirador *atirador) error {
+	return s.simulaRemover(atirador)
+}
+
+func (s simulaAtiradorDAO) resgatarPorCR(cr int) (atirador, error) {
+	return s.simulaResgatarPorCR(cr)
+}
+
 const imagemBasePNG = `
 iVBORw0KGgoAAAANSUhEUgAAAKgAAACoCAMAAABDlVWGAAABI1BMVEX/////////////////////
 ////////////////////////////////////////////////////////////////////////////
@@ -1225,3 +5329,39 @@ eC86wd1Lped1d/L+TJKZ3fEe/eFOoxQlk9zruYs77BqgJ/kYgLsA1szs7sxDFMXhJWeSM/WJPqle
 q51BIvkogNSTWjezjZlFKoqD5IpX69tmdiO/PnSKk2RqqCZRb6cGq5ltTz1aUQw+lHTIO0Q3zOx2
 3edGzsH7hP0hH4LqAUhtg810ycy2pha9WDhILrtvqcQ84FOZN73w47D7Gi8S8aL4gGdgmh4VY7Ll
 C5NSAXfHzDab3PT/AAAA//80vzGsvg8e/AAAAABJRU5ErkJggg==`
+
+type simulaAmostraAuditoriaDAO struct {
+	simulaCriar    func(*amostraAuditoria) error
+	simulaResgatar func(id int64) (amostraAuditoria, error)
+}
+
+func (s simulaAmostraAuditoriaDAO) criar(amostraAuditoria *amostraAuditoria) error {
+	return s.simulaCriar(amostraAuditoria)
+}
+
+func (s simulaAmostraAuditoriaDAO) resgatar(id int64) (amostraAuditoria, error) {
+	return s.simulaResgatar(id)
+}
+
+type simulaAuditoriaDAO struct {
+	simulaCriar            func(*auditoria) error
+	simulaAtualizar        func(*auditoria) error
+	simulaResgatar         func(id int64) (auditoria, error)
+	simulaListarPorAmostra func(idAmostra int64) ([]auditoria, error)
+}
+
+func (s simulaAuditoriaDAO) criar(auditoria *auditoria) error {
+	return s.simulaCriar(auditoria)
+}
+
+func (s simulaAuditoriaDAO) atualizar(auditoria *auditoria) error {
+	return s.simulaAtualizar(auditoria)
+}
+
+func (s simulaAuditoriaDAO) resgatar(id int64) (auditoria, error) {
+	return s.simulaResgatar(id)
+}
+
+func (s simulaAuditoriaDAO) listarPorAmostra(idAmostra int64) ([]auditoria, error) {
+	return s.simulaListarPorAmostra(idAmostra)
+}
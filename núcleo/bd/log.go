@@ -18,6 +18,18 @@ const (
 	// AçãoLogAtualização utilizado para identificar a ação de atualização de um
 	// objeto na base de dados.
 	AçãoLogAtualização AçãoLog = "ATUALIZACAO"
+
+	// AçãoLogRemoção utilizado para identificar a ação de remoção de um objeto
+	// da base de dados.
+	AçãoLogRemoção AçãoLog = "REMOCAO"
+
+	// AçãoLogCancelamento utilizado para identificar a ação de cancelamento de
+	// um objeto, que é mantido na base de dados.
+	AçãoLogCancelamento AçãoLog = "CANCELAMENTO"
+
+	// AçãoLogExpiração utilizado para identificar a ação de expiração de um
+	// objeto por uma rotina automática do sistema.
+	AçãoLogExpiração AçãoLog = "EXPIRACAO"
 )
 
 // AçãoLog define a ação realizada sobre um objeto no banco de dados.
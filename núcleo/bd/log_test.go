@@ -22,6 +22,8 @@ func TestAçãoLog_String(t *testing.T) {
 	}{
 		{ação: bd.AçãoLogCriação, esperado: string(bd.AçãoLogCriação)},
 		{ação: bd.AçãoLogAtualização, esperado: string(bd.AçãoLogAtualização)},
+		{ação: bd.AçãoLogRemoção, esperado: string(bd.AçãoLogRemoção)},
+		{ação: bd.AçãoLogExpiração, esperado: string(bd.AçãoLogExpiração)},
 	}
 
 	for _, cenário := range cenários {
@@ -40,6 +42,8 @@ func TestAçãoLog_Value(t *testing.T) {
 	}{
 		{ação: bd.AçãoLogCriação, valorEsperado: string(bd.AçãoLogCriação)},
 		{ação: bd.AçãoLogAtualização, valorEsperado: string(bd.AçãoLogAtualização)},
+		{ação: bd.AçãoLogRemoção, valorEsperado: string(bd.AçãoLogRemoção)},
+		{ação: bd.AçãoLogExpiração, valorEsperado: string(bd.AçãoLogExpiração)},
 	}
 
 	for _, cenário := range cenários {
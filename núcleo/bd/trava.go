@@ -0,0 +1,19 @@
+package bd
+
+import "github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+
+// Travar tenta obter uma trava exclusiva no banco de dados (advisory lock)
+// identificada pela chave, sem aguardar caso outra transação já a possua. A
+// trava é liberada automaticamente ao término da transação, permitindo que
+// somente um dos servidores que compartilham o banco de dados execute uma
+// rotina por vez.
+func (s *SQLogger) Travar(chave int64) (bool, error) {
+	var obtida bool
+	if err := s.QueryRow(travaComando, chave).Scan(&obtida); err != nil {
+		return false, erros.Novo(err)
+	}
+
+	return obtida, nil
+}
+
+var travaComando = `SELECT pg_try_advisory_xact_lock($1)`
@@ -0,0 +1,65 @@
+package bd_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestSQLogger_Travar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	travaComando := `SELECT pg_try_advisory_xact_lock($1)`
+
+	cenários := []struct {
+		descrição    string
+		simulação    func()
+		esperado     bool
+		erroEsperado error
+	}{
+		{
+			descrição: "deve obter a trava corretamente",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{true}}))
+			},
+			esperado: true,
+		},
+		{
+			descrição: "deve identificar quando a trava pertence a outra transação",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{false}}))
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao obter a trava",
+			simulação: func() {
+				testdb.StubQueryError(travaComando, fmt.Errorf("erro ao obter a trava"))
+			},
+			erroEsperado: errors.Errorf("erro ao obter a trava"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		sqlogger := bd.NovoSQLogger(conexão, net.ParseIP("192.168.1.1"))
+		obtida, err := sqlogger.Travar(1)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(obtida, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
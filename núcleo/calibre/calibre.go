@@ -0,0 +1,33 @@
+package calibre
+
+import (
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type calibre struct {
+	ID       int64
+	Nome     string
+	Apelidos []string
+	Classe   protocolo.CalibreClasse
+}
+
+func (c calibre) protocolo() protocolo.CalibreResposta {
+	return protocolo.CalibreResposta{
+		ID:       c.ID,
+		Nome:     c.Nome,
+		Apelidos: c.Apelidos,
+		Classe:   c.Classe,
+	}
+}
+
+// preencherApelidos interpreta os apelidos agregados pelo banco de dados em
+// um único texto.
+func (c *calibre) preencherApelidos(apelidos string) {
+	if apelidos == "" {
+		return
+	}
+
+	c.Apelidos = strings.Split(apelidos, calibreApelidosSeparador)
+}
@@ -0,0 +1,88 @@
+package calibre
+
+import (
+	"fmt"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type calibreDAO interface {
+	resgatarTodos() ([]calibre, error)
+	resgatarPorNome(nome string) (calibre, error)
+}
+
+var novoCalibreDAO = func(sqlogger *bd.SQLogger) calibreDAO {
+	return calibreDAOImpl{sqlogger: sqlogger}
+}
+
+type calibreDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (c calibreDAOImpl) resgatarTodos() ([]calibre, error) {
+	linhas, err := c.sqlogger.Query(calibreListagemComando)
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer linhas.Close()
+
+	var calibres []calibre
+	for linhas.Next() {
+		var cal calibre
+		var apelidos, classe string
+
+		if err := linhas.Scan(&cal.ID, &cal.Nome, &classe, &apelidos); err != nil {
+			return nil, erros.Novo(err)
+		}
+
+		cal.preencherApelidos(apelidos)
+		cal.Classe = protocolo.CalibreClasse(classe)
+		calibres = append(calibres, cal)
+	}
+
+	return calibres, erros.Novo(linhas.Err())
+}
+
+// resgatarPorNome busca o calibre tanto pelo nome canônico quanto pelos
+// apelidos cadastrados.
+func (c calibreDAOImpl) resgatarPorNome(nome string) (calibre, error) {
+	resultado := c.sqlogger.QueryRow(calibreResgatePorNomeComando, nome)
+
+	var cal calibre
+	var apelidos, classe string
+
+	err := resultado.Scan(&cal.ID, &cal.Nome, &classe, &apelidos)
+	cal.preencherApelidos(apelidos)
+	cal.Classe = protocolo.CalibreClasse(classe)
+
+	return cal, erros.Novo(err)
+}
+
+var (
+	calibreTabela        = "calibre"
+	calibreApelidoTabela = "calibre_apelido"
+
+	// calibreApelidosSeparador é utilizado para agregar todos os apelidos de
+	// um calibre em uma única coluna, evitando uma consulta por calibre.
+	calibreApelidosSeparador = "|"
+
+	calibreResgateCampos = []string{
+		"id",
+		"nome",
+		"classe",
+		"apelidos",
+	}
+	calibreResgateConsulta = fmt.Sprintf(`SELECT c.id, c.nome, c.classe,
+	COALESCE(string_agg(a.apelido, '%s' ORDER BY a.apelido), '') AS apelidos
+	FROM %s c LEFT JOIN %s a ON a.id_calibre = c.id`,
+		calibreApelidosSeparador, calibreTabela, calibreApelidoTabela)
+
+	calibreListagemComando = fmt.Sprintf(`%s GROUP BY c.id ORDER BY c.nome`,
+		calibreResgateConsulta)
+
+	calibreResgatePorNomeComando = fmt.Sprintf(`%s
+	WHERE c.nome = $1 OR c.id IN (SELECT id_calibre FROM %s WHERE apelido = $1)
+	GROUP BY c.id`, calibreResgateConsulta, calibreApelidoTabela)
+)
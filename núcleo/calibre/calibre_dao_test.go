@@ -0,0 +1,134 @@
+package calibre
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestCalibreDAOImpl_resgatarTodos(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição        string
+		simulação        func()
+		calibresEsperado []calibre
+		erroEsperado     error
+	}{
+		{
+			descrição: "deve resgatar corretamente todos os calibres",
+			simulação: func() {
+				testdb.StubQuery(calibreListagemComando, testdb.RowsFromSlice(calibreResgateCampos, [][]driver.Value{
+					{1, ".380 ACP", "permitido", ".380|380"},
+					{2, "5,56X45MM", "restrito", ""},
+				}))
+			},
+			calibresEsperado: []calibre{
+				{
+					ID:       1,
+					Nome:     ".380 ACP",
+					Apelidos: []string{".380", "380"},
+					Classe:   protocolo.CalibreClassePermitido,
+				},
+				{
+					ID:     2,
+					Nome:   "5,56X45MM",
+					Classe: protocolo.CalibreClasseRestrito,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar os calibres",
+			simulação: func() {
+				testdb.StubQueryError(calibreListagemComando, fmt.Errorf("erro de execução"))
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoCalibreDAO(bd.NovoSQLogger(conexão, nil))
+		calibres, err := dao.resgatarTodos()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.calibresEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(calibres, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCalibreDAOImpl_resgatarPorNome(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição       string
+		simulação       func()
+		nome            string
+		calibreEsperado calibre
+		erroEsperado    error
+	}{
+		{
+			descrição: "deve resgatar corretamente um calibre",
+			simulação: func() {
+				testdb.StubQuery(calibreResgatePorNomeComando, testdb.RowsFromSlice(calibreResgateCampos, [][]driver.Value{
+					{3, ".40 S&W", "permitido", ".40|40SW"},
+				}))
+			},
+			nome: "40SW",
+			calibreEsperado: calibre{
+				ID:       3,
+				Nome:     ".40 S&W",
+				Apelidos: []string{".40", "40SW"},
+				Classe:   protocolo.CalibreClassePermitido,
+			},
+		},
+		{
+			descrição: "deve detectar quando o calibre não existe",
+			simulação: func() {
+				testdb.StubQuery(calibreResgatePorNomeComando, testdb.RowsFromSlice(calibreResgateCampos, [][]driver.Value{}))
+			},
+			nome:         "XPTO",
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar um calibre",
+			simulação: func() {
+				testdb.StubQueryError(calibreResgatePorNomeComando, fmt.Errorf("erro de execução"))
+			},
+			nome:         ".380",
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoCalibreDAO(bd.NovoSQLogger(conexão, nil))
+		c, err := dao.resgatarPorNome(cenário.nome)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.calibreEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(c, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package calibre provê o catálogo de calibres, utilizado para padronizar os
+// calibres informados nas frequências e no acervo de armas.
+package calibre
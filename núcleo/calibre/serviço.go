@@ -0,0 +1,67 @@
+package calibre
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// Serviço disponibiliza as ações que podem ser feitas relacionadas ao catálogo
+// de calibres.
+type Serviço interface {
+	// ListarCalibres retorna todos os calibres do catálogo ordenados pelo
+	// nome.
+	ListarCalibres() (protocolo.CalibreListaResposta, error)
+
+	// ResolverCalibre identifica o calibre do catálogo a partir do nome
+	// canônico ou de um dos seus apelidos. O nome deve estar normalizado em
+	// caixa alta. Retorna erros.NãoEncontrado quando o calibre é desconhecido.
+	ResolverCalibre(nome string) (protocolo.CalibreResposta, error)
+}
+
+// NovoServiço inicializa um serviço concreto do catálogo de calibres. Pode ser
+// substituído em testes por simuladores, permitindo uma abstração da camada de
+// serviços.
+var NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) Serviço {
+	return serviço{
+		sqlogger:     s,
+		logger:       l,
+		configuração: configuração,
+	}
+}
+
+type serviço struct {
+	sqlogger     *bd.SQLogger
+	logger       log.Serviço
+	configuração config.Configuração
+}
+
+func (s serviço) ListarCalibres() (protocolo.CalibreListaResposta, error) {
+	dao := novoCalibreDAO(s.sqlogger)
+	calibres, err := dao.resgatarTodos()
+	if err != nil {
+		return protocolo.CalibreListaResposta{}, erros.Novo(err)
+	}
+
+	resposta := protocolo.CalibreListaResposta{
+		Calibres: make([]protocolo.CalibreResposta, 0, len(calibres)),
+	}
+
+	for _, c := range calibres {
+		resposta.Calibres = append(resposta.Calibres, c.protocolo())
+	}
+
+	return resposta, nil
+}
+
+func (s serviço) ResolverCalibre(nome string) (protocolo.CalibreResposta, error) {
+	dao := novoCalibreDAO(s.sqlogger)
+	c, err := dao.resgatarPorNome(nome)
+	if err != nil {
+		return protocolo.CalibreResposta{}, erros.Novo(err)
+	}
+
+	return c.protocolo(), nil
+}
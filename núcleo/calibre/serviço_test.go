@@ -0,0 +1,146 @@
+package calibre
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestServiço_ListarCalibres(t *testing.T) {
+	cenários := []struct {
+		descrição    string
+		calibreDAO   calibreDAO
+		esperado     protocolo.CalibreListaResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve listar corretamente os calibres",
+			calibreDAO: simulaCalibreDAO{
+				simulaResgatarTodos: func() ([]calibre, error) {
+					return []calibre{
+						{ID: 1, Nome: ".380 ACP", Apelidos: []string{".380"}, Classe: protocolo.CalibreClassePermitido},
+						{ID: 2, Nome: "5,56X45MM", Classe: protocolo.CalibreClasseRestrito},
+					}, nil
+				},
+			},
+			esperado: protocolo.CalibreListaResposta{
+				Calibres: []protocolo.CalibreResposta{
+					{ID: 1, Nome: ".380 ACP", Apelidos: []string{".380"}, Classe: protocolo.CalibreClassePermitido},
+					{ID: 2, Nome: "5,56X45MM", Classe: protocolo.CalibreClasseRestrito},
+				},
+			},
+		},
+		{
+			descrição: "deve retornar uma lista vazia quando não existem calibres",
+			calibreDAO: simulaCalibreDAO{
+				simulaResgatarTodos: func() ([]calibre, error) {
+					return nil, nil
+				},
+			},
+			esperado: protocolo.CalibreListaResposta{
+				Calibres: []protocolo.CalibreResposta{},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar os calibres",
+			calibreDAO: simulaCalibreDAO{
+				simulaResgatarTodos: func() ([]calibre, error) {
+					return nil, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+	}
+
+	daoOriginal := novoCalibreDAO
+	defer func() {
+		novoCalibreDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoCalibreDAO = func(sqlogger *bd.SQLogger) calibreDAO {
+			return cenário.calibreDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ListarCalibres()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ResolverCalibre(t *testing.T) {
+	cenários := []struct {
+		descrição    string
+		nome         string
+		calibreDAO   calibreDAO
+		esperado     protocolo.CalibreResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve resolver corretamente um apelido",
+			nome:      "40SW",
+			calibreDAO: simulaCalibreDAO{
+				simulaResgatarPorNome: func(nome string) (calibre, error) {
+					return calibre{ID: 3, Nome: ".40 S&W", Apelidos: []string{".40", "40SW"}, Classe: protocolo.CalibreClassePermitido}, nil
+				},
+			},
+			esperado: protocolo.CalibreResposta{
+				ID:       3,
+				Nome:     ".40 S&W",
+				Apelidos: []string{".40", "40SW"},
+				Classe:   protocolo.CalibreClassePermitido,
+			},
+		},
+		{
+			descrição: "deve detectar quando o calibre é desconhecido",
+			nome:      "XPTO",
+			calibreDAO: simulaCalibreDAO{
+				simulaResgatarPorNome: func(nome string) (calibre, error) {
+					return calibre{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+	}
+
+	daoOriginal := novoCalibreDAO
+	defer func() {
+		novoCalibreDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoCalibreDAO = func(sqlogger *bd.SQLogger) calibreDAO {
+			return cenário.calibreDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ResolverCalibre(cenário.nome)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type simulaCalibreDAO struct {
+	simulaResgatarTodos   func() ([]calibre, error)
+	simulaResgatarPorNome func(nome string) (calibre, error)
+}
+
+func (s simulaCalibreDAO) resgatarTodos() ([]calibre, error) {
+	return s.simulaResgatarTodos()
+}
+
+func (s simulaCalibreDAO) resgatarPorNome(nome string) (calibre, error) {
+	return s.simulaResgatarPorNome(nome)
+}
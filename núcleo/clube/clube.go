@@ -0,0 +1,70 @@
+package clube
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type clube struct {
+	ID              int64
+	CNPJ            string
+	CR              int
+	Nome            string
+	Endereço        string
+	Cidade          string
+	UF              string
+	RegiãoMilitar   int
+	Situação        protocolo.ClubeSituação
+	DataCriação     time.Time
+	DataAtualização time.Time
+
+	// Localização posição do estande de tiro, quando informada.
+	Localização *protocolo.Coordenadas
+
+	// revisão utilizado para o controle de versão do objeto na base de dados,
+	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
+	// objeto.
+	revisão int
+}
+
+func novoClube(clubePedido protocolo.ClubePedido) clube {
+	c := clube{}
+	c.preencher(clubePedido)
+	return c
+}
+
+// preencher copia os dados do pedido para o clube. Quando a situação não é
+// informada o clube é considerado ativo.
+func (c *clube) preencher(clubePedido protocolo.ClubePedido) {
+	c.CNPJ = clubePedido.CNPJ
+	c.CR = clubePedido.CR
+	c.Nome = clubePedido.Nome
+	c.Endereço = clubePedido.Endereço
+	c.Cidade = clubePedido.Cidade
+	c.UF = clubePedido.UF
+	c.RegiãoMilitar = clubePedido.RegiãoMilitar
+	c.Situação = clubePedido.Situação
+	c.Localização = clubePedido.Localização
+
+	if c.Situação == "" {
+		c.Situação = protocolo.ClubeSituaçãoAtivo
+	}
+}
+
+func (c clube) protocolo() protocolo.ClubeResposta {
+	return protocolo.ClubeResposta{
+		ID:              c.ID,
+		CNPJ:            c.CNPJ,
+		CR:              c.CR,
+		Nome:            c.Nome,
+		Endereço:        c.Endereço,
+		Cidade:          c.Cidade,
+		UF:              c.UF,
+		RegiãoMilitar:   c.RegiãoMilitar,
+		Situação:        c.Situação,
+		DataCriação:     c.DataCriação,
+		DataAtualização: c.DataAtualização,
+		Localização:     c.Localização,
+	}
+}
@@ -0,0 +1,227 @@
+package clube
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type clubeDAO interface {
+	criar(*clube) error
+	atualizar(*clube) error
+	resgatar(id int64) (clube, error)
+	resgatarPorCNPJ(cnpj string) (clube, error)
+}
+
+var novoClubeDAO = func(sqlogger *bd.SQLogger) clubeDAO {
+	return clubeDAOImpl{sqlogger: sqlogger}
+}
+
+type clubeDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (c clubeDAOImpl) criar(clube *clube) error {
+	if clube == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	clube.DataCriação = time.Now().UTC()
+	clube.revisão = 0
+
+	latitude, longitude := coordenadasBD(clube.Localização)
+
+	resultado := c.sqlogger.QueryRow(clubeCriaçãoComando,
+		clube.CNPJ,
+		clube.CR,
+		clube.Nome,
+		clube.Endereço,
+		clube.Cidade,
+		clube.UF,
+		clube.RegiãoMilitar,
+		clube.Situação,
+		latitude,
+		longitude,
+		clube.DataCriação.UTC(),
+		clube.revisão,
+	)
+
+	if err := resultado.Scan(&clube.ID); err != nil {
+		return erros.Novo(err)
+	}
+
+	clubeLogDAO := novoClubeLogDAO(c.sqlogger)
+	return erros.Novo(clubeLogDAO.criar(*clube, bd.AçãoLogCriação))
+}
+
+func (c clubeDAOImpl) atualizar(clube *clube) error {
+	if clube == nil {
+		return erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	clube.DataAtualização = time.Now().UTC()
+	clube.revisão++
+
+	latitude, longitude := coordenadasBD(clube.Localização)
+
+	resultado, err := c.sqlogger.Exec(clubeAtualizaçãoComando,
+		clube.CNPJ,
+		clube.CR,
+		clube.Nome,
+		clube.Endereço,
+		clube.Cidade,
+		clube.UF,
+		clube.RegiãoMilitar,
+		clube.Situação,
+		latitude,
+		longitude,
+		clube.DataAtualização.UTC(),
+		clube.revisão,
+		clube.ID,
+		clube.revisão-1,
+	)
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	atualizados, err := resultado.RowsAffected()
+
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if atualizados != 1 {
+		return erros.NãoAtualizado
+	}
+
+	clubeLogDAO := novoClubeLogDAO(c.sqlogger)
+	return erros.Novo(clubeLogDAO.criar(*clube, bd.AçãoLogAtualização))
+}
+
+func (c clubeDAOImpl) resgatar(id int64) (clube, error) {
+	return c.resgatarPorComando(clubeResgateComando, id)
+}
+
+func (c clubeDAOImpl) resgatarPorCNPJ(cnpj string) (clube, error) {
+	return c.resgatarPorComando(clubeResgatePorCNPJComando, cnpj)
+}
+
+func (c clubeDAOImpl) resgatarPorComando(comando string, argumento interface{}) (clube, error) {
+	resultado := c.sqlogger.QueryRow(comando, argumento)
+
+	var clb clube
+	var situação string
+	var dataAtualização pq.NullTime
+	var latitude, longitude sql.NullFloat64
+
+	err := resultado.Scan(
+		&clb.ID,
+		&clb.CNPJ,
+		&clb.CR,
+		&clb.Nome,
+		&clb.Endereço,
+		&clb.Cidade,
+		&clb.UF,
+		&clb.RegiãoMilitar,
+		&situação,
+		&latitude,
+		&longitude,
+		&clb.DataCriação,
+		&dataAtualização,
+		&clb.revisão,
+	)
+
+	clb.Situação = protocolo.ClubeSituação(situação)
+
+	if dataAtualização.Valid {
+		clb.DataAtualização = dataAtualização.Time
+	}
+
+	if latitude.Valid && longitude.Valid {
+		clb.Localização = &protocolo.Coordenadas{
+			Latitude:  latitude.Float64,
+			Longitude: longitude.Float64,
+		}
+	}
+
+	return clb, erros.Novo(err)
+}
+
+// coordenadasBD converte a localização do clube para os valores das colunas,
+// que ficam nulas quando a localização não foi informada.
+func coordenadasBD(localização *protocolo.Coordenadas) (latitude, longitude sql.NullFloat64) {
+	if localização == nil {
+		return
+	}
+
+	latitude = sql.NullFloat64{Float64: localização.Latitude, Valid: true}
+	longitude = sql.NullFloat64{Float64: localização.Longitude, Valid: true}
+	return
+}
+
+var (
+	clubeTabela = "clube"
+
+	clubeCriaçãoCampos = []string{
+		"id",
+		"cnpj",
+		"cr",
+		"nome",
+		"endereco",
+		"cidade",
+		"uf",
+		"regiao_militar",
+		"situacao",
+		"latitude",
+		"longitude",
+		"data_criacao",
+		"revisao",
+	}
+	clubeCriaçãoCamposTexto = strings.Join(clubeCriaçãoCampos, ", ")
+	clubeCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s) RETURNING id`,
+		clubeTabela, clubeCriaçãoCamposTexto, bd.MarcadoresPSQL(len(clubeCriaçãoCampos)-1))
+
+	clubeAtualizaçãoComando = fmt.Sprintf(`UPDATE %s SET
+	cnpj = $1,
+	cr = $2,
+	nome = $3,
+	endereco = $4,
+	cidade = $5,
+	uf = $6,
+	regiao_militar = $7,
+	situacao = $8,
+	latitude = $9,
+	longitude = $10,
+	data_atualizacao = $11,
+	revisao = $12
+	WHERE id = $13 AND revisao = $14`, clubeTabela)
+
+	clubeResgateCampos = []string{
+		"id",
+		"cnpj",
+		"cr",
+		"nome",
+		"endereco",
+		"cidade",
+		"uf",
+		"regiao_militar",
+		"situacao",
+		"latitude",
+		"longitude",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	clubeResgateCamposTexto = strings.Join(clubeResgateCampos, ", ")
+	clubeResgateComando     = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`,
+		clubeResgateCamposTexto, clubeTabela)
+	clubeResgatePorCNPJComando = fmt.Sprintf(`SELECT %s FROM %s WHERE cnpj = $1`,
+		clubeResgateCamposTexto, clubeTabela)
+)
@@ -0,0 +1,386 @@
+package clube
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestClubeDAOImpl_criar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição     string
+		simulação     func()
+		clube         *clube
+		clubeEsperado clube
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve criar corretamente o clube",
+			simulação: func() {
+				testdb.StubQuery(clubeCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExec(clubeLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			clube: &clube{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+				revisão:       2, // revisão sempre inicia com zero
+			},
+			clubeEsperado: clube{
+				ID:            1,
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+				DataCriação:   data,
+				revisão:       0,
+			},
+		},
+		{
+			descrição:    "deve detectar quando o clube não está definido",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao criar o clube",
+			simulação: func() {
+				testdb.StubQueryError(clubeCriaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			clube: &clube{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar um erro ao gerar uma entrada de log",
+			simulação: func() {
+				testdb.StubQuery(clubeCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+				testdb.StubExecError(clubeLogCriaçãoComando, fmt.Errorf("erro na criação do log"))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			clube: &clube{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+			},
+			erroEsperado: errors.Errorf("erro na criação do log"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novoClubeDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.criar(cenário.clube)
+
+		if cenário.clube != nil {
+			if cenário.clube.DataCriação.Before(cenário.clubeEsperado.DataCriação) {
+				t.Errorf("Item %d, “%s”: data de criação inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.clubeEsperado.DataCriação, cenário.clube.DataCriação)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de criação já
+			// que é definida no próprio método.
+			cenário.clubeEsperado.DataCriação = cenário.clube.DataCriação
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.clubeEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.clube, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeDAOImpl_atualizar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição     string
+		simulação     func()
+		clube         *clube
+		clubeEsperado clube
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve atualizar corretamente o clube",
+			simulação: func() {
+				testdb.StubExec(clubeAtualizaçãoComando, testdb.NewResult(1, nil, 1, nil))
+				testdb.StubExec(clubeLogCriaçãoComando, testdb.NewResult(1, nil, 1, nil))
+
+				logCriaçãoComando := `INSERT INTO log (id, data_criacao, endereco_remoto) VALUES (DEFAULT, $1, $2) RETURNING id`
+				testdb.StubQuery(logCriaçãoComando, testdb.RowsFromSlice([]string{"id"}, [][]driver.Value{{1}}))
+			},
+			clube: &clube{
+				ID:            1,
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoInativo,
+				DataCriação:   data.Add(-time.Hour),
+			},
+			clubeEsperado: clube{
+				ID:              1,
+				CNPJ:            "11222333000181",
+				CR:              123456,
+				Nome:            "Clube de Tiro Centro",
+				Endereço:        "Rua das Flores, 123",
+				Cidade:          "Rio de Janeiro",
+				UF:              "RJ",
+				RegiãoMilitar:   1,
+				Situação:        protocolo.ClubeSituaçãoInativo,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+				revisão:         1,
+			},
+		},
+		{
+			descrição:    "deve detectar quando o clube não está definido",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar o clube",
+			simulação: func() {
+				testdb.StubExecError(clubeAtualizaçãoComando, fmt.Errorf("erro de execução"))
+			},
+			clube: &clube{
+				ID:          1,
+				CNPJ:        "11222333000181",
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+		{
+			descrição: "deve detectar quando a atualização não surtiu efeito",
+			simulação: func() {
+				testdb.StubExec(clubeAtualizaçãoComando, testdb.NewResult(0, nil, 0, nil))
+			},
+			clube: &clube{
+				ID:          1,
+				CNPJ:        "11222333000181",
+				DataCriação: data.Add(-time.Hour),
+			},
+			erroEsperado: erros.NãoAtualizado,
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		dao := novoClubeDAO(bd.NovoSQLogger(conexão, nil))
+		err := dao.atualizar(cenário.clube)
+
+		if cenário.clube != nil {
+			if cenário.clube.DataAtualização.Before(cenário.clubeEsperado.DataAtualização) {
+				t.Errorf("Item %d, “%s”: data de atualização inesperada. Esperava que fosse após “%s”, e foi “%s”",
+					i, cenário.descrição, cenário.clubeEsperado.DataAtualização, cenário.clube.DataAtualização)
+			}
+
+			// Após comparar as datas, deixamos elas iguais para comparar os demais
+			// campos. Isto é necessário pois não é possível prever a data de
+			// atualização já que é definida no próprio método.
+			cenário.clubeEsperado.DataAtualização = cenário.clube.DataAtualização
+
+			if cenário.erroEsperado != nil {
+				cenário.clubeEsperado = *cenário.clube
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(&cenário.clubeEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(cenário.clube, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeDAOImpl_resgatar(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição     string
+		simulação     func()
+		id            int64
+		clubeEsperado clube
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve resgatar corretamente um clube",
+			simulação: func() {
+				testdb.StubQuery(clubeResgateComando, testdb.RowsFromSlice(clubeResgateCampos, [][]driver.Value{
+					{
+						1, "11222333000181", 123456, "Clube de Tiro Centro", "Rua das Flores, 123",
+						"Rio de Janeiro", "RJ", 1, "ativo", -22.9068, -43.1729, data, nil, 0,
+					},
+				}))
+			},
+			id: 1,
+			clubeEsperado: clube{
+				ID:            1,
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+				DataCriação:   data,
+				Localização: &protocolo.Coordenadas{
+					Latitude:  -22.9068,
+					Longitude: -43.1729,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar um clube",
+			simulação: func() {
+				testdb.StubQueryError(clubeResgateComando, fmt.Errorf("erro de execução"))
+			},
+			id:           1,
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoClubeDAO(bd.NovoSQLogger(conexão, nil))
+		c, err := dao.resgatar(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.clubeEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(c, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeDAOImpl_resgatarPorCNPJ(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição     string
+		simulação     func()
+		cnpj          string
+		clubeEsperado clube
+		erroEsperado  error
+	}{
+		{
+			descrição: "deve resgatar corretamente um clube pelo CNPJ",
+			simulação: func() {
+				testdb.StubQuery(clubeResgatePorCNPJComando, testdb.RowsFromSlice(clubeResgateCampos, [][]driver.Value{
+					{
+						1, "11222333000181", 123456, "Clube de Tiro Centro", "Rua das Flores, 123",
+						"Rio de Janeiro", "RJ", 1, "inativo", nil, nil, data, data, 3,
+					},
+				}))
+			},
+			cnpj: "11222333000181",
+			clubeEsperado: clube{
+				ID:              1,
+				CNPJ:            "11222333000181",
+				CR:              123456,
+				Nome:            "Clube de Tiro Centro",
+				Endereço:        "Rua das Flores, 123",
+				Cidade:          "Rio de Janeiro",
+				UF:              "RJ",
+				RegiãoMilitar:   1,
+				Situação:        protocolo.ClubeSituaçãoInativo,
+				DataCriação:     data,
+				DataAtualização: data,
+				revisão:         3,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar um clube pelo CNPJ",
+			simulação: func() {
+				testdb.StubQueryError(clubeResgatePorCNPJComando, fmt.Errorf("erro de execução"))
+			},
+			cnpj:         "11222333000181",
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoClubeDAO(bd.NovoSQLogger(conexão, nil))
+		c, err := dao.resgatarPorCNPJ(cenário.cnpj)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.clubeEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(c, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
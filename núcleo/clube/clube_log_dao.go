@@ -0,0 +1,77 @@
+package clube
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+)
+
+type clubeLogDAO interface {
+	criar(clube, bd.AçãoLog) error
+}
+
+var novoClubeLogDAO = func(sqlogger *bd.SQLogger) clubeLogDAO {
+	return clubeLogDAOImpl{sqlogger: sqlogger}
+}
+
+type clubeLogDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (c clubeLogDAOImpl) criar(clube clube, ação bd.AçãoLog) error {
+	if err := c.sqlogger.Gerar(); err != nil {
+		return erros.Novo(err)
+	}
+
+	latitude, longitude := coordenadasBD(clube.Localização)
+
+	_, err := c.sqlogger.Exec(clubeLogCriaçãoComando,
+		c.sqlogger.Log.ID,
+		ação,
+		clube.ID,
+		clube.CNPJ,
+		clube.CR,
+		clube.Nome,
+		clube.Endereço,
+		clube.Cidade,
+		clube.UF,
+		clube.RegiãoMilitar,
+		clube.Situação,
+		latitude,
+		longitude,
+		clube.DataCriação.UTC(),
+		clube.DataAtualização.UTC(),
+		clube.revisão,
+	)
+
+	return erros.Novo(err)
+}
+
+var (
+	clubeLogTabela = "clube_log"
+
+	clubeLogCriaçãoCampos = []string{
+		"id",
+		"id_log",
+		"acao",
+		"id_clube",
+		"cnpj",
+		"cr",
+		"nome",
+		"endereco",
+		"cidade",
+		"uf",
+		"regiao_militar",
+		"situacao",
+		"latitude",
+		"longitude",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	clubeLogCriaçãoCamposTexto = strings.Join(clubeLogCriaçãoCampos, ", ")
+	clubeLogCriaçãoComando     = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (DEFAULT, %s)`,
+		clubeLogTabela, clubeLogCriaçãoCamposTexto, bd.MarcadoresPSQL(len(clubeLogCriaçãoCampos)-1))
+)
@@ -0,0 +1,3 @@
+// Package clube provê os serviços necessários em torno do Clube de Tiro que
+// reporta as frequências dos Atiradores.
+package clube
@@ -0,0 +1,27 @@
+package clube
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
+)
+
+// validarCNPJDisponível garante que não existe outro clube cadastrado com o
+// mesmo CNPJ. O número de identificação informado é ignorado na busca,
+// permitindo que um clube seja atualizado mantendo o seu próprio CNPJ.
+func validarCNPJDisponível(dao clubeDAO, id int64, cnpj string) (protocolo.Mensagens, error) {
+	c, err := dao.resgatarPorCNPJ(cnpj)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		return nil, nil
+	} else if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	if c.ID != id {
+		return protocolo.NovasMensagens(
+			protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, cnpj),
+		), nil
+	}
+
+	return nil, nil
+}
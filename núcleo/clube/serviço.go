@@ -0,0 +1,90 @@
+package clube
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// Serviço disponibiliza as ações que podem ser feitas relacionadas ao Clube de
+// Tiro.
+type Serviço interface {
+	// CadastrarClube persiste em banco de dados um novo Clube de Tiro. Não é
+	// permitido cadastrar dois clubes com o mesmo CNPJ.
+	CadastrarClube(protocolo.ClubePedido) (protocolo.ClubeResposta, error)
+
+	// ObterClube retorna os dados do Clube de Tiro a partir do seu número de
+	// identificação.
+	ObterClube(id int64) (protocolo.ClubeResposta, error)
+
+	// AtualizarClube substitui os dados do Clube de Tiro pelos dados informados.
+	// É através desta ação que um clube pode ser desativado.
+	AtualizarClube(protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error)
+}
+
+// NovoServiço inicializa um serviço concreto do Clube de Tiro. Pode ser
+// substituído em testes por simuladores, permitindo uma abstração da camada de
+// serviços.
+var NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) Serviço {
+	return serviço{
+		sqlogger:     s,
+		logger:       l,
+		configuração: configuração,
+	}
+}
+
+type serviço struct {
+	sqlogger     *bd.SQLogger
+	logger       log.Serviço
+	configuração config.Configuração
+}
+
+func (s serviço) CadastrarClube(clubePedido protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+	dao := novoClubeDAO(s.sqlogger)
+
+	if mensagens, err := validarCNPJDisponível(dao, 0, clubePedido.CNPJ); err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.ClubeResposta{}, mensagens
+	}
+
+	c := novoClube(clubePedido)
+	if err := dao.criar(&c); err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	}
+
+	return c.protocolo(), nil
+}
+
+func (s serviço) ObterClube(id int64) (protocolo.ClubeResposta, error) {
+	dao := novoClubeDAO(s.sqlogger)
+	c, err := dao.resgatar(id)
+	if err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	}
+
+	return c.protocolo(), nil
+}
+
+func (s serviço) AtualizarClube(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+	dao := novoClubeDAO(s.sqlogger)
+	c, err := dao.resgatar(clubePedidoCompleto.ID)
+	if err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	}
+
+	if mensagens, err := validarCNPJDisponível(dao, c.ID, clubePedidoCompleto.CNPJ); err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	} else if len(mensagens) > 0 {
+		return protocolo.ClubeResposta{}, mensagens
+	}
+
+	c.preencher(clubePedidoCompleto.ClubePedido)
+	if err := dao.atualizar(&c); err != nil {
+		return protocolo.ClubeResposta{}, erros.Novo(err)
+	}
+
+	return c.protocolo(), nil
+}
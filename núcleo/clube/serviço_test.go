@@ -0,0 +1,325 @@
+package clube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestServiço_CadastrarClube(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		clubePedido  protocolo.ClubePedido
+		clubeDAO     clubeDAO
+		esperado     protocolo.ClubeResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve cadastrar corretamente um clube",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+			},
+			clubeDAO: simulaClubeDAO{
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(c *clube) error {
+					c.ID = 1
+					c.DataCriação = data
+					return nil
+				},
+			},
+			esperado: protocolo.ClubeResposta{
+				ID:            1,
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+				DataCriação:   data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o CNPJ já está cadastrado",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			clubeDAO: simulaClubeDAO{
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{ID: 2, CNPJ: cnpj}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao verificar o CNPJ",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			clubeDAO: simulaClubeDAO{
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{}, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+		{
+			descrição: "deve detectar um erro ao criar o clube",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			clubeDAO: simulaClubeDAO{
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{}, erros.NãoEncontrado
+				},
+				simulaCriar: func(c *clube) error {
+					return errors.Errorf("erro de criação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de criação"),
+		},
+	}
+
+	daoOriginal := novoClubeDAO
+	defer func() {
+		novoClubeDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoClubeDAO = func(sqlogger *bd.SQLogger) clubeDAO {
+			return cenário.clubeDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.CadastrarClube(cenário.clubePedido)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_ObterClube(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição    string
+		id           int64
+		clubeDAO     clubeDAO
+		esperado     protocolo.ClubeResposta
+		erroEsperado error
+	}{
+		{
+			descrição: "deve obter corretamente um clube",
+			id:        1,
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{
+						ID:          id,
+						CNPJ:        "11222333000181",
+						CR:          123456,
+						Nome:        "Clube de Tiro Centro",
+						Situação:    protocolo.ClubeSituaçãoInativo,
+						DataCriação: data,
+					}, nil
+				},
+			},
+			esperado: protocolo.ClubeResposta{
+				ID:          1,
+				CNPJ:        "11222333000181",
+				CR:          123456,
+				Nome:        "Clube de Tiro Centro",
+				Situação:    protocolo.ClubeSituaçãoInativo,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o clube não existe",
+			id:        1,
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+	}
+
+	daoOriginal := novoClubeDAO
+	defer func() {
+		novoClubeDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoClubeDAO = func(sqlogger *bd.SQLogger) clubeDAO {
+			return cenário.clubeDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ObterClube(cenário.id)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestServiço_AtualizarClube(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição           string
+		clubePedidoCompleto protocolo.ClubePedidoCompleto
+		clubeDAO            clubeDAO
+		esperado            protocolo.ClubeResposta
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve desativar corretamente um clube",
+			clubePedidoCompleto: protocolo.NovoClubePedidoCompleto(1, protocolo.ClubePedido{
+				CNPJ:     "11222333000181",
+				CR:       123456,
+				Nome:     "Clube de Tiro Centro",
+				Situação: protocolo.ClubeSituaçãoInativo,
+			}),
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{
+						ID:          id,
+						CNPJ:        "11222333000181",
+						CR:          123456,
+						Nome:        "Clube de Tiro",
+						Situação:    protocolo.ClubeSituaçãoAtivo,
+						DataCriação: data,
+					}, nil
+				},
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{ID: 1, CNPJ: cnpj}, nil
+				},
+				simulaAtualizar: func(c *clube) error {
+					c.DataAtualização = data
+					return nil
+				},
+			},
+			esperado: protocolo.ClubeResposta{
+				ID:              1,
+				CNPJ:            "11222333000181",
+				CR:              123456,
+				Nome:            "Clube de Tiro Centro",
+				Situação:        protocolo.ClubeSituaçãoInativo,
+				DataCriação:     data,
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando o novo CNPJ pertence a outro clube",
+			clubePedidoCompleto: protocolo.NovoClubePedidoCompleto(1, protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			}),
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{ID: id, CNPJ: "11444777000161"}, nil
+				},
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{ID: 2, CNPJ: cnpj}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+			},
+		},
+		{
+			descrição: "deve detectar quando o clube não existe",
+			clubePedidoCompleto: protocolo.NovoClubePedidoCompleto(1, protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			}),
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: erros.NãoEncontrado,
+		},
+		{
+			descrição: "deve detectar um erro ao atualizar o clube",
+			clubePedidoCompleto: protocolo.NovoClubePedidoCompleto(1, protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			}),
+			clubeDAO: simulaClubeDAO{
+				simulaResgatar: func(id int64) (clube, error) {
+					return clube{ID: id, CNPJ: "11222333000181"}, nil
+				},
+				simulaResgatarPorCNPJ: func(cnpj string) (clube, error) {
+					return clube{ID: 1, CNPJ: cnpj}, nil
+				},
+				simulaAtualizar: func(c *clube) error {
+					return errors.Errorf("erro de atualização")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de atualização"),
+		},
+	}
+
+	daoOriginal := novoClubeDAO
+	defer func() {
+		novoClubeDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoClubeDAO = func(sqlogger *bd.SQLogger) clubeDAO {
+			return cenário.clubeDAO
+		}
+
+		serviço := NovoServiço(nil, nil, config.Configuração{})
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.AtualizarClube(cenário.clubePedidoCompleto)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type simulaClubeDAO struct {
+	simulaCriar           func(*clube) error
+	simulaAtualizar       func(*clube) error
+	simulaResgatar        func(id int64) (clube, error)
+	simulaResgatarPorCNPJ func(cnpj string) (clube, error)
+}
+
+func (s simulaClubeDAO) criar(c *clube) error {
+	return s.simulaCriar(c)
+}
+
+func (s simulaClubeDAO) atualizar(c *clube) error {
+	return s.simulaAtualizar(c)
+}
+
+func (s simulaClubeDAO) resgatar(id int64) (clube, error) {
+	return s.simulaResgatar(id)
+}
+
+func (s simulaClubeDAO) resgatarPorCNPJ(cnpj string) (clube, error) {
+	return s.simulaResgatarPorCNPJ(cnpj)
+}
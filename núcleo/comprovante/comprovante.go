@@ -0,0 +1,204 @@
+// Package comprovante assina os dados canônicos da frequência com Ed25519,
+// permitindo que o comprovante impresso seja verificado sem acesso ao servidor
+// ou ao banco de dados, bastando a chave pública divulgada pelo serviço.
+package comprovante
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/registrobr/gostk/errors"
+)
+
+// Versão identifica o formato do conteúdo assinado, permitindo alterar os
+// campos no futuro sem invalidar os comprovantes já impressos.
+const Versão = "1"
+
+// ParâmetroURL nome do parâmetro utilizado para embutir o comprovante na URL
+// do QR Code.
+const ParâmetroURL = "comprovante"
+
+// separador divide a versão, o conteúdo e a assinatura do comprovante. O
+// caractere não faz parte do alfabeto base64 utilizado.
+const separador = "."
+
+// separadorCampos divide os campos do conteúdo assinado. Como o calibre pode
+// conter pontos, utilizamos um caractere que não aparece nos nomes canônicos.
+const separadorCampos = "|"
+
+// codificação base64 sem preenchimento e segura para URLs, reduzindo o tamanho
+// do QR Code.
+var codificação = base64.RawURLEncoding
+
+var (
+	// FormatoInválido erro utilizado quando o comprovante não possui a
+	// estrutura esperada.
+	FormatoInválido = errors.Errorf("formato do comprovante inválido")
+
+	// VersãoDesconhecida erro utilizado quando o comprovante foi gerado em um
+	// formato não suportado.
+	VersãoDesconhecida = errors.Errorf("versão do comprovante desconhecida")
+
+	// AssinaturaInválida erro utilizado quando a assinatura não confere com os
+	// dados do comprovante ou com a chave pública informada.
+	AssinaturaInválida = errors.Errorf("assinatura do comprovante inválida")
+)
+
+// Dados campos canônicos da frequência cobertos pela assinatura.
+type Dados struct {
+	ID          int64
+	CR          int
+	Controle    int64
+	Calibre     string
+	DataInício  time.Time
+	DataTérmino time.Time
+}
+
+// conteúdo monta a representação canônica dos dados. As datas são
+// representadas em segundos desde a época Unix, evitando diferenças de fuso
+// horário.
+func (d Dados) conteúdo() string {
+	return strings.Join([]string{
+		Versão,
+		strconv.FormatInt(d.ID, 10),
+		strconv.Itoa(d.CR),
+		strconv.FormatInt(d.Controle, 10),
+		d.Calibre,
+		strconv.FormatInt(d.DataInício.Unix(), 10),
+		strconv.FormatInt(d.DataTérmino.Unix(), 10),
+	}, separadorCampos)
+}
+
+// String descreve os dados do comprovante para exibição.
+func (d Dados) String() string {
+	return fmt.Sprintf("Frequência %d-%d do CR %d, calibre %s, treino de %s a %s (UTC)",
+		d.ID, d.Controle, d.CR, d.Calibre,
+		d.DataInício.UTC().Format("02/01/2006 15:04"), d.DataTérmino.UTC().Format("02/01/2006 15:04"))
+}
+
+// Assinar gera o comprovante compacto com os dados e a assinatura, no formato
+// "versão.dados.assinatura", com os dados e a assinatura em base64.
+func Assinar(chavePrivada ed25519.PrivateKey, dados Dados) (string, error) {
+	if len(chavePrivada) != ed25519.PrivateKeySize {
+		return "", errors.Errorf("chave privada de assinatura inválida")
+	}
+
+	conteúdo := dados.conteúdo()
+	if strings.Count(conteúdo, separadorCampos) != 6 {
+		return "", errors.Errorf("calibre “%s” não pode conter o caractere “%s”", dados.Calibre, separadorCampos)
+	}
+
+	assinatura := ed25519.Sign(chavePrivada, []byte(conteúdo))
+
+	return Versão + separador +
+		codificação.EncodeToString([]byte(conteúdo)) + separador +
+		codificação.EncodeToString(assinatura), nil
+}
+
+// Verificar valida o comprovante com a chave pública do serviço, retornando os
+// dados assinados. O comprovante pode ser informado diretamente ou através da
+// URL lida no QR Code.
+func Verificar(chavePública ed25519.PublicKey, comprovante string) (Dados, error) {
+	if len(chavePública) != ed25519.PublicKeySize {
+		return Dados{}, errors.Errorf("chave pública de assinatura inválida")
+	}
+
+	comprovante = extrairDaURL(strings.TrimSpace(comprovante))
+
+	partes := strings.Split(comprovante, separador)
+	if len(partes) != 3 {
+		return Dados{}, FormatoInválido
+	}
+
+	if partes[0] != Versão {
+		return Dados{}, VersãoDesconhecida
+	}
+
+	conteúdo, err := codificação.DecodeString(partes[1])
+	if err != nil {
+		return Dados{}, FormatoInválido
+	}
+
+	assinatura, err := codificação.DecodeString(partes[2])
+	if err != nil {
+		return Dados{}, FormatoInválido
+	}
+
+	if !ed25519.Verify(chavePública, conteúdo, assinatura) {
+		return Dados{}, AssinaturaInválida
+	}
+
+	return interpretarConteúdo(string(conteúdo))
+}
+
+// URL adiciona o comprovante como parâmetro da URL do QR Code.
+func URL(endereço, comprovante string) string {
+	if comprovante == "" {
+		return endereço
+	}
+
+	conector := "?"
+	if strings.Contains(endereço, "?") {
+		conector = "&"
+	}
+
+	return endereço + conector + ParâmetroURL + "=" + comprovante
+}
+
+// extrairDaURL obtém o comprovante do parâmetro da URL lida no QR Code. Quando
+// o texto não for uma URL com o parâmetro, o próprio texto é retornado.
+func extrairDaURL(texto string) string {
+	endereço, err := url.Parse(texto)
+	if err != nil || endereço.RawQuery == "" {
+		return texto
+	}
+
+	if comprovante := endereço.Query().Get(ParâmetroURL); comprovante != "" {
+		return comprovante
+	}
+
+	return texto
+}
+
+func interpretarConteúdo(conteúdo string) (Dados, error) {
+	campos := strings.Split(conteúdo, separadorCampos)
+	if len(campos) != 7 || campos[0] != Versão {
+		return Dados{}, FormatoInválido
+	}
+
+	var dados Dados
+	var err error
+
+	if dados.ID, err = strconv.ParseInt(campos[1], 10, 64); err != nil {
+		return Dados{}, FormatoInválido
+	}
+
+	if dados.CR, err = strconv.Atoi(campos[2]); err != nil {
+		return Dados{}, FormatoInválido
+	}
+
+	if dados.Controle, err = strconv.ParseInt(campos[3], 10, 64); err != nil {
+		return Dados{}, FormatoInválido
+	}
+
+	dados.Calibre = campos[4]
+
+	início, err := strconv.ParseInt(campos[5], 10, 64)
+	if err != nil {
+		return Dados{}, FormatoInválido
+	}
+	dados.DataInício = time.Unix(início, 0).UTC()
+
+	término, err := strconv.ParseInt(campos[6], 10, 64)
+	if err != nil {
+		return Dados{}, FormatoInválido
+	}
+	dados.DataTérmino = time.Unix(término, 0).UTC()
+
+	return dados, nil
+}
@@ -0,0 +1,154 @@
+package comprovante_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/comprovante"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestVerificar(t *testing.T) {
+	chavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+	chavePública := chavePrivada.Public().(ed25519.PublicKey)
+
+	outraChavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x02}, ed25519.SeedSize))
+	outraChavePública := outraChavePrivada.Public().(ed25519.PublicKey)
+
+	dados := comprovante.Dados{
+		ID:          7654,
+		CR:          123456789,
+		Controle:    918273645,
+		Calibre:     ".380 ACP",
+		DataInício:  time.Date(2017, time.March, 10, 14, 0, 0, 0, time.UTC),
+		DataTérmino: time.Date(2017, time.March, 10, 15, 30, 0, 0, time.UTC),
+	}
+
+	assinado, err := comprovante.Assinar(chavePrivada, dados)
+	if err != nil {
+		t.Fatalf("Erro ao assinar o comprovante. Detalhes: %s", err)
+	}
+
+	partes := strings.Split(assinado, ".")
+	if len(partes) != 3 {
+		t.Fatalf("Comprovante “%s” em formato inesperado", assinado)
+	}
+
+	// altera o conteúdo mantendo a assinatura original
+	adulterado, err := comprovante.Assinar(chavePrivada, comprovante.Dados{
+		ID:          dados.ID,
+		CR:          987654321,
+		Controle:    dados.Controle,
+		Calibre:     dados.Calibre,
+		DataInício:  dados.DataInício,
+		DataTérmino: dados.DataTérmino,
+	})
+	if err != nil {
+		t.Fatalf("Erro ao assinar o comprovante adulterado. Detalhes: %s", err)
+	}
+	adulterado = partes[0] + "." + strings.Split(adulterado, ".")[1] + "." + partes[2]
+
+	cenários := []struct {
+		descrição     string
+		chavePública  ed25519.PublicKey
+		comprovante   string
+		dadosEsperado comprovante.Dados
+		erroEsperado  error
+	}{
+		{
+			descrição:     "deve verificar corretamente um comprovante",
+			chavePública:  chavePública,
+			comprovante:   assinado,
+			dadosEsperado: dados,
+		},
+		{
+			descrição:     "deve verificar corretamente um comprovante lido do QR Code",
+			chavePública:  chavePública,
+			comprovante:   comprovante.URL("https://exemplo.com.br/frequencia/123456789/7654-918273645/verificacao?verificacao=abc", assinado),
+			dadosEsperado: dados,
+		},
+		{
+			descrição:    "deve detectar um comprovante assinado com outra chave",
+			chavePública: outraChavePública,
+			comprovante:  assinado,
+			erroEsperado: comprovante.AssinaturaInválida,
+		},
+		{
+			descrição:    "deve detectar um comprovante adulterado",
+			chavePública: chavePública,
+			comprovante:  adulterado,
+			erroEsperado: comprovante.AssinaturaInválida,
+		},
+		{
+			descrição:    "deve detectar um comprovante em formato inválido",
+			chavePública: chavePública,
+			comprovante:  "1." + partes[1],
+			erroEsperado: comprovante.FormatoInválido,
+		},
+		{
+			descrição:    "deve detectar um comprovante com base64 inválido",
+			chavePública: chavePública,
+			comprovante:  "1.%%%." + partes[2],
+			erroEsperado: comprovante.FormatoInválido,
+		},
+		{
+			descrição:    "deve detectar um comprovante de versão desconhecida",
+			chavePública: chavePública,
+			comprovante:  "2." + partes[1] + "." + partes[2],
+			erroEsperado: comprovante.VersãoDesconhecida,
+		},
+		{
+			descrição:    "deve detectar uma chave pública inválida",
+			chavePública: ed25519.PublicKey("curta"),
+			comprovante:  assinado,
+			erroEsperado: errors.Errorf("chave pública de assinatura inválida"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		dadosVerificados, err := comprovante.Verificar(cenário.chavePública, cenário.comprovante)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.dadosEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(dadosVerificados, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAssinar(t *testing.T) {
+	chavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+
+	cenários := []struct {
+		descrição    string
+		chavePrivada ed25519.PrivateKey
+		dados        comprovante.Dados
+		erroEsperado error
+	}{
+		{
+			descrição:    "deve detectar uma chave privada inválida",
+			chavePrivada: ed25519.PrivateKey("curta"),
+			erroEsperado: errors.Errorf("chave privada de assinatura inválida"),
+		},
+		{
+			descrição:    "deve detectar um calibre com o separador dos campos",
+			chavePrivada: chavePrivada,
+			dados:        comprovante.Dados{Calibre: ".380|ACP"},
+			erroEsperado: errors.Errorf("calibre “.380|ACP” não pode conter o caractere “|”"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		_, err := comprovante.Assinar(cenário.chavePrivada, cenário.dados)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -1,17 +1,25 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
 	"image"
 	_ "image/gif"  // adiciona o suporte para imagens GIF no image.Decode
 	_ "image/jpeg" // adiciona o suporte para imagens JPEG no image.Decode
 	_ "image/png"  // adiciona o suporte para imagens PNG no image.Decode
 	"io/ioutil"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/freetype/truetype"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/registrobr/gostk/errors"
 	"golang.org/x/image/font/gofont/goregular"
+	_ "golang.org/x/image/webp" // adiciona o suporte para imagens WebP no image.Decode
 )
 
 // Configuração define os valores configuráveis referentes a regras de negócio e
@@ -22,6 +30,50 @@ type Configuração struct {
 		// frequência a partir do momento de sua criação.
 		PrazoConfirmação time.Duration `yaml:"prazo confirmacao" envconfig:"prazo_confirmacao"`
 
+		// PrazoCancelamento define o tempo máximo permitido para que o clube
+		// cancele uma frequência ainda não confirmada a partir do momento de sua
+		// criação.
+		PrazoCancelamento time.Duration `yaml:"prazo cancelamento" envconfig:"prazo_cancelamento"`
+
+		// RemoverImagemExpirada define se a imagem do número de controle deve ser
+		// descartada quando a frequência expira sem confirmação, economizando
+		// espaço na base de dados.
+		RemoverImagemExpirada bool `yaml:"remover imagem expirada" envconfig:"remover_imagem_expirada"`
+
+		// DistânciaHashImagem define a distância de Hamming máxima entre o hash
+		// perceptual de uma imagem de confirmação e o das imagens já aceitas para
+		// que ela seja considerada reutilizada. Quanto maior o valor, mais
+		// tolerante a verificação fica a recortes e recompressões, porém aumentam
+		// os falsos positivos. Um valor negativo desabilita a verificação.
+		DistânciaHashImagem int `yaml:"distancia hash imagem" envconfig:"distancia_hash_imagem"`
+
+		// TolerânciaDataImagem define o tempo aceito antes do início e depois do
+		// término do treino para a data de captura registrada nos metadados EXIF
+		// da imagem de confirmação, absorvendo diferenças no relógio da câmera.
+		TolerânciaDataImagem time.Duration `yaml:"tolerancia data imagem" envconfig:"tolerancia_data_imagem"`
+
+		// FusoHorárioImagem define o fuso horário, no formato da base de dados
+		// IANA (exemplo: America/Sao_Paulo), utilizado para interpretar a data de
+		// captura registrada nos metadados EXIF da imagem de confirmação quando a
+		// câmera não informa o deslocamento em relação ao UTC.
+		FusoHorárioImagem fusoHorário `yaml:"fuso horario imagem" envconfig:"fuso_horario_imagem"`
+
+		// PolíticaDataImagem define o tratamento dado a uma imagem de confirmação
+		// capturada fora do período do treino. Os valores aceitos são "permitir",
+		// "sinalizar" e "rejeitar".
+		PolíticaDataImagem PolíticaImagem `yaml:"politica data imagem" envconfig:"politica_data_imagem"`
+
+		// PolíticaImagemSemEXIF define o tratamento dado a uma imagem de
+		// confirmação sem a data de captura nos metadados EXIF. Os valores aceitos
+		// são "permitir", "sinalizar" e "rejeitar".
+		PolíticaImagemSemEXIF PolíticaImagem `yaml:"politica imagem sem exif" envconfig:"politica_imagem_sem_exif"`
+
+		// RaioClube define a distância máxima, em metros, entre o local onde a
+		// imagem de confirmação foi capturada e o estande de tiro do clube. Imagens
+		// capturadas mais longe são sinalizadas para auditoria. Um valor zero
+		// desabilita a sinalização, mantendo somente o registro da distância.
+		RaioClube int `yaml:"raio clube" envconfig:"raio_clube"`
+
 		// TempoMáximoCadastro período máximo permitido para que um treino seja
 		// registrado.
 		TempoMáximoCadastro time.Duration `yaml:"tempo maximo cadastro" envconfig:"tempo_maximo_cadastro"`
@@ -31,18 +83,50 @@ type Configuração struct {
 
 		// ChaveCódigoVerificação armazena a chave simétrica utilizada para
 		// criptografar a frequência gerando o código de verificação, que garante
-		// a autencidade do documento.
+		// a autencidade do documento. É a chave legada, sem identificador,
+		// utilizada nos códigos gerados antes da rotação de chaves ou enquanto
+		// nenhuma chave ativa for definida.
 		//
 		// TODO(rafaeljusto): Criptografar esta chave na configuração.
 		ChaveCódigoVerificação string `yaml:"chave codigo verificacao" envconfig:"chave_codigo_verificacao"`
 
+		// ChavesCódigoVerificação armazena as chaves simétricas identificadas,
+		// permitindo a rotação da chave sem invalidar os documentos já impressos.
+		// O formato é uma lista separada por vírgulas de pares identificador e
+		// chave. O identificador aceita somente letras, números, hífen e
+		// sublinhado. Exemplo:
+		//
+		//     2017a:chave1,2017b:chave2
+		ChavesCódigoVerificação chavesVerificação `yaml:"chaves codigo verificacao" envconfig:"chaves_codigo_verificacao"`
+
+		// ArquivoChavesCódigoVerificação caminho de um arquivo com chaves
+		// identificadas adicionais, mantendo-as fora do arquivo de configuração.
+		// O arquivo possui um par identificador e chave por linha, no mesmo
+		// formato de ChavesCódigoVerificação.
+		ArquivoChavesCódigoVerificação arquivoChavesVerificação `yaml:"arquivo chaves codigo verificacao" envconfig:"arquivo_chaves_codigo_verificacao"`
+
+		// ChaveCódigoVerificaçãoAtiva identificador da chave utilizada nos novos
+		// códigos de verificação, que passam a conter este identificador. As
+		// demais chaves são consideradas aposentadas, sendo utilizadas somente na
+		// verificação dos códigos já gerados. Quando vazio a chave legada é
+		// utilizada.
+		ChaveCódigoVerificaçãoAtiva string `yaml:"chave codigo verificacao ativa" envconfig:"chave_codigo_verificacao_ativa"`
+
+		// ChaveAssinatura chave privada Ed25519 utilizada para assinar os dados
+		// canônicos da frequência, permitindo verificar o comprovante impresso
+		// sem acesso ao servidor. É informado o caminho de um arquivo PEM no
+		// formato PKCS #8, como o gerado por "openssl genpkey -algorithm
+		// ed25519". Quando não definida os comprovantes não são assinados.
+		ChaveAssinatura chaveAssinatura `yaml:"chave assinatura" envconfig:"chave_assinatura"`
+
 		// ImagemNúmeroControle define as propriedades para geração da imagem que
 		// contém o número de controle.
 		ImagemNúmeroControle struct {
-			// Fonte define as propriedades da fonte utilizada na imagem.
+			// Fonte define as propriedades da fonte utilizada na imagem e embutida
+			// no PDF.
 			Fonte fonteFamília `yaml:"fonte" envconfig:"fonte"`
 			// ImagemBase caminho para o arquivo que contém a imagem, são suportados
-			// os formatos: JPEG, PNG e GIF.
+			// os formatos: JPEG, PNG, GIF e WebP.
 			ImagemBase imagem `yaml:"imagem base" envconfig:"imagem_base"`
 			// URLQRCode define o endereço HTTP que será embutido no QRCode da imagem
 			// do número de controle. Esta URL deve possuir 3 posições de substituição
@@ -50,10 +134,83 @@ type Configuração struct {
 			// controle e o código de verificação. Exemplo de uma URL para o QRCode
 			// seria:
 			//
-			//     https://exemplo.com.br/frequencia/%s/%s?verificacao=%s
+			//     https://exemplo.com.br/frequencia/%s/%s/verificacao?verificacao=%s
 			URLQRCode string `yaml:"url qrcode" envconfig:"url_qrcode"`
+			// Layout caminho para o arquivo YAML que descreve a posição, o tamanho,
+			// a cor, o alinhamento e a largura máxima de cada dado escrito na imagem
+			// base, além da posição e do tamanho do QR Code. O arquivo é validado
+			// ao ser carregado. Quando não informado é utilizado o layout da imagem
+			// base distribuída junto ao projeto. Exemplo:
+			//
+			//     textos:
+			//       - campo: numero controle
+			//         x: 150
+			//         y: 495
+			//         tamanho: 18
+			//         cor: "#ff0000"
+			//         alinhamento: esquerda
+			//         largura maxima: 700
+			//       - campo: codigo verificacao
+			//         x: 32
+			//         y: 1035
+			//         tamanho: 11
+			//     qrcode:
+			//       x: 200
+			//       y: 600
+			//       tamanho: 300
+			//
+			// Os campos aceitos são: cr, calibre, periodo, arma, municao, numero
+			// controle e codigo verificacao, sendo os dois últimos obrigatórios.
+			Layout arquivoLayoutImagem `yaml:"layout" envconfig:"layout"`
 		} `yaml:"imagem numero controle" envconfig:"imagem_numero_controle"`
+
+		// ImagemConfirmação define como a imagem enviada na confirmação é
+		// normalizada antes de ser armazenada. Toda imagem é recodificada em JPEG,
+		// sem os metadados EXIF, que são mantidos separadamente.
+		ImagemConfirmação struct {
+			// ResoluçãoMáxima tamanho máximo, em pixels, do maior lado da imagem.
+			// Imagens maiores são reduzidas mantendo a proporção.
+			ResoluçãoMáxima int `yaml:"resolucao maxima" envconfig:"resolucao_maxima"`
+			// Qualidade define a qualidade da compressão JPEG, de 1 a 100.
+			Qualidade int `yaml:"qualidade" envconfig:"qualidade"`
+			// ResoluçãoMiniatura tamanho máximo, em pixels, do maior lado da
+			// miniatura gerada a partir da imagem.
+			ResoluçãoMiniatura int `yaml:"resolucao miniatura" envconfig:"resolucao_miniatura"`
+			// PixelsMáximo quantidade máxima de pixels (largura vezes altura) da
+			// imagem recebida. Imagens maiores são rejeitadas antes de serem
+			// decodificadas, evitando o consumo excessivo de memória. Um valor zero
+			// desabilita o limite.
+			PixelsMáximo int `yaml:"pixels maximo" envconfig:"pixels_maximo"`
+		} `yaml:"imagem confirmacao" envconfig:"imagem_confirmacao"`
+
+		// Habitualidade define a quantidade mínima de treinos confirmados por ano
+		// exigida de um atirador em cada nível de atividade. O formato é uma lista
+		// separada por vírgulas de pares nível e quantidade de treinos. Exemplo:
+		//
+		//     1:8,2:12,3:20
+		Habitualidade treinosPorNível `yaml:"habitualidade" envconfig:"habitualidade"`
+
+		// CotaMunição define a quantidade máxima de munição que um atirador pode
+		// consumir por ano em cada calibre, de acordo com a classe de uso do
+		// calibre. Classes sem cota definida não possuem limite. O formato é uma
+		// lista separada por vírgulas de pares classe e quantidade. Exemplo:
+		//
+		//     permitido:5000,restrito:1000
+		CotaMunição muniçãoPorClasse `yaml:"cota municao" envconfig:"cota_municao"`
 	} `yaml:"atirador" envconfig:"atirador"`
+
+	Autenticação struct {
+		// ChaveToken armazena a chave simétrica utilizada para assinar os tokens
+		// de acesso entregues aos usuários no login. Ao trocar esta chave todos os
+		// tokens emitidos anteriormente deixam de ser aceitos.
+		//
+		// TODO(rafaeljusto): Criptografar esta chave na configuração.
+		ChaveToken string `yaml:"chave token" envconfig:"chave_token"`
+
+		// DuraçãoToken tempo de validade de um token de acesso a partir do momento
+		// do login.
+		DuraçãoToken time.Duration `yaml:"duracao token" envconfig:"duracao_token"`
+	} `yaml:"autenticacao" envconfig:"autenticacao"`
 }
 
 // DefinirValoresPadrão utiliza valores padrão em todos os campos da
@@ -61,10 +218,99 @@ type Configuração struct {
 // sobrescrever somente alguns valores, mantendo os demais com valores padrão.
 func DefinirValoresPadrão(c *Configuração) {
 	c.Atirador.PrazoConfirmação = 30 * time.Minute
+	c.Atirador.PrazoCancelamento = time.Hour
 	c.Atirador.TempoMáximoCadastro = 12 * time.Hour
 	c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+	c.Atirador.DistânciaHashImagem = 5
+	c.Atirador.TolerânciaDataImagem = time.Hour
+	c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+	c.Atirador.PolíticaDataImagem = PolíticaImagemSinalizar
+	c.Atirador.PolíticaImagemSemEXIF = PolíticaImagemPermitir
+	c.Atirador.RaioClube = 1000
 	c.Atirador.ImagemNúmeroControle.Fonte.Font, _ = truetype.Parse(goregular.TTF)
-	c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+	c.Atirador.ImagemNúmeroControle.Fonte.TTF = goregular.TTF
+	c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+	c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+	c.Atirador.ImagemConfirmação.Qualidade = 85
+	c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+	c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+	c.Atirador.Habitualidade = treinosPorNível{1: 8, 2: 12, 3: 20}
+	c.Atirador.CotaMunição = muniçãoPorClasse{"permitido": 5000, "restrito": 1000}
+	c.Autenticação.DuraçãoToken = 8 * time.Hour
+}
+
+// ChaveVerificação retorna a chave do código de verificação com o identificador
+// informado, procurando primeiro nas chaves da configuração e depois no arquivo
+// de chaves. O identificador vazio representa a chave legada. Uma chave vazia
+// nunca é retornada, pois permitiria que qualquer um gerasse códigos de
+// verificação válidos.
+func (c Configuração) ChaveVerificação(id string) (string, bool) {
+	var chave string
+
+	if id == "" {
+		chave = c.Atirador.ChaveCódigoVerificação
+	} else if chaveConfiguração, ok := c.Atirador.ChavesCódigoVerificação[id]; ok {
+		chave = chaveConfiguração
+	} else {
+		chave = c.Atirador.ArquivoChavesCódigoVerificação.Chaves[id]
+	}
+
+	return chave, chave != ""
+}
+
+// Validar verifica a consistência entre os campos da configuração que só pode
+// ser analisada após o carregamento completo, evitando que um problema seja
+// descoberto somente durante o atendimento das requisições. Como a imagem base
+// e o layout do número de controle podem ser definidos em origens diferentes,
+// somente aqui é possível verificar se o layout cabe na imagem.
+func (c Configuração) Validar() error {
+	if _, ok := c.ChaveVerificação(c.Atirador.ChaveCódigoVerificaçãoAtiva); !ok {
+		return errors.Errorf("chave ativa do código de verificação “%s” não definida", c.Atirador.ChaveCódigoVerificaçãoAtiva)
+	}
+
+	if imagemBase := c.Atirador.ImagemNúmeroControle.ImagemBase.Image; imagemBase != nil {
+		layout := c.Atirador.ImagemNúmeroControle.Layout.LayoutImagem
+		if layout.Vazio() {
+			layout = LayoutImagemPadrão()
+		}
+
+		if err := layout.validarLimites(imagemBase.Bounds()); err != nil {
+			return erros.Novo(err)
+		}
+	}
+
+	return nil
+}
+
+type chaveAssinatura struct {
+	ed25519.PrivateKey
+}
+
+// UnmarshalText carrega um arquivo PEM com a chave privada Ed25519 no formato
+// PKCS #8.
+func (c *chaveAssinatura) UnmarshalText(texto []byte) error {
+	conteúdo, err := ioutil.ReadFile(string(texto))
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	bloco, _ := pem.Decode(conteúdo)
+	if bloco == nil {
+		return errors.Errorf("arquivo da chave de assinatura não está no formato PEM")
+	}
+
+	chave, err := x509.ParsePKCS8PrivateKey(bloco.Bytes)
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	chavePrivada, ok := chave.(ed25519.PrivateKey)
+	if !ok {
+		return errors.Errorf("chave de assinatura não é do tipo Ed25519")
+	}
+
+	c.PrivateKey = chavePrivada
+	return nil
 }
 
 type imagem struct {
@@ -72,7 +318,7 @@ type imagem struct {
 }
 
 // UnmarshalText carrega um arquivo de imagem. Os formatos suportados são JPEG,
-// PNG e GIF.
+// PNG, GIF e WebP.
 func (i *imagem) UnmarshalText(texto []byte) error {
 	arquivoImagem, err := os.Open(string(texto))
 	if err != nil {
@@ -87,8 +333,58 @@ func (i *imagem) UnmarshalText(texto []byte) error {
 	return nil
 }
 
+type fusoHorário struct {
+	*time.Location
+}
+
+// UnmarshalText carrega o fuso horário a partir do seu nome na base de dados
+// IANA.
+func (f *fusoHorário) UnmarshalText(texto []byte) error {
+	localização, err := time.LoadLocation(strings.TrimSpace(string(texto)))
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	f.Location = localização
+	return nil
+}
+
+// PolíticaImagem define o tratamento dado a uma imagem de confirmação que não
+// atende a uma das verificações de metadados.
+type PolíticaImagem string
+
+const (
+	// PolíticaImagemPermitir aceita a imagem sem nenhuma marcação.
+	PolíticaImagemPermitir PolíticaImagem = "permitir"
+
+	// PolíticaImagemSinalizar aceita a imagem, porém registra na frequência o
+	// motivo da suspeita para análise posterior.
+	PolíticaImagemSinalizar PolíticaImagem = "sinalizar"
+
+	// PolíticaImagemRejeitar recusa a confirmação da frequência.
+	PolíticaImagemRejeitar PolíticaImagem = "rejeitar"
+)
+
+// UnmarshalText interpreta a política, aceitando somente os valores
+// conhecidos.
+func (p *PolíticaImagem) UnmarshalText(texto []byte) error {
+	política := PolíticaImagem(strings.ToLower(strings.TrimSpace(string(texto))))
+
+	switch política {
+	case PolíticaImagemPermitir, PolíticaImagemSinalizar, PolíticaImagemRejeitar:
+		*p = política
+		return nil
+	}
+
+	return errors.Errorf("política de imagem inválida “%s”", string(texto))
+}
+
 type fonteFamília struct {
 	*truetype.Font
+
+	// TTF conteúdo original do arquivo da fonte, necessário para embuti-la nos
+	// documentos PDF.
+	TTF []byte
 }
 
 // UnmarshalText carrega um arquivo de fonte TTF.
@@ -102,5 +398,145 @@ func (f *fonteFamília) UnmarshalText(texto []byte) error {
 		return erros.Novo(err)
 	}
 
+	f.TTF = fonte
+	return nil
+}
+
+type treinosPorNível map[int]int
+
+// UnmarshalText interpreta a lista de pares nível e quantidade mínima de
+// treinos, no formato "nível:treinos" separados por vírgula. Os valores
+// anteriores são descartados.
+func (t *treinosPorNível) UnmarshalText(texto []byte) error {
+	treinos := make(treinosPorNível)
+
+	for _, par := range strings.Split(string(texto), ",") {
+		par = strings.TrimSpace(par)
+		if par == "" {
+			continue
+		}
+
+		partes := strings.Split(par, ":")
+		if len(partes) != 2 {
+			return errors.Errorf("formato inválido para o nível de habitualidade “%s”", par)
+		}
+
+		nível, err := strconv.Atoi(strings.TrimSpace(partes[0]))
+		if err != nil {
+			return erros.Novo(err)
+		}
+
+		quantidade, err := strconv.Atoi(strings.TrimSpace(partes[1]))
+		if err != nil {
+			return erros.Novo(err)
+		}
+
+		if nível <= 0 || quantidade < 0 {
+			return errors.Errorf("valores inválidos para o nível de habitualidade “%s”", par)
+		}
+
+		treinos[nível] = quantidade
+	}
+
+	*t = treinos
+	return nil
+}
+
+type muniçãoPorClasse map[string]int
+
+// UnmarshalText interpreta a lista de pares classe de calibre e quantidade
+// máxima anual de munição, no formato "classe:quantidade" separados por
+// vírgula. Os valores anteriores são descartados.
+func (m *muniçãoPorClasse) UnmarshalText(texto []byte) error {
+	cotas := make(muniçãoPorClasse)
+
+	for _, par := range strings.Split(string(texto), ",") {
+		par = strings.TrimSpace(par)
+		if par == "" {
+			continue
+		}
+
+		partes := strings.Split(par, ":")
+		if len(partes) != 2 {
+			return errors.Errorf("formato inválido para a cota de munição “%s”", par)
+		}
+
+		classe := strings.ToLower(strings.TrimSpace(partes[0]))
+
+		quantidade, err := strconv.Atoi(strings.TrimSpace(partes[1]))
+		if err != nil {
+			return erros.Novo(err)
+		}
+
+		if classe == "" || quantidade < 0 {
+			return errors.Errorf("valores inválidos para a cota de munição “%s”", par)
+		}
+
+		cotas[classe] = quantidade
+	}
+
+	*m = cotas
+	return nil
+}
+
+// identificadorChaveVerificação formato aceito para os identificadores das
+// chaves, que não podem conter o separador utilizado no código de verificação.
+var identificadorChaveVerificação = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+type chavesVerificação map[string]string
+
+// UnmarshalText interpreta a lista de pares identificador e chave, no formato
+// "identificador:chave" separados por vírgula ou quebra de linha. Os valores
+// anteriores são descartados.
+func (c *chavesVerificação) UnmarshalText(texto []byte) error {
+	chaves := make(chavesVerificação)
+
+	separador := func(r rune) bool {
+		return r == ',' || r == '\n'
+	}
+
+	for _, par := range strings.FieldsFunc(string(texto), separador) {
+		par = strings.TrimSpace(par)
+		if par == "" {
+			continue
+		}
+
+		// a chave pode conter o símbolo ":", então somente a primeira ocorrência
+		// separa o identificador
+		partes := strings.SplitN(par, ":", 2)
+		if len(partes) != 2 {
+			return errors.Errorf("formato inválido para uma das chaves do código de verificação")
+		}
+
+		id := strings.TrimSpace(partes[0])
+		chave := strings.TrimSpace(partes[1])
+
+		if !identificadorChaveVerificação.MatchString(id) || chave == "" {
+			return errors.Errorf("valores inválidos para a chave do código de verificação “%s”", id)
+		}
+
+		chaves[id] = chave
+	}
+
+	*c = chaves
+	return nil
+}
+
+type arquivoChavesVerificação struct {
+	Chaves chavesVerificação
+}
+
+// UnmarshalText carrega um arquivo com as chaves do código de verificação, com
+// um par identificador e chave por linha.
+func (a *arquivoChavesVerificação) UnmarshalText(texto []byte) error {
+	conteúdo, err := ioutil.ReadFile(string(texto))
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	if err := a.Chaves.UnmarshalText(conteúdo); err != nil {
+		return erros.Novo(err)
+	}
+
 	return nil
 }
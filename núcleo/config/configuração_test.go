@@ -1,8 +1,14 @@
 package config_test
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"image"
+	"image/color"
 	"io/ioutil"
 	"os"
 	"reflect"
@@ -45,6 +51,72 @@ func TestConfiguração_yaml(t *testing.T) {
 	arquivoImagemBase.Write(imagemBaseExtraída)
 	arquivoImagemBase.Close()
 
+	arquivoChaves, err := ioutil.TempFile("", "teste-nucleo-config-")
+	if err != nil {
+		t.Fatalf("Erro gerar o arquivo de chaves. Detalhes: %s", err)
+	}
+
+	arquivoChaves.WriteString("2016a:chave0\n2016b:chave1\n")
+	arquivoChaves.Close()
+
+	arquivoChaveAssinatura, chaveAssinatura := gerarArquivoChaveAssinatura(t)
+
+	arquivoLayout := gerarArquivoLayout(t, `
+textos:
+  - campo: cr
+    x: 100
+    y: 200
+    tamanho: 12
+    cor: "#336699"
+    alinhamento: direita
+    largura maxima: 300
+  - campo: numero controle
+    x: 150
+    y: 495
+    tamanho: 18
+  - campo: codigo verificacao
+    x: 32
+    y: 1035
+    tamanho: 11
+    cor: "ff0000"
+    alinhamento: centro
+qrcode:
+  x: 200
+  y: 600
+  tamanho: 300
+`)
+
+	layoutEsperado := config.LayoutImagem{
+		Textos: []config.TextoLayout{
+			{
+				Campo:         config.CampoImagemCR,
+				X:             100,
+				Y:             200,
+				Tamanho:       12,
+				Cor:           config.Cor{RGBA: color.RGBA{0x33, 0x66, 0x99, 0xff}},
+				Alinhamento:   config.AlinhamentoTextoDireita,
+				LarguraMáxima: 300,
+			},
+			{
+				Campo:       config.CampoImagemNúmeroControle,
+				X:           150,
+				Y:           495,
+				Tamanho:     18,
+				Cor:         config.Cor{RGBA: color.RGBA{0x00, 0x00, 0x00, 0xff}},
+				Alinhamento: config.AlinhamentoTextoEsquerda,
+			},
+			{
+				Campo:       config.CampoImagemCódigoVerificação,
+				X:           32,
+				Y:           1035,
+				Tamanho:     11,
+				Cor:         config.Cor{RGBA: color.RGBA{0xff, 0x00, 0x00, 0xff}},
+				Alinhamento: config.AlinhamentoTextoCentro,
+			},
+		},
+		QRCode: config.QRCodeLayout{X: 200, Y: 600, Tamanho: 300},
+	}
+
 	cenários := []struct {
 		descrição            string
 		conteúdoArquivo      string
@@ -58,23 +130,67 @@ func TestConfiguração_yaml(t *testing.T) {
 			conteúdoArquivo: `
 atirador:
   prazo confirmacao: 30m
+  prazo cancelamento: 2h
+  remover imagem expirada: true
+  distancia hash imagem: 8
+  tolerancia data imagem: 2h
+  fuso horario imagem: America/Recife
+  politica data imagem: rejeitar
+  politica imagem sem exif: sinalizar
+  raio clube: 500
   tempo maximo cadastro: 12h
   duracao maxima treino: 12h
   chave codigo verificacao: abc123
+  chaves codigo verificacao: 2017a:chave2, 2017b:chave:3
+  arquivo chaves codigo verificacao: ` + arquivoChaves.Name() + `
+  chave codigo verificacao ativa: 2017b
+  chave assinatura: ` + arquivoChaveAssinatura + `
   imagem numero controle:
     fonte: ` + arquivoFonte.Name() + `
     imagem base: ` + arquivoImagemBase.Name() + `
     url qrcode: https://exemplo.com.br/frequencia/%s/%s?verificacao=%s
+    layout: ` + arquivoLayout + `
+  imagem confirmacao:
+    resolucao maxima: 1280
+    qualidade: 75
+    resolucao miniatura: 160
+    pixels maximo: 20000000
+  habitualidade: 1:6, 2:10
+  cota municao: permitido:3000, restrito:600
+autenticacao:
+  chave token: xyz789
+  duracao token: 2h
 `,
 			deveConterFonte:      true,
 			deveConterImagemBase: true,
 			configuraçãoEsperada: func() config.Configuração {
 				var configuração config.Configuração
 				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				configuração.Atirador.PrazoCancelamento = 2 * time.Hour
+				configuração.Atirador.RemoverImagemExpirada = true
+				configuração.Atirador.DistânciaHashImagem = 8
+				configuração.Atirador.TolerânciaDataImagem = 2 * time.Hour
+				configuração.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Recife")
+				configuração.Atirador.PolíticaDataImagem = config.PolíticaImagemRejeitar
+				configuração.Atirador.PolíticaImagemSemEXIF = config.PolíticaImagemSinalizar
+				configuração.Atirador.RaioClube = 500
 				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
 				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave2", "2017b": "chave:3"}
+				configuração.Atirador.ArquivoChavesCódigoVerificação.Chaves = map[string]string{"2016a": "chave0", "2016b": "chave1"}
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				configuração.Atirador.ChaveAssinatura.PrivateKey = chaveAssinatura
 				configuração.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				configuração.Atirador.ImagemNúmeroControle.Layout.LayoutImagem = layoutEsperado
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1280
+				configuração.Atirador.ImagemConfirmação.Qualidade = 75
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 160
+				configuração.Atirador.ImagemConfirmação.PixelsMáximo = 20000000
+				configuração.Atirador.Habitualidade = map[int]int{1: 6, 2: 10}
+				configuração.Atirador.CotaMunição = map[string]int{"permitido": 3000, "restrito": 600}
+				configuração.Autenticação.ChaveToken = "xyz789"
+				configuração.Autenticação.DuraçãoToken = 2 * time.Hour
 				return configuração
 			}(),
 		},
@@ -207,13 +323,285 @@ atirador:
 			}(),
 			erroEsperado: errors.Errorf("image: unknown format"),
 		},
+		{
+			descrição: "deve detectar quando a habitualidade esta em um formato inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  habitualidade: 1-8
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("formato inválido para o nível de habitualidade “1-8”"),
+		},
+		{
+			descrição: "deve detectar quando a cota de munição esta em um formato inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  cota municao: permitido=3000
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("formato inválido para a cota de munição “permitido=3000”"),
+		},
+		{
+			descrição: "deve detectar quando a política de imagem é desconhecida",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  politica data imagem: ignorar
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("política de imagem inválida “ignorar”"),
+		},
+		{
+			descrição: "deve detectar quando o fuso horário da imagem é desconhecido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  fuso horario imagem: America/Atlantida
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("unknown time zone America/Atlantida"),
+		},
+		{
+			descrição: "deve detectar quando as chaves do código de verificação estão em um formato inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  chaves codigo verificacao: 2017a=chave1
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("formato inválido para uma das chaves do código de verificação"),
+		},
+		{
+			descrição: "deve detectar quando o identificador da chave do código de verificação é inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  chaves codigo verificacao: 2017.a:chave1
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("valores inválidos para a chave do código de verificação “2017.a”"),
+		},
+		{
+			descrição: "deve detectar quando a chave de assinatura não está no formato PEM",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  chave assinatura: ` + arquivoQualquer.Name() + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("arquivo da chave de assinatura não está no formato PEM"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem possui um campo desconhecido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: nome
+    x: 10
+    y: 10
+    tamanho: 11
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("campo desconhecido no layout da imagem “nome”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem não possui um campo obrigatório",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: numero controle
+    x: 150
+    y: 495
+    tamanho: 18
+qrcode:
+  x: 200
+  y: 600
+  tamanho: 300
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("campo obrigatório ausente no layout da imagem “codigo verificacao”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem possui um campo repetido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: cr
+    x: 260
+    y: 252
+    tamanho: 11
+  - campo: cr
+    x: 260
+    y: 285
+    tamanho: 11
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("campo repetido no layout da imagem “cr”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem possui um tamanho inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: calibre
+    x: 260
+    y: 285
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("valores inválidos para o campo do layout da imagem “calibre”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem possui uma cor inválida",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: cr
+    x: 260
+    y: 252
+    tamanho: 11
+    cor: "#zz0000"
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("cor inválida “#zz0000”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem possui um alinhamento inválido",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: cr
+    x: 260
+    y: 252
+    tamanho: 11
+    alinhamento: justificado
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("alinhamento de texto inválido “justificado”"),
+		},
+		{
+			descrição: "deve detectar quando o layout da imagem não define o QR Code",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  imagem numero controle:
+    layout: ` + gerarArquivoLayout(t, `
+textos:
+  - campo: numero controle
+    x: 150
+    y: 495
+    tamanho: 18
+  - campo: codigo verificacao
+    x: 32
+    y: 1035
+    tamanho: 11
+`) + `
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("valores inválidos para o QR Code do layout da imagem"),
+		},
+		{
+			descrição: "deve detectar quando o arquivo de chaves do código de verificação não existe",
+			conteúdoArquivo: `
+atirador:
+  prazo confirmacao: 30m
+  arquivo chaves codigo verificacao: /tmp/eu-nao-existo-atirador-frequente.txt
+`,
+			configuraçãoEsperada: func() config.Configuração {
+				var configuração config.Configuração
+				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				return configuração
+			}(),
+			erroEsperado: errors.Errorf("open /tmp/eu-nao-existo-atirador-frequente.txt: no such file or directory"),
+		},
 	}
 
 	for i, cenário := range cenários {
 		var configuração config.Configuração
 		err := yaml.Unmarshal([]byte(cenário.conteúdoArquivo), &configuração)
 
-		if cenário.deveConterFonte && configuração.Atirador.ImagemNúmeroControle.Fonte.Font == nil {
+		if cenário.deveConterFonte && (configuração.Atirador.ImagemNúmeroControle.Fonte.Font == nil || len(configuração.Atirador.ImagemNúmeroControle.Fonte.TTF) == 0) {
 			t.Errorf("Item %d, “%s”: fonte não foi carregada corretamente",
 				i, cenário.descrição)
 		}
@@ -226,6 +614,7 @@ atirador:
 		// a comparação de fonte e imagem consome muita memória, portanto nos
 		// restringimos a uma verificação simples
 		configuração.Atirador.ImagemNúmeroControle.Fonte.Font = nil
+		configuração.Atirador.ImagemNúmeroControle.Fonte.TTF = nil
 		configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = nil
 
 		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
@@ -264,6 +653,72 @@ func TestConfiguração_variáveisDeAmbiente(t *testing.T) {
 	arquivoImagemBase.Write(imagemBaseExtraída)
 	arquivoImagemBase.Close()
 
+	arquivoChaves, err := ioutil.TempFile("", "teste-nucleo-config-")
+	if err != nil {
+		t.Fatalf("Erro gerar o arquivo de chaves. Detalhes: %s", err)
+	}
+
+	arquivoChaves.WriteString("2016a:chave0\n2016b:chave1\n")
+	arquivoChaves.Close()
+
+	arquivoChaveAssinatura, chaveAssinatura := gerarArquivoChaveAssinatura(t)
+
+	arquivoLayout := gerarArquivoLayout(t, `
+textos:
+  - campo: cr
+    x: 100
+    y: 200
+    tamanho: 12
+    cor: "#336699"
+    alinhamento: direita
+    largura maxima: 300
+  - campo: numero controle
+    x: 150
+    y: 495
+    tamanho: 18
+  - campo: codigo verificacao
+    x: 32
+    y: 1035
+    tamanho: 11
+    cor: "ff0000"
+    alinhamento: centro
+qrcode:
+  x: 200
+  y: 600
+  tamanho: 300
+`)
+
+	layoutEsperado := config.LayoutImagem{
+		Textos: []config.TextoLayout{
+			{
+				Campo:         config.CampoImagemCR,
+				X:             100,
+				Y:             200,
+				Tamanho:       12,
+				Cor:           config.Cor{RGBA: color.RGBA{0x33, 0x66, 0x99, 0xff}},
+				Alinhamento:   config.AlinhamentoTextoDireita,
+				LarguraMáxima: 300,
+			},
+			{
+				Campo:       config.CampoImagemNúmeroControle,
+				X:           150,
+				Y:           495,
+				Tamanho:     18,
+				Cor:         config.Cor{RGBA: color.RGBA{0x00, 0x00, 0x00, 0xff}},
+				Alinhamento: config.AlinhamentoTextoEsquerda,
+			},
+			{
+				Campo:       config.CampoImagemCódigoVerificação,
+				X:           32,
+				Y:           1035,
+				Tamanho:     11,
+				Cor:         config.Cor{RGBA: color.RGBA{0xff, 0x00, 0x00, 0xff}},
+				Alinhamento: config.AlinhamentoTextoCentro,
+			},
+		},
+		QRCode: config.QRCodeLayout{X: 200, Y: 600, Tamanho: 300},
+	}
+
 	cenários := []struct {
 		descrição            string
 		variáveisAmbiente    map[string]string
@@ -275,23 +730,65 @@ func TestConfiguração_variáveisDeAmbiente(t *testing.T) {
 		{
 			descrição: "deve carregar a configuração corretamente",
 			variáveisAmbiente: map[string]string{
-				"AF_ATIRADOR_PRAZO_CONFIRMACAO":                  "30m",
-				"AF_ATIRADOR_TEMPO_MAXIMO_CADASTRO":              "12h",
-				"AF_ATIRADOR_DURACAO_MAXIMA_TREINO":              "12h",
-				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO":           "abc123",
-				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_FONTE":       arquivoFonte.Name(),
-				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_IMAGEM_BASE": arquivoImagemBase.Name(),
-				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_URL_QRCODE":  "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s",
+				"AF_ATIRADOR_PRAZO_CONFIRMACAO":                      "30m",
+				"AF_ATIRADOR_PRAZO_CANCELAMENTO":                     "2h",
+				"AF_ATIRADOR_REMOVER_IMAGEM_EXPIRADA":                "true",
+				"AF_ATIRADOR_DISTANCIA_HASH_IMAGEM":                  "8",
+				"AF_ATIRADOR_TOLERANCIA_DATA_IMAGEM":                 "2h",
+				"AF_ATIRADOR_FUSO_HORARIO_IMAGEM":                    "America/Recife",
+				"AF_ATIRADOR_POLITICA_DATA_IMAGEM":                   "rejeitar",
+				"AF_ATIRADOR_POLITICA_IMAGEM_SEM_EXIF":               "sinalizar",
+				"AF_ATIRADOR_RAIO_CLUBE":                             "500",
+				"AF_ATIRADOR_TEMPO_MAXIMO_CADASTRO":                  "12h",
+				"AF_ATIRADOR_DURACAO_MAXIMA_TREINO":                  "12h",
+				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO":               "abc123",
+				"AF_ATIRADOR_CHAVES_CODIGO_VERIFICACAO":              "2017a:chave2,2017b:chave:3",
+				"AF_ATIRADOR_ARQUIVO_CHAVES_CODIGO_VERIFICACAO":      arquivoChaves.Name(),
+				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO_ATIVA":         "2017b",
+				"AF_ATIRADOR_CHAVE_ASSINATURA":                       arquivoChaveAssinatura,
+				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_FONTE":           arquivoFonte.Name(),
+				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_IMAGEM_BASE":     arquivoImagemBase.Name(),
+				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_URL_QRCODE":      "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s",
+				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_LAYOUT":          arquivoLayout,
+				"AF_ATIRADOR_IMAGEM_CONFIRMACAO_RESOLUCAO_MAXIMA":    "1280",
+				"AF_ATIRADOR_IMAGEM_CONFIRMACAO_QUALIDADE":           "75",
+				"AF_ATIRADOR_IMAGEM_CONFIRMACAO_RESOLUCAO_MINIATURA": "160",
+				"AF_ATIRADOR_IMAGEM_CONFIRMACAO_PIXELS_MAXIMO":       "20000000",
+				"AF_ATIRADOR_HABITUALIDADE":                          "1:6,2:10",
+				"AF_ATIRADOR_COTA_MUNICAO":                           "permitido:3000,restrito:600",
+				"AF_AUTENTICACAO_CHAVE_TOKEN":                        "xyz789",
+				"AF_AUTENTICACAO_DURACAO_TOKEN":                      "2h",
 			},
 			deveConterFonte:      true,
 			deveConterImagemBase: true,
 			configuraçãoEsperada: func() config.Configuração {
 				var configuração config.Configuração
 				configuração.Atirador.PrazoConfirmação = 30 * time.Minute
+				configuração.Atirador.PrazoCancelamento = 2 * time.Hour
+				configuração.Atirador.RemoverImagemExpirada = true
+				configuração.Atirador.DistânciaHashImagem = 8
+				configuração.Atirador.TolerânciaDataImagem = 2 * time.Hour
+				configuração.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Recife")
+				configuração.Atirador.PolíticaDataImagem = config.PolíticaImagemRejeitar
+				configuração.Atirador.PolíticaImagemSemEXIF = config.PolíticaImagemSinalizar
+				configuração.Atirador.RaioClube = 500
 				configuração.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				configuração.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
 				configuração.Atirador.ChaveCódigoVerificação = "abc123"
+				configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave2", "2017b": "chave:3"}
+				configuração.Atirador.ArquivoChavesCódigoVerificação.Chaves = map[string]string{"2016a": "chave0", "2016b": "chave1"}
+				configuração.Atirador.ChaveCódigoVerificaçãoAtiva = "2017b"
+				configuração.Atirador.ChaveAssinatura.PrivateKey = chaveAssinatura
 				configuração.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				configuração.Atirador.ImagemNúmeroControle.Layout.LayoutImagem = layoutEsperado
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1280
+				configuração.Atirador.ImagemConfirmação.Qualidade = 75
+				configuração.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 160
+				configuração.Atirador.ImagemConfirmação.PixelsMáximo = 20000000
+				configuração.Atirador.Habitualidade = map[int]int{1: 6, 2: 10}
+				configuração.Atirador.CotaMunição = map[string]int{"permitido": 3000, "restrito": 600}
+				configuração.Autenticação.ChaveToken = "xyz789"
+				configuração.Autenticação.DuraçãoToken = 2 * time.Hour
 				return configuração
 			}(),
 		},
@@ -421,7 +918,7 @@ func TestConfiguração_variáveisDeAmbiente(t *testing.T) {
 			err = erroEspecífico.Err
 		}
 
-		if cenário.deveConterFonte && configuração.Atirador.ImagemNúmeroControle.Fonte.Font == nil {
+		if cenário.deveConterFonte && (configuração.Atirador.ImagemNúmeroControle.Fonte.Font == nil || len(configuração.Atirador.ImagemNúmeroControle.Fonte.TTF) == 0) {
 			t.Errorf("Item %d, “%s”: fonte não foi carregada corretamente",
 				i, cenário.descrição)
 		}
@@ -434,6 +931,7 @@ func TestConfiguração_variáveisDeAmbiente(t *testing.T) {
 		// a comparação de fonte e imagem consome muita memória, portanto nos
 		// restringimos a uma verificação simples
 		configuração.Atirador.ImagemNúmeroControle.Fonte.Font = nil
+		configuração.Atirador.ImagemNúmeroControle.Fonte.TTF = nil
 		configuração.Atirador.ImagemNúmeroControle.ImagemBase.Image = nil
 
 		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
@@ -449,7 +947,21 @@ func TestDefinirValoresPadrão(t *testing.T) {
 	esperado.Atirador.PrazoConfirmação = 30 * time.Minute
 	esperado.Atirador.TempoMáximoCadastro = 12 * time.Hour
 	esperado.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-	esperado.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+	esperado.Atirador.DistânciaHashImagem = 5
+	esperado.Atirador.TolerânciaDataImagem = time.Hour
+	esperado.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+	esperado.Atirador.PolíticaDataImagem = config.PolíticaImagemSinalizar
+	esperado.Atirador.PolíticaImagemSemEXIF = config.PolíticaImagemPermitir
+	esperado.Atirador.RaioClube = 1000
+	esperado.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+	esperado.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+	esperado.Atirador.ImagemConfirmação.Qualidade = 85
+	esperado.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+	esperado.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+	esperado.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+	esperado.Atirador.PrazoCancelamento = time.Hour
+	esperado.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+	esperado.Autenticação.DuraçãoToken = 8 * time.Hour
 
 	var c config.Configuração
 	config.DefinirValoresPadrão(&c)
@@ -457,12 +969,210 @@ func TestDefinirValoresPadrão(t *testing.T) {
 	// a comparação de fonte e imagem consome muita memória, portanto nos
 	// restringimos a uma verificação simples
 	c.Atirador.ImagemNúmeroControle.Fonte.Font = nil
+	c.Atirador.ImagemNúmeroControle.Fonte.TTF = nil
 
 	if !reflect.DeepEqual(c, esperado) {
 		t.Errorf("Resultados não batem.\n%s", testes.Diff(esperado, c))
 	}
 }
 
+func TestConfiguração_ChaveVerificação(t *testing.T) {
+	var configuração config.Configuração
+	configuração.Atirador.ChaveCódigoVerificação = "abc123"
+	configuração.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave1", "2014a": ""}
+	configuração.Atirador.ArquivoChavesCódigoVerificação.Chaves = map[string]string{"2016a": "chave0"}
+
+	type resultado struct {
+		Chave      string
+		Encontrada bool
+	}
+
+	cenários := []struct {
+		descrição         string
+		id                string
+		resultadoEsperado resultado
+	}{
+		{
+			descrição:         "deve retornar a chave legada quando o identificador é vazio",
+			resultadoEsperado: resultado{Chave: "abc123", Encontrada: true},
+		},
+		{
+			descrição:         "deve retornar a chave definida na configuração",
+			id:                "2017a",
+			resultadoEsperado: resultado{Chave: "chave1", Encontrada: true},
+		},
+		{
+			descrição:         "deve retornar a chave definida no arquivo de chaves",
+			id:                "2016a",
+			resultadoEsperado: resultado{Chave: "chave0", Encontrada: true},
+		},
+		{
+			descrição: "deve detectar uma chave desconhecida",
+			id:        "2015a",
+		},
+		{
+			descrição: "deve detectar uma chave vazia",
+			id:        "2014a",
+		},
+	}
+
+	for i, cenário := range cenários {
+		var r resultado
+		r.Chave, r.Encontrada = configuração.ChaveVerificação(cenário.id)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.resultadoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(r, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestConfiguração_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição    string
+		configuração func() config.Configuração
+		erroEsperado error
+	}{
+		{
+			descrição: "deve aceitar a chave legada como chave ativa",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				return c
+			},
+		},
+		{
+			descrição: "deve aceitar uma chave identificada como chave ativa",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave1"}
+				c.Atirador.ChaveCódigoVerificaçãoAtiva = "2017a"
+				return c
+			},
+		},
+		{
+			descrição: "deve detectar quando a chave legada ativa está vazia",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChavesCódigoVerificação = map[string]string{"2017a": "chave1"}
+				return c
+			},
+			erroEsperado: errors.Errorf("chave ativa do código de verificação “” não definida"),
+		},
+		{
+			descrição: "deve detectar quando a chave ativa não existe",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				c.Atirador.ChaveCódigoVerificaçãoAtiva = "2018a"
+				return c
+			},
+			erroEsperado: errors.Errorf("chave ativa do código de verificação “2018a” não definida"),
+		},
+		{
+			descrição: "deve aceitar o layout padrão dentro da imagem base",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				c.Atirador.ImagemNúmeroControle.ImagemBase.Image = image.NewRGBA(image.Rect(0, 0, 800, 1100))
+				return c
+			},
+		},
+		{
+			descrição: "deve detectar quando o layout padrão não cabe na imagem base",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				c.Atirador.ImagemNúmeroControle.ImagemBase.Image = image.NewRGBA(image.Rect(0, 0, 400, 400))
+				return c
+			},
+			erroEsperado: errors.Errorf("campo do layout “numero controle” fora dos limites da imagem base de 400x400 pixels"),
+		},
+		{
+			descrição: "deve detectar quando a largura máxima de um texto ultrapassa a imagem base",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				c.Atirador.ImagemNúmeroControle.ImagemBase.Image = image.NewRGBA(image.Rect(0, 0, 400, 400))
+				c.Atirador.ImagemNúmeroControle.Layout.LayoutImagem = config.LayoutImagem{
+					Textos: []config.TextoLayout{
+						{Campo: config.CampoImagemNúmeroControle, X: 200, Y: 50, Tamanho: 18, Alinhamento: config.AlinhamentoTextoCentro, LarguraMáxima: 300},
+						{Campo: config.CampoImagemCódigoVerificação, X: 390, Y: 380, Tamanho: 11, Alinhamento: config.AlinhamentoTextoDireita, LarguraMáxima: 400},
+					},
+					QRCode: config.QRCodeLayout{X: 100, Y: 100, Tamanho: 200},
+				}
+				return c
+			},
+			erroEsperado: errors.Errorf("campo do layout “codigo verificacao” fora dos limites da imagem base de 400x400 pixels"),
+		},
+		{
+			descrição: "deve detectar quando o QR Code ultrapassa a imagem base",
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Atirador.ChaveCódigoVerificação = "abc123"
+				c.Atirador.ImagemNúmeroControle.ImagemBase.Image = image.NewRGBA(image.Rect(0, 0, 400, 400))
+				c.Atirador.ImagemNúmeroControle.Layout.LayoutImagem = config.LayoutImagem{
+					Textos: []config.TextoLayout{
+						{Campo: config.CampoImagemNúmeroControle, X: 10, Y: 50, Tamanho: 18, Alinhamento: config.AlinhamentoTextoEsquerda},
+						{Campo: config.CampoImagemCódigoVerificação, X: 10, Y: 380, Tamanho: 11, Alinhamento: config.AlinhamentoTextoEsquerda},
+					},
+					QRCode: config.QRCodeLayout{X: 250, Y: 100, Tamanho: 200},
+				}
+				return c
+			},
+			erroEsperado: errors.Errorf("QR Code do layout fora dos limites da imagem base de 400x400 pixels"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		if err := verificadorResultado.VerificaResultado(nil, cenário.configuração().Validar()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// gerarArquivoChaveAssinatura cria um arquivo PEM com uma chave privada
+// Ed25519 determinística, retornando o caminho do arquivo e a chave.
+func gerarArquivoChaveAssinatura(t *testing.T) (string, ed25519.PrivateKey) {
+	chave := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+
+	conteúdo, err := x509.MarshalPKCS8PrivateKey(chave)
+	if err != nil {
+		t.Fatalf("Erro ao codificar a chave de assinatura. Detalhes: %s", err)
+	}
+
+	arquivo, err := ioutil.TempFile("", "teste-nucleo-config-")
+	if err != nil {
+		t.Fatalf("Erro gerar o arquivo da chave de assinatura. Detalhes: %s", err)
+	}
+	defer arquivo.Close()
+
+	if err := pem.Encode(arquivo, &pem.Block{Type: "PRIVATE KEY", Bytes: conteúdo}); err != nil {
+		t.Fatalf("Erro ao escrever a chave de assinatura. Detalhes: %s", err)
+	}
+
+	return arquivo.Name(), chave
+}
+
+// gerarArquivoLayout cria um arquivo com o layout da imagem do número de
+// controle, retornando o caminho do arquivo.
+func gerarArquivoLayout(t *testing.T, conteúdo string) string {
+	arquivo, err := ioutil.TempFile("", "teste-nucleo-config-")
+	if err != nil {
+		t.Fatalf("Erro gerar o arquivo de layout. Detalhes: %s", err)
+	}
+	defer arquivo.Close()
+
+	if _, err := arquivo.WriteString(conteúdo); err != nil {
+		t.Fatalf("Erro ao escrever o arquivo de layout. Detalhes: %s", err)
+	}
+
+	return arquivo.Name()
+}
+
 const imagemBasePNG = `
 iVBORw0KGgoAAAANSUhEUgAAAKgAAACoCAMAAABDlVWGAAABI1BMVEX/////////////////////
 ////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"encoding/hex"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/registrobr/gostk/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// CampoImagem identifica um dado da frequência escrito na imagem do número de
+// controle.
+type CampoImagem string
+
+const (
+	// CampoImagemCR número de registro do atirador.
+	CampoImagemCR CampoImagem = "cr"
+
+	// CampoImagemCalibre calibre utilizado no treino.
+	CampoImagemCalibre CampoImagem = "calibre"
+
+	// CampoImagemPeríodo data e horários de início e término do treino.
+	CampoImagemPeríodo CampoImagem = "periodo"
+
+	// CampoImagemArma arma utilizada no treino.
+	CampoImagemArma CampoImagem = "arma"
+
+	// CampoImagemMunição quantidade de munição utilizada no treino.
+	CampoImagemMunição CampoImagem = "municao"
+
+	// CampoImagemNúmeroControle número de controle da frequência.
+	CampoImagemNúmeroControle CampoImagem = "numero controle"
+
+	// CampoImagemCódigoVerificação código de verificação da frequência.
+	CampoImagemCódigoVerificação CampoImagem = "codigo verificacao"
+)
+
+// camposImagem campos aceitos no layout, indicando se são obrigatórios.
+var camposImagem = map[CampoImagem]bool{
+	CampoImagemCR:                false,
+	CampoImagemCalibre:           false,
+	CampoImagemPeríodo:           false,
+	CampoImagemArma:              false,
+	CampoImagemMunição:           false,
+	CampoImagemNúmeroControle:    true,
+	CampoImagemCódigoVerificação: true,
+}
+
+// AlinhamentoTexto define como o texto é posicionado em relação à coordenada
+// horizontal do campo.
+type AlinhamentoTexto string
+
+const (
+	// AlinhamentoTextoEsquerda o texto começa na coordenada informada.
+	AlinhamentoTextoEsquerda AlinhamentoTexto = "esquerda"
+
+	// AlinhamentoTextoCentro o texto é centralizado na coordenada informada.
+	AlinhamentoTextoCentro AlinhamentoTexto = "centro"
+
+	// AlinhamentoTextoDireita o texto termina na coordenada informada.
+	AlinhamentoTextoDireita AlinhamentoTexto = "direita"
+)
+
+// UnmarshalText interpreta o alinhamento, aceitando somente os valores
+// conhecidos.
+func (a *AlinhamentoTexto) UnmarshalText(texto []byte) error {
+	alinhamento := AlinhamentoTexto(strings.ToLower(strings.TrimSpace(string(texto))))
+
+	switch alinhamento {
+	case AlinhamentoTextoEsquerda, AlinhamentoTextoCentro, AlinhamentoTextoDireita:
+		*a = alinhamento
+		return nil
+	}
+
+	return errors.Errorf("alinhamento de texto inválido “%s”", string(texto))
+}
+
+// Cor cor de um texto da imagem, informada no formato hexadecimal "#rrggbb".
+// Como no YAML o símbolo "#" inicia um comentário, o valor deve estar entre
+// aspas.
+type Cor struct {
+	color.RGBA
+}
+
+// UnmarshalText interpreta a cor no formato hexadecimal "#rrggbb" ou
+// "rrggbb".
+func (c *Cor) UnmarshalText(texto []byte) error {
+	cor := strings.TrimSpace(string(texto))
+
+	componentes, err := hex.DecodeString(strings.TrimPrefix(cor, "#"))
+	if err != nil || len(componentes) != 3 {
+		return errors.Errorf("cor inválida “%s”", string(texto))
+	}
+
+	c.RGBA = color.RGBA{componentes[0], componentes[1], componentes[2], 0xff}
+	return nil
+}
+
+// TextoLayout define como um dado da frequência é escrito na imagem. As
+// coordenadas são em pixels, sendo que a coordenada vertical representa a
+// linha de base do texto.
+type TextoLayout struct {
+	Campo CampoImagem `yaml:"campo"`
+	X     int         `yaml:"x"`
+	Y     int         `yaml:"y"`
+
+	// Tamanho tamanho da fonte em pontos, considerando uma resolução de 150
+	// DPI.
+	Tamanho float64 `yaml:"tamanho"`
+
+	// Cor quando não informada o texto é escrito em preto.
+	Cor Cor `yaml:"cor"`
+
+	// Alinhamento quando não informado o texto é alinhado à esquerda.
+	Alinhamento AlinhamentoTexto `yaml:"alinhamento"`
+
+	// LarguraMáxima largura máxima do texto em pixels. Textos maiores são
+	// truncados com reticências. Um valor zero não limita a largura.
+	LarguraMáxima int `yaml:"largura maxima"`
+}
+
+// QRCodeLayout define a posição, em pixels, do canto superior esquerdo do QR
+// Code e o seu tamanho.
+type QRCodeLayout struct {
+	X       int `yaml:"x"`
+	Y       int `yaml:"y"`
+	Tamanho int `yaml:"tamanho"`
+}
+
+// LayoutImagem descreve a disposição dos dados da frequência sobre a imagem
+// base do número de controle.
+type LayoutImagem struct {
+	Textos []TextoLayout `yaml:"textos"`
+	QRCode QRCodeLayout  `yaml:"qrcode"`
+}
+
+// Vazio identifica se nenhum layout foi definido.
+func (l LayoutImagem) Vazio() bool {
+	return len(l.Textos) == 0 && l.QRCode == QRCodeLayout{}
+}
+
+// validar verifica se todos os campos do layout são conhecidos e possuem
+// valores aceitáveis, preenchendo os valores padrão dos campos opcionais.
+func (l *LayoutImagem) validar() error {
+	encontrados := make(map[CampoImagem]bool)
+
+	for i := range l.Textos {
+		texto := &l.Textos[i]
+
+		if _, ok := camposImagem[texto.Campo]; !ok {
+			return errors.Errorf("campo desconhecido no layout da imagem “%s”", texto.Campo)
+		}
+
+		if encontrados[texto.Campo] {
+			return errors.Errorf("campo repetido no layout da imagem “%s”", texto.Campo)
+		}
+		encontrados[texto.Campo] = true
+
+		if texto.X < 0 || texto.Y < 0 || texto.Tamanho <= 0 || texto.LarguraMáxima < 0 {
+			return errors.Errorf("valores inválidos para o campo do layout da imagem “%s”", texto.Campo)
+		}
+
+		if texto.Cor.A == 0 {
+			texto.Cor.RGBA = color.RGBA{0x00, 0x00, 0x00, 0xff}
+		}
+
+		if texto.Alinhamento == "" {
+			texto.Alinhamento = AlinhamentoTextoEsquerda
+		}
+	}
+
+	for campo, obrigatório := range camposImagem {
+		if obrigatório && !encontrados[campo] {
+			return errors.Errorf("campo obrigatório ausente no layout da imagem “%s”", campo)
+		}
+	}
+
+	if l.QRCode.X < 0 || l.QRCode.Y < 0 || l.QRCode.Tamanho <= 0 {
+		return errors.Errorf("valores inválidos para o QR Code do layout da imagem")
+	}
+
+	return nil
+}
+
+// validarLimites verifica se os textos e o QR Code do layout estão dentro dos
+// limites da imagem base. Para os textos com largura máxima é verificada toda
+// a faixa horizontal que o texto pode ocupar, conforme o seu alinhamento.
+func (l LayoutImagem) validarLimites(limites image.Rectangle) error {
+	largura, altura := limites.Dx(), limites.Dy()
+
+	for _, texto := range l.Textos {
+		início, fim := texto.X, texto.X
+		switch texto.Alinhamento {
+		case AlinhamentoTextoCentro:
+			início, fim = texto.X-texto.LarguraMáxima/2, texto.X+texto.LarguraMáxima/2
+		case AlinhamentoTextoDireita:
+			início = texto.X - texto.LarguraMáxima
+		default:
+			fim = texto.X + texto.LarguraMáxima
+		}
+
+		if início < 0 || fim > largura || texto.Y > altura {
+			return errors.Errorf("campo do layout “%s” fora dos limites da imagem base de %dx%d pixels",
+				texto.Campo, largura, altura)
+		}
+	}
+
+	if l.QRCode.X+l.QRCode.Tamanho > largura || l.QRCode.Y+l.QRCode.Tamanho > altura {
+		return errors.Errorf("QR Code do layout fora dos limites da imagem base de %dx%d pixels",
+			largura, altura)
+	}
+
+	return nil
+}
+
+// LayoutImagemPadrão retorna o layout compatível com a imagem base distribuída
+// junto ao projeto, utilizado quando nenhum arquivo de layout é informado.
+func LayoutImagemPadrão() LayoutImagem {
+	preto := Cor{color.RGBA{0x00, 0x00, 0x00, 0xff}}
+	vermelho := Cor{color.RGBA{0xff, 0x00, 0x00, 0xff}}
+
+	return LayoutImagem{
+		Textos: []TextoLayout{
+			{Campo: CampoImagemCR, X: 260, Y: 252, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemCalibre, X: 260, Y: 285, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemPeríodo, X: 260, Y: 318, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemArma, X: 260, Y: 350, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemMunição, X: 260, Y: 383, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemNúmeroControle, X: 150, Y: 495, Tamanho: 18, Cor: vermelho, Alinhamento: AlinhamentoTextoEsquerda},
+			{Campo: CampoImagemCódigoVerificação, X: 32, Y: 1035, Tamanho: 11, Cor: preto, Alinhamento: AlinhamentoTextoEsquerda},
+		},
+		QRCode: QRCodeLayout{X: 200, Y: 600, Tamanho: 300},
+	}
+}
+
+type arquivoLayoutImagem struct {
+	LayoutImagem
+}
+
+// UnmarshalText carrega e valida um arquivo YAML com o layout da imagem do
+// número de controle.
+func (a *arquivoLayoutImagem) UnmarshalText(texto []byte) error {
+	conteúdo, err := ioutil.ReadFile(string(texto))
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	var layout LayoutImagem
+	if err := yaml.Unmarshal(conteúdo, &layout); err != nil {
+		return erros.Novo(err)
+	}
+
+	if err := layout.validar(); err != nil {
+		return erros.Novo(err)
+	}
+
+	a.LayoutImagem = layout
+	return nil
+}
@@ -20,6 +20,10 @@ var (
 	// ObjetoIndefinido erro utilizado quando se tenta manipular um objeto não
 	// inicializado.
 	ObjetoIndefinido = errors.Errorf("Objeto indefinido")
+
+	// AcessoNegado erro utilizado quando o usuário não possui permissão para
+	// manipular o objeto.
+	AcessoNegado = errors.Errorf("Acesso negado ao objeto")
 )
 
 // Novo cria um novo erro tratando casos de erros de baixo nível específicos,
@@ -0,0 +1,117 @@
+package protocolo
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ArmaTipoRegistroSIGMA indica que a arma está registrada no Sistema de
+	// Gerenciamento Militar de Armas (SIGMA), administrado pelo Exército.
+	ArmaTipoRegistroSIGMA ArmaTipoRegistro = "sigma"
+
+	// ArmaTipoRegistroSINARM indica que a arma está registrada no Sistema
+	// Nacional de Armas (SINARM), administrado pela Polícia Federal.
+	ArmaTipoRegistroSINARM ArmaTipoRegistro = "sinarm"
+)
+
+// ArmaTipoRegistro define os sistemas onde uma arma do acervo pode estar
+// registrada.
+type ArmaTipoRegistro string
+
+// Válido verifica se o tipo de registro é um dos tipos conhecidos.
+func (a ArmaTipoRegistro) Válido() bool {
+	return a == ArmaTipoRegistroSIGMA || a == ArmaTipoRegistroSINARM
+}
+
+// ArmaPedido armazena os dados de uma arma do acervo. A arma pertence a um
+// Atirador, identificado pelo CR, ou a um Clube de Tiro, nunca aos dois ao
+// mesmo tempo.
+type ArmaPedido struct {
+	NúmeroSérie string `json:"numeroSerie"`
+	Modelo      string `json:"modelo"`
+	Calibre     string `json:"calibre"`
+
+	// CR do Atirador proprietário da arma.
+	CR int `json:"cr,omitempty"`
+
+	// Clube número de identificação do Clube de Tiro proprietário da arma.
+	Clube int64 `json:"clube,omitempty"`
+
+	TipoRegistro ArmaTipoRegistro `json:"tipoRegistro"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição. Remove espaços e
+// mantém alguns conteúdos em caixa alta, da mesma forma que na frequência.
+func (a *ArmaPedido) Normalizar() {
+	a.NúmeroSérie = strings.TrimSpace(a.NúmeroSérie)
+	a.NúmeroSérie = strings.ToUpper(a.NúmeroSérie)
+
+	a.Modelo = strings.TrimSpace(a.Modelo)
+	a.Modelo = strings.ToUpper(a.Modelo)
+
+	a.Calibre = strings.TrimSpace(a.Calibre)
+	a.Calibre = strings.ToUpper(a.Calibre)
+
+	tipoRegistro := strings.TrimSpace(string(a.TipoRegistro))
+	a.TipoRegistro = ArmaTipoRegistro(strings.ToLower(tipoRegistro))
+}
+
+// Validar analisa se os dados informados possuem o formato correto e se os
+// campos obrigatórios foram preenchidos.
+func (a ArmaPedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if a.NúmeroSérie == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "numeroSerie", ""))
+	} else if !númeroSérieFormato.MatchString(a.NúmeroSérie) {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoNúmeroSérieInválido, a.NúmeroSérie))
+	}
+
+	if a.Modelo == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "modelo", ""))
+	}
+
+	if a.Calibre == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "calibre", ""))
+	}
+
+	if (a.CR > 0) == (a.Clube > 0) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoProprietárioInválido))
+	}
+
+	if !a.TipoRegistro.Válido() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoTipoRegistroInválido, string(a.TipoRegistro)))
+	}
+
+	return mensagens
+}
+
+// ArmaPedidoCompleto é uma extensão do tipo ArmaPedido incluindo o número de
+// identificação da arma enviado no endereço.
+type ArmaPedidoCompleto struct {
+	ID int64
+	ArmaPedido
+}
+
+// NovoArmaPedidoCompleto inicializa o tipo ArmaPedidoCompleto a partir do
+// número de identificação e do tipo ArmaPedido.
+func NovoArmaPedidoCompleto(id int64, armaPedido ArmaPedido) ArmaPedidoCompleto {
+	return ArmaPedidoCompleto{
+		ID:         id,
+		ArmaPedido: armaPedido,
+	}
+}
+
+// ArmaResposta armazena os dados da arma do acervo visualizada.
+type ArmaResposta struct {
+	ID              int64            `json:"id"`
+	NúmeroSérie     string           `json:"numeroSerie"`
+	Modelo          string           `json:"modelo"`
+	Calibre         string           `json:"calibre"`
+	CR              int              `json:"cr,omitempty"`
+	Clube           int64            `json:"clube,omitempty"`
+	TipoRegistro    ArmaTipoRegistro `json:"tipoRegistro"`
+	DataCriação     time.Time        `json:"dataCriacao"`
+	DataAtualização time.Time        `json:"dataAtualizacao,omitempty"`
+}
@@ -0,0 +1,162 @@
+package protocolo_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestArmaPedido_Normalizar(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		armaPedido protocolo.ArmaPedido
+		esperado   protocolo.ArmaPedido
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "  hg72643653  ",
+				Modelo:       " Taurus PT 938 ",
+				Calibre:      " .380 acp ",
+				CR:           380308,
+				TipoRegistro: " SIGMA ",
+			},
+			esperado: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380 ACP",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.armaPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.armaPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaPedido_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		armaPedido protocolo.ArmaPedido
+		esperado   protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar uma arma de um atirador",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+		},
+		{
+			descrição: "deve aceitar uma arma de um clube",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSINARM,
+			},
+		},
+		{
+			descrição: "deve detectar erros de validação em todos os campos",
+			armaPedido: protocolo.ArmaPedido{
+				TipoRegistro: "exercito",
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "numeroSerie", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "modelo", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "calibre", ""),
+				protocolo.NovaMensagem(protocolo.MensagemCódigoProprietárioInválido),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoTipoRegistroInválido, "exercito"),
+			},
+		},
+		{
+			descrição: "deve detectar um número de série em formato inválido",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "123ABC",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNúmeroSérieInválido, "123ABC"),
+			},
+		},
+		{
+			descrição: "deve detectar uma arma com dois proprietários",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				Clube:        1,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoProprietárioInválido),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.armaPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNovoArmaPedidoCompleto(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		id         int64
+		armaPedido protocolo.ArmaPedido
+		esperado   protocolo.ArmaPedidoCompleto
+	}{
+		{
+			descrição: "deve inicializar um objeto do tipo ArmaPedidoCompleto corretamente",
+			id:        1,
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			esperado: protocolo.ArmaPedidoCompleto{
+				ID: 1,
+				ArmaPedido: protocolo.ArmaPedido{
+					NúmeroSérie:  "HG72643653",
+					Modelo:       "TAURUS PT 938",
+					Calibre:      ".380",
+					CR:           380308,
+					TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				},
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		armaPedidoCompleto := protocolo.NovoArmaPedidoCompleto(cenário.id, cenário.armaPedido)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(armaPedidoCompleto, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package protocolo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// AtiradorSituaçãoAtivo indica que o CR do Atirador está regular e que as
+	// suas frequências podem ser registradas.
+	AtiradorSituaçãoAtivo AtiradorSituação = "ativo"
+
+	// AtiradorSituaçãoSuspenso indica que o CR do Atirador foi suspenso
+	// temporariamente pelo Exército.
+	AtiradorSituaçãoSuspenso AtiradorSituação = "suspenso"
+
+	// AtiradorSituaçãoCancelado indica que o CR do Atirador foi cancelado
+	// definitivamente.
+	AtiradorSituaçãoCancelado AtiradorSituação = "cancelado"
+)
+
+// AtiradorSituação define os possíveis estados do CR de um Atirador no
+// sistema.
+type AtiradorSituação string
+
+// Válida verifica se a situação é uma das situações conhecidas.
+func (a AtiradorSituação) Válida() bool {
+	return a == AtiradorSituaçãoAtivo ||
+		a == AtiradorSituaçãoSuspenso ||
+		a == AtiradorSituaçãoCancelado
+}
+
+// AtiradorImportaçãoLimite define a quantidade máxima de atiradores que podem
+// ser enviados em uma única importação.
+const AtiradorImportaçãoLimite = 1000
+
+// AtiradorPedido armazena os dados do Atirador detentor de um Certificado de
+// Registro (CR) no Exército.
+type AtiradorPedido struct {
+	CR           int              `json:"cr"`
+	Nome         string           `json:"nome"`
+	CPF          string           `json:"cpf"`
+	DataEmissão  time.Time        `json:"dataEmissao"`
+	DataValidade time.Time        `json:"dataValidade"`
+	Situação     AtiradorSituação `json:"situacao"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição. Remove espaços e
+// mantém somente os dígitos do CPF.
+func (a *AtiradorPedido) Normalizar() {
+	a.CPF = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, a.CPF)
+
+	a.Nome = strings.TrimSpace(a.Nome)
+
+	situação := strings.TrimSpace(string(a.Situação))
+	a.Situação = AtiradorSituação(strings.ToLower(situação))
+}
+
+// Validar analisa se os dados informados possuem o formato correto e se os
+// campos obrigatórios foram preenchidos. A situação é opcional, sendo
+// considerado um atirador ativo quando não informada.
+func (a AtiradorPedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if a.CR <= 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "cr", strconv.Itoa(a.CR)))
+	}
+
+	if a.Nome == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "nome", ""))
+	}
+
+	if !cpfVálido(a.CPF) {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoCPFInválido, a.CPF))
+	}
+
+	if a.DataEmissão.IsZero() {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "dataEmissao", ""))
+	}
+
+	if a.DataValidade.IsZero() {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "dataValidade", ""))
+	}
+
+	if !a.DataEmissão.IsZero() && !a.DataValidade.IsZero() && !a.DataValidade.After(a.DataEmissão) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoDatasPeríodoIncorreto))
+	}
+
+	if a.Situação != "" && !a.Situação.Válida() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoSituaçãoInválida, string(a.Situação)))
+	}
+
+	return mensagens
+}
+
+// AtiradorPedidoCompleto é uma extensão do tipo AtiradorPedido incluindo o CR
+// atual do atirador, enviado no endereço.
+type AtiradorPedidoCompleto struct {
+	CRAtual int
+	AtiradorPedido
+}
+
+// NovoAtiradorPedidoCompleto inicializa o tipo AtiradorPedidoCompleto a partir
+// do CR atual e do tipo AtiradorPedido.
+func NovoAtiradorPedidoCompleto(cr int, atiradorPedido AtiradorPedido) AtiradorPedidoCompleto {
+	return AtiradorPedidoCompleto{
+		CRAtual:        cr,
+		AtiradorPedido: atiradorPedido,
+	}
+}
+
+// AtiradorResposta armazena os dados do Atirador visualizado.
+type AtiradorResposta struct {
+	CR              int              `json:"cr"`
+	Nome            string           `json:"nome"`
+	CPF             string           `json:"cpf"`
+	DataEmissão     time.Time        `json:"dataEmissao"`
+	DataValidade    time.Time        `json:"dataValidade"`
+	Situação        AtiradorSituação `json:"situacao"`
+	DataCriação     time.Time        `json:"dataCriacao"`
+	DataAtualização time.Time        `json:"dataAtualizacao,omitempty"`
+}
+
+// AtiradorImportaçãoPedido armazena um lote de atiradores que devem ser
+// cadastrados ou atualizados de uma única vez, normalmente a partir de uma
+// listagem fornecida pelo Exército.
+type AtiradorImportaçãoPedido struct {
+	Atiradores []AtiradorPedido `json:"atiradores"`
+}
+
+// Normalizar padroniza o formato de todos os atiradores do lote.
+func (a *AtiradorImportaçãoPedido) Normalizar() {
+	for i := range a.Atiradores {
+		a.Atiradores[i].Normalizar()
+	}
+}
+
+// Validar analisa cada um dos atiradores do lote. O campo das mensagens é
+// prefixado com a posição do atirador no lote para facilitar a identificação
+// do registro com problema.
+func (a AtiradorImportaçãoPedido) Validar() Mensagens {
+	if len(a.Atiradores) == 0 {
+		return NovasMensagens(NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "atiradores", ""))
+	}
+
+	if len(a.Atiradores) > AtiradorImportaçãoLimite {
+		return NovasMensagens(NovaMensagemComCampo(MensagemCódigoImportaçãoMuitoGrande, "atiradores", strconv.Itoa(len(a.Atiradores))))
+	}
+
+	var mensagens Mensagens
+	for i, atiradorPedido := range a.Atiradores {
+		for _, mensagem := range atiradorPedido.Validar() {
+			prefixo := fmt.Sprintf("atiradores[%d]", i)
+			if mensagem.Campo == "" {
+				mensagem.Campo = prefixo
+			} else {
+				mensagem.Campo = prefixo + "." + mensagem.Campo
+			}
+			mensagens = append(mensagens, mensagem)
+		}
+	}
+
+	return mensagens
+}
+
+// AtiradorImportaçãoResposta resume o resultado da importação de atiradores.
+type AtiradorImportaçãoResposta struct {
+	Cadastrados int `json:"cadastrados"`
+	Atualizados int `json:"atualizados"`
+}
+
+// cpfVálido verifica a quantidade de dígitos e os dígitos verificadores do
+// CPF. O CPF deve estar normalizado, contendo somente números.
+func cpfVálido(cpf string) bool {
+	if len(cpf) != 11 {
+		return false
+	}
+
+	for _, r := range cpf {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	// CPFs com todos os dígitos iguais passam no cálculo dos dígitos
+	// verificadores, mas não são válidos
+	if strings.Count(cpf, cpf[:1]) == len(cpf) {
+		return false
+	}
+
+	dígitoVerificador := func(base string, pesoInicial int) byte {
+		soma := 0
+		for i := 0; i < pesoInicial-1; i++ {
+			soma += int(base[i]-'0') * (pesoInicial - i)
+		}
+
+		resto := soma % 11
+		if resto < 2 {
+			return '0'
+		}
+		return byte(11-resto) + '0'
+	}
+
+	return cpf[9] == dígitoVerificador(cpf, 10) && cpf[10] == dígitoVerificador(cpf, 11)
+}
@@ -0,0 +1,269 @@
+package protocolo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestAtiradorPedido_Normalizar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição      string
+		atiradorPedido protocolo.AtiradorPedido
+		esperado       protocolo.AtiradorPedido
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "  João da Silva  ",
+				CPF:          " 529.982.247-25 ",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(3, 0, 0),
+				Situação:     " SUSPENSO ",
+			},
+			esperado: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(3, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoSuspenso,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.atiradorPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.atiradorPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorPedido_Validar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição      string
+		atiradorPedido protocolo.AtiradorPedido
+		esperado       protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um pedido válido",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(3, 0, 0),
+			},
+		},
+		{
+			descrição: "deve detectar erros de validação em todos os campos",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CPF:      "52998224726",
+				Situação: "inativo",
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "cr", "0"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "nome", ""),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCPFInválido, "52998224726"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "dataEmissao", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "dataValidade", ""),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoSituaçãoInválida, "inativo"),
+			},
+		},
+		{
+			descrição: "deve detectar um CPF com todos os dígitos iguais",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "11111111111",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(3, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoCancelado,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCPFInválido, "11111111111"),
+			},
+		},
+		{
+			descrição: "deve detectar uma data de validade anterior a data de emissão",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(-1, 0, 0),
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.atiradorPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNovoAtiradorPedidoCompleto(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição      string
+		cr             int
+		atiradorPedido protocolo.AtiradorPedido
+		esperado       protocolo.AtiradorPedidoCompleto
+	}{
+		{
+			descrição: "deve inicializar um objeto do tipo AtiradorPedidoCompleto corretamente",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data,
+				DataValidade: data.AddDate(3, 0, 0),
+			},
+			esperado: protocolo.AtiradorPedidoCompleto{
+				CRAtual: 380308,
+				AtiradorPedido: protocolo.AtiradorPedido{
+					CR:           380308,
+					Nome:         "João da Silva",
+					CPF:          "52998224725",
+					DataEmissão:  data,
+					DataValidade: data.AddDate(3, 0, 0),
+				},
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		atiradorPedidoCompleto := protocolo.NovoAtiradorPedidoCompleto(cenário.cr, cenário.atiradorPedido)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(atiradorPedidoCompleto, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorImportaçãoPedido_Normalizar(t *testing.T) {
+	cenários := []struct {
+		descrição                string
+		atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido
+		esperado                 protocolo.AtiradorImportaçãoPedido
+	}{
+		{
+			descrição: "deve normalizar todos os atiradores do lote",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308, Nome: " João da Silva ", CPF: "529.982.247-25"},
+					{CR: 380309, Nome: " Maria da Silva ", CPF: "111.444.777-35", Situação: "Ativo"},
+				},
+			},
+			esperado: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{CR: 380308, Nome: "João da Silva", CPF: "52998224725"},
+					{CR: 380309, Nome: "Maria da Silva", CPF: "11144477735", Situação: protocolo.AtiradorSituaçãoAtivo},
+				},
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.atiradorImportaçãoPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.atiradorImportaçãoPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorImportaçãoPedido_Validar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição                string
+		atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido
+		esperado                 protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um lote válido",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{
+						CR:           380308,
+						Nome:         "João da Silva",
+						CPF:          "52998224725",
+						DataEmissão:  data,
+						DataValidade: data.AddDate(3, 0, 0),
+					},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar um lote vazio",
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "atiradores", ""),
+			},
+		},
+		{
+			descrição: "deve detectar um lote acima do limite",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: make([]protocolo.AtiradorPedido, protocolo.AtiradorImportaçãoLimite+1),
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImportaçãoMuitoGrande, "atiradores", "1001"),
+			},
+		},
+		{
+			descrição: "deve identificar a posição dos atiradores inválidos no lote",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{
+					{
+						CR:           380308,
+						Nome:         "João da Silva",
+						CPF:          "52998224725",
+						DataEmissão:  data,
+						DataValidade: data.AddDate(3, 0, 0),
+					},
+					{
+						Nome:         "Maria da Silva",
+						CPF:          "11144477736",
+						DataEmissão:  data,
+						DataValidade: data.AddDate(3, 0, 0),
+					},
+				},
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "atiradores[1].cr", "0"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCPFInválido, "atiradores[1]", "11144477736"),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.atiradorImportaçãoPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package protocolo
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AuditoriaVereditoAprovada indica que a foto enviada realmente é do
+	// atirador reportado com o número de controle gerado. A frequência volta a
+	// ser considerada confirmada.
+	AuditoriaVereditoAprovada AuditoriaVeredito = "aprovada"
+
+	// AuditoriaVereditoSuspeita indica que o auditor encontrou indícios de
+	// problemas, mas que ainda precisam ser analisados. A frequência permanece
+	// em auditoria até um veredito definitivo.
+	AuditoriaVereditoSuspeita AuditoriaVeredito = "suspeita"
+
+	// AuditoriaVereditoFraudulenta indica que a foto não corresponde ao
+	// atirador ou ao número de controle. A frequência é invalidada.
+	AuditoriaVereditoFraudulenta AuditoriaVeredito = "fraudulenta"
+)
+
+// AuditoriaVeredito define os possíveis resultados da análise de uma
+// frequência sorteada para auditoria.
+type AuditoriaVeredito string
+
+// Válido verifica se o veredito é um dos vereditos conhecidos.
+func (a AuditoriaVeredito) Válido() bool {
+	return a == AuditoriaVereditoAprovada ||
+		a == AuditoriaVereditoSuspeita ||
+		a == AuditoriaVereditoFraudulenta
+}
+
+// Definitivo verifica se o veredito encerra a auditoria, não permitindo novas
+// alterações.
+func (a AuditoriaVeredito) Definitivo() bool {
+	return a == AuditoriaVereditoAprovada || a == AuditoriaVereditoFraudulenta
+}
+
+// AmostraAuditoriaPedido armazena os critérios para sortear as frequências
+// confirmadas que serão auditadas. A amostra é definida por um percentual ou
+// por uma quantidade fixa de frequências de cada clube no período.
+type AmostraAuditoriaPedido struct {
+	// DataInício e DataTérmino delimitam o período, inclusive, em que os
+	// treinos foram iniciados.
+	DataInício  time.Time `json:"dataInicio"`
+	DataTérmino time.Time `json:"dataTermino"`
+
+	// Percentual porcentagem das frequências de cada clube que serão
+	// sorteadas, arredondada para cima.
+	Percentual int `json:"percentual"`
+
+	// QuantidadePorClube quantidade de frequências sorteadas de cada clube.
+	QuantidadePorClube int `json:"quantidadePorClube"`
+
+	// Semente valor utilizado no sorteio. Com a mesma semente e as mesmas
+	// frequências candidatas o sorteio é sempre o mesmo, permitindo reproduzir
+	// a amostra. Quando não informada uma semente aleatória é gerada.
+	Semente int64 `json:"semente"`
+}
+
+// Normalizar define os valores padrão do pedido. Quando não informado, o
+// período considerado é o último mês até o momento atual.
+func (a *AmostraAuditoriaPedido) Normalizar() {
+	if a.DataTérmino.IsZero() {
+		a.DataTérmino = time.Now()
+	}
+
+	if a.DataInício.IsZero() {
+		a.DataInício = a.DataTérmino.AddDate(0, -1, 0)
+	}
+}
+
+// Validar analisa se os critérios informados possuem valores aceitáveis.
+func (a AmostraAuditoriaPedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if a.DataInício.After(a.DataTérmino) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoDatasPeríodoIncorreto))
+	}
+
+	if (a.Percentual == 0) == (a.QuantidadePorClube == 0) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoCritérioAmostraInválido))
+	}
+
+	if a.Percentual < 0 || a.Percentual > 100 {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoPercentualInválido, strconv.Itoa(a.Percentual)))
+	}
+
+	if a.QuantidadePorClube < 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoParâmetroInválido, "quantidadePorClube", strconv.Itoa(a.QuantidadePorClube)))
+	}
+
+	return mensagens
+}
+
+// AmostraAuditoriaResposta armazena os critérios utilizados no sorteio e as
+// auditorias geradas para cada frequência sorteada.
+type AmostraAuditoriaResposta struct {
+	ID                 int64                `json:"id"`
+	DataInício         time.Time            `json:"dataInicio"`
+	DataTérmino        time.Time            `json:"dataTermino"`
+	Percentual         int                  `json:"percentual,omitempty"`
+	QuantidadePorClube int                  `json:"quantidadePorClube,omitempty"`
+	Semente            int64                `json:"semente"`
+	DataCriação        time.Time            `json:"dataCriacao"`
+	Auditorias         []AuditoriaListaItem `json:"auditorias"`
+}
+
+// AuditoriaListaItem armazena os dados resumidos de uma auditoria da amostra.
+// As imagens podem ser obtidas na consulta individual da auditoria.
+type AuditoriaListaItem struct {
+	ID             int64             `json:"id"`
+	NúmeroControle NúmeroControle    `json:"numeroControle"`
+	CR             int               `json:"cr"`
+	Clube          int64             `json:"clube"`
+	Veredito       AuditoriaVeredito `json:"veredito,omitempty"`
+}
+
+// AuditoriaResposta armazena os dados necessários para o auditor analisar uma
+// frequência sorteada, com a imagem do número de controle gerado e a foto
+// enviada na confirmação lado a lado.
+type AuditoriaResposta struct {
+	ID                   int64              `json:"id"`
+	Amostra              int64              `json:"amostra"`
+	NúmeroControle       NúmeroControle     `json:"numeroControle"`
+	CR                   int                `json:"cr"`
+	Clube                int64              `json:"clube"`
+	Calibre              string             `json:"calibre"`
+	ArmaUtilizada        string             `json:"armaUtilizada"`
+	QuantidadeMunição    int                `json:"quantidadeMunicao"`
+	DataInício           time.Time          `json:"dataInicio"`
+	DataTérmino          time.Time          `json:"dataTermino"`
+	DataConfirmação      time.Time          `json:"dataConfirmacao"`
+	Situação             FrequênciaSituação `json:"situacao"`
+	ImagemNúmeroControle string             `json:"imagemNumeroControle"`
+	ImagemConfirmação    string             `json:"imagemConfirmacao"`
+	MiniaturaConfirmação string             `json:"miniaturaConfirmacao,omitempty"`
+	EXIFConfirmação      string             `json:"exifConfirmacao,omitempty"` // base64
+	SinalizaçãoImagem    MensagemCódigo     `json:"sinalizacaoImagem,omitempty"`
+	DistânciaClube       *float64           `json:"distanciaClube,omitempty"`
+	Veredito             AuditoriaVeredito  `json:"veredito,omitempty"`
+	Observações          string             `json:"observacoes,omitempty"`
+	DataVeredito         time.Time          `json:"dataVeredito,omitempty"`
+}
+
+// AuditoriaVereditoPedido armazena o resultado da análise do auditor.
+type AuditoriaVereditoPedido struct {
+	Veredito    AuditoriaVeredito `json:"veredito"`
+	Observações string            `json:"observacoes"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição.
+func (a *AuditoriaVereditoPedido) Normalizar() {
+	veredito := strings.TrimSpace(string(a.Veredito))
+	a.Veredito = AuditoriaVeredito(strings.ToLower(veredito))
+	a.Observações = strings.TrimSpace(a.Observações)
+}
+
+// Validar analisa se o veredito é conhecido. Vereditos diferentes de aprovada
+// exigem observações que justifiquem a decisão do auditor.
+func (a AuditoriaVereditoPedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if !a.Veredito.Válido() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoVereditoInválido, string(a.Veredito)))
+
+	} else if a.Veredito != AuditoriaVereditoAprovada && a.Observações == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "observacoes", ""))
+	}
+
+	return mensagens
+}
+
+// AuditoriaVereditoPedidoCompleta extende o tipo AuditoriaVereditoPedido
+// incluindo o número de identificação da auditoria encontrado no endereço,
+// além da identidade do auditor.
+type AuditoriaVereditoPedidoCompleta struct {
+	ID         int64
+	Identidade Identidade
+	AuditoriaVereditoPedido
+}
+
+// NovaAuditoriaVereditoPedidoCompleta inicializa o tipo
+// AuditoriaVereditoPedidoCompleta a partir do número de identificação da
+// auditoria, identidade do auditor e do tipo AuditoriaVereditoPedido.
+func NovaAuditoriaVereditoPedidoCompleta(id int64, identidade Identidade, auditoriaVereditoPedido AuditoriaVereditoPedido) AuditoriaVereditoPedidoCompleta {
+	return AuditoriaVereditoPedidoCompleta{
+		ID:                      id,
+		Identidade:              identidade,
+		AuditoriaVereditoPedido: auditoriaVereditoPedido,
+	}
+}
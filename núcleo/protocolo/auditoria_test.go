@@ -0,0 +1,215 @@
+package protocolo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestAmostraAuditoriaPedido_Normalizar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição              string
+		amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido
+		esperado               protocolo.AmostraAuditoriaPedido
+	}{
+		{
+			descrição: "deve manter os valores informados",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+			},
+			esperado: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Percentual:  10,
+				Semente:     42,
+			},
+		},
+		{
+			descrição: "deve definir o início do período",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataTérmino:        data,
+				QuantidadePorClube: 3,
+			},
+			esperado: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 3,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.amostraAuditoriaPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.amostraAuditoriaPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAmostraAuditoriaPedido_Validar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição              string
+		amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido
+		esperado               protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar uma amostra por percentual",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Percentual:  10,
+			},
+		},
+		{
+			descrição: "deve aceitar uma amostra por quantidade por clube",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.Add(-24 * time.Hour),
+				DataTérmino:        data,
+				QuantidadePorClube: 5,
+			},
+		},
+		{
+			descrição: "deve detectar um período incoerente",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data,
+				DataTérmino: data.Add(-24 * time.Hour),
+				Percentual:  10,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
+			),
+		},
+		{
+			descrição: "deve detectar quando nenhum critério foi informado",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCritérioAmostraInválido),
+			),
+		},
+		{
+			descrição: "deve detectar quando os dois critérios foram informados",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.Add(-24 * time.Hour),
+				DataTérmino:        data,
+				Percentual:         10,
+				QuantidadePorClube: 5,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCritérioAmostraInválido),
+			),
+		},
+		{
+			descrição: "deve detectar um percentual inválido",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Percentual:  101,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoPercentualInválido, "101"),
+			),
+		},
+		{
+			descrição: "deve detectar uma quantidade por clube inválida",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.Add(-24 * time.Hour),
+				DataTérmino:        data,
+				QuantidadePorClube: -1,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "quantidadePorClube", "-1"),
+			),
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.amostraAuditoriaPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaVereditoPedido_Normalizar(t *testing.T) {
+	auditoriaVereditoPedido := protocolo.AuditoriaVereditoPedido{
+		Veredito:    "  Fraudulenta ",
+		Observações: "  Foto de outra pessoa  ",
+	}
+
+	auditoriaVereditoPedido.Normalizar()
+
+	esperado := protocolo.AuditoriaVereditoPedido{
+		Veredito:    protocolo.AuditoriaVereditoFraudulenta,
+		Observações: "Foto de outra pessoa",
+	}
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve normalizar o veredito", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(auditoriaVereditoPedido, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAuditoriaVereditoPedido_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição               string
+		auditoriaVereditoPedido protocolo.AuditoriaVereditoPedido
+		esperado                protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar uma aprovação sem observações",
+			auditoriaVereditoPedido: protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoAprovada,
+			},
+		},
+		{
+			descrição: "deve aceitar uma fraude com observações",
+			auditoriaVereditoPedido: protocolo.AuditoriaVereditoPedido{
+				Veredito:    protocolo.AuditoriaVereditoFraudulenta,
+				Observações: "Foto de outra pessoa",
+			},
+		},
+		{
+			descrição: "deve exigir observações em uma suspeita",
+			auditoriaVereditoPedido: protocolo.AuditoriaVereditoPedido{
+				Veredito: protocolo.AuditoriaVereditoSuspeita,
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "observacoes", ""),
+			),
+		},
+		{
+			descrição: "deve detectar um veredito desconhecido",
+			auditoriaVereditoPedido: protocolo.AuditoriaVereditoPedido{
+				Veredito: "talvez",
+			},
+			esperado: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVereditoInválido, "talvez"),
+			),
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.auditoriaVereditoPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package protocolo
+
+const (
+	// CalibreClassePermitido indica que o calibre é de uso permitido, podendo
+	// ser utilizado por qualquer atirador com CR.
+	CalibreClassePermitido CalibreClasse = "permitido"
+
+	// CalibreClasseRestrito indica que o calibre é de uso restrito, exigindo
+	// autorização específica do Exército.
+	CalibreClasseRestrito CalibreClasse = "restrito"
+)
+
+// CalibreClasse define a classificação de uso de um calibre segundo a
+// regulamentação do Exército.
+type CalibreClasse string
+
+// CalibreResposta armazena os dados de um calibre do catálogo. O nome é a
+// forma canônica armazenada nas frequências e no acervo de armas, enquanto os
+// apelidos são as outras formas aceitas ao informar o calibre.
+type CalibreResposta struct {
+	ID       int64         `json:"id"`
+	Nome     string        `json:"nome"`
+	Apelidos []string      `json:"apelidos,omitempty"`
+	Classe   CalibreClasse `json:"classe"`
+}
+
+// CalibreListaResposta armazena todos os calibres do catálogo.
+type CalibreListaResposta struct {
+	Calibres []CalibreResposta `json:"calibres"`
+}
@@ -0,0 +1,234 @@
+package protocolo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// ClubeSituaçãoAtivo indica que o Clube de Tiro está apto a reportar
+	// frequências de Atiradores.
+	ClubeSituaçãoAtivo ClubeSituação = "ativo"
+
+	// ClubeSituaçãoInativo indica que o Clube de Tiro não pode mais reportar
+	// frequências, seja por encerramento das atividades ou por determinação do
+	// Exército.
+	ClubeSituaçãoInativo ClubeSituação = "inativo"
+)
+
+// raioTerra raio médio da Terra em metros.
+const raioTerra = 6371000
+
+// ClubeSituação define os possíveis estados de um Clube de Tiro no sistema.
+type ClubeSituação string
+
+// Válida verifica se a situação é uma das situações conhecidas.
+func (c ClubeSituação) Válida() bool {
+	return c == ClubeSituaçãoAtivo || c == ClubeSituaçãoInativo
+}
+
+// ufs lista as Unidades Federativas aceitas no endereço do Clube de Tiro.
+var ufs = map[string]bool{
+	"AC": true, "AL": true, "AM": true, "AP": true, "BA": true, "CE": true,
+	"DF": true, "ES": true, "GO": true, "MA": true, "MG": true, "MS": true,
+	"MT": true, "PA": true, "PB": true, "PE": true, "PI": true, "PR": true,
+	"RJ": true, "RN": true, "RO": true, "RR": true, "RS": true, "SC": true,
+	"SE": true, "SP": true, "TO": true,
+}
+
+// regiõesMilitares define a quantidade de Regiões Militares existentes no
+// Exército Brasileiro.
+const regiõesMilitares = 12
+
+// Coordenadas define uma posição geográfica em graus decimais, utilizando o
+// sistema de referência WGS 84 adotado pelo GPS.
+type Coordenadas struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Válidas verifica se a latitude e a longitude estão dentro dos limites
+// possíveis.
+func (c Coordenadas) Válidas() bool {
+	return c.Latitude >= -90 && c.Latitude <= 90 &&
+		c.Longitude >= -180 && c.Longitude <= 180
+}
+
+// Distância calcula a distância em metros até outra coordenada pela fórmula de
+// haversine, que considera a Terra uma esfera. O erro da aproximação é
+// desprezível nas distâncias envolvidas na verificação de um estande de tiro.
+func (c Coordenadas) Distância(outra Coordenadas) float64 {
+	radianos := func(graus float64) float64 {
+		return graus * math.Pi / 180
+	}
+
+	variaçãoLatitude := radianos(outra.Latitude - c.Latitude)
+	variaçãoLongitude := radianos(outra.Longitude - c.Longitude)
+
+	a := math.Pow(math.Sin(variaçãoLatitude/2), 2) +
+		math.Cos(radianos(c.Latitude))*math.Cos(radianos(outra.Latitude))*
+			math.Pow(math.Sin(variaçãoLongitude/2), 2)
+
+	return 2 * raioTerra * math.Asin(math.Sqrt(a))
+}
+
+// String retorna as coordenadas no formato "latitude,longitude".
+func (c Coordenadas) String() string {
+	return strconv.FormatFloat(c.Latitude, 'f', -1, 64) + "," +
+		strconv.FormatFloat(c.Longitude, 'f', -1, 64)
+}
+
+// ClubePedido armazena os dados de um Clube de Tiro que deseja reportar as
+// frequências dos seus Atiradores.
+type ClubePedido struct {
+	CNPJ          string        `json:"cnpj"`
+	CR            int           `json:"cr"`
+	Nome          string        `json:"nome"`
+	Endereço      string        `json:"endereco"`
+	Cidade        string        `json:"cidade"`
+	UF            string        `json:"uf"`
+	RegiãoMilitar int           `json:"regiaoMilitar"`
+	Situação      ClubeSituação `json:"situacao"`
+
+	// Localização posição do estande de tiro do clube, utilizada para verificar
+	// o local onde as fotos de confirmação das frequências foram tiradas.
+	Localização *Coordenadas `json:"localizacao,omitempty"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição. Remove espaços,
+// mantém somente os dígitos do CNPJ e deixa a UF em caixa alta.
+func (c *ClubePedido) Normalizar() {
+	c.CNPJ = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, c.CNPJ)
+
+	c.Nome = strings.TrimSpace(c.Nome)
+	c.Endereço = strings.TrimSpace(c.Endereço)
+	c.Cidade = strings.TrimSpace(c.Cidade)
+
+	c.UF = strings.TrimSpace(c.UF)
+	c.UF = strings.ToUpper(c.UF)
+
+	situação := strings.TrimSpace(string(c.Situação))
+	c.Situação = ClubeSituação(strings.ToLower(situação))
+}
+
+// Validar analisa se os dados informados possuem o formato correto e se os
+// campos obrigatórios foram preenchidos. A situação é opcional, sendo
+// considerado um clube ativo quando não informada.
+func (c ClubePedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if !cnpjVálido(c.CNPJ) {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoCNPJInválido, c.CNPJ))
+	}
+
+	if c.CR <= 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "cr", "0"))
+	}
+
+	if c.Nome == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "nome", ""))
+	}
+
+	if c.Endereço == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "endereco", ""))
+	}
+
+	if c.Cidade == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "cidade", ""))
+	}
+
+	if !ufs[c.UF] {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoUFInválida, c.UF))
+	}
+
+	if c.RegiãoMilitar < 1 || c.RegiãoMilitar > regiõesMilitares {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoRegiãoMilitarInválida, strconv.Itoa(c.RegiãoMilitar)))
+	}
+
+	if c.Situação != "" && !c.Situação.Válida() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoSituaçãoInválida, string(c.Situação)))
+	}
+
+	if c.Localização != nil && !c.Localização.Válidas() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoLocalizaçãoInválida, c.Localização.String()))
+	}
+
+	return mensagens
+}
+
+// ClubePedidoCompleto é uma extensão do tipo ClubePedido incluindo o número de
+// identificação do clube enviado no endereço.
+type ClubePedidoCompleto struct {
+	ID int64
+	ClubePedido
+}
+
+// NovoClubePedidoCompleto inicializa o tipo ClubePedidoCompleto a partir do
+// número de identificação e do tipo ClubePedido.
+func NovoClubePedidoCompleto(id int64, clubePedido ClubePedido) ClubePedidoCompleto {
+	return ClubePedidoCompleto{
+		ID:          id,
+		ClubePedido: clubePedido,
+	}
+}
+
+// ClubeResposta armazena os dados do Clube de Tiro visualizado.
+type ClubeResposta struct {
+	ID              int64         `json:"id"`
+	CNPJ            string        `json:"cnpj"`
+	CR              int           `json:"cr"`
+	Nome            string        `json:"nome"`
+	Endereço        string        `json:"endereco"`
+	Cidade          string        `json:"cidade"`
+	UF              string        `json:"uf"`
+	RegiãoMilitar   int           `json:"regiaoMilitar"`
+	Situação        ClubeSituação `json:"situacao"`
+	DataCriação     time.Time     `json:"dataCriacao"`
+	DataAtualização time.Time     `json:"dataAtualizacao,omitempty"`
+	Localização     *Coordenadas  `json:"localizacao,omitempty"`
+}
+
+// cnpjVálido verifica a quantidade de dígitos e os dígitos verificadores do
+// CNPJ. O CNPJ deve estar normalizado, contendo somente números.
+func cnpjVálido(cnpj string) bool {
+	if len(cnpj) != 14 {
+		return false
+	}
+
+	for _, r := range cnpj {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	// CNPJs com todos os dígitos iguais passam no cálculo dos dígitos
+	// verificadores, mas não são válidos
+	if strings.Count(cnpj, cnpj[:1]) == len(cnpj) {
+		return false
+	}
+
+	dígitoVerificador := func(base string, pesos []int) byte {
+		soma := 0
+		for i, peso := range pesos {
+			soma += int(base[i]-'0') * peso
+		}
+
+		resto := soma % 11
+		if resto < 2 {
+			return '0'
+		}
+		return byte(11-resto) + '0'
+	}
+
+	primeiro := dígitoVerificador(cnpj, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	segundo := dígitoVerificador(cnpj, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	return cnpj[12] == primeiro && cnpj[13] == segundo
+}
@@ -0,0 +1,219 @@
+package protocolo_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestClubePedido_Normalizar(t *testing.T) {
+	cenários := []struct {
+		descrição   string
+		clubePedido protocolo.ClubePedido
+		esperado    protocolo.ClubePedido
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          " 11.222.333/0001-81 ",
+				CR:            123456,
+				Nome:          "  Clube de Tiro Centro  ",
+				Endereço:      "  Rua das Flores, 123  ",
+				Cidade:        "  Rio de Janeiro  ",
+				UF:            " rj ",
+				RegiãoMilitar: 1,
+				Situação:      " ATIVO ",
+			},
+			esperado: protocolo.ClubePedido{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.clubePedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.clubePedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubePedido_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição   string
+		clubePedido protocolo.ClubePedido
+		esperado    protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um pedido válido",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Localização: &protocolo.Coordenadas{
+					Latitude:  -22.9068,
+					Longitude: -43.1729,
+				},
+			},
+		},
+		{
+			descrição: "deve detectar erros de validação em todos os campos",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "11222333000182",
+				UF:            "XX",
+				RegiãoMilitar: 13,
+				Situação:      "suspenso",
+				Localização: &protocolo.Coordenadas{
+					Latitude:  -91.5,
+					Longitude: -43.2,
+				},
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCNPJInválido, "11222333000182"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "cr", "0"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "nome", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "endereco", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "cidade", ""),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoUFInválida, "XX"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoRegiãoMilitarInválida, "13"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoSituaçãoInválida, "suspenso"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoLocalizaçãoInválida, "-91.5,-43.2"),
+			},
+		},
+		{
+			descrição: "deve detectar um CNPJ com todos os dígitos iguais",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "00000000000000",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoInativo,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCNPJInválido, "00000000000000"),
+			},
+		},
+		{
+			descrição: "deve detectar um CNPJ com tamanho incorreto",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "1122233300018",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCNPJInválido, "1122233300018"),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.clubePedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNovoClubePedidoCompleto(t *testing.T) {
+	cenários := []struct {
+		descrição   string
+		id          int64
+		clubePedido protocolo.ClubePedido
+		esperado    protocolo.ClubePedidoCompleto
+	}{
+		{
+			descrição: "deve inicializar um objeto do tipo ClubePedidoCompleto corretamente",
+			id:        12,
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+			},
+			esperado: protocolo.ClubePedidoCompleto{
+				ID: 12,
+				ClubePedido: protocolo.ClubePedido{
+					CNPJ:          "11222333000181",
+					CR:            123456,
+					Nome:          "Clube de Tiro Centro",
+					Endereço:      "Rua das Flores, 123",
+					Cidade:        "Rio de Janeiro",
+					UF:            "RJ",
+					RegiãoMilitar: 1,
+				},
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		clubePedidoCompleto := protocolo.NovoClubePedidoCompleto(cenário.id, cenário.clubePedido)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(clubePedidoCompleto, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCoordenadas_Distância(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		origem    protocolo.Coordenadas
+		destino   protocolo.Coordenadas
+		esperado  float64
+	}{
+		{
+			descrição: "deve calcular uma distância nula para a mesma coordenada",
+			origem:    protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729},
+			destino:   protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729},
+			esperado:  0,
+		},
+		{
+			descrição: "deve calcular a distância entre duas cidades",
+			origem:    protocolo.Coordenadas{Latitude: -22.9068, Longitude: -43.1729},
+			destino:   protocolo.Coordenadas{Latitude: -23.5505, Longitude: -46.6333},
+			esperado:  360750,
+		},
+		{
+			descrição: "deve calcular a distância de um grau no equador",
+			origem:    protocolo.Coordenadas{Latitude: 0, Longitude: 0},
+			destino:   protocolo.Coordenadas{Latitude: 0, Longitude: 1},
+			esperado:  111195,
+		},
+	}
+
+	for i, cenário := range cenários {
+		distância := cenário.origem.Distância(cenário.destino)
+		if diferença := distância - cenário.esperado; diferença > 500 || diferença < -500 {
+			t.Errorf("Item %d, “%s”: distância %.0f inesperada, esperado %.0f",
+				i, cenário.descrição, distância, cenário.esperado)
+		}
+	}
+}
@@ -17,6 +17,10 @@ var númeroControleFormato = regexp.MustCompile(`^[0-9]+\-[0-9]+$`)
 // FrequênciaPedido armazena os dados exigidos pelo Exército ao utilizar um
 // estande de Tiro.
 type FrequênciaPedido struct {
+	// Clube número de identificação do Clube de Tiro que está reportando a
+	// frequência do Atirador.
+	Clube int64 `json:"clube"`
+
 	Calibre           string `json:"calibre"`
 	ArmaUtilizada     string `json:"armaUtilizada"`
 	NúmeroSérie       string `json:"numeroSerie"`
@@ -48,6 +52,10 @@ func (f *FrequênciaPedido) Normalizar() {
 func (f FrequênciaPedido) Validar() Mensagens {
 	var mensagens Mensagens
 
+	if f.Clube <= 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "clube", "0"))
+	}
+
 	if f.Calibre == "" {
 		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "calibre", ""))
 	}
@@ -92,16 +100,32 @@ func NovaFrequênciaPedidoCompleta(cr int, frequênciaPedido FrequênciaPedido)
 type FrequênciaPendenteResposta struct {
 	NúmeroControle    NúmeroControle `json:"numeroControle"`
 	CódigoVerificação string         `json:"codigoVerificacao"`
-	Imagem            string         `json:"imagem"` // base64
+
+	// Comprovante dados canônicos da frequência assinados com Ed25519, que
+	// também são embutidos no QR Code. Permite a verificação sem acesso ao
+	// servidor, somente com a chave pública. Fica vazio quando o serviço não
+	// possui uma chave de assinatura.
+	Comprovante string `json:"comprovante,omitempty"`
+
+	Imagem string `json:"imagem"` // base64
+}
+
+// ComprovanteChaveResposta chave pública utilizada para verificar os
+// comprovantes assinados das frequências.
+type ComprovanteChaveResposta struct {
+	Algoritmo string `json:"algoritmo"`
+	Chave     string `json:"chave"` // base64
 }
 
 // FrequênciaResposta armazena os dados da frequência visualizada.
 type FrequênciaResposta struct {
 	NúmeroControle    NúmeroControle `json:"numeroControle"`
 	CódigoVerificação string         `json:"codigoVerificacao"`
+	Clube             int64          `json:"clube"`
 	Calibre           string         `json:"calibre"`
 	ArmaUtilizada     string         `json:"armaUtilizada"`
 	NúmeroSérie       string         `json:"numeroSerie,omitempty"`
+	Arma              int64          `json:"arma,omitempty"`
 	GuiaDeTráfego     int            `json:"guiaTrafego,omitempty"`
 	QuantidadeMunição int            `json:"quantidadeMunicao"`
 	DataInício        time.Time      `json:"dataInicio"`
@@ -109,6 +133,13 @@ type FrequênciaResposta struct {
 	DataCriação       time.Time      `json:"dataCriacao"`
 	DataConfirmação   time.Time      `json:"dataConfirmacao,omitempty"`
 	Imagem            string         `json:"imagem"` // base64
+
+	// Situação indica o estado atual da frequência no seu ciclo de vida.
+	// Frequências canceladas possuem também a data e o motivo do
+	// cancelamento.
+	Situação           FrequênciaSituação `json:"situacao"`
+	DataCancelamento   time.Time          `json:"dataCancelamento,omitempty"`
+	MotivoCancelamento string             `json:"motivoCancelamento,omitempty"`
 }
 
 // FrequênciaConfirmaçãoPedido armazena os dados necessários para confirmar a
@@ -123,6 +154,13 @@ func (f *FrequênciaConfirmaçãoPedido) Normalizar() {
 	f.Imagem = strings.TrimSpace(f.Imagem)
 }
 
+// DefinirImagem preenche a imagem a partir do seu conteúdo binário, utilizado
+// quando a imagem não é enviada em base64 dentro do JSON, mas diretamente no
+// corpo da requisição.
+func (f *FrequênciaConfirmaçãoPedido) DefinirImagem(imagem []byte) {
+	f.Imagem = base64.StdEncoding.EncodeToString(imagem)
+}
+
 // Validar verifica se a imagem enviada na confirmação possuí um formato
 // correto.
 func (f FrequênciaConfirmaçãoPedido) Validar() Mensagens {
@@ -131,8 +169,10 @@ func (f FrequênciaConfirmaçãoPedido) Validar() Mensagens {
 		return NovasMensagens(NovaMensagemComCampo(MensagemCódigoImagemBase64Inválido, "imagem", f.Imagem))
 	}
 
+	// somente o cabeçalho é lido, evitando decodificar os pixels de imagens que
+	// ainda podem ser rejeitadas pelo tamanho
 	leitorImagem := bytes.NewReader(imagem)
-	if _, _, err = image.Decode(leitorImagem); err != nil {
+	if _, _, err = image.DecodeConfig(leitorImagem); err != nil {
 		return NovasMensagens(NovaMensagemComCampo(MensagemCódigoImagemFormatoInválido, "imagem", f.Imagem))
 	}
 
@@ -161,6 +201,50 @@ func NovaFrequênciaConfirmaçãoPedidoCompleta(cr int, númeroControle NúmeroC
 	}
 }
 
+// FrequênciaCancelamentoPedido armazena os dados necessários para que o Clube
+// de Tiro cancele uma frequência registrada por engano.
+type FrequênciaCancelamentoPedido struct {
+	Motivo string `json:"motivo"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição. Remove espaços do
+// início e do fim do motivo.
+func (f *FrequênciaCancelamentoPedido) Normalizar() {
+	f.Motivo = strings.TrimSpace(f.Motivo)
+}
+
+// Validar garante que o motivo do cancelamento foi informado.
+func (f FrequênciaCancelamentoPedido) Validar() Mensagens {
+	if f.Motivo == "" {
+		return NovasMensagens(NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "motivo", ""))
+	}
+
+	return nil
+}
+
+// FrequênciaCancelamentoPedidoCompleta extende o tipo
+// FrequênciaCancelamentoPedido incluindo o CR e o número de controle
+// encontrados no endereço, além da identidade do usuário que solicitou o
+// cancelamento.
+type FrequênciaCancelamentoPedidoCompleta struct {
+	CR             int
+	NúmeroControle NúmeroControle
+	Identidade     Identidade
+	FrequênciaCancelamentoPedido
+}
+
+// NovaFrequênciaCancelamentoPedidoCompleta inicializa o tipo
+// FrequênciaCancelamentoPedidoCompleta a partir do CR, número de controle,
+// identidade do usuário e do tipo FrequênciaCancelamentoPedido.
+func NovaFrequênciaCancelamentoPedidoCompleta(cr int, númeroControle NúmeroControle, identidade Identidade, frequênciaCancelamentoPedido FrequênciaCancelamentoPedido) FrequênciaCancelamentoPedidoCompleta {
+	return FrequênciaCancelamentoPedidoCompleta{
+		CR:                           cr,
+		NúmeroControle:               númeroControle,
+		Identidade:                   identidade,
+		FrequênciaCancelamentoPedido: frequênciaCancelamentoPedido,
+	}
+}
+
 // NúmeroControle número gerado a partir do cadastro de uma frequência para
 // comprovação da presença física do atirador no estande de tiro. Formado a
 // partir do número de identificação da frequência com um número gerado
@@ -237,3 +321,220 @@ func (n *NúmeroControle) UnmarshalText(texto []byte) error {
 	n.Normalizar()
 	return n.Validar().Expor()
 }
+
+const (
+	// FrequênciaSituaçãoPendente indica que a frequência foi cadastrada, mas
+	// ainda não foi confirmada com a imagem do Atirador.
+	FrequênciaSituaçãoPendente FrequênciaSituação = "pendente"
+
+	// FrequênciaSituaçãoConfirmada indica que a frequência foi confirmada com a
+	// imagem do Atirador no Clube de Tiro.
+	FrequênciaSituaçãoConfirmada FrequênciaSituação = "confirmada"
+
+	// FrequênciaSituaçãoCancelada indica que a frequência foi cancelada pelo
+	// Clube de Tiro antes da confirmação.
+	FrequênciaSituaçãoCancelada FrequênciaSituação = "cancelada"
+
+	// FrequênciaSituaçãoExpirada indica que a imagem de confirmação não foi
+	// enviada dentro do prazo de confirmação.
+	FrequênciaSituaçãoExpirada FrequênciaSituação = "expirada"
+
+	// FrequênciaSituaçãoEmAuditoria indica que a frequência confirmada foi
+	// selecionada para a verificação de um auditor.
+	FrequênciaSituaçãoEmAuditoria FrequênciaSituação = "em-auditoria"
+
+	// FrequênciaSituaçãoInvalidada indica que a frequência confirmada foi
+	// considerada inválida, não sendo contabilizada como treino.
+	FrequênciaSituaçãoInvalidada FrequênciaSituação = "invalidada"
+)
+
+// FrequênciaSituação define os possíveis estados de uma frequência, também
+// utilizados como filtro na listagem.
+type FrequênciaSituação string
+
+// Válida verifica se a situação é uma das situações conhecidas.
+func (f FrequênciaSituação) Válida() bool {
+	return f == FrequênciaSituaçãoPendente ||
+		f == FrequênciaSituaçãoConfirmada ||
+		f == FrequênciaSituaçãoCancelada ||
+		f == FrequênciaSituaçãoExpirada ||
+		f == FrequênciaSituaçãoEmAuditoria ||
+		f == FrequênciaSituaçãoInvalidada
+}
+
+const (
+	// FrequênciaOrdenaçãoDataInício ordena as frequências pela data de início
+	// do treino, das mais antigas para as mais recentes.
+	FrequênciaOrdenaçãoDataInício FrequênciaOrdenação = "dataInicio"
+
+	// FrequênciaOrdenaçãoDataInícioDecrescente ordena as frequências pela data
+	// de início do treino, das mais recentes para as mais antigas.
+	FrequênciaOrdenaçãoDataInícioDecrescente FrequênciaOrdenação = "-dataInicio"
+
+	// FrequênciaOrdenaçãoDataCriação ordena as frequências pela data de
+	// cadastro, das mais antigas para as mais recentes.
+	FrequênciaOrdenaçãoDataCriação FrequênciaOrdenação = "dataCriacao"
+
+	// FrequênciaOrdenaçãoDataCriaçãoDecrescente ordena as frequências pela data
+	// de cadastro, das mais recentes para as mais antigas.
+	FrequênciaOrdenaçãoDataCriaçãoDecrescente FrequênciaOrdenação = "-dataCriacao"
+
+	// FrequênciaOrdenaçãoCR ordena as frequências pelo CR do Atirador de forma
+	// crescente.
+	FrequênciaOrdenaçãoCR FrequênciaOrdenação = "cr"
+
+	// FrequênciaOrdenaçãoCRDecrescente ordena as frequências pelo CR do
+	// Atirador de forma decrescente.
+	FrequênciaOrdenaçãoCRDecrescente FrequênciaOrdenação = "-cr"
+)
+
+// FrequênciaOrdenação define o critério de ordenação da listagem de
+// frequências. O prefixo "-" indica a ordem decrescente.
+type FrequênciaOrdenação string
+
+// Válida verifica se a ordenação é uma das ordenações suportadas.
+func (f FrequênciaOrdenação) Válida() bool {
+	switch f {
+	case FrequênciaOrdenaçãoDataInício, FrequênciaOrdenaçãoDataInícioDecrescente,
+		FrequênciaOrdenaçãoDataCriação, FrequênciaOrdenaçãoDataCriaçãoDecrescente,
+		FrequênciaOrdenaçãoCR, FrequênciaOrdenaçãoCRDecrescente:
+		return true
+	}
+
+	return false
+}
+
+// Decrescente informa se a ordenação deve ser feita do maior para o menor
+// valor.
+func (f FrequênciaOrdenação) Decrescente() bool {
+	return strings.HasPrefix(string(f), "-")
+}
+
+const (
+	// FrequênciaListaLimitePadrão quantidade de frequências retornadas por página
+	// quando nenhum limite é informado.
+	FrequênciaListaLimitePadrão = 20
+
+	// FrequênciaListaLimiteMáximo quantidade máxima de frequências que podem ser
+	// retornadas em uma única página.
+	FrequênciaListaLimiteMáximo = 100
+)
+
+// FrequênciaFiltro armazena os critérios utilizados na listagem administrativa
+// das frequências. Campos não preenchidos não restringem o resultado.
+type FrequênciaFiltro struct {
+	CR          int
+	Clube       int64
+	Calibre     string
+	NúmeroSérie string
+
+	// DataInícioDe e DataInícioAté delimitam o período, inclusive, em que o
+	// treino foi iniciado.
+	DataInícioDe  time.Time
+	DataInícioAté time.Time
+
+	Situação  FrequênciaSituação
+	Ordenação FrequênciaOrdenação
+
+	// Cursor identifica a posição a partir da qual a próxima página deve ser
+	// retornada. É um valor opaco obtido na resposta da página anterior.
+	Cursor string
+
+	// Limite quantidade máxima de frequências retornadas na página.
+	Limite int
+}
+
+// Normalizar padroniza o formato dos campos do filtro, seguindo as mesmas
+// regras aplicadas no cadastro da frequência, e define os valores padrão de
+// ordenação e limite.
+func (f *FrequênciaFiltro) Normalizar() {
+	f.Calibre = strings.TrimSpace(f.Calibre)
+	f.Calibre = strings.ToUpper(f.Calibre)
+
+	f.NúmeroSérie = strings.TrimSpace(f.NúmeroSérie)
+	f.NúmeroSérie = strings.ToUpper(f.NúmeroSérie)
+
+	situação := strings.TrimSpace(string(f.Situação))
+	f.Situação = FrequênciaSituação(strings.ToLower(situação))
+
+	f.Ordenação = FrequênciaOrdenação(strings.TrimSpace(string(f.Ordenação)))
+	if f.Ordenação == "" {
+		f.Ordenação = FrequênciaOrdenaçãoDataInícioDecrescente
+	}
+
+	f.Cursor = strings.TrimSpace(f.Cursor)
+
+	if f.Limite == 0 {
+		f.Limite = FrequênciaListaLimitePadrão
+	}
+}
+
+// Validar analisa se os critérios informados possuem valores aceitáveis.
+func (f FrequênciaFiltro) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if f.CR < 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoParâmetroInválido, "cr", strconv.Itoa(f.CR)))
+	}
+
+	if f.Clube < 0 {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoParâmetroInválido, "clube", strconv.FormatInt(f.Clube, 10)))
+	}
+
+	if !f.DataInícioDe.IsZero() && !f.DataInícioAté.IsZero() && f.DataInícioDe.After(f.DataInícioAté) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoDatasPeríodoIncorreto))
+	}
+
+	if f.Situação != "" && !f.Situação.Válida() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoSituaçãoInválida, string(f.Situação)))
+	}
+
+	if !f.Ordenação.Válida() {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoOrdenaçãoInválida, string(f.Ordenação)))
+	}
+
+	if f.Limite < 1 || f.Limite > FrequênciaListaLimiteMáximo {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoParâmetroInválido, "limite", strconv.Itoa(f.Limite)))
+	}
+
+	return mensagens
+}
+
+// FrequênciaPendênciaChave armazena a quantidade de frequências pendentes cujo
+// código de verificação foi gerado com uma determinada chave. A chave legada
+// possui o identificador vazio.
+type FrequênciaPendênciaChave struct {
+	Chave     string `json:"chave"`
+	Pendentes int    `json:"pendentes"`
+}
+
+// FrequênciaListaResposta armazena uma página da listagem administrativa das
+// frequências.
+type FrequênciaListaResposta struct {
+	Frequências []FrequênciaListaItem `json:"frequencias"`
+
+	// PróximoCursor deve ser informado no filtro para obter a próxima página.
+	// Quando vazio não existem mais frequências a serem listadas.
+	PróximoCursor string `json:"proximoCursor,omitempty"`
+}
+
+// FrequênciaListaItem armazena os dados de uma frequência na listagem
+// administrativa. As imagens não são incluídas para manter a página pequena,
+// podendo ser obtidas na consulta individual da frequência.
+type FrequênciaListaItem struct {
+	NúmeroControle    NúmeroControle     `json:"numeroControle"`
+	CR                int                `json:"cr"`
+	Clube             int64              `json:"clube"`
+	Calibre           string             `json:"calibre"`
+	ArmaUtilizada     string             `json:"armaUtilizada"`
+	NúmeroSérie       string             `json:"numeroSerie,omitempty"`
+	Arma              int64              `json:"arma,omitempty"`
+	GuiaDeTráfego     int                `json:"guiaTrafego,omitempty"`
+	QuantidadeMunição int                `json:"quantidadeMunicao"`
+	DataInício        time.Time          `json:"dataInicio"`
+	DataTérmino       time.Time          `json:"dataTermino"`
+	DataCriação       time.Time          `json:"dataCriacao"`
+	DataConfirmação   time.Time          `json:"dataConfirmacao,omitempty"`
+	Situação          FrequênciaSituação `json:"situacao"`
+	DataCancelamento  time.Time          `json:"dataCancelamento,omitempty"`
+}
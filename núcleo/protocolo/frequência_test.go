@@ -62,6 +62,7 @@ func TestFrequênciaPedido_Validar(t *testing.T) {
 		{
 			descrição: "deve aceitar um pedido válido",
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           "380",
 				ArmaUtilizada:     "Arma do Clube",
 				NúmeroSérie:       "HG785671",
@@ -78,6 +79,7 @@ func TestFrequênciaPedido_Validar(t *testing.T) {
 				DataTérmino: data.Add(-10 * time.Minute),
 			},
 			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "clube", "0"),
 				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "calibre", ""),
 				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "armaUtilizada", ""),
 				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNúmeroSérieInválido, "785671"),
@@ -88,6 +90,7 @@ func TestFrequênciaPedido_Validar(t *testing.T) {
 		{
 			descrição: "deve detectar quando a data de término está depois do momento atual",
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           "380",
 				ArmaUtilizada:     "Arma do Clube",
 				NúmeroSérie:       "HG785671",
@@ -236,6 +239,36 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 	}
 }
 
+func TestFrequênciaConfirmaçãoPedido_DefinirImagem(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		imagem    []byte
+		esperado  protocolo.FrequênciaConfirmaçãoPedido
+	}{
+		{
+			descrição: "deve codificar a imagem em base64",
+			imagem:    []byte("Man is distinguished"),
+			esperado: protocolo.FrequênciaConfirmaçãoPedido{
+				Imagem: "TWFuIGlzIGRpc3Rpbmd1aXNoZWQ=",
+			},
+		},
+		{
+			descrição: "deve manter a imagem vazia quando não houver conteúdo",
+		},
+	}
+
+	for i, cenário := range cenários {
+		var frequênciaConfirmaçãoPedido protocolo.FrequênciaConfirmaçãoPedido
+		frequênciaConfirmaçãoPedido.DefinirImagem(cenário.imagem)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(frequênciaConfirmaçãoPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFrequênciaConfirmaçãoPedido_Validar(t *testing.T) {
 	cenários := []struct {
 		descrição                   string
@@ -302,6 +335,102 @@ YII=`,
 	}
 }
 
+func TestNovaFrequênciaCancelamentoPedidoCompleta(t *testing.T) {
+	cenários := []struct {
+		descrição                    string
+		cr                           int
+		númeroControle               protocolo.NúmeroControle
+		identidade                   protocolo.Identidade
+		frequênciaCancelamentoPedido protocolo.FrequênciaCancelamentoPedido
+		esperado                     protocolo.FrequênciaCancelamentoPedidoCompleta
+	}{
+		{
+			descrição:      "deve inicializar um objeto do tipo FrequênciaCancelamentoPedidoCompleta corretamente",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			identidade:     protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "CR informado incorretamente",
+			},
+			esperado: protocolo.FrequênciaCancelamentoPedidoCompleta{
+				CR:             123456789,
+				NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+				Identidade:     protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+				FrequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+					Motivo: "CR informado incorretamente",
+				},
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		frequênciaCancelamentoPedidoCompleta := protocolo.NovaFrequênciaCancelamentoPedidoCompleta(cenário.cr, cenário.númeroControle, cenário.identidade, cenário.frequênciaCancelamentoPedido)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(frequênciaCancelamentoPedidoCompleta, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaCancelamentoPedido_Normalizar(t *testing.T) {
+	cenários := []struct {
+		descrição                    string
+		frequênciaCancelamentoPedido protocolo.FrequênciaCancelamentoPedido
+		esperado                     protocolo.FrequênciaCancelamentoPedido
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "   CR informado incorretamente  ",
+			},
+			esperado: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "CR informado incorretamente",
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.frequênciaCancelamentoPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.frequênciaCancelamentoPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaCancelamentoPedido_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição                    string
+		frequênciaCancelamentoPedido protocolo.FrequênciaCancelamentoPedido
+		esperado                     protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um motivo preenchido",
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "CR informado incorretamente",
+			},
+		},
+		{
+			descrição: "deve detectar quando o motivo não foi informado",
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "motivo", ""),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.frequênciaCancelamentoPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestNovoNúmeroControle(t *testing.T) {
 	cenários := []struct {
 		descrição string
@@ -517,3 +646,135 @@ func TestNúmeroControle_UnmarshalText(t *testing.T) {
 		}
 	}
 }
+
+func TestFrequênciaOrdenação_Decrescente(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		ordenação protocolo.FrequênciaOrdenação
+		esperado  bool
+	}{
+		{
+			descrição: "deve identificar uma ordenação crescente",
+			ordenação: protocolo.FrequênciaOrdenaçãoCR,
+		},
+		{
+			descrição: "deve identificar uma ordenação decrescente",
+			ordenação: protocolo.FrequênciaOrdenaçãoDataInícioDecrescente,
+			esperado:  true,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.ordenação.Decrescente(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaFiltro_Normalizar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição        string
+		frequênciaFiltro protocolo.FrequênciaFiltro
+		esperado         protocolo.FrequênciaFiltro
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				CR:            380308,
+				Clube:         1,
+				Calibre:       "  calibre .380  ",
+				NúmeroSérie:   "  za785671  ",
+				DataInícioDe:  data.Add(-time.Hour),
+				DataInícioAté: data,
+				Situação:      "  Confirmada ",
+				Ordenação:     " cr ",
+				Cursor:        " abc123 ",
+				Limite:        10,
+			},
+			esperado: protocolo.FrequênciaFiltro{
+				CR:            380308,
+				Clube:         1,
+				Calibre:       "CALIBRE .380",
+				NúmeroSérie:   "ZA785671",
+				DataInícioDe:  data.Add(-time.Hour),
+				DataInícioAté: data,
+				Situação:      protocolo.FrequênciaSituaçãoConfirmada,
+				Ordenação:     protocolo.FrequênciaOrdenaçãoCR,
+				Cursor:        "abc123",
+				Limite:        10,
+			},
+		},
+		{
+			descrição: "deve definir os valores padrão",
+			esperado: protocolo.FrequênciaFiltro{
+				Ordenação: protocolo.FrequênciaOrdenaçãoDataInícioDecrescente,
+				Limite:    protocolo.FrequênciaListaLimitePadrão,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.frequênciaFiltro.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.frequênciaFiltro, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaFiltro_Validar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição        string
+		frequênciaFiltro protocolo.FrequênciaFiltro
+		esperado         protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um filtro válido",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				CR:            380308,
+				Clube:         1,
+				DataInícioDe:  data.Add(-time.Hour),
+				DataInícioAté: data,
+				Situação:      protocolo.FrequênciaSituaçãoPendente,
+				Ordenação:     protocolo.FrequênciaOrdenaçãoDataCriação,
+				Limite:        protocolo.FrequênciaListaLimiteMáximo,
+			},
+		},
+		{
+			descrição: "deve detectar erros de validação em todos os campos",
+			frequênciaFiltro: protocolo.FrequênciaFiltro{
+				CR:            -1,
+				Clube:         -2,
+				DataInícioDe:  data,
+				DataInícioAté: data.Add(-time.Hour),
+				Situação:      "arquivada",
+				Ordenação:     "calibre",
+				Limite:        protocolo.FrequênciaListaLimiteMáximo + 1,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "cr", "-1"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "clube", "-2"),
+				protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoSituaçãoInválida, "arquivada"),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoOrdenaçãoInválida, "calibre"),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "limite", "101"),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.frequênciaFiltro.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package protocolo
+
+import (
+	"strconv"
+	"time"
+)
+
+// HabitualidadeNívelPadrão nível de atividade utilizado no relatório de
+// habitualidade quando nenhum nível é informado.
+const HabitualidadeNívelPadrão = 1
+
+// HabitualidadeFiltro armazena os critérios do relatório de habitualidade, que
+// identifica os atiradores que não atingiram a quantidade mínima de treinos
+// confirmados no período.
+type HabitualidadeFiltro struct {
+	// DataInício e DataTérmino delimitam o período, inclusive, em que os
+	// treinos foram iniciados.
+	DataInício  time.Time
+	DataTérmino time.Time
+
+	// Nível nível de atividade dos atiradores, que define a quantidade mínima
+	// de treinos exigida.
+	Nível int
+}
+
+// Normalizar define os valores padrão do filtro. Quando não informado, o
+// período considerado é o último ano até o momento atual.
+func (h *HabitualidadeFiltro) Normalizar() {
+	if h.DataTérmino.IsZero() {
+		h.DataTérmino = time.Now()
+	}
+
+	if h.DataInício.IsZero() {
+		h.DataInício = h.DataTérmino.AddDate(-1, 0, 0)
+	}
+
+	if h.Nível == 0 {
+		h.Nível = HabitualidadeNívelPadrão
+	}
+}
+
+// Validar analisa se os critérios informados possuem valores aceitáveis.
+func (h HabitualidadeFiltro) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if h.DataInício.After(h.DataTérmino) {
+		mensagens = append(mensagens, NovaMensagem(MensagemCódigoDatasPeríodoIncorreto))
+	}
+
+	if h.Nível < 0 {
+		mensagens = append(mensagens, NovaMensagemComValor(MensagemCódigoNívelInválido, strconv.Itoa(h.Nível)))
+	}
+
+	return mensagens
+}
+
+// HabitualidadeResposta armazena o resultado do relatório de habitualidade.
+type HabitualidadeResposta struct {
+	DataInício  time.Time `json:"dataInicio"`
+	DataTérmino time.Time `json:"dataTermino"`
+	Nível       int       `json:"nivel"`
+
+	// TreinosExigidos quantidade mínima de treinos confirmados no período,
+	// proporcional à quantidade anual exigida para o nível.
+	TreinosExigidos int `json:"treinosExigidos"`
+
+	// Atiradores lista dos atiradores que não atingiram a quantidade mínima de
+	// treinos, ordenada pelo CR.
+	Atiradores []HabitualidadeAtirador `json:"atiradores"`
+}
+
+// HabitualidadeAtirador armazena a quantidade de treinos confirmados de um
+// atirador no período do relatório.
+type HabitualidadeAtirador struct {
+	CR      int `json:"cr"`
+	Treinos int `json:"treinos"`
+}
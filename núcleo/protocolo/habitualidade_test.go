@@ -0,0 +1,105 @@
+package protocolo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestHabitualidadeFiltro_Normalizar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição           string
+		habitualidadeFiltro protocolo.HabitualidadeFiltro
+		esperado            protocolo.HabitualidadeFiltro
+	}{
+		{
+			descrição: "deve manter os valores informados",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Nível:       2,
+			},
+			esperado: protocolo.HabitualidadeFiltro{
+				DataInício:  data.Add(-24 * time.Hour),
+				DataTérmino: data,
+				Nível:       2,
+			},
+		},
+		{
+			descrição: "deve definir o início do período e o nível padrão",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataTérmino: data,
+			},
+			esperado: protocolo.HabitualidadeFiltro{
+				DataInício:  data.AddDate(-1, 0, 0),
+				DataTérmino: data,
+				Nível:       protocolo.HabitualidadeNívelPadrão,
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.habitualidadeFiltro.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.habitualidadeFiltro, nil); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// quando nenhuma data é informada o período deve terminar no momento atual
+	var habitualidadeFiltro protocolo.HabitualidadeFiltro
+	habitualidadeFiltro.Normalizar()
+
+	if habitualidadeFiltro.DataTérmino.Before(data) {
+		t.Errorf("data de término inesperada: %s", habitualidadeFiltro.DataTérmino)
+	}
+
+	if !habitualidadeFiltro.DataInício.Equal(habitualidadeFiltro.DataTérmino.AddDate(-1, 0, 0)) {
+		t.Errorf("data de início inesperada: %s", habitualidadeFiltro.DataInício)
+	}
+}
+
+func TestHabitualidadeFiltro_Validar(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição           string
+		habitualidadeFiltro protocolo.HabitualidadeFiltro
+		esperado            protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar um filtro válido",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  data.AddDate(-1, 0, 0),
+				DataTérmino: data,
+				Nível:       1,
+			},
+		},
+		{
+			descrição: "deve detectar erros de validação nos campos",
+			habitualidadeFiltro: protocolo.HabitualidadeFiltro{
+				DataInício:  data,
+				DataTérmino: data.AddDate(-1, 0, 0),
+				Nível:       -1,
+			},
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNívelInválido, "-1"),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.habitualidadeFiltro.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
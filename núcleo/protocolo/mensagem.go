@@ -58,6 +58,180 @@ const (
 	// MensagemCódigoVerificaçãoInválida o código de verificação informado não
 	// corresponde ao calculado.
 	MensagemCódigoVerificaçãoInválida = "verificacao-invalida"
+
+	// MensagemCódigoCNPJInválido CNPJ informado não possui 14 dígitos ou os
+	// dígitos verificadores não conferem.
+	MensagemCódigoCNPJInválido = "cnpj-invalido"
+
+	// MensagemCódigoUFInválida Unidade Federativa informada não existe.
+	MensagemCódigoUFInválida = "uf-invalida"
+
+	// MensagemCódigoRegiãoMilitarInválida Região Militar informada não existe.
+	MensagemCódigoRegiãoMilitarInválida = "regiao-militar-invalida"
+
+	// MensagemCódigoSituaçãoInválida situação informada não é uma das situações
+	// conhecidas.
+	MensagemCódigoSituaçãoInválida = "situacao-invalida"
+
+	// MensagemCódigoClubeJáCadastrado já existe um clube cadastrado com o mesmo
+	// CNPJ.
+	MensagemCódigoClubeJáCadastrado = "clube-ja-cadastrado"
+
+	// MensagemCódigoClubeInválido clube informado não está cadastrado no
+	// sistema.
+	MensagemCódigoClubeInválido = "clube-invalido"
+
+	// MensagemCódigoClubeInativo clube informado não está ativo e não pode
+	// reportar frequências.
+	MensagemCódigoClubeInativo = "clube-inativo"
+
+	// MensagemCódigoCredenciaisInválidas usuário não existe ou a senha informada
+	// não confere. Não é informado qual dos dois casos ocorreu para não facilitar
+	// a descoberta de usuários válidos.
+	MensagemCódigoCredenciaisInválidas = "credenciais-invalidas"
+
+	// MensagemCódigoAutenticaçãoNecessária o recurso exige que o usuário esteja
+	// autenticado, porém nenhum token foi informado.
+	MensagemCódigoAutenticaçãoNecessária = "autenticacao-necessaria"
+
+	// MensagemCódigoTokenInválido token de autenticação informado possui um
+	// formato incorreto ou a assinatura não confere.
+	MensagemCódigoTokenInválido = "token-invalido"
+
+	// MensagemCódigoTokenExpirado token de autenticação informado já passou do
+	// prazo de validade, sendo necessário um novo login.
+	MensagemCódigoTokenExpirado = "token-expirado"
+
+	// MensagemCódigoAcessoNegado usuário autenticado não possui permissão para
+	// executar a ação solicitada.
+	MensagemCódigoAcessoNegado = "acesso-negado"
+
+	// MensagemCódigoOrdenaçãoInválida ordenação informada não é uma das
+	// ordenações suportadas na listagem.
+	MensagemCódigoOrdenaçãoInválida = "ordenacao-invalida"
+
+	// MensagemCódigoCursorInválido cursor de paginação informado não foi gerado
+	// pelo sistema ou não corresponde à ordenação solicitada.
+	MensagemCódigoCursorInválido = "cursor-invalido"
+
+	// MensagemCódigoNívelInválido nível de atividade informado não possui uma
+	// quantidade mínima de treinos configurada.
+	MensagemCódigoNívelInválido = "nivel-invalido"
+
+	// MensagemCódigoCPFInválido CPF informado não possui 11 dígitos ou os
+	// dígitos verificadores não conferem.
+	MensagemCódigoCPFInválido = "cpf-invalido"
+
+	// MensagemCódigoAtiradorJáCadastrado já existe um atirador cadastrado com o
+	// mesmo CR.
+	MensagemCódigoAtiradorJáCadastrado = "atirador-ja-cadastrado"
+
+	// MensagemCódigoImportaçãoMuitoGrande quantidade de registros enviados na
+	// importação excede o limite permitido.
+	MensagemCódigoImportaçãoMuitoGrande = "importacao-muito-grande"
+
+	// MensagemCódigoCRNãoCadastrado CR informado não pertence a nenhum atirador
+	// cadastrado no sistema.
+	MensagemCódigoCRNãoCadastrado = "cr-nao-cadastrado"
+
+	// MensagemCódigoCRExpirado CR informado já havia passado da data de validade
+	// no momento do treino.
+	MensagemCódigoCRExpirado = "cr-expirado"
+
+	// MensagemCódigoCRSuspenso CR informado está suspenso e não pode ter
+	// frequências registradas.
+	MensagemCódigoCRSuspenso = "cr-suspenso"
+
+	// MensagemCódigoCRCancelado CR informado foi cancelado e não pode ter
+	// frequências registradas.
+	MensagemCódigoCRCancelado = "cr-cancelado"
+
+	// MensagemCódigoProprietárioInválido arma deve pertencer a um atirador ou a
+	// um clube, não sendo permitido informar os dois ou nenhum deles.
+	MensagemCódigoProprietárioInválido = "proprietario-invalido"
+
+	// MensagemCódigoTipoRegistroInválido tipo de registro informado não é um
+	// dos sistemas de registro de armas conhecidos.
+	MensagemCódigoTipoRegistroInválido = "tipo-registro-invalido"
+
+	// MensagemCódigoArmaJáCadastrada já existe uma arma cadastrada no acervo
+	// com o mesmo número de série.
+	MensagemCódigoArmaJáCadastrada = "arma-ja-cadastrada"
+
+	// MensagemCódigoArmaNãoCadastrada número de série informado não pertence a
+	// nenhuma arma do acervo.
+	MensagemCódigoArmaNãoCadastrada = "arma-nao-cadastrada"
+
+	// MensagemCódigoArmaCalibreDivergente calibre informado não corresponde ao
+	// calibre da arma cadastrada no acervo.
+	MensagemCódigoArmaCalibreDivergente = "arma-calibre-divergente"
+
+	// MensagemCódigoArmaProprietárioDivergente arma informada não pertence ao
+	// atirador nem ao clube que está reportando a frequência.
+	MensagemCódigoArmaProprietárioDivergente = "arma-proprietario-divergente"
+
+	// MensagemCódigoCalibreDesconhecido calibre informado não corresponde ao
+	// nome ou a nenhum dos apelidos dos calibres do catálogo.
+	MensagemCódigoCalibreDesconhecido = "calibre-desconhecido"
+
+	// MensagemCódigoCotaMuniçãoExcedida quantidade de munição informada somada
+	// ao consumo do atirador no ano ultrapassa a cota anual do calibre.
+	MensagemCódigoCotaMuniçãoExcedida = "cota-municao-excedida"
+
+	// MensagemCódigoFrequênciaCancelada frequência referenciada foi cancelada
+	// pelo clube e não pode mais ser alterada.
+	MensagemCódigoFrequênciaCancelada = "frequencia-cancelada"
+
+	// MensagemCódigoPrazoCancelamentoExpirado prazo limite para o cancelamento
+	// da frequência já passou.
+	MensagemCódigoPrazoCancelamentoExpirado = "prazo-cancelamento-expirado"
+
+	// MensagemCódigoTransiçãoSituaçãoInválida situação atual da frequência não
+	// permite a operação solicitada.
+	MensagemCódigoTransiçãoSituaçãoInválida = "transicao-situacao-invalida"
+
+	// MensagemCódigoCritérioAmostraInválido a amostra de auditoria deve ser
+	// definida por um percentual ou por uma quantidade por clube, nunca pelos
+	// dois ao mesmo tempo.
+	MensagemCódigoCritérioAmostraInválido = "criterio-amostra-invalido"
+
+	// MensagemCódigoPercentualInválido percentual informado deve estar entre 1
+	// e 100.
+	MensagemCódigoPercentualInválido = "percentual-invalido"
+
+	// MensagemCódigoVereditoInválido veredito informado não é um dos vereditos
+	// conhecidos da auditoria.
+	MensagemCódigoVereditoInválido = "veredito-invalido"
+
+	// MensagemCódigoAuditoriaConcluída auditoria já recebeu um veredito
+	// definitivo e não pode mais ser alterada.
+	MensagemCódigoAuditoriaConcluída = "auditoria-concluida"
+
+	// MensagemCódigoImagemReutilizada imagem enviada na confirmação é igual ou
+	// muito semelhante a uma imagem já aceita na confirmação de outra
+	// frequência.
+	MensagemCódigoImagemReutilizada = "imagem-reutilizada"
+
+	// MensagemCódigoImagemForaPeríodoTreino imagem enviada na confirmação foi
+	// capturada, segundo os seus metadados, fora do período do treino.
+	MensagemCódigoImagemForaPeríodoTreino = "imagem-fora-periodo-treino"
+
+	// MensagemCódigoImagemSemMetadados imagem enviada na confirmação não possui
+	// a data de captura nos seus metadados.
+	MensagemCódigoImagemSemMetadados = "imagem-sem-metadados"
+
+	// MensagemCódigoImagemForaClube imagem enviada na confirmação foi
+	// capturada, segundo as coordenadas GPS dos seus metadados, longe do
+	// estande de tiro do clube.
+	MensagemCódigoImagemForaClube = "imagem-fora-clube"
+
+	// MensagemCódigoImagemResoluçãoExcedida imagem enviada na confirmação possui
+	// uma quantidade de pixels acima do limite configurado.
+	MensagemCódigoImagemResoluçãoExcedida = "imagem-resolucao-excedida"
+
+	// MensagemCódigoLocalizaçãoInválida coordenadas geográficas com latitude ou
+	// longitude fora dos limites possíveis.
+	MensagemCódigoLocalizaçãoInválida = "localizacao-invalida"
 )
 
 // MensagemCódigo tipo que define as possíveis mensagens a serem retornadas. A
@@ -168,7 +342,3 @@ func JuntarMensagens(m ...Mensagens) Mensagens {
 	}
 	return mensagensJuntas
 }
-
-// TODO(rafaeljusto): Criar método de tradução do código para uma mensagem.
-// Embora tenhamos somente o idioma português, devemos evitar de deixar as
-// mensagens escritas diretamente no código fonte.
@@ -0,0 +1,25 @@
+package protocolo
+
+// MuniçãoConsumoResposta armazena a quantidade de munição consumida por um
+// atirador em cada calibre durante um ano civil.
+type MuniçãoConsumoResposta struct {
+	CR  int `json:"cr"`
+	Ano int `json:"ano"`
+
+	// Calibres lista do consumo em cada calibre utilizado no ano, ordenada pelo
+	// nome do calibre.
+	Calibres []MuniçãoConsumoCalibre `json:"calibres"`
+}
+
+// MuniçãoConsumoCalibre armazena o consumo de munição de um calibre no ano e a
+// cota anual definida para a classe do calibre.
+type MuniçãoConsumoCalibre struct {
+	Calibre    string        `json:"calibre"`
+	Classe     CalibreClasse `json:"classe,omitempty"`
+	Quantidade int           `json:"quantidade"`
+
+	// Cota quantidade máxima de munição no ano. Quando não existe cota
+	// configurada para a classe do calibre o consumo não possui limite e o
+	// campo é omitido.
+	Cota *int `json:"cota,omitempty"`
+}
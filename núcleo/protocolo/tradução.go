@@ -0,0 +1,239 @@
+package protocolo
+
+import (
+	"sort"
+	"strings"
+)
+
+// Idioma identifica, no formato de etiqueta da BCP 47, o idioma dos textos das
+// mensagens.
+type Idioma string
+
+const (
+	// IdiomaPortuguês português do Brasil, idioma principal do sistema.
+	IdiomaPortuguês Idioma = "pt-BR"
+
+	// IdiomaInglês inglês, disponibilizado como exemplo de tradução.
+	IdiomaInglês Idioma = "en"
+
+	// IdiomaPadrão idioma utilizado quando o cliente não solicita nenhum dos
+	// idiomas suportados.
+	IdiomaPadrão = IdiomaPortuguês
+)
+
+// Idiomas retorna os idiomas que possuem textos no catálogo de mensagens, com
+// o idioma padrão em primeiro lugar.
+func Idiomas() []Idioma {
+	return []Idioma{IdiomaPortuguês, IdiomaInglês}
+}
+
+// catálogoMensagens armazena os textos de cada código de mensagem por idioma.
+// Os marcadores {campo} e {valor} são substituídos pelos respectivos atributos
+// da mensagem, portanto só são utilizados nos códigos que sempre os informam.
+var catálogoMensagens = map[Idioma]map[MensagemCódigo]string{
+	IdiomaPortuguês: {
+		MensagemCódigoParâmetroInválido:           "Parâmetro com formato inválido",
+		MensagemCódigoNúmeroControleInválido:      "Número de controle “{valor}” inválido",
+		MensagemCódigoCRInválido:                  "CR inválido",
+		MensagemCódigoPrazoConfirmaçãoExpirado:    "Prazo para a confirmação da frequência expirado",
+		MensagemCódigoDatasPeríodoIncorreto:       "Datas de início e término do treino incoerentes",
+		MensagemCódigoNúmeroSérieInválido:         "Número de série “{valor}” inválido",
+		MensagemCódigoCampoNãoPreenchido:          "Campo “{campo}” de preenchimento obrigatório",
+		MensagemCódigoImagemBase64Inválido:        "Imagem do campo “{campo}” não está codificada corretamente em base64",
+		MensagemCódigoImagemFormatoInválido:       "Imagem do campo “{campo}” possui um formato inválido ou não suportado",
+		MensagemCódigoImagemNãoAceita:             "Imagem não aceita na confirmação da frequência",
+		MensagemCódigoFrequênciaJáConfirmada:      "Frequência já confirmada",
+		MensagemCódigoTreinoMuitoLongo:            "Duração do treino excede o máximo permitido",
+		MensagemCódigoTempoMáximaCadastroExcedido: "Prazo para o cadastro do treino excedido",
+		MensagemCódigoVerificaçãoInválida:         "Código de verificação inválido",
+		MensagemCódigoCNPJInválido:                "CNPJ “{valor}” inválido",
+		MensagemCódigoUFInválida:                  "Unidade Federativa “{valor}” inexistente",
+		MensagemCódigoRegiãoMilitarInválida:       "Região Militar “{valor}” inexistente",
+		MensagemCódigoSituaçãoInválida:            "Situação “{valor}” desconhecida",
+		MensagemCódigoClubeJáCadastrado:           "Já existe um clube cadastrado com o CNPJ “{valor}”",
+		MensagemCódigoClubeInválido:               "Clube “{valor}” não cadastrado",
+		MensagemCódigoClubeInativo:                "Clube “{valor}” inativo",
+		MensagemCódigoCredenciaisInválidas:        "Usuário ou senha inválidos",
+		MensagemCódigoAutenticaçãoNecessária:      "Autenticação necessária",
+		MensagemCódigoTokenInválido:               "Token de autenticação inválido",
+		MensagemCódigoTokenExpirado:               "Token de autenticação expirado",
+		MensagemCódigoAcessoNegado:                "Acesso negado",
+		MensagemCódigoOrdenaçãoInválida:           "Ordenação “{valor}” não suportada",
+		MensagemCódigoCursorInválido:              "Cursor de paginação “{valor}” inválido",
+		MensagemCódigoNívelInválido:               "Nível de atividade “{valor}” sem quantidade mínima de treinos",
+		MensagemCódigoCPFInválido:                 "CPF “{valor}” inválido",
+		MensagemCódigoAtiradorJáCadastrado:        "Já existe um atirador cadastrado com o CR “{valor}”",
+		MensagemCódigoImportaçãoMuitoGrande:       "Quantidade de registros do campo “{campo}” excede o limite da importação",
+		MensagemCódigoCRNãoCadastrado:             "CR “{valor}” não cadastrado",
+		MensagemCódigoCRExpirado:                  "CR “{valor}” expirado na data do treino",
+		MensagemCódigoCRSuspenso:                  "CR “{valor}” suspenso",
+		MensagemCódigoCRCancelado:                 "CR “{valor}” cancelado",
+		MensagemCódigoProprietárioInválido:        "A arma deve pertencer a um atirador ou a um clube",
+		MensagemCódigoTipoRegistroInválido:        "Tipo de registro “{valor}” desconhecido",
+		MensagemCódigoArmaJáCadastrada:            "Já existe uma arma cadastrada com o número de série “{valor}”",
+		MensagemCódigoArmaNãoCadastrada:           "Arma com número de série “{valor}” não cadastrada",
+		MensagemCódigoArmaCalibreDivergente:       "Calibre “{valor}” diferente do calibre da arma cadastrada",
+		MensagemCódigoArmaProprietárioDivergente:  "Arma com número de série “{valor}” não pertence ao atirador nem ao clube",
+		MensagemCódigoCalibreDesconhecido:         "Calibre “{valor}” desconhecido",
+		MensagemCódigoCotaMuniçãoExcedida:         "Cota anual de munição do calibre “{valor}” excedida",
+		MensagemCódigoFrequênciaCancelada:         "Frequência cancelada",
+		MensagemCódigoPrazoCancelamentoExpirado:   "Prazo para o cancelamento da frequência expirado",
+		MensagemCódigoTransiçãoSituaçãoInválida:   "Operação não permitida para uma frequência na situação “{valor}”",
+		MensagemCódigoCritérioAmostraInválido:     "Amostra deve ser definida por percentual ou por quantidade por clube",
+		MensagemCódigoPercentualInválido:          "Percentual “{valor}” deve estar entre 1 e 100",
+		MensagemCódigoVereditoInválido:            "Veredito “{valor}” desconhecido",
+		MensagemCódigoAuditoriaConcluída:          "Auditoria já concluída",
+		MensagemCódigoImagemReutilizada:           "Imagem já utilizada na confirmação de outra frequência",
+		MensagemCódigoImagemForaPeríodoTreino:     "Imagem capturada em “{valor}”, fora do período do treino",
+		MensagemCódigoImagemSemMetadados:          "Imagem sem a data de captura nos metadados",
+		MensagemCódigoImagemForaClube:             "Imagem capturada longe do estande de tiro do clube",
+		MensagemCódigoImagemResoluçãoExcedida:     "Imagem do campo “{campo}” com resolução {valor} acima do limite permitido",
+		MensagemCódigoLocalizaçãoInválida:         "Localização “{valor}” fora dos limites possíveis",
+	},
+	IdiomaInglês: {
+		MensagemCódigoParâmetroInválido:           "Parameter with invalid format",
+		MensagemCódigoNúmeroControleInválido:      "Invalid control number “{valor}”",
+		MensagemCódigoCRInválido:                  "Invalid CR",
+		MensagemCódigoPrazoConfirmaçãoExpirado:    "Attendance confirmation deadline expired",
+		MensagemCódigoDatasPeríodoIncorreto:       "Inconsistent training start and end dates",
+		MensagemCódigoNúmeroSérieInválido:         "Invalid serial number “{valor}”",
+		MensagemCódigoCampoNãoPreenchido:          "Field “{campo}” is required",
+		MensagemCódigoImagemBase64Inválido:        "Image in field “{campo}” is not correctly base64 encoded",
+		MensagemCódigoImagemFormatoInválido:       "Image in field “{campo}” has an invalid or unsupported format",
+		MensagemCódigoImagemNãoAceita:             "Image not accepted for the attendance confirmation",
+		MensagemCódigoFrequênciaJáConfirmada:      "Attendance already confirmed",
+		MensagemCódigoTreinoMuitoLongo:            "Training duration exceeds the allowed maximum",
+		MensagemCódigoTempoMáximaCadastroExcedido: "Training registration deadline exceeded",
+		MensagemCódigoVerificaçãoInválida:         "Invalid verification code",
+		MensagemCódigoCNPJInválido:                "Invalid CNPJ “{valor}”",
+		MensagemCódigoUFInválida:                  "State “{valor}” does not exist",
+		MensagemCódigoRegiãoMilitarInválida:       "Military Region “{valor}” does not exist",
+		MensagemCódigoSituaçãoInválida:            "Unknown status “{valor}”",
+		MensagemCódigoClubeJáCadastrado:           "A club with CNPJ “{valor}” is already registered",
+		MensagemCódigoClubeInválido:               "Club “{valor}” is not registered",
+		MensagemCódigoClubeInativo:                "Club “{valor}” is inactive",
+		MensagemCódigoCredenciaisInválidas:        "Invalid username or password",
+		MensagemCódigoAutenticaçãoNecessária:      "Authentication required",
+		MensagemCódigoTokenInválido:               "Invalid authentication token",
+		MensagemCódigoTokenExpirado:               "Authentication token expired",
+		MensagemCódigoAcessoNegado:                "Access denied",
+		MensagemCódigoOrdenaçãoInválida:           "Unsupported ordering “{valor}”",
+		MensagemCódigoCursorInválido:              "Invalid pagination cursor “{valor}”",
+		MensagemCódigoNívelInválido:               "Activity level “{valor}” has no minimum number of trainings",
+		MensagemCódigoCPFInválido:                 "Invalid CPF “{valor}”",
+		MensagemCódigoAtiradorJáCadastrado:        "A shooter with CR “{valor}” is already registered",
+		MensagemCódigoImportaçãoMuitoGrande:       "Number of records in field “{campo}” exceeds the import limit",
+		MensagemCódigoCRNãoCadastrado:             "CR “{valor}” is not registered",
+		MensagemCódigoCRExpirado:                  "CR “{valor}” was expired on the training date",
+		MensagemCódigoCRSuspenso:                  "CR “{valor}” is suspended",
+		MensagemCódigoCRCancelado:                 "CR “{valor}” is cancelled",
+		MensagemCódigoProprietárioInválido:        "The weapon must belong to either a shooter or a club",
+		MensagemCódigoTipoRegistroInválido:        "Unknown registry type “{valor}”",
+		MensagemCódigoArmaJáCadastrada:            "A weapon with serial number “{valor}” is already registered",
+		MensagemCódigoArmaNãoCadastrada:           "Weapon with serial number “{valor}” is not registered",
+		MensagemCódigoArmaCalibreDivergente:       "Calibre “{valor}” differs from the registered weapon calibre",
+		MensagemCódigoArmaProprietárioDivergente:  "Weapon with serial number “{valor}” belongs neither to the shooter nor to the club",
+		MensagemCódigoCalibreDesconhecido:         "Unknown calibre “{valor}”",
+		MensagemCódigoCotaMuniçãoExcedida:         "Annual ammunition quota for calibre “{valor}” exceeded",
+		MensagemCódigoFrequênciaCancelada:         "Attendance cancelled",
+		MensagemCódigoPrazoCancelamentoExpirado:   "Attendance cancellation deadline expired",
+		MensagemCódigoTransiçãoSituaçãoInválida:   "Operation not allowed for an attendance with status “{valor}”",
+		MensagemCódigoCritérioAmostraInválido:     "Sample must be defined either by percentage or by quantity per club",
+		MensagemCódigoPercentualInválido:          "Percentage “{valor}” must be between 1 and 100",
+		MensagemCódigoVereditoInválido:            "Unknown verdict “{valor}”",
+		MensagemCódigoAuditoriaConcluída:          "Audit already concluded",
+		MensagemCódigoImagemReutilizada:           "Image already used to confirm another attendance",
+		MensagemCódigoImagemForaPeríodoTreino:     "Image captured at “{valor}”, outside the training period",
+		MensagemCódigoImagemSemMetadados:          "Image without capture date in its metadata",
+		MensagemCódigoImagemForaClube:             "Image captured far from the club shooting range",
+		MensagemCódigoImagemResoluçãoExcedida:     "Image in field “{campo}” with resolution {valor} exceeds the allowed limit",
+		MensagemCódigoLocalizaçãoInválida:         "Location “{valor}” out of the possible bounds",
+	},
+}
+
+// CódigosMensagem retorna todos os códigos de mensagem conhecidos, em ordem
+// alfabética.
+func CódigosMensagem() []MensagemCódigo {
+	códigos := make([]MensagemCódigo, 0, len(catálogoMensagens[IdiomaPadrão]))
+	for código := range catálogoMensagens[IdiomaPadrão] {
+		códigos = append(códigos, código)
+	}
+
+	sort.Slice(códigos, func(i, j int) bool {
+		return códigos[i] < códigos[j]
+	})
+
+	return códigos
+}
+
+// textoMensagem retorna o texto do código no idioma solicitado, utilizando o
+// idioma padrão quando não existe tradução.
+func textoMensagem(código MensagemCódigo, idioma Idioma) string {
+	if texto, ok := catálogoMensagens[idioma][código]; ok {
+		return texto
+	}
+
+	return catálogoMensagens[IdiomaPadrão][código]
+}
+
+// Traduzir retorna uma cópia da mensagem com o texto no idioma solicitado,
+// substituindo os marcadores pelo campo e pelo valor da mensagem. Códigos
+// desconhecidos resultam em um texto vazio.
+func (m Mensagem) Traduzir(idioma Idioma) Mensagem {
+	substituidor := strings.NewReplacer("{campo}", m.Campo, "{valor}", m.Valor)
+	m.Texto = substituidor.Replace(textoMensagem(m.Código, idioma))
+	return m
+}
+
+// Traduzir retorna uma cópia das mensagens com os textos no idioma
+// solicitado.
+func (m Mensagens) Traduzir(idioma Idioma) Mensagens {
+	if m == nil {
+		return nil
+	}
+
+	mensagens := make(Mensagens, len(m))
+	for i, mensagem := range m {
+		mensagens[i] = mensagem.Traduzir(idioma)
+	}
+	return mensagens
+}
+
+// MensagemCatálogoResposta descreve um código de mensagem. A descrição mantém
+// os marcadores {campo} e {valor}, substituídos nas mensagens pelos atributos
+// de mesmo nome.
+type MensagemCatálogoResposta struct {
+	Código    MensagemCódigo `json:"codigo"`
+	Descrição string         `json:"descricao"`
+}
+
+// CatálogoMensagensResposta armazena todos os códigos de mensagem conhecidos,
+// permitindo que os sistemas dos clubes mantenham as suas traduções
+// sincronizadas.
+type CatálogoMensagensResposta struct {
+	Idioma    Idioma                     `json:"idioma"`
+	Mensagens []MensagemCatálogoResposta `json:"mensagens"`
+}
+
+// NovoCatálogoMensagensResposta lista todos os códigos de mensagem conhecidos
+// com a descrição no idioma solicitado.
+func NovoCatálogoMensagensResposta(idioma Idioma) CatálogoMensagensResposta {
+	if _, ok := catálogoMensagens[idioma]; !ok {
+		idioma = IdiomaPadrão
+	}
+
+	códigos := CódigosMensagem()
+
+	catálogo := CatálogoMensagensResposta{
+		Idioma:    idioma,
+		Mensagens: make([]MensagemCatálogoResposta, len(códigos)),
+	}
+
+	for i, código := range códigos {
+		catálogo.Mensagens[i] = MensagemCatálogoResposta{
+			Código:    código,
+			Descrição: textoMensagem(código, idioma),
+		}
+	}
+	return catálogo
+}
@@ -0,0 +1,213 @@
+package protocolo_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestMensagem_Traduzir(t *testing.T) {
+	cenários := []struct {
+		descrição        string
+		mensagem         protocolo.Mensagem
+		idioma           protocolo.Idioma
+		mensagemEsperada protocolo.Mensagem
+	}{
+		{
+			descrição: "deve traduzir uma mensagem sem campo e valor",
+			mensagem:  protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
+			idioma:    protocolo.IdiomaPortuguês,
+			mensagemEsperada: protocolo.Mensagem{
+				Código: protocolo.MensagemCódigoFrequênciaJáConfirmada,
+				Texto:  "Frequência já confirmada",
+			},
+		},
+		{
+			descrição: "deve substituir o campo no texto da mensagem",
+			mensagem:  protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "calibre", ""),
+			idioma:    protocolo.IdiomaInglês,
+			mensagemEsperada: protocolo.Mensagem{
+				Código: protocolo.MensagemCódigoCampoNãoPreenchido,
+				Campo:  "calibre",
+				Texto:  "Field “calibre” is required",
+			},
+		},
+		{
+			descrição: "deve substituir o valor no texto da mensagem",
+			mensagem:  protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRNãoCadastrado, "380308"),
+			idioma:    protocolo.IdiomaPortuguês,
+			mensagemEsperada: protocolo.Mensagem{
+				Código: protocolo.MensagemCódigoCRNãoCadastrado,
+				Valor:  "380308",
+				Texto:  "CR “380308” não cadastrado",
+			},
+		},
+		{
+			descrição: "deve utilizar o idioma padrão quando o idioma não é suportado",
+			mensagem:  protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			idioma:    protocolo.Idioma("fr"),
+			mensagemEsperada: protocolo.Mensagem{
+				Código: protocolo.MensagemCódigoAcessoNegado,
+				Texto:  "Acesso negado",
+			},
+		},
+		{
+			descrição: "deve deixar o texto vazio para um código desconhecido",
+			mensagem:  protocolo.NovaMensagemComValor(protocolo.MensagemCódigo("codigo-desconhecido"), "valor"),
+			idioma:    protocolo.IdiomaPortuguês,
+			mensagemEsperada: protocolo.Mensagem{
+				Código: protocolo.MensagemCódigo("codigo-desconhecido"),
+				Valor:  "valor",
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		mensagem := cenário.mensagem.Traduzir(cenário.idioma)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.mensagemEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(mensagem, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestMensagens_Traduzir(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		mensagens          protocolo.Mensagens
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve traduzir todas as mensagens",
+			mensagens: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenExpirado),
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, ".999"),
+			),
+			mensagensEsperadas: protocolo.Mensagens{
+				{Código: protocolo.MensagemCódigoTokenExpirado, Texto: "Authentication token expired"},
+				{Código: protocolo.MensagemCódigoCalibreDesconhecido, Valor: ".999", Texto: "Unknown calibre “.999”"},
+			},
+		},
+		{
+			descrição: "deve manter as mensagens indefinidas",
+		},
+	}
+
+	for i, cenário := range cenários {
+		mensagens := cenário.mensagens.Traduzir(protocolo.IdiomaInglês)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCódigosMensagem(t *testing.T) {
+	// os códigos são extraídos diretamente das constantes declaradas, garantindo
+	// que um novo código não seja criado sem o seu texto no catálogo
+	arquivo, err := parser.ParseFile(token.NewFileSet(), "mensagem.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Erro ao interpretar o arquivo de mensagens. Detalhes: %s", err)
+	}
+
+	var esperados []protocolo.MensagemCódigo
+	ast.Inspect(arquivo, func(nó ast.Node) bool {
+		especificação, ok := nó.(*ast.ValueSpec)
+		if !ok || len(especificação.Names) != 1 || !strings.HasPrefix(especificação.Names[0].Name, "MensagemCódigo") {
+			return true
+		}
+
+		if literal, ok := especificação.Values[0].(*ast.BasicLit); ok {
+			código, _ := strconv.Unquote(literal.Value)
+			esperados = append(esperados, protocolo.MensagemCódigo(código))
+		}
+		return true
+	})
+
+	sort.Slice(esperados, func(i, j int) bool {
+		return esperados[i] < esperados[j]
+	})
+
+	if len(esperados) == 0 {
+		t.Fatal("Nenhum código de mensagem foi encontrado no arquivo de mensagens")
+	}
+
+	códigos := protocolo.CódigosMensagem()
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve listar todos os códigos declarados", 0)
+	verificadorResultado.DefinirEsperado(esperados, nil)
+	if err := verificadorResultado.VerificaResultado(códigos, nil); err != nil {
+		t.Error(err)
+	}
+
+	for _, idioma := range protocolo.Idiomas() {
+		for _, código := range códigos {
+			if texto := protocolo.NovaMensagem(código).Traduzir(idioma).Texto; texto == "" {
+				t.Errorf("Código “%s” sem texto no idioma “%s”", código, idioma)
+			}
+		}
+	}
+}
+
+func TestNovoCatálogoMensagensResposta(t *testing.T) {
+	cenários := []struct {
+		descrição      string
+		idioma         protocolo.Idioma
+		idiomaEsperado protocolo.Idioma
+		textoEsperado  string
+	}{
+		{
+			descrição:      "deve listar as descrições no idioma solicitado mantendo os marcadores",
+			idioma:         protocolo.IdiomaInglês,
+			idiomaEsperado: protocolo.IdiomaInglês,
+			textoEsperado:  "Field “{campo}” is required",
+		},
+		{
+			descrição:      "deve utilizar o idioma padrão quando o idioma não é suportado",
+			idioma:         protocolo.Idioma("fr"),
+			idiomaEsperado: protocolo.IdiomaPortuguês,
+			textoEsperado:  "Campo “{campo}” de preenchimento obrigatório",
+		},
+	}
+
+	códigos := protocolo.CódigosMensagem()
+
+	for i, cenário := range cenários {
+		catálogo := protocolo.NovoCatálogoMensagensResposta(cenário.idioma)
+
+		if len(catálogo.Mensagens) != len(códigos) {
+			t.Errorf("Item %d, “%s”: quantidade de códigos no catálogo não bate. Esperado %d; encontrado %d",
+				i, cenário.descrição, len(códigos), len(catálogo.Mensagens))
+			continue
+		}
+
+		var texto string
+		for _, mensagem := range catálogo.Mensagens {
+			if mensagem.Código == protocolo.MensagemCódigoCampoNãoPreenchido {
+				texto = mensagem.Descrição
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.idiomaEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(catálogo.Idioma, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.textoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(texto, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
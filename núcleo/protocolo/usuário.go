@@ -0,0 +1,115 @@
+package protocolo
+
+import (
+	"strings"
+	"time"
+)
+
+// Papel define o perfil de acesso de um usuário do sistema.
+type Papel string
+
+const (
+	// PapelClube usuário operador de um Clube de Tiro, que somente pode reportar
+	// frequências do seu próprio clube.
+	PapelClube Papel = "clube"
+
+	// PapelAdministrador usuário administrativo (Exército), com acesso a todos
+	// os clubes e frequências.
+	PapelAdministrador Papel = "administrador"
+
+	// PapelAuditor usuário responsável por analisar as frequências sorteadas
+	// nas amostras de auditoria, sem permissão para alterar os cadastros.
+	PapelAuditor Papel = "auditor"
+
+	// PapelAtirador atirador vinculado a um CR, que somente pode consultar as
+	// frequências registradas no seu próprio nome.
+	PapelAtirador Papel = "atirador"
+)
+
+// LoginPedido armazena as credenciais informadas pelo usuário para obter um
+// token de acesso.
+type LoginPedido struct {
+	Usuário string `json:"usuario"`
+	Senha   string `json:"senha"`
+}
+
+// Normalizar padroniza o formato dos campos da requisição. O usuário não
+// diferencia letras maiúsculas de minúsculas, já a senha é mantida intacta.
+func (l *LoginPedido) Normalizar() {
+	l.Usuário = strings.TrimSpace(l.Usuário)
+	l.Usuário = strings.ToLower(l.Usuário)
+}
+
+// Validar analisa se os campos obrigatórios foram preenchidos.
+func (l LoginPedido) Validar() Mensagens {
+	var mensagens Mensagens
+
+	if l.Usuário == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "usuario", ""))
+	}
+
+	if l.Senha == "" {
+		mensagens = append(mensagens, NovaMensagemComCampo(MensagemCódigoCampoNãoPreenchido, "senha", ""))
+	}
+
+	return mensagens
+}
+
+// LoginResposta armazena o token de acesso gerado para o usuário. O token deve
+// ser enviado no cabeçalho HTTP Authorization, com o esquema "Bearer", nas
+// requisições que exigem autenticação.
+type LoginResposta struct {
+	Token     string    `json:"token"`
+	Expiração time.Time `json:"expiracao"`
+}
+
+// Identidade representa o usuário autenticado a partir de um token de acesso.
+type Identidade struct {
+	IDUsuário int64
+	Usuário   string
+	Papel     Papel
+
+	// IDClube número de identificação do Clube de Tiro ao qual o usuário está
+	// vinculado. Somente preenchido para usuários com o papel de clube.
+	IDClube int64
+
+	// CR número de registro do atirador ao qual o usuário está vinculado.
+	// Somente preenchido para usuários com o papel de atirador.
+	CR int
+
+	// Expiração data a partir da qual o token não é mais aceito.
+	Expiração time.Time
+}
+
+// Administrador verifica se a identidade possui o papel administrativo.
+func (i Identidade) Administrador() bool {
+	return i.Papel == PapelAdministrador
+}
+
+// PodeReportarClube verifica se a identidade tem permissão para reportar
+// frequências do Clube de Tiro informado. Administradores podem reportar
+// frequências de qualquer clube.
+func (i Identidade) PodeReportarClube(idClube int64) bool {
+	return i.Administrador() || (i.Papel == PapelClube && i.IDClube == idClube)
+}
+
+// PodeAuditar verifica se a identidade tem permissão para sortear amostras e
+// registrar os vereditos das auditorias. Administradores também podem auditar.
+func (i Identidade) PodeAuditar() bool {
+	return i.Administrador() || i.Papel == PapelAuditor
+}
+
+// PodeConsultarAtirador verifica se a identidade tem permissão para consultar
+// as frequências registradas no CR informado. Administradores podem consultar
+// as frequências de qualquer atirador.
+func (i Identidade) PodeConsultarAtirador(cr int) bool {
+	return i.Administrador() || (i.Papel == PapelAtirador && i.CR == cr)
+}
+
+// PodeConsultarMunição verifica se a identidade tem permissão para consultar o
+// consumo de munição do CR informado. Operadores de clube podem consultar
+// qualquer atirador, pois verificam o saldo antes do treino, enquanto o
+// atirador somente pode consultar o seu próprio CR.
+func (i Identidade) PodeConsultarMunição(cr int) bool {
+	return i.Papel == PapelClube || i.PodeConsultarAtirador(cr)
+}
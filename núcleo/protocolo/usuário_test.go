@@ -0,0 +1,271 @@
+package protocolo_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestLoginPedido_Normalizar(t *testing.T) {
+	cenários := []struct {
+		descrição   string
+		loginPedido protocolo.LoginPedido
+		esperado    protocolo.LoginPedido
+	}{
+		{
+			descrição: "deve normalizar os campos corretamente",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "  Operador.Clube  ",
+				Senha:   "  Senha Secreta  ",
+			},
+			esperado: protocolo.LoginPedido{
+				Usuário: "operador.clube",
+				Senha:   "  Senha Secreta  ",
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		cenário.loginPedido.Normalizar()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.loginPedido, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLoginPedido_Validar(t *testing.T) {
+	cenários := []struct {
+		descrição   string
+		loginPedido protocolo.LoginPedido
+		esperado    protocolo.Mensagens
+	}{
+		{
+			descrição: "deve aceitar credenciais preenchidas",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "abc123",
+			},
+		},
+		{
+			descrição: "deve detectar quando os campos obrigatórios não foram preenchidos",
+			esperado: protocolo.Mensagens{
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "usuario", ""),
+				protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "senha", ""),
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.loginPedido.Validar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIdentidade_PodeReportarClube(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		identidade protocolo.Identidade
+		idClube    int64
+		esperado   bool
+	}{
+		{
+			descrição: "deve permitir que o administrador reporte qualquer clube",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAdministrador,
+			},
+			idClube:  10,
+			esperado: true,
+		},
+		{
+			descrição: "deve permitir que o operador reporte o seu próprio clube",
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 10,
+			},
+			idClube:  10,
+			esperado: true,
+		},
+		{
+			descrição: "deve impedir que o operador reporte outro clube",
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 10,
+			},
+			idClube:  11,
+			esperado: false,
+		},
+		{
+			descrição: "deve impedir uma identidade sem papel",
+			idClube:   10,
+			esperado:  false,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.identidade.PodeReportarClube(cenário.idClube), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIdentidade_PodeAuditar(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		identidade protocolo.Identidade
+		esperado   bool
+	}{
+		{
+			descrição: "deve permitir que o administrador audite",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAdministrador,
+			},
+			esperado: true,
+		},
+		{
+			descrição: "deve permitir que o auditor audite",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAuditor,
+			},
+			esperado: true,
+		},
+		{
+			descrição: "deve impedir que o operador do clube audite",
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 10,
+			},
+			esperado: false,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.identidade.PodeAuditar(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIdentidade_PodeConsultarAtirador(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		identidade protocolo.Identidade
+		cr         int
+		esperado   bool
+	}{
+		{
+			descrição: "deve permitir que o administrador consulte qualquer atirador",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAdministrador,
+			},
+			cr:       380308,
+			esperado: true,
+		},
+		{
+			descrição: "deve permitir que o atirador consulte o seu próprio CR",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAtirador,
+				CR:    380308,
+			},
+			cr:       380308,
+			esperado: true,
+		},
+		{
+			descrição: "deve impedir que o atirador consulte outro CR",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAtirador,
+				CR:    380308,
+			},
+			cr:       380309,
+			esperado: false,
+		},
+		{
+			descrição: "deve impedir que o operador do clube consulte um atirador",
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 10,
+			},
+			cr:       380308,
+			esperado: false,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.identidade.PodeConsultarAtirador(cenário.cr), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestIdentidade_PodeConsultarMunição(t *testing.T) {
+	cenários := []struct {
+		descrição  string
+		identidade protocolo.Identidade
+		cr         int
+		esperado   bool
+	}{
+		{
+			descrição: "deve permitir que o administrador consulte qualquer atirador",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAdministrador,
+			},
+			cr:       380308,
+			esperado: true,
+		},
+		{
+			descrição: "deve permitir que o operador do clube consulte qualquer atirador",
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 10,
+			},
+			cr:       380308,
+			esperado: true,
+		},
+		{
+			descrição: "deve permitir que o atirador consulte o seu próprio CR",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAtirador,
+				CR:    380308,
+			},
+			cr:       380308,
+			esperado: true,
+		},
+		{
+			descrição: "deve impedir que o atirador consulte outro CR",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAtirador,
+				CR:    380308,
+			},
+			cr:       380309,
+			esperado: false,
+		},
+		{
+			descrição: "deve impedir que o auditor consulte um atirador",
+			identidade: protocolo.Identidade{
+				Papel: protocolo.PapelAuditor,
+			},
+			cr:       380308,
+			esperado: false,
+		},
+	}
+
+	for i, cenário := range cenários {
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(cenário.identidade.PodeConsultarMunição(cenário.cr), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
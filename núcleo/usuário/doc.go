@@ -0,0 +1,3 @@
+// Package usuário provê os serviços de autenticação dos usuários do sistema,
+// sejam eles operadores de Clubes de Tiro ou administradores.
+package usuário
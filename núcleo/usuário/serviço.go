@@ -0,0 +1,87 @@
+package usuário
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/registrobr/gostk/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Serviço disponibiliza as ações de autenticação dos usuários.
+type Serviço interface {
+	// Autenticar verifica as credenciais do usuário e, caso estejam corretas,
+	// gera um token de acesso assinado com prazo de validade.
+	Autenticar(protocolo.LoginPedido) (protocolo.LoginResposta, error)
+
+	// ValidarToken verifica a assinatura e o prazo de validade do token,
+	// retornando a identidade do usuário autenticado. Esta ação não depende do
+	// banco de dados.
+	ValidarToken(token string) (protocolo.Identidade, error)
+}
+
+// NovoServiço inicializa um serviço concreto de usuários. Pode ser substituído
+// em testes por simuladores, permitindo uma abstração da camada de serviços.
+var NovoServiço = func(s *bd.SQLogger, l log.Serviço, configuração config.Configuração) Serviço {
+	return serviço{
+		sqlogger:     s,
+		logger:       l,
+		configuração: configuração,
+	}
+}
+
+type serviço struct {
+	sqlogger     *bd.SQLogger
+	logger       log.Serviço
+	configuração config.Configuração
+}
+
+func (s serviço) Autenticar(loginPedido protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+	credenciaisInválidas := protocolo.NovasMensagens(
+		protocolo.NovaMensagem(protocolo.MensagemCódigoCredenciaisInválidas),
+	)
+
+	dao := novoUsuárioDAO(s.sqlogger)
+	u, err := dao.resgatarPorUsuário(loginPedido.Usuário)
+	if errors.Equal(err, erros.NãoEncontrado) {
+		// a comparação é realizada mesmo sem o usuário, igualando o tempo de
+		// resposta ao de uma senha incorreta
+		bcrypt.CompareHashAndPassword([]byte(senhaFictícia), []byte(loginPedido.Senha))
+		return protocolo.LoginResposta{}, credenciaisInválidas
+	} else if err != nil {
+		return protocolo.LoginResposta{}, erros.Novo(err)
+	}
+
+	if !u.senhaConfere(loginPedido.Senha) {
+		return protocolo.LoginResposta{}, credenciaisInválidas
+	}
+
+	if s.configuração.Autenticação.ChaveToken == "" {
+		return protocolo.LoginResposta{}, errors.Errorf("chave de assinatura do token indefinida")
+	}
+
+	expiração := time.Now().Add(s.configuração.Autenticação.DuraçãoToken).UTC()
+	t := novoToken(u.identidade(expiração))
+
+	return protocolo.LoginResposta{
+		Token:     t.assinar(s.configuração.Autenticação.ChaveToken),
+		Expiração: t.Expiração,
+	}, nil
+}
+
+func (s serviço) ValidarToken(texto string) (protocolo.Identidade, error) {
+	if s.configuração.Autenticação.ChaveToken == "" {
+		return protocolo.Identidade{}, errors.Errorf("chave de assinatura do token indefinida")
+	}
+
+	t, mensagens := interpretarToken(texto, s.configuração.Autenticação.ChaveToken)
+	if mensagens != nil {
+		return protocolo.Identidade{}, mensagens
+	}
+
+	return t.identidade(), nil
+}
@@ -0,0 +1,277 @@
+package usuário
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestServiço_Autenticar(t *testing.T) {
+	senha, err := bcrypt.GenerateFromPassword([]byte("abc123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Erro ao gerar a senha de teste. Detalhes: %s", err)
+	}
+
+	var configuração config.Configuração
+	configuração.Autenticação.ChaveToken = "chave"
+	configuração.Autenticação.DuraçãoToken = time.Hour
+
+	cenários := []struct {
+		descrição    string
+		loginPedido  protocolo.LoginPedido
+		usuárioDAO   usuárioDAO
+		esperado     protocolo.Identidade
+		erroEsperado error
+	}{
+		{
+			descrição: "deve autenticar corretamente um operador de clube",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "abc123",
+			},
+			usuárioDAO: simulaUsuárioDAO{
+				simulaResgatarPorUsuário: func(nomeUsuário string) (usuário, error) {
+					return usuário{
+						ID:      1,
+						Usuário: nomeUsuário,
+						Senha:   string(senha),
+						Papel:   protocolo.PapelClube,
+						IDClube: 7,
+					}, nil
+				},
+			},
+			esperado: protocolo.Identidade{
+				IDUsuário: 1,
+				Usuário:   "operador",
+				Papel:     protocolo.PapelClube,
+				IDClube:   7,
+			},
+		},
+		{
+			descrição: "deve autenticar corretamente um atirador mantendo o seu CR",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "joao",
+				Senha:   "abc123",
+			},
+			usuárioDAO: simulaUsuárioDAO{
+				simulaResgatarPorUsuário: func(nomeUsuário string) (usuário, error) {
+					return usuário{
+						ID:      2,
+						Usuário: nomeUsuário,
+						Senha:   string(senha),
+						Papel:   protocolo.PapelAtirador,
+						CR:      380308,
+					}, nil
+				},
+			},
+			esperado: protocolo.Identidade{
+				IDUsuário: 2,
+				Usuário:   "joao",
+				Papel:     protocolo.PapelAtirador,
+				CR:        380308,
+			},
+		},
+		{
+			descrição: "deve detectar quando o usuário não existe",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "inexistente",
+				Senha:   "abc123",
+			},
+			usuárioDAO: simulaUsuárioDAO{
+				simulaResgatarPorUsuário: func(nomeUsuário string) (usuário, error) {
+					return usuário{}, erros.NãoEncontrado
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCredenciaisInválidas),
+			},
+		},
+		{
+			descrição: "deve detectar quando a senha não confere",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "123abc",
+			},
+			usuárioDAO: simulaUsuárioDAO{
+				simulaResgatarPorUsuário: func(nomeUsuário string) (usuário, error) {
+					return usuário{
+						ID:      1,
+						Usuário: nomeUsuário,
+						Senha:   string(senha),
+						Papel:   protocolo.PapelClube,
+						IDClube: 7,
+					}, nil
+				},
+			},
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCredenciaisInválidas),
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o usuário",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "abc123",
+			},
+			usuárioDAO: simulaUsuárioDAO{
+				simulaResgatarPorUsuário: func(nomeUsuário string) (usuário, error) {
+					return usuário{}, errors.Errorf("erro de resgate")
+				},
+			},
+			erroEsperado: errors.Errorf("erro de resgate"),
+		},
+	}
+
+	daoOriginal := novoUsuárioDAO
+	defer func() {
+		novoUsuárioDAO = daoOriginal
+	}()
+
+	for i, cenário := range cenários {
+		novoUsuárioDAO = func(sqlogger *bd.SQLogger) usuárioDAO {
+			return cenário.usuárioDAO
+		}
+
+		serviço := NovoServiço(nil, nil, configuração)
+		loginResposta, err := serviço.Autenticar(cenário.loginPedido)
+
+		// o token gerado é validado pelo próprio serviço, garantindo que a
+		// identidade transportada é a mesma do usuário autenticado
+		var identidade protocolo.Identidade
+		if err == nil {
+			if loginResposta.Expiração.Before(time.Now().Add(59 * time.Minute)) {
+				t.Errorf("Item %d, “%s”: expiração inesperada “%s”", i, cenário.descrição, loginResposta.Expiração)
+			}
+
+			if identidade, err = serviço.ValidarToken(loginResposta.Token); err == nil {
+				cenário.esperado.Expiração = identidade.Expiração
+			}
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(identidade, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestSenhaFictícia(t *testing.T) {
+	// um hash inválido faria a comparação retornar imediatamente, permitindo
+	// diferenciar um usuário inexistente pelo tempo de resposta
+	custo, err := bcrypt.Cost([]byte(senhaFictícia))
+	if err != nil {
+		t.Fatalf("Senha fictícia inválida. Detalhes: %s", err)
+	}
+
+	if custo != bcrypt.DefaultCost {
+		t.Errorf("Custo da senha fictícia inesperado. Esperado “%d”; encontrado “%d”", bcrypt.DefaultCost, custo)
+	}
+}
+
+func TestServiço_ValidarToken(t *testing.T) {
+	expiração := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	identidade := protocolo.Identidade{
+		IDUsuário: 3,
+		Usuário:   "administrador",
+		Papel:     protocolo.PapelAdministrador,
+		Expiração: expiração,
+	}
+
+	cenários := []struct {
+		descrição    string
+		chave        string
+		token        string
+		esperado     protocolo.Identidade
+		erroEsperado error
+	}{
+		{
+			descrição: "deve validar corretamente um token",
+			chave:     "chave",
+			token:     novoToken(identidade).assinar("chave"),
+			esperado:  identidade,
+		},
+		{
+			descrição: "deve detectar um token assinado com outra chave",
+			chave:     "chave",
+			token:     novoToken(identidade).assinar("outra chave"),
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido),
+			},
+		},
+		{
+			descrição: "deve detectar um token expirado",
+			chave:     "chave",
+			token: func() string {
+				identidadeExpirada := identidade
+				identidadeExpirada.Expiração = time.Now().Add(-time.Minute)
+				return novoToken(identidadeExpirada).assinar("chave")
+			}(),
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenExpirado),
+			},
+		},
+		{
+			descrição: "deve detectar um token com formato inválido",
+			chave:     "chave",
+			token:     "formato-invalido",
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido),
+			},
+		},
+		{
+			descrição: "deve detectar um token com assinatura mal codificada",
+			chave:     "chave",
+			token:     "e30.@@@",
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido),
+			},
+		},
+		{
+			descrição: "deve detectar um token com conteúdo que não é JSON",
+			chave:     "chave",
+			token: func() string {
+				conteúdo := "bmFvIGUganNvbg"
+				return conteúdo + "." + base64.RawURLEncoding.EncodeToString(assinatura("chave", conteúdo))
+			}(),
+			erroEsperado: protocolo.Mensagens{
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido),
+			},
+		},
+		{
+			descrição:    "deve detectar quando a chave de assinatura não foi definida",
+			token:        novoToken(identidade).assinar(""),
+			erroEsperado: errors.Errorf("chave de assinatura do token indefinida"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		var configuração config.Configuração
+		configuração.Autenticação.ChaveToken = cenário.chave
+
+		serviço := NovoServiço(nil, nil, configuração)
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, cenário.erroEsperado)
+
+		if err := verificadorResultado.VerificaResultado(serviço.ValidarToken(cenário.token)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type simulaUsuárioDAO struct {
+	simulaResgatarPorUsuário func(usuário string) (usuário, error)
+}
+
+func (s simulaUsuárioDAO) resgatarPorUsuário(nomeUsuário string) (usuário, error) {
+	return s.simulaResgatarPorUsuário(nomeUsuário)
+}
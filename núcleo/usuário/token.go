@@ -0,0 +1,92 @@
+package usuário
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+// token representa o conteúdo assinado que é entregue ao usuário no login. O
+// formato final é composto pelo conteúdo em JSON e pela assinatura HMAC-SHA256,
+// ambos codificados em base64 (URL) e separados por um ponto.
+type token struct {
+	IDUsuário int64           `json:"id"`
+	Usuário   string          `json:"usuario"`
+	Papel     protocolo.Papel `json:"papel"`
+	IDClube   int64           `json:"clube,omitempty"`
+	CR        int             `json:"cr,omitempty"`
+	Expiração time.Time       `json:"expiracao"`
+}
+
+func novoToken(identidade protocolo.Identidade) token {
+	return token{
+		IDUsuário: identidade.IDUsuário,
+		Usuário:   identidade.Usuário,
+		Papel:     identidade.Papel,
+		IDClube:   identidade.IDClube,
+		CR:        identidade.CR,
+		Expiração: identidade.Expiração.UTC(),
+	}
+}
+
+func (t token) assinar(chave string) string {
+	// o erro retornado é ignorado, pois a estrutura possui somente tipos que
+	// sempre podem ser convertidos para JSON
+	conteúdo, _ := json.Marshal(t)
+	conteúdoCodificado := base64.RawURLEncoding.EncodeToString(conteúdo)
+	return conteúdoCodificado + "." + base64.RawURLEncoding.EncodeToString(assinatura(chave, conteúdoCodificado))
+}
+
+func (t token) identidade() protocolo.Identidade {
+	return protocolo.Identidade{
+		IDUsuário: t.IDUsuário,
+		Usuário:   t.Usuário,
+		Papel:     t.Papel,
+		IDClube:   t.IDClube,
+		CR:        t.CR,
+		Expiração: t.Expiração,
+	}
+}
+
+// interpretarToken verifica a assinatura e a validade do token, retornando o
+// seu conteúdo. Qualquer problema encontrado é retornado como mensagem para o
+// usuário.
+func interpretarToken(texto, chave string) (token, protocolo.Mensagens) {
+	partes := strings.Split(texto, ".")
+	if len(partes) != 2 {
+		return token{}, protocolo.NovasMensagens(protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido))
+	}
+
+	assinaturaInformada, err := base64.RawURLEncoding.DecodeString(partes[1])
+	if err != nil || !hmac.Equal(assinaturaInformada, assinatura(chave, partes[0])) {
+		return token{}, protocolo.NovasMensagens(protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido))
+	}
+
+	conteúdo, err := base64.RawURLEncoding.DecodeString(partes[0])
+	if err != nil {
+		return token{}, protocolo.NovasMensagens(protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido))
+	}
+
+	var t token
+	if err := json.Unmarshal(conteúdo, &t); err != nil {
+		return token{}, protocolo.NovasMensagens(protocolo.NovaMensagem(protocolo.MensagemCódigoTokenInválido))
+	}
+
+	if time.Now().After(t.Expiração) {
+		return token{}, protocolo.NovasMensagens(protocolo.NovaMensagem(protocolo.MensagemCódigoTokenExpirado))
+	}
+
+	return t, nil
+}
+
+func assinatura(chave, conteúdo string) []byte {
+	// o erro retornado é ignorado, pois o método Write do SHA256 não gera erro
+	mac := hmac.New(sha256.New, []byte(chave))
+	mac.Write([]byte(conteúdo))
+	return mac.Sum(nil)
+}
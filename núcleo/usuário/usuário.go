@@ -0,0 +1,51 @@
+package usuário
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// senhaFictícia é o resultado do bcrypt, com o custo padrão, sobre uma senha
+// que não pertence a nenhum usuário. A senha informada para um usuário
+// inexistente é comparada com ela, para que o tempo de resposta não revele
+// quais usuários estão cadastrados.
+const senhaFictícia = "$2a$10$vUShC9VvkfinXo36qHN1weInryjLmwDDTZm.ZOuVAEe9W6mL1sGlC"
+
+type usuário struct {
+	ID      int64
+	Usuário string
+	Nome    string
+
+	// Senha armazena o resultado do bcrypt sobre a senha do usuário. A senha em
+	// texto claro nunca é persistida.
+	Senha string
+
+	Papel           protocolo.Papel
+	IDClube         int64
+	CR              int
+	DataCriação     time.Time
+	DataAtualização time.Time
+
+	// revisão utilizado para o controle de versão do objeto na base de dados,
+	// minimizando problemas de concorrência quando 2 transações alteram o mesmo
+	// objeto.
+	revisão int
+}
+
+// senhaConfere compara a senha informada com a senha armazenada do usuário.
+func (u usuário) senhaConfere(senha string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.Senha), []byte(senha)) == nil
+}
+
+func (u usuário) identidade(expiração time.Time) protocolo.Identidade {
+	return protocolo.Identidade{
+		IDUsuário: u.ID,
+		Usuário:   u.Usuário,
+		Papel:     u.Papel,
+		IDClube:   u.IDClube,
+		CR:        u.CR,
+		Expiração: expiração,
+	}
+}
@@ -0,0 +1,82 @@
+package usuário
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+)
+
+type usuárioDAO interface {
+	resgatarPorUsuário(usuário string) (usuário, error)
+}
+
+var novoUsuárioDAO = func(sqlogger *bd.SQLogger) usuárioDAO {
+	return usuárioDAOImpl{sqlogger: sqlogger}
+}
+
+type usuárioDAOImpl struct {
+	sqlogger *bd.SQLogger
+}
+
+func (u usuárioDAOImpl) resgatarPorUsuário(nomeUsuário string) (usuário, error) {
+	resultado := u.sqlogger.QueryRow(usuárioResgatePorUsuárioComando, nomeUsuário)
+
+	var usr usuário
+	var papel string
+	var idClube, cr sql.NullInt64
+	var dataAtualização pq.NullTime
+
+	err := resultado.Scan(
+		&usr.ID,
+		&usr.Usuário,
+		&usr.Nome,
+		&usr.Senha,
+		&papel,
+		&idClube,
+		&cr,
+		&usr.DataCriação,
+		&dataAtualização,
+		&usr.revisão,
+	)
+
+	usr.Papel = protocolo.Papel(papel)
+
+	if idClube.Valid {
+		usr.IDClube = idClube.Int64
+	}
+
+	if cr.Valid {
+		usr.CR = int(cr.Int64)
+	}
+
+	if dataAtualização.Valid {
+		usr.DataAtualização = dataAtualização.Time
+	}
+
+	return usr, erros.Novo(err)
+}
+
+var (
+	usuárioTabela = "usuario"
+
+	usuárioResgateCampos = []string{
+		"id",
+		"usuario",
+		"nome",
+		"senha",
+		"papel",
+		"id_clube",
+		"cr",
+		"data_criacao",
+		"data_atualizacao",
+		"revisao",
+	}
+	usuárioResgateCamposTexto       = strings.Join(usuárioResgateCampos, ", ")
+	usuárioResgatePorUsuárioComando = fmt.Sprintf(`SELECT %s FROM %s WHERE usuario = $1`,
+		usuárioResgateCamposTexto, usuárioTabela)
+)
@@ -0,0 +1,110 @@
+package usuário
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestUsuárioDAOImpl_resgatarPorUsuário(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	data := time.Now()
+
+	cenários := []struct {
+		descrição       string
+		simulação       func()
+		usuário         string
+		usuárioEsperado usuário
+		erroEsperado    error
+	}{
+		{
+			descrição: "deve resgatar corretamente um operador de clube",
+			simulação: func() {
+				testdb.StubQuery(usuárioResgatePorUsuárioComando, testdb.RowsFromSlice(usuárioResgateCampos, [][]driver.Value{
+					{1, "operador", "Operador do Clube", "$2a$04$hash", "clube", 7, nil, data, nil, 0},
+				}))
+			},
+			usuário: "operador",
+			usuárioEsperado: usuário{
+				ID:          1,
+				Usuário:     "operador",
+				Nome:        "Operador do Clube",
+				Senha:       "$2a$04$hash",
+				Papel:       protocolo.PapelClube,
+				IDClube:     7,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve resgatar corretamente um administrador sem clube",
+			simulação: func() {
+				testdb.StubQuery(usuárioResgatePorUsuárioComando, testdb.RowsFromSlice(usuárioResgateCampos, [][]driver.Value{
+					{2, "admin", "Administrador", "$2a$04$hash", "administrador", nil, nil, data, data, 1},
+				}))
+			},
+			usuário: "admin",
+			usuárioEsperado: usuário{
+				ID:              2,
+				Usuário:         "admin",
+				Nome:            "Administrador",
+				Senha:           "$2a$04$hash",
+				Papel:           protocolo.PapelAdministrador,
+				DataCriação:     data,
+				DataAtualização: data,
+				revisão:         1,
+			},
+		},
+		{
+			descrição: "deve resgatar corretamente um atirador com o seu CR",
+			simulação: func() {
+				testdb.StubQuery(usuárioResgatePorUsuárioComando, testdb.RowsFromSlice(usuárioResgateCampos, [][]driver.Value{
+					{3, "joao", "João da Silva", "$2a$04$hash", "atirador", nil, 380308, data, nil, 0},
+				}))
+			},
+			usuário: "joao",
+			usuárioEsperado: usuário{
+				ID:          3,
+				Usuário:     "joao",
+				Nome:        "João da Silva",
+				Senha:       "$2a$04$hash",
+				Papel:       protocolo.PapelAtirador,
+				CR:          380308,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao resgatar o usuário",
+			simulação: func() {
+				testdb.StubQueryError(usuárioResgatePorUsuárioComando, fmt.Errorf("erro de execução"))
+			},
+			usuário:      "operador",
+			erroEsperado: errors.Errorf("erro de execução"),
+		},
+	}
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		cenário.simulação()
+
+		dao := novoUsuárioDAO(bd.NovoSQLogger(conexão, nil))
+		u, err := dao.resgatarPorUsuário(cenário.usuário)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.usuárioEsperado, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(u, err); err != nil {
+			t.Error(err)
+		}
+	}
+}
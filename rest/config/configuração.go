@@ -60,6 +60,11 @@ type Configuração struct {
 		// TempoEsgotadoLeitura define o tempo em que o servidor irá aguardar após
 		// um cliente se conectar para que alguma requisição seja recebida.
 		TempoEsgotadoLeitura time.Duration `yaml:"tempo esgotado leitura" envconfig:"tempo_esgotado_leitura"`
+
+		// TamanhoMáximoImagem define o tamanho máximo, em bytes, de uma imagem
+		// enviada diretamente no corpo da requisição ou em um formulário
+		// multipart.
+		TamanhoMáximoImagem int64 `yaml:"tamanho maximo imagem" envconfig:"tamanho_maximo_imagem"`
 	} `yaml:"servidor" envconfig:"servidor"`
 
 	Syslog struct {
@@ -83,6 +88,43 @@ type Configuração struct {
 		MáximoNúmeroConexõesAbertas  int           `yaml:"maximo numero conexoes abertas" envconfig:"maximo_numero_conexoes_abertas"`
 	} `yaml:"banco de dados" envconfig:"bd"`
 
+	// Armazenamento define o repositório de objetos onde as imagens das
+	// frequências são gravadas. No banco de dados ficam somente as referências
+	// das imagens.
+	Armazenamento struct {
+		// Tipo define a implementação do repositório. Os valores aceitos são
+		// "local", que grava as imagens no sistema de arquivos, e "s3", que
+		// utiliza um serviço compatível com o Amazon S3.
+		Tipo string `yaml:"tipo" envconfig:"tipo"`
+
+		// Diretório caminho onde as imagens são gravadas quando o repositório for
+		// local. Quando existir mais de um servidor, o diretório deve ser
+		// compartilhado entre eles.
+		Diretório string `yaml:"diretorio" envconfig:"diretorio"`
+
+		S3 struct {
+			// Endereço URL do serviço compatível com o S3. Quando não informado o
+			// serviço da Amazon é utilizado.
+			Endereço     string `yaml:"endereco" envconfig:"endereco"`
+			Região       string `yaml:"regiao" envconfig:"regiao"`
+			Balde        string `yaml:"balde" envconfig:"balde"`
+			ChaveAcesso  string `yaml:"chave acesso" envconfig:"chave_acesso"`
+			ChaveSecreta string `yaml:"chave secreta" envconfig:"chave_secreta"`
+		} `yaml:"s3" envconfig:"s3"`
+	} `yaml:"armazenamento" envconfig:"armazenamento"`
+
+	// Expiração define a execução periódica da tarefa que expira as frequências
+	// não confirmadas dentro do prazo. Quando mais de um servidor compartilha o
+	// mesmo banco de dados, somente um deles executa a tarefa em cada ciclo.
+	Expiração struct {
+		// Intervalo tempo entre as execuções da tarefa. Um intervalo zerado
+		// desabilita a tarefa neste servidor.
+		Intervalo time.Duration `yaml:"intervalo" envconfig:"intervalo"`
+
+		// Lote quantidade máxima de frequências expiradas em cada execução.
+		Lote int `yaml:"lote" envconfig:"lote"`
+	} `yaml:"expiracao" envconfig:"expiracao"`
+
 	// Proxies define a lista de endereços IPs que podem informar os cabeçalhos
 	// HTTP X-Forwarded-For ou X-Real-IP para identificar os clientes finais.
 	Proxies []net.IP `yaml:"proxies" envconfig:"proxies"`
@@ -116,6 +158,7 @@ func DefinirValoresPadrão() {
 	c.Servidor.Endereço = "0.0.0.0:443"
 	c.Servidor.TLS.Habilitado = false
 	c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+	c.Servidor.TamanhoMáximoImagem = 10 << 20
 	c.Syslog.Endereço = "127.0.0.1:514"
 	c.Syslog.TempoEsgotadoConexão = 2 * time.Second
 	c.BancoDados.Endereço = "127.0.0.1"
@@ -127,6 +170,11 @@ func DefinirValoresPadrão() {
 	c.BancoDados.TempoEsgotadoTransação = 3 * time.Second
 	c.BancoDados.MáximoNúmeroConexõesInativas = 16
 	c.BancoDados.MáximoNúmeroConexõesAbertas = 32
+	c.Armazenamento.Tipo = "local"
+	c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+	c.Armazenamento.S3.Região = "us-east-1"
+	c.Expiração.Intervalo = 5 * time.Minute
+	c.Expiração.Lote = 100
 
 	AtualizarConfiguração(c)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/freetype/truetype"
 	"github.com/kelseyhightower/envconfig"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
 	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/rafaeljusto/atiradorfrequente/testes"
 	"golang.org/x/image/font/gofont/goregular"
@@ -38,13 +39,29 @@ func TestDefinirValoresPadrão(t *testing.T) {
 	esperado.Atirador.PrazoConfirmação = 30 * time.Minute
 	esperado.Atirador.TempoMáximoCadastro = 12 * time.Hour
 	esperado.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+	esperado.Atirador.DistânciaHashImagem = 5
+	esperado.Atirador.TolerânciaDataImagem = time.Hour
+	esperado.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+	esperado.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+	esperado.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+	esperado.Atirador.RaioClube = 1000
 	esperado.Atirador.ImagemNúmeroControle.Fonte.Font, _ = truetype.Parse(goregular.TTF)
-	esperado.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+	esperado.Atirador.ImagemNúmeroControle.Fonte.TTF = goregular.TTF
+	esperado.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+	esperado.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+	esperado.Atirador.ImagemConfirmação.Qualidade = 85
+	esperado.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+	esperado.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+	esperado.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+	esperado.Atirador.PrazoCancelamento = time.Hour
+	esperado.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+	esperado.Autenticação.DuraçãoToken = 8 * time.Hour
 	esperado.Binário.URL = "http://localhost:4000/binarios/rest.af"
 	esperado.Binário.TempoAtualização = 5 * time.Second
 	esperado.Servidor.Endereço = "0.0.0.0:443"
 	esperado.Servidor.TLS.Habilitado = false
 	esperado.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+	esperado.Servidor.TamanhoMáximoImagem = 10 << 20
 	esperado.Syslog.Endereço = "127.0.0.1:514"
 	esperado.Syslog.TempoEsgotadoConexão = 2 * time.Second
 	esperado.BancoDados.Endereço = "127.0.0.1"
@@ -56,6 +73,11 @@ func TestDefinirValoresPadrão(t *testing.T) {
 	esperado.BancoDados.TempoEsgotadoTransação = 3 * time.Second
 	esperado.BancoDados.MáximoNúmeroConexõesInativas = 16
 	esperado.BancoDados.MáximoNúmeroConexõesAbertas = 32
+	esperado.Armazenamento.Tipo = "local"
+	esperado.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+	esperado.Armazenamento.S3.Região = "us-east-1"
+	esperado.Expiração.Intervalo = 5 * time.Minute
+	esperado.Expiração.Lote = 100
 
 	config.DefinirValoresPadrão()
 
@@ -85,6 +107,7 @@ servidor:
     arquivo certificado: teste.crt
     arquivo chave: teste.key
   tempo esgotado leitura: 5s
+  tamanho maximo imagem: 5242880
 syslog:
   endereco: 192.0.2.2:514
   tempo esgotado conexao: 5s
@@ -99,6 +122,17 @@ banco de dados:
   tempo esgotado transacao: 5s
   maximo numero conexoes inativas: 10
   maximo numero conexoes abertas: 40
+armazenamento:
+  tipo: s3
+  s3:
+    endereco: http://192.0.2.7:9000
+    regiao: sa-east-1
+    balde: imagens
+    chave acesso: chave
+    chave secreta: segredo
+expiracao:
+  intervalo: 1m
+  lote: 50
 proxies:
   - 192.0.2.4
   - 192.0.2.5
@@ -110,6 +144,9 @@ atirador:
   chave codigo verificacao: cba321
   imagem numero controle:
     url qrcode: https://exemplo.com.br/frequencia/%s/%s?verificacao=%s
+autenticacao:
+  chave token: zyx987
+  duracao token: 4h
 `,
 			configuraçãoEsperada: func() *config.Configuração {
 				c := new(config.Configuração)
@@ -118,6 +155,8 @@ atirador:
 				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
 				c.Atirador.ChaveCódigoVerificação = "cba321"
 				c.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				c.Autenticação.ChaveToken = "zyx987"
+				c.Autenticação.DuraçãoToken = 4 * time.Hour
 				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
 				c.Binário.TempoAtualização = 1 * time.Second
 				c.Servidor.Endereço = "192.0.2.1:443"
@@ -125,6 +164,7 @@ atirador:
 				c.Servidor.TLS.ArquivoCertificado = "teste.crt"
 				c.Servidor.TLS.ArquivoChave = "teste.key"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 5 << 20
 				c.Syslog.Endereço = "192.0.2.2:514"
 				c.Syslog.TempoEsgotadoConexão = 5 * time.Second
 				c.BancoDados.Endereço = "192.0.2.3"
@@ -137,6 +177,14 @@ atirador:
 				c.BancoDados.TempoEsgotadoTransação = 5 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 10
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 40
+				c.Armazenamento.Tipo = "s3"
+				c.Armazenamento.S3.Endereço = "http://192.0.2.7:9000"
+				c.Armazenamento.S3.Região = "sa-east-1"
+				c.Armazenamento.S3.Balde = "imagens"
+				c.Armazenamento.S3.ChaveAcesso = "chave"
+				c.Armazenamento.S3.ChaveSecreta = "segredo"
+				c.Expiração.Intervalo = 1 * time.Minute
+				c.Expiração.Lote = 50
 				c.Proxies = []net.IP{
 					net.ParseIP("192.0.2.4"),
 					net.ParseIP("192.0.2.5"),
@@ -162,7 +210,7 @@ servidor:
 `,
 			erroEsperado: &yaml.TypeError{
 				Errors: []string{
-					`line 3: cannot unmarshal !!seq into struct { Endereço string "yaml:\"endereco\" envconfig:\"endereco\""; TLS struct { Habilitado bool "yaml:\"habilitado\" envconfig:\"habilitado\""; ArquivoCertificado string "yaml:\"arquivo certificado\" envconfig:\"arquivo_certificado\""; ArquivoChave string "yaml:\"arquivo chave\" envconfig:\"arquivo_chave\"" } "yaml:\"tls\" envconfig:\"tls\""; TempoEsgotadoLeitura time.Duration "yaml:\"tempo esgotado leitura\" envconfig:\"tempo_esgotado_leitura\"" }`,
+					`line 3: cannot unmarshal !!seq into struct { Endereço string "yaml:\"endereco\" envconfig:\"endereco\""; TLS struct { Habilitado bool "yaml:\"habilitado\" envconfig:\"habilitado\""; ArquivoCertificado string "yaml:\"arquivo certificado\" envconfig:\"arquivo_certificado\""; ArquivoChave string "yaml:\"arquivo chave\" envconfig:\"arquivo_chave\"" } "yaml:\"tls\" envconfig:\"tls\""; TempoEsgotadoLeitura time.Duration "yaml:\"tempo esgotado leitura\" envconfig:\"tempo_esgotado_leitura\""; TamanhoMáximoImagem int64 "yaml:\"tamanho maximo imagem\" envconfig:\"tamanho_maximo_imagem\"" }`,
 				},
 			},
 		},
@@ -211,6 +259,7 @@ func TestCarregarDeVariávelAmbiente(t *testing.T) {
 				"AF_SERVIDOR_TLS_ARQUIVO_CERTIFICADO":           "teste.crt",
 				"AF_SERVIDOR_TLS_ARQUIVO_CHAVE":                 "teste.key",
 				"AF_SERVIDOR_TEMPO_ESGOTADO_LEITURA":            "5s",
+				"AF_SERVIDOR_TAMANHO_MAXIMO_IMAGEM":             "5242880",
 				"AF_SYSLOG_ENDERECO":                            "192.0.2.2:514",
 				"AF_SYSLOG_TEMPO_ESGOTADO_CONEXAO":              "5s",
 				"AF_BD_ENDERECO":                                "192.0.2.3",
@@ -223,12 +272,22 @@ func TestCarregarDeVariávelAmbiente(t *testing.T) {
 				"AF_BD_TEMPO_ESGOTADO_TRANSACAO":                "5s",
 				"AF_BD_MAXIMO_NUMERO_CONEXOES_INATIVAS":         "10",
 				"AF_BD_MAXIMO_NUMERO_CONEXOES_ABERTAS":          "40",
+				"AF_ARMAZENAMENTO_TIPO":                         "s3",
+				"AF_ARMAZENAMENTO_S3_ENDERECO":                  "http://192.0.2.7:9000",
+				"AF_ARMAZENAMENTO_S3_REGIAO":                    "sa-east-1",
+				"AF_ARMAZENAMENTO_S3_BALDE":                     "imagens",
+				"AF_ARMAZENAMENTO_S3_CHAVE_ACESSO":              "chave",
+				"AF_ARMAZENAMENTO_S3_CHAVE_SECRETA":             "segredo",
+				"AF_EXPIRACAO_INTERVALO":                        "1m",
+				"AF_EXPIRACAO_LOTE":                             "50",
 				"AF_PROXIES":                                    "192.0.2.4,192.0.2.5,192.0.2.6",
 				"AF_ATIRADOR_PRAZO_CONFIRMACAO":                 "10m",
 				"AF_ATIRADOR_TEMPO_MAXIMO_CADASTRO":             "12h",
 				"AF_ATIRADOR_DURACAO_MAXIMA_TREINO":             "12h",
 				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO":          "cba321",
 				"AF_ATIRADOR_IMAGEM_NUMERO_CONTROLE_URL_QRCODE": "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s",
+				"AF_AUTENTICACAO_CHAVE_TOKEN":                   "zyx987",
+				"AF_AUTENTICACAO_DURACAO_TOKEN":                 "4h",
 			},
 			configuraçãoEsperada: func() *config.Configuração {
 				c := new(config.Configuração)
@@ -237,6 +296,8 @@ func TestCarregarDeVariávelAmbiente(t *testing.T) {
 				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
 				c.Atirador.ChaveCódigoVerificação = "cba321"
 				c.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				c.Autenticação.ChaveToken = "zyx987"
+				c.Autenticação.DuraçãoToken = 4 * time.Hour
 				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
 				c.Binário.TempoAtualização = 1 * time.Second
 				c.Servidor.Endereço = "192.0.2.1:443"
@@ -244,6 +305,7 @@ func TestCarregarDeVariávelAmbiente(t *testing.T) {
 				c.Servidor.TLS.ArquivoCertificado = "teste.crt"
 				c.Servidor.TLS.ArquivoChave = "teste.key"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 5 << 20
 				c.Syslog.Endereço = "192.0.2.2:514"
 				c.Syslog.TempoEsgotadoConexão = 5 * time.Second
 				c.BancoDados.Endereço = "192.0.2.3"
@@ -256,6 +318,14 @@ func TestCarregarDeVariávelAmbiente(t *testing.T) {
 				c.BancoDados.TempoEsgotadoTransação = 5 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 10
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 40
+				c.Armazenamento.Tipo = "s3"
+				c.Armazenamento.S3.Endereço = "http://192.0.2.7:9000"
+				c.Armazenamento.S3.Região = "sa-east-1"
+				c.Armazenamento.S3.Balde = "imagens"
+				c.Armazenamento.S3.ChaveAcesso = "chave"
+				c.Armazenamento.S3.ChaveSecreta = "segredo"
+				c.Expiração.Intervalo = 1 * time.Minute
+				c.Expiração.Lote = 50
 				c.Proxies = []net.IP{
 					net.ParseIP("192.0.2.4"),
 					net.ParseIP("192.0.2.5"),
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/arma", func() handy.Handler { return &armaHandler{} })
+}
+
+type armaHandler struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	ArmaPedido   protocolo.ArmaPedido    `request:"post"`
+	ArmaResposta *protocolo.ArmaResposta `response:"post"`
+}
+
+func (a *armaHandler) Post() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoArma := arma.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	armaResposta, err := serviçoArma.CadastrarArma(a.ArmaPedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.ArmaResposta = &armaResposta
+	a.DefinirCabeçalho("Location", fmt.Sprintf("/arma/%d", a.ArmaResposta.ID))
+	return http.StatusCreated
+}
+
+func (a *armaHandler) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
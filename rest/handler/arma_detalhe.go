@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/arma/{id}", func() handy.Handler { return &armaDetalhe{} })
+}
+
+type armaDetalhe struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	ID           int64                   `urivar:"id"`
+	ArmaPedido   protocolo.ArmaPedido    `request:"put"`
+	ArmaResposta *protocolo.ArmaResposta `response:"all"`
+}
+
+func (a *armaDetalhe) Get() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoArma := arma.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	armaResposta, err := serviçoArma.ObterArma(a.ID)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.ArmaResposta = &armaResposta
+	return http.StatusOK
+}
+
+func (a *armaDetalhe) Put() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoArma := arma.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	armaPedidoCompleto := protocolo.NovoArmaPedidoCompleto(a.ID, a.ArmaPedido)
+	armaResposta, err := serviçoArma.AtualizarArma(armaPedidoCompleto)
+
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.ArmaResposta = &armaResposta
+	return http.StatusOK
+}
+
+func (a *armaDetalhe) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
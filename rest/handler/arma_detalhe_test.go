@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestArmaDetalhe_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		id                 int64
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoArma        arma.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ArmaResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve obter corretamente os dados da arma",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArma: func(id int64) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{
+						ID:          id,
+						NúmeroSérie: "HG72643653",
+						Calibre:     ".380",
+						CR:          380308,
+						DataCriação: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.ArmaResposta{
+				ID:          12,
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				CR:          380308,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			id:        12,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 12},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando a arma não existe",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArma: func(id int64) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço da arma",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaObterArma: func(id int64) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoArmaOriginal := arma.NovoServiço
+	defer func() {
+		arma.NovoServiço = serviçoArmaOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		arma.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) arma.Serviço {
+			return cenário.serviçoArma
+		}
+
+		handler := armaDetalhe{
+			ID: cenário.id,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ArmaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaDetalhe_Put(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		id                 int64
+		armaPedido         protocolo.ArmaPedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoArma        arma.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ArmaResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve transferir corretamente a arma para um clube",
+			id:        12,
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+				Calibre:     ".380",
+				Clube:       3,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaAtualizarArma: func(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+					if armaPedidoCompleto.ID != 12 {
+						t.Errorf("identificador da arma inesperado: %d", armaPedidoCompleto.ID)
+					}
+
+					return protocolo.ArmaResposta{
+						ID:              armaPedidoCompleto.ID,
+						NúmeroSérie:     armaPedidoCompleto.NúmeroSérie,
+						Calibre:         armaPedidoCompleto.Calibre,
+						Clube:           armaPedidoCompleto.Clube,
+						DataCriação:     data.Add(-time.Hour),
+						DataAtualização: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.ArmaResposta{
+				ID:              12,
+				NúmeroSérie:     "HG72643653",
+				Calibre:         ".380",
+				Clube:           3,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			id:        12,
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 12},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando a arma não existe",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaAtualizarArma: func(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço da arma",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaAtualizarArma: func(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:  "deve detectar mensagens na camada de serviço da arma",
+			id:         12,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaAtualizarArma: func(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoArmaOriginal := arma.NovoServiço
+	defer func() {
+		arma.NovoServiço = serviçoArmaOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		arma.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) arma.Serviço {
+			return cenário.serviçoArma
+		}
+
+		handler := armaDetalhe{
+			ID:         cenário.id,
+			ArmaPedido: cenário.armaPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Put(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ArmaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaDetalhe_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler armaDetalhe
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
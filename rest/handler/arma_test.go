@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/arma"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestArmaHandler_Post(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		armaPedido         protocolo.ArmaPedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoArma        arma.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ArmaResposta
+		mensagensEsperadas protocolo.Mensagens
+		cabeçalhoEsperado  http.Header
+	}{
+		{
+			descrição: "deve cadastrar corretamente a arma",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaCadastrarArma: func(armaPedido protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{
+						ID:           12,
+						NúmeroSérie:  armaPedido.NúmeroSérie,
+						Modelo:       armaPedido.Modelo,
+						Calibre:      armaPedido.Calibre,
+						CR:           armaPedido.CR,
+						TipoRegistro: armaPedido.TipoRegistro,
+						DataCriação:  data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusCreated,
+			esperado: &protocolo.ArmaResposta{
+				ID:           12,
+				NúmeroSérie:  "HG72643653",
+				Modelo:       "TAURUS PT 938",
+				Calibre:      ".380",
+				CR:           380308,
+				TipoRegistro: protocolo.ArmaTipoRegistroSIGMA,
+				DataCriação:  data,
+			},
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/arma/12"},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço da arma",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaCadastrarArma: func(armaPedido protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço da arma",
+			armaPedido: protocolo.ArmaPedido{
+				NúmeroSérie: "HG72643653",
+			},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoArma: simulador.ServiçoArma{
+				SimulaCadastrarArma: func(armaPedido protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+					return protocolo.ArmaResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaJáCadastrada, "HG72643653"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoArmaOriginal := arma.NovoServiço
+	defer func() {
+		arma.NovoServiço = serviçoArmaOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		arma.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) arma.Serviço {
+			return cenário.serviçoArma
+		}
+
+		handler := armaHandler{
+			ArmaPedido: cenário.armaPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ArmaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestArmaHandler_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler armaHandler
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/atirador", func() handy.Handler { return &atiradorHandler{} })
+}
+
+type atiradorHandler struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	AtiradorPedido   protocolo.AtiradorPedido    `request:"post"`
+	AtiradorResposta *protocolo.AtiradorResposta `response:"post"`
+}
+
+func (a *atiradorHandler) Post() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	atiradorResposta, err := serviçoAtirador.CadastrarAtirador(a.AtiradorPedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AtiradorResposta = &atiradorResposta
+	a.DefinirCabeçalho("Location", fmt.Sprintf("/atirador/%d", a.AtiradorResposta.CR))
+	return http.StatusCreated
+}
+
+func (a *atiradorHandler) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
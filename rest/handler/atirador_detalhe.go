@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/atirador/{cr}", func() handy.Handler { return &atiradorDetalhe{} })
+}
+
+type atiradorDetalhe struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	CR               int                         `urivar:"cr"`
+	AtiradorPedido   protocolo.AtiradorPedido    `request:"put"`
+	AtiradorResposta *protocolo.AtiradorResposta `response:"all"`
+}
+
+func (a *atiradorDetalhe) Get() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	atiradorResposta, err := serviçoAtirador.ObterAtirador(a.CR)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AtiradorResposta = &atiradorResposta
+	return http.StatusOK
+}
+
+func (a *atiradorDetalhe) Put() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	atiradorPedidoCompleto := protocolo.NovoAtiradorPedidoCompleto(a.CR, a.AtiradorPedido)
+	atiradorResposta, err := serviçoAtirador.AtualizarAtirador(atiradorPedidoCompleto)
+
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AtiradorResposta = &atiradorResposta
+	return http.StatusOK
+}
+
+func (a *atiradorDetalhe) Delete() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	if err := serviçoAtirador.RemoverAtirador(a.CR); err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	return http.StatusNoContent
+}
+
+func (a *atiradorDetalhe) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
@@ -0,0 +1,519 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAtiradorDetalhe_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.AtiradorResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve obter corretamente os dados do atirador",
+			cr:         380308,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAtirador: func(cr int) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{
+						CR:          cr,
+						Nome:        "João da Silva",
+						Situação:    protocolo.AtiradorSituaçãoAtivo,
+						DataCriação: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.AtiradorResposta{
+				CR:          380308,
+				Nome:        "João da Silva",
+				Situação:    protocolo.AtiradorSituaçãoAtivo,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			cr:        380308,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando o atirador não existe",
+			cr:         380308,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAtirador: func(cr int) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAtirador: func(cr int) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorDetalhe{
+			CR: cenário.cr,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AtiradorResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDetalhe_Put(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		atiradorPedido     protocolo.AtiradorPedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.AtiradorResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve atualizar corretamente os dados do atirador",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoSuspenso,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaAtualizarAtirador: func(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+					if atiradorPedidoCompleto.CRAtual != 380308 {
+						t.Errorf("CR atual inesperado: %d", atiradorPedidoCompleto.CRAtual)
+					}
+
+					return protocolo.AtiradorResposta{
+						CR:              atiradorPedidoCompleto.CR,
+						Nome:            atiradorPedidoCompleto.Nome,
+						CPF:             atiradorPedidoCompleto.CPF,
+						DataEmissão:     atiradorPedidoCompleto.DataEmissão,
+						DataValidade:    atiradorPedidoCompleto.DataValidade,
+						Situação:        atiradorPedidoCompleto.Situação,
+						DataCriação:     data.Add(-time.Hour),
+						DataAtualização: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.AtiradorResposta{
+				CR:              380308,
+				Nome:            "João da Silva",
+				CPF:             "52998224725",
+				DataEmissão:     data.AddDate(-1, 0, 0),
+				DataValidade:    data.AddDate(2, 0, 0),
+				Situação:        protocolo.AtiradorSituaçãoSuspenso,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar quando o atirador não existe",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaAtualizarAtirador: func(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaAtualizarAtirador: func(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço do atirador",
+			cr:        380308,
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaAtualizarAtirador: func(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380308"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380308"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorDetalhe{
+			CR:             cenário.cr,
+			AtiradorPedido: cenário.atiradorPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Put(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AtiradorResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDetalhe_Delete(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve remover corretamente o atirador",
+			cr:         380308,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRemoverAtirador: func(cr int) error {
+					if cr != 380308 {
+						t.Errorf("CR inesperado: %d", cr)
+					}
+					return nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusNoContent,
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			cr:        380308,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando o atirador não existe",
+			cr:         380308,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRemoverAtirador: func(cr int) error {
+					return erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRemoverAtirador: func(cr int) error {
+					return errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorDetalhe{
+			CR: cenário.cr,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Delete(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorDetalhe_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler atiradorDetalhe
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
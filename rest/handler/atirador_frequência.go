@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/atirador/{cr}/frequencias", func() handy.Handler { return &atiradorFrequências{} })
+}
+
+type atiradorFrequências struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	CR                      int                                `urivar:"cr"`
+	DataInícioDe            time.Time                          `query:"dataInicioDe"`
+	DataInícioAté           time.Time                          `query:"dataInicioAte"`
+	Cursor                  string                             `query:"cursor"`
+	Limite                  int                                `query:"limite"`
+	FrequênciaListaResposta *protocolo.FrequênciaListaResposta `response:"get"`
+}
+
+func (a *atiradorFrequências) Get() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().PodeConsultarAtirador(a.CR) {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	frequênciaListaResposta, err := serviçoAtirador.ListarFrequênciasAtirador(a.CR, protocolo.FrequênciaFiltro{
+		DataInícioDe:  a.DataInícioDe,
+		DataInícioAté: a.DataInícioAté,
+		Cursor:        a.Cursor,
+		Limite:        a.Limite,
+	})
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.FrequênciaListaResposta = &frequênciaListaResposta
+	return http.StatusOK
+}
+
+func (a *atiradorFrequências) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
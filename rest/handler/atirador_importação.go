@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/importacao/atirador", func() handy.Handler { return &atiradorImportação{} })
+}
+
+type atiradorImportação struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	AtiradorImportaçãoPedido   protocolo.AtiradorImportaçãoPedido    `request:"post"`
+	AtiradorImportaçãoResposta *protocolo.AtiradorImportaçãoResposta `response:"post"`
+}
+
+func (a *atiradorImportação) Post() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().Administrador() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	atiradorImportaçãoResposta, err := serviçoAtirador.ImportarAtiradores(a.AtiradorImportaçãoPedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AtiradorImportaçãoResposta = &atiradorImportaçãoResposta
+	return http.StatusOK
+}
+
+func (a *atiradorImportação) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
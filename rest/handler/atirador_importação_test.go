@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAtiradorImportação_Post(t *testing.T) {
+	cenários := []struct {
+		descrição                string
+		atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido
+		logger                   gostklog.Logger
+		configuração             *restconfig.Configuração
+		identidade               protocolo.Identidade
+		serviçoAtirador          atirador.Serviço
+		códigoHTTPEsperado       int
+		esperado                 *protocolo.AtiradorImportaçãoResposta
+		mensagensEsperadas       protocolo.Mensagens
+	}{
+		{
+			descrição: "deve importar corretamente os atiradores",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{{CR: 380308}, {CR: 380309}},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaImportarAtiradores: func(atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error) {
+					return protocolo.AtiradorImportaçãoResposta{
+						Cadastrados: 1,
+						Atualizados: len(atiradorImportaçãoPedido.Atiradores) - 1,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.AtiradorImportaçãoResposta{
+				Cadastrados: 1,
+				Atualizados: 1,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{{CR: 380308}},
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{{CR: 380308}},
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			atiradorImportaçãoPedido: protocolo.AtiradorImportaçãoPedido{
+				Atiradores: []protocolo.AtiradorPedido{{CR: 380308}},
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaImportarAtiradores: func(atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error) {
+					return protocolo.AtiradorImportaçãoResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorImportação{
+			AtiradorImportaçãoPedido: cenário.atiradorImportaçãoPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AtiradorImportaçãoResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorImportação_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler atiradorImportação
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/atirador/{cr}/municao", func() handy.Handler { return &atiradorMunição{} })
+}
+
+type atiradorMunição struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	CR                     int                               `urivar:"cr"`
+	Ano                    int                               `query:"ano"`
+	MuniçãoConsumoResposta *protocolo.MuniçãoConsumoResposta `response:"get"`
+}
+
+func (a *atiradorMunição) Get() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().PodeConsultarMunição(a.CR) {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	muniçãoConsumoResposta, err := serviçoAtirador.ConsumoMunição(a.CR, a.Ano)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.MuniçãoConsumoResposta = &muniçãoConsumoResposta
+	return http.StatusOK
+}
+
+func (a *atiradorMunição) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAtiradorMunição_Get(t *testing.T) {
+	cota := 5000
+
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		ano                int
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.MuniçãoConsumoResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve obter corretamente o consumo de munição do atirador",
+			cr:         380308,
+			ano:        2016,
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaConsumoMunição: func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+					return protocolo.MuniçãoConsumoResposta{
+						CR:  cr,
+						Ano: ano,
+						Calibres: []protocolo.MuniçãoConsumoCalibre{
+							{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 150, Cota: &cota},
+						},
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.MuniçãoConsumoResposta{
+				CR:  380308,
+				Ano: 2016,
+				Calibres: []protocolo.MuniçãoConsumoCalibre{
+					{Calibre: ".380 ACP", Classe: protocolo.CalibreClassePermitido, Quantidade: 150, Cota: &cota},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:  "deve permitir que o atirador consulte o seu próprio consumo de munição",
+			cr:         380308,
+			ano:        2016,
+			identidade: protocolo.Identidade{IDUsuário: 3, Papel: protocolo.PapelAtirador, CR: 380308},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaConsumoMunição: func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+					return protocolo.MuniçãoConsumoResposta{CR: cr, Ano: ano}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado:           &protocolo.MuniçãoConsumoResposta{CR: 380308, Ano: 2016},
+		},
+		{
+			descrição:          "deve recusar um atirador consultando outro CR",
+			cr:                 380309,
+			logger:             simulador.Logger{},
+			identidade:         protocolo.Identidade{IDUsuário: 3, Papel: protocolo.PapelAtirador, CR: 380308},
+			configuração:       new(restconfig.Configuração),
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:          "deve recusar um auditor",
+			cr:                 380308,
+			logger:             simulador.Logger{},
+			identidade:         protocolo.Identidade{IDUsuário: 4, Papel: protocolo.PapelAuditor},
+			configuração:       new(restconfig.Configuração),
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando o atirador não existe",
+			cr:         380308,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaConsumoMunição: func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+					return protocolo.MuniçãoConsumoResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			cr:        380308,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaConsumoMunição: func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+					return protocolo.MuniçãoConsumoResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorMunição{
+			CR:  cenário.cr,
+			Ano: cenário.ano,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.MuniçãoConsumoResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorMunição_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler atiradorMunição
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
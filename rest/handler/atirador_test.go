@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAtiradorHandler_Post(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		atiradorPedido     protocolo.AtiradorPedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.AtiradorResposta
+		mensagensEsperadas protocolo.Mensagens
+		cabeçalhoEsperado  http.Header
+	}{
+		{
+			descrição: "deve cadastrar corretamente o atirador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCadastrarAtirador: func(atiradorPedido protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{
+						CR:           atiradorPedido.CR,
+						Nome:         atiradorPedido.Nome,
+						CPF:          atiradorPedido.CPF,
+						DataEmissão:  atiradorPedido.DataEmissão,
+						DataValidade: atiradorPedido.DataValidade,
+						Situação:     protocolo.AtiradorSituaçãoAtivo,
+						DataCriação:  data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusCreated,
+			esperado: &protocolo.AtiradorResposta{
+				CR:           380308,
+				Nome:         "João da Silva",
+				CPF:          "52998224725",
+				DataEmissão:  data.AddDate(-1, 0, 0),
+				DataValidade: data.AddDate(2, 0, 0),
+				Situação:     protocolo.AtiradorSituaçãoAtivo,
+				DataCriação:  data,
+			},
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/atirador/380308"},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCadastrarAtirador: func(atiradorPedido protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço do atirador",
+			atiradorPedido: protocolo.AtiradorPedido{
+				CR: 380308,
+			},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCadastrarAtirador: func(atiradorPedido protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+					return protocolo.AtiradorResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380308"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoAtiradorJáCadastrado, "380308"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := atiradorHandler{
+			AtiradorPedido: cenário.atiradorPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AtiradorResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtiradorHandler_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler atiradorHandler
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/auditoria/amostra", func() handy.Handler { return &auditoriaAmostra{} })
+}
+
+type auditoriaAmostra struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	AmostraAuditoriaPedido   protocolo.AmostraAuditoriaPedido    `request:"post"`
+	AmostraAuditoriaResposta *protocolo.AmostraAuditoriaResposta `response:"post"`
+}
+
+func (a *auditoriaAmostra) Post() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().PodeAuditar() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	amostraAuditoriaResposta, err := serviçoAtirador.SortearAmostraAuditoria(a.AmostraAuditoriaPedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			a.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AmostraAuditoriaResposta = &amostraAuditoriaResposta
+	a.DefinirCabeçalho("Location", fmt.Sprintf("/auditoria/amostra/%d", a.AmostraAuditoriaResposta.ID))
+	return http.StatusCreated
+}
+
+func (a *auditoriaAmostra) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/auditoria/amostra/{id}", func() handy.Handler { return &auditoriaAmostraDetalhe{} })
+}
+
+type auditoriaAmostraDetalhe struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	ID                       int64                               `urivar:"id"`
+	AmostraAuditoriaResposta *protocolo.AmostraAuditoriaResposta `response:"get"`
+}
+
+func (a *auditoriaAmostraDetalhe) Get() int {
+	if config.Atual() == nil {
+		a.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !a.Identidade().PodeAuditar() {
+		a.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(a.Tx(), a.Logger(), config.Atual().Configuração)
+	amostraAuditoriaResposta, err := serviçoAtirador.ObterAmostraAuditoria(a.ID)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		a.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.AmostraAuditoriaResposta = &amostraAuditoriaResposta
+	return http.StatusOK
+}
+
+func (a *auditoriaAmostraDetalhe) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(a).
+		Chain(interceptador.NovaAutenticação(a)).
+		Chain(interceptador.NovoBD(a))
+}
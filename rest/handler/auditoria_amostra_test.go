@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAuditoriaAmostra_Post(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição              string
+		amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido
+		logger                 gostklog.Logger
+		configuração           *restconfig.Configuração
+		identidade             protocolo.Identidade
+		serviçoAtirador        atirador.Serviço
+		códigoHTTPEsperado     int
+		esperado               *protocolo.AmostraAuditoriaResposta
+		mensagensEsperadas     protocolo.Mensagens
+		cabeçalhoEsperado      http.Header
+	}{
+		{
+			descrição: "deve sortear corretamente a amostra de auditoria",
+			amostraAuditoriaPedido: protocolo.AmostraAuditoriaPedido{
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 5,
+				Semente:            42,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaSortearAmostraAuditoria: func(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+					return protocolo.AmostraAuditoriaResposta{
+						ID:                 3,
+						DataInício:         amostraAuditoriaPedido.DataInício,
+						DataTérmino:        amostraAuditoriaPedido.DataTérmino,
+						QuantidadePorClube: amostraAuditoriaPedido.QuantidadePorClube,
+						Semente:            amostraAuditoriaPedido.Semente,
+						DataCriação:        data,
+						Auditorias: []protocolo.AuditoriaListaItem{
+							{ID: 11, NúmeroControle: protocolo.NovoNúmeroControle(1, 98765), CR: 380308, Clube: 1},
+						},
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusCreated,
+			esperado: &protocolo.AmostraAuditoriaResposta{
+				ID:                 3,
+				DataInício:         data.AddDate(0, -1, 0),
+				DataTérmino:        data,
+				QuantidadePorClube: 5,
+				Semente:            42,
+				DataCriação:        data,
+				Auditorias: []protocolo.AuditoriaListaItem{
+					{ID: 11, NúmeroControle: protocolo.NovoNúmeroControle(1, 98765), CR: 380308, Clube: 1},
+				},
+			},
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/auditoria/amostra/3"},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não pode auditar",
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar um erro de validação na camada de serviço",
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaSortearAmostraAuditoria: func(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+					return protocolo.AmostraAuditoriaResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoCritérioAmostraInválido),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCritérioAmostraInválido),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaSortearAmostraAuditoria: func(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+					return protocolo.AmostraAuditoriaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := auditoriaAmostra{
+			AmostraAuditoriaPedido: cenário.amostraAuditoriaPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AmostraAuditoriaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaAmostra_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler auditoriaAmostra
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestAuditoriaAnálise_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		id                 int64
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.AuditoriaResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve obter corretamente a auditoria com as imagens",
+			id:         11,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAuditoria: func(id int64) (protocolo.AuditoriaResposta, error) {
+					return protocolo.AuditoriaResposta{
+						ID:                   id,
+						Amostra:              3,
+						NúmeroControle:       protocolo.NovoNúmeroControle(1, 98765),
+						CR:                   380308,
+						Clube:                1,
+						DataConfirmação:      data,
+						Situação:             protocolo.FrequênciaSituaçãoEmAuditoria,
+						ImagemNúmeroControle: "AAAA",
+						ImagemConfirmação:    "BBBB",
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.AuditoriaResposta{
+				ID:                   11,
+				Amostra:              3,
+				NúmeroControle:       protocolo.NovoNúmeroControle(1, 98765),
+				CR:                   380308,
+				Clube:                1,
+				DataConfirmação:      data,
+				Situação:             protocolo.FrequênciaSituaçãoEmAuditoria,
+				ImagemNúmeroControle: "AAAA",
+				ImagemConfirmação:    "BBBB",
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        11,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não pode auditar",
+			id:        11,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando a auditoria não existe",
+			id:         11,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAuditoria: func(id int64) (protocolo.AuditoriaResposta, error) {
+					return protocolo.AuditoriaResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			id:        11,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterAuditoria: func(id int64) (protocolo.AuditoriaResposta, error) {
+					return protocolo.AuditoriaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := auditoriaAnálise{
+			ID: cenário.id,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AuditoriaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaAnálise_Put(t *testing.T) {
+	cenários := []struct {
+		descrição               string
+		id                      int64
+		auditoriaVereditoPedido protocolo.AuditoriaVereditoPedido
+		logger                  gostklog.Logger
+		configuração            *restconfig.Configuração
+		identidade              protocolo.Identidade
+		serviçoAtirador         atirador.Serviço
+		códigoHTTPEsperado      int
+		mensagensEsperadas      protocolo.Mensagens
+	}{
+		{
+			descrição: "deve registrar corretamente o veredito",
+			id:        11,
+			auditoriaVereditoPedido: protocolo.AuditoriaVereditoPedido{
+				Veredito:    protocolo.AuditoriaVereditoFraudulenta,
+				Observações: "Foto de outra pessoa",
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRegistrarVeredito: func(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+					if auditoriaVereditoPedidoCompleta.ID != 11 {
+						t.Errorf("auditoria inesperada: %d", auditoriaVereditoPedidoCompleta.ID)
+					}
+
+					if auditoriaVereditoPedidoCompleta.Identidade.IDUsuário != 1 {
+						t.Errorf("usuário inesperado: %d", auditoriaVereditoPedidoCompleta.Identidade.IDUsuário)
+					}
+
+					return nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusNoContent,
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        11,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não pode auditar",
+			id:        11,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando a auditoria não existe",
+			id:         11,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRegistrarVeredito: func(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+					return erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição:  "deve detectar uma auditoria já concluída",
+			id:         11,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRegistrarVeredito: func(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+					return protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoAuditoriaConcluída),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAuditoriaConcluída),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			id:        11,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAuditor},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRegistrarVeredito: func(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+					return errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := auditoriaAnálise{
+			ID:                      cenário.id,
+			AuditoriaVereditoPedido: cenário.auditoriaVereditoPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Put(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAuditoriaAnálise_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler auditoriaAnálise
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/calibre"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/calibre", func() handy.Handler { return &calibreLista{} })
+}
+
+type calibreLista struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	CalibreListaResposta *protocolo.CalibreListaResposta `response:"get"`
+}
+
+func (c *calibreLista) Get() int {
+	if config.Atual() == nil {
+		c.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	serviçoCalibre := calibre.NovoServiço(c.Tx(), c.Logger(), config.Atual().Configuração)
+	calibreListaResposta, err := serviçoCalibre.ListarCalibres()
+	if err != nil {
+		c.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	c.CalibreListaResposta = &calibreListaResposta
+	return http.StatusOK
+}
+
+func (c *calibreLista) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(c).
+		Chain(interceptador.NovaAutenticação(c)).
+		Chain(interceptador.NovoBD(c))
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/clube", func() handy.Handler { return &clubeHandler{} })
+}
+
+type clubeHandler struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	ClubePedido   protocolo.ClubePedido    `request:"post"`
+	ClubeResposta *protocolo.ClubeResposta `response:"post"`
+}
+
+func (c *clubeHandler) Post() int {
+	if config.Atual() == nil {
+		c.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !c.Identidade().Administrador() {
+		c.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoClube := clube.NovoServiço(c.Tx(), c.Logger(), config.Atual().Configuração)
+	clubeResposta, err := serviçoClube.CadastrarClube(c.ClubePedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			c.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		c.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	c.ClubeResposta = &clubeResposta
+	c.DefinirCabeçalho("Location", fmt.Sprintf("/clube/%d", c.ClubeResposta.ID))
+	return http.StatusCreated
+}
+
+func (c *clubeHandler) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(c).
+		Chain(interceptador.NovaAutenticação(c)).
+		Chain(interceptador.NovoBD(c))
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/clube/{id}", func() handy.Handler { return &clubeDetalhe{} })
+}
+
+type clubeDetalhe struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	ID            int64                    `urivar:"id"`
+	ClubePedido   protocolo.ClubePedido    `request:"put"`
+	ClubeResposta *protocolo.ClubeResposta `response:"all"`
+}
+
+func (c *clubeDetalhe) Get() int {
+	if config.Atual() == nil {
+		c.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !c.Identidade().Administrador() {
+		c.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoClube := clube.NovoServiço(c.Tx(), c.Logger(), config.Atual().Configuração)
+	clubeResposta, err := serviçoClube.ObterClube(c.ID)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		c.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	c.ClubeResposta = &clubeResposta
+	return http.StatusOK
+}
+
+func (c *clubeDetalhe) Put() int {
+	if config.Atual() == nil {
+		c.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !c.Identidade().Administrador() {
+		c.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoClube := clube.NovoServiço(c.Tx(), c.Logger(), config.Atual().Configuração)
+	clubePedidoCompleto := protocolo.NovoClubePedidoCompleto(c.ID, c.ClubePedido)
+	clubeResposta, err := serviçoClube.AtualizarClube(clubePedidoCompleto)
+
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			c.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		c.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	c.ClubeResposta = &clubeResposta
+	return http.StatusOK
+}
+
+func (c *clubeDetalhe) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(c).
+		Chain(interceptador.NovaAutenticação(c)).
+		Chain(interceptador.NovoBD(c))
+}
@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestClubeDetalhe_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		id                 int64
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoClube       clube.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ClubeResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:  "deve obter corretamente os dados do clube",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{
+						ID:          id,
+						CNPJ:        "11222333000181",
+						Nome:        "Clube de Tiro Centro",
+						Situação:    protocolo.ClubeSituaçãoAtivo,
+						DataCriação: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.ClubeResposta{
+				ID:          12,
+				CNPJ:        "11222333000181",
+				Nome:        "Clube de Tiro Centro",
+				Situação:    protocolo.ClubeSituaçãoAtivo,
+				DataCriação: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			id:        12,
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 12},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando o clube não existe",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do clube",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoClubeOriginal := clube.NovoServiço
+	defer func() {
+		clube.NovoServiço = serviçoClubeOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		clube.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		handler := clubeDetalhe{
+			ID: cenário.id,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ClubeResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeDetalhe_Put(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		id                 int64
+		clubePedido        protocolo.ClubePedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoClube       clube.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ClubeResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve desativar corretamente o clube",
+			id:        12,
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:     "11222333000181",
+				Nome:     "Clube de Tiro Centro",
+				Situação: protocolo.ClubeSituaçãoInativo,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaAtualizarClube: func(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+					if clubePedidoCompleto.ID != 12 {
+						t.Errorf("identificador do clube inesperado: %d", clubePedidoCompleto.ID)
+					}
+
+					return protocolo.ClubeResposta{
+						ID:              clubePedidoCompleto.ID,
+						CNPJ:            clubePedidoCompleto.CNPJ,
+						Nome:            clubePedidoCompleto.Nome,
+						Situação:        clubePedidoCompleto.Situação,
+						DataCriação:     data.Add(-time.Hour),
+						DataAtualização: data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.ClubeResposta{
+				ID:              12,
+				CNPJ:            "11222333000181",
+				Nome:            "Clube de Tiro Centro",
+				Situação:        protocolo.ClubeSituaçãoInativo,
+				DataCriação:     data.Add(-time.Hour),
+				DataAtualização: data,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			id:        12,
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 12},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:  "deve detectar quando o clube não existe",
+			id:         12,
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaAtualizarClube: func(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do clube",
+			id:        12,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaAtualizarClube: func(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:  "deve detectar mensagens na camada de serviço do clube",
+			id:         12,
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaAtualizarClube: func(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoClubeOriginal := clube.NovoServiço
+	defer func() {
+		clube.NovoServiço = serviçoClubeOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		clube.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		handler := clubeDetalhe{
+			ID:          cenário.id,
+			ClubePedido: cenário.clubePedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Put(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ClubeResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeDetalhe_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler clubeDetalhe
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestClubeHandler_Post(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		clubePedido        protocolo.ClubePedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoClube       clube.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.ClubeResposta
+		mensagensEsperadas protocolo.Mensagens
+		cabeçalhoEsperado  http.Header
+	}{
+		{
+			descrição: "deve cadastrar corretamente o clube",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaCadastrarClube: func(clubePedido protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{
+						ID:            12,
+						CNPJ:          clubePedido.CNPJ,
+						CR:            clubePedido.CR,
+						Nome:          clubePedido.Nome,
+						Endereço:      clubePedido.Endereço,
+						Cidade:        clubePedido.Cidade,
+						UF:            clubePedido.UF,
+						RegiãoMilitar: clubePedido.RegiãoMilitar,
+						Situação:      protocolo.ClubeSituaçãoAtivo,
+						DataCriação:   data,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusCreated,
+			esperado: &protocolo.ClubeResposta{
+				ID:            12,
+				CNPJ:          "11222333000181",
+				CR:            123456,
+				Nome:          "Clube de Tiro Centro",
+				Endereço:      "Rua das Flores, 123",
+				Cidade:        "Rio de Janeiro",
+				UF:            "RJ",
+				RegiãoMilitar: 1,
+				Situação:      protocolo.ClubeSituaçãoAtivo,
+				DataCriação:   data,
+			},
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/clube/12"},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do clube",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaCadastrarClube: func(clubePedido protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço do clube",
+			clubePedido: protocolo.ClubePedido{
+				CNPJ: "11222333000181",
+			},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoClube: simulador.ServiçoClube{
+				SimulaCadastrarClube: func(clubePedido protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoClubeJáCadastrado, "11222333000181"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoClubeOriginal := clube.NovoServiço
+	defer func() {
+		clube.NovoServiço = serviçoClubeOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		clube.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		handler := clubeHandler{
+			ClubePedido: cenário.clubePedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ClubeResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestClubeHandler_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler clubeHandler
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
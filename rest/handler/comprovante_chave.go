@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/comprovante/chave", func() handy.Handler { return &comprovanteChave{} })
+}
+
+type comprovanteChave struct {
+	básico
+
+	ComprovanteChaveResposta *protocolo.ComprovanteChaveResposta `response:"get"`
+}
+
+func (c *comprovanteChave) Get() int {
+	if config.Atual() == nil {
+		c.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	chavePrivada := config.Atual().Atirador.ChaveAssinatura.PrivateKey
+	if chavePrivada == nil {
+		return http.StatusNotFound
+	}
+
+	c.ComprovanteChaveResposta = &protocolo.ComprovanteChaveResposta{
+		Algoritmo: "Ed25519",
+		Chave:     base64.StdEncoding.EncodeToString(chavePrivada.Public().(ed25519.PublicKey)),
+	}
+
+	return http.StatusOK
+}
+
+func (c *comprovanteChave) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(c)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestComprovanteChave_Get(t *testing.T) {
+	chavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+
+	cenários := []struct {
+		descrição          string
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		códigoHTTPEsperado int
+		esperado           *protocolo.ComprovanteChaveResposta
+	}{
+		{
+			descrição: "deve publicar corretamente a chave pública dos comprovantes",
+			configuração: func() *restconfig.Configuração {
+				configuração := new(restconfig.Configuração)
+				configuração.Atirador.ChaveAssinatura.PrivateKey = chavePrivada
+				return configuração
+			}(),
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.ComprovanteChaveResposta{
+				Algoritmo: "Ed25519",
+				Chave:     "iojj3XQJ8ZX9UtstPLpdcspnCb8dlBIb83SIAbQPb1w=",
+			},
+		},
+		{
+			descrição: "deve detectar quando não existe chave de assinatura configurada",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		var handler comprovanteChave
+		handler.DefineLogger(cenário.logger)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.ComprovanteChaveResposta, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestComprovanteChave_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+	}
+
+	var handler comprovanteChave
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -18,11 +18,17 @@ func init() {
 
 type frequênciaAtirador struct {
 	básico
+	interceptador.AutenticaçãoCompatível
 	interceptador.BDCompatível
+	interceptador.PDFCompatível
 
 	CR                         int                                   `urivar:"cr"`
 	FrequênciaPedido           protocolo.FrequênciaPedido            `request:"post"`
 	FrequênciaPendenteResposta *protocolo.FrequênciaPendenteResposta `response:"post"`
+
+	// FrequênciaPDF substitui a resposta em JSON quando o cliente solicita a
+	// folha do número de controle em PDF.
+	FrequênciaPDF *frequênciaPDF `response:"pdf"`
 }
 
 func (f *frequênciaAtirador) Post() int {
@@ -31,6 +37,13 @@ func (f *frequênciaAtirador) Post() int {
 		return http.StatusInternalServerError
 	}
 
+	if !f.Identidade().PodeReportarClube(f.FrequênciaPedido.Clube) {
+		f.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
 	serviçoAtirador := atirador.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
 	frequênciaPedidoCompleta := protocolo.NovaFrequênciaPedidoCompleta(f.CR, f.FrequênciaPedido)
 	frequênciaPendenteResposta, err := serviçoAtirador.CadastrarFrequência(frequênciaPedidoCompleta)
@@ -47,10 +60,22 @@ func (f *frequênciaAtirador) Post() int {
 
 	f.FrequênciaPendenteResposta = &frequênciaPendenteResposta
 	f.DefinirCabeçalho("Location", fmt.Sprintf("/frequencia/%d/%s?verificacao=%s", f.CR, f.FrequênciaPendenteResposta.NúmeroControle, f.FrequênciaPendenteResposta.CódigoVerificação))
+
+	if f.AceitaPDF() {
+		pdf, err := serviçoAtirador.PDFNúmeroControle(f.CR, frequênciaPendenteResposta.NúmeroControle, frequênciaPendenteResposta.CódigoVerificação)
+		if err != nil {
+			f.Logger().Error(erros.Novo(err))
+			return http.StatusInternalServerError
+		}
+
+		f.FrequênciaPDF = (*frequênciaPDF)(&pdf)
+	}
+
 	return http.StatusCreated
 }
 
 func (f *frequênciaAtirador) Interceptors() handy.InterceptorChain {
 	return criarCorrenteBásica(f).
+		Chain(interceptador.NovaAutenticação(f)).
 		Chain(interceptador.NovoBD(f))
 }
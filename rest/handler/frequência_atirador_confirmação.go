@@ -18,13 +18,15 @@ func init() {
 
 type frequênciaAtiradorConfirmação struct {
 	básico
+	interceptador.AutenticaçãoCompatível
 	interceptador.BDCompatível
 
-	CR                          int                                   `urivar:"cr"`
-	NúmeroControle              protocolo.NúmeroControle              `urivar:"numeroControle"`
-	CódigoVerificação           string                                `query:"verificacao"`
-	FrequênciaConfirmaçãoPedido protocolo.FrequênciaConfirmaçãoPedido `request:"put"`
-	FrequênciaResposta          *protocolo.FrequênciaResposta         `response:"get"`
+	CR                           int                                    `urivar:"cr"`
+	NúmeroControle               protocolo.NúmeroControle               `urivar:"numeroControle"`
+	CódigoVerificação            string                                 `query:"verificacao"`
+	FrequênciaConfirmaçãoPedido  protocolo.FrequênciaConfirmaçãoPedido  `request:"put"`
+	FrequênciaCancelamentoPedido protocolo.FrequênciaCancelamentoPedido `request:"delete"`
+	FrequênciaResposta           *protocolo.FrequênciaResposta          `response:"get"`
 }
 
 func (f *frequênciaAtiradorConfirmação) Get() int {
@@ -79,7 +81,41 @@ func (f *frequênciaAtiradorConfirmação) Put() int {
 	return http.StatusNoContent
 }
 
+func (f *frequênciaAtiradorConfirmação) Delete() int {
+	if config.Atual() == nil {
+		f.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	serviçoAtirador := atirador.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
+	frequênciaCancelamentoPedidoCompleta := protocolo.NovaFrequênciaCancelamentoPedidoCompleta(f.CR, f.NúmeroControle, f.Identidade(), f.FrequênciaCancelamentoPedido)
+
+	if err := serviçoAtirador.CancelarFrequência(frequênciaCancelamentoPedidoCompleta); err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		if errors.Equal(err, erros.AcessoNegado) {
+			f.Mensagens = protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			)
+			return http.StatusForbidden
+		}
+
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			f.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		f.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	return http.StatusNoContent
+}
+
 func (f *frequênciaAtiradorConfirmação) Interceptors() handy.InterceptorChain {
 	return criarCorrenteBásica(f).
+		Chain(interceptador.NovaAutenticaçãoMétodos(f, "DELETE")).
 		Chain(interceptador.NovoBD(f))
 }
@@ -339,6 +339,200 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 	}
 }
 
+func TestFrequênciaAtiradorConfirmação_Delete(t *testing.T) {
+	cenários := []struct {
+		descrição                    string
+		cr                           int
+		númeroControle               protocolo.NúmeroControle
+		identidade                   protocolo.Identidade
+		frequênciaCancelamentoPedido protocolo.FrequênciaCancelamentoPedido
+		logger                       gostklog.Logger
+		configuração                 *restconfig.Configuração
+		serviçoAtirador              atirador.Serviço
+		códigoHTTPEsperado           int
+		mensagensEsperadas           protocolo.Mensagens
+	}{
+		{
+			descrição:      "deve cancelar corretamente a frequência do atirador",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			identidade: protocolo.Identidade{
+				Papel:   protocolo.PapelClube,
+				IDClube: 1,
+			},
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCancelarFrequência: func(frequênciaCancelamentoPedidoCompleta protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+					esperado := protocolo.FrequênciaCancelamentoPedidoCompleta{
+						CR:             123456789,
+						NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+						Identidade: protocolo.Identidade{
+							Papel:   protocolo.PapelClube,
+							IDClube: 1,
+						},
+						FrequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+							Motivo: "Registro duplicado",
+						},
+					}
+
+					verificadorResultado := testes.NovoVerificadorResultados("deve repassar o pedido de cancelamento", 0)
+					verificadorResultado.DefinirEsperado(esperado, nil)
+					if err := verificadorResultado.VerificaResultado(frequênciaCancelamentoPedidoCompleta, nil); err != nil {
+						t.Error(err)
+					}
+
+					return nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusNoContent,
+		},
+		{
+			descrição:      "deve detectar quando a configuração não foi inicializada",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:      "deve detectar quando a frequência do atirador não existe",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCancelarFrequência: func(protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+					return erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição:      "deve recusar o cancelamento de uma frequência de outro clube",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCancelarFrequência: func(protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+					return erros.AcessoNegado
+				},
+			},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição:      "deve detectar um erro na camada de serviço do atirador",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCancelarFrequência: func(protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+					return errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:      "deve detectar mensagens na camada de serviço do atirador",
+			cr:             123456789,
+			númeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			frequênciaCancelamentoPedido: protocolo.FrequênciaCancelamentoPedido{
+				Motivo: "Registro duplicado",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCancelarFrequência: func(protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+					return protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoCancelamentoExpirado),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoCancelamentoExpirado),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := frequênciaAtiradorConfirmação{
+			CR:                           cenário.cr,
+			NúmeroControle:               cenário.númeroControle,
+			FrequênciaCancelamentoPedido: cenário.frequênciaCancelamentoPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Delete(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestFrequênciaAtiradorConfirmação_Interceptors(t *testing.T) {
 	esperado := []string{
 		"*interceptador.EndereçoRemoto",
@@ -348,6 +542,7 @@ func TestFrequênciaAtiradorConfirmação_Interceptors(t *testing.T) {
 		"*interceptador.ParâmetrosConsulta",
 		"*interceptador.VariáveisEndereço",
 		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
 		"*interceptador.BD",
 	}
 
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/frequencia", func() handy.Handler { return &frequênciaAtiradorLista{} })
+}
+
+type frequênciaAtiradorLista struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	CR                      int                                `query:"cr"`
+	Clube                   int64                              `query:"clube"`
+	Calibre                 string                             `query:"calibre"`
+	NúmeroSérie             string                             `query:"numeroSerie"`
+	DataInícioDe            time.Time                          `query:"dataInicioDe"`
+	DataInícioAté           time.Time                          `query:"dataInicioAte"`
+	Situação                string                             `query:"situacao"`
+	Ordenação               string                             `query:"ordenacao"`
+	Cursor                  string                             `query:"cursor"`
+	Limite                  int                                `query:"limite"`
+	FrequênciaListaResposta *protocolo.FrequênciaListaResposta `response:"get"`
+}
+
+func (f *frequênciaAtiradorLista) Get() int {
+	if config.Atual() == nil {
+		f.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	if !f.Identidade().Administrador() {
+		f.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
+	frequênciaListaResposta, err := serviçoAtirador.ListarFrequências(protocolo.FrequênciaFiltro{
+		CR:            f.CR,
+		Clube:         f.Clube,
+		Calibre:       f.Calibre,
+		NúmeroSérie:   f.NúmeroSérie,
+		DataInícioDe:  f.DataInícioDe,
+		DataInícioAté: f.DataInícioAté,
+		Situação:      protocolo.FrequênciaSituação(f.Situação),
+		Ordenação:     protocolo.FrequênciaOrdenação(f.Ordenação),
+		Cursor:        f.Cursor,
+		Limite:        f.Limite,
+	})
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			f.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		f.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	f.FrequênciaListaResposta = &frequênciaListaResposta
+	return http.StatusOK
+}
+
+func (f *frequênciaAtiradorLista) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(f).
+		Chain(interceptador.NovaAutenticação(f)).
+		Chain(interceptador.NovoBD(f))
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestFrequênciaAtiradorLista_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		handler            frequênciaAtiradorLista
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.FrequênciaListaResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve listar corretamente as frequências",
+			handler: frequênciaAtiradorLista{
+				CR:            380308,
+				Clube:         1,
+				Calibre:       ".380",
+				NúmeroSérie:   "ZA785671",
+				DataInícioDe:  data.Add(-24 * time.Hour),
+				DataInícioAté: data,
+				Situação:      "confirmada",
+				Ordenação:     "cr",
+				Cursor:        "abc123",
+				Limite:        10,
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaListarFrequências: func(frequênciaFiltro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+					esperado := protocolo.FrequênciaFiltro{
+						CR:            380308,
+						Clube:         1,
+						Calibre:       ".380",
+						NúmeroSérie:   "ZA785671",
+						DataInícioDe:  data.Add(-24 * time.Hour),
+						DataInícioAté: data,
+						Situação:      protocolo.FrequênciaSituaçãoConfirmada,
+						Ordenação:     protocolo.FrequênciaOrdenaçãoCR,
+						Cursor:        "abc123",
+						Limite:        10,
+					}
+
+					if frequênciaFiltro != esperado {
+						t.Errorf("filtro inesperado: %#v", frequênciaFiltro)
+					}
+
+					return protocolo.FrequênciaListaResposta{
+						Frequências: []protocolo.FrequênciaListaItem{
+							{
+								NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+								CR:                380308,
+								Clube:             1,
+								Calibre:           ".380",
+								ArmaUtilizada:     "ARMA DO CLUBE",
+								NúmeroSérie:       "ZA785671",
+								QuantidadeMunição: 50,
+								DataInício:        data.Add(-1 * time.Hour),
+								DataTérmino:       data.Add(-30 * time.Minute),
+								DataCriação:       data.Add(-20 * time.Minute),
+								DataConfirmação:   data.Add(-10 * time.Minute),
+							},
+						},
+						PróximoCursor: "def456",
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.FrequênciaListaResposta{
+				Frequências: []protocolo.FrequênciaListaItem{
+					{
+						NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+						CR:                380308,
+						Clube:             1,
+						Calibre:           ".380",
+						ArmaUtilizada:     "ARMA DO CLUBE",
+						NúmeroSérie:       "ZA785671",
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-1 * time.Hour),
+						DataTérmino:       data.Add(-30 * time.Minute),
+						DataCriação:       data.Add(-20 * time.Minute),
+						DataConfirmação:   data.Add(-10 * time.Minute),
+					},
+				},
+				PróximoCursor: "def456",
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaListarFrequências: func(frequênciaFiltro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+					return protocolo.FrequênciaListaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço do atirador",
+			handler: frequênciaAtiradorLista{
+				Ordenação: "calibre",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaListarFrequências: func(frequênciaFiltro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+					return protocolo.FrequênciaListaResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoOrdenaçãoInválida, "calibre"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoOrdenaçãoInválida, "calibre"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := cenário.handler
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.FrequênciaListaResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaAtiradorLista_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler frequênciaAtiradorLista
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/frequencia/{cr}/{numeroControle}/numero-controle.pdf", func() handy.Handler { return &frequênciaAtiradorPDF{} })
+}
+
+// frequênciaAtiradorPDF permite o download da folha do número de controle em
+// PDF a qualquer momento, para que o clube possa reimprimi-la. Assim como na
+// consulta da frequência, é necessário informar o código de verificação.
+type frequênciaAtiradorPDF struct {
+	básico
+	interceptador.BDCompatível
+
+	CR                int                      `urivar:"cr"`
+	NúmeroControle    protocolo.NúmeroControle `urivar:"numeroControle"`
+	CódigoVerificação string                   `query:"verificacao"`
+	FrequênciaPDF     *frequênciaPDF           `response:"get"`
+}
+
+func (f *frequênciaAtiradorPDF) Get() int {
+	if config.Atual() == nil {
+		f.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	serviçoAtirador := atirador.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
+	pdf, err := serviçoAtirador.PDFNúmeroControle(f.CR, f.NúmeroControle, f.CódigoVerificação)
+	if err != nil {
+		if errors.Equal(err, erros.NãoEncontrado) {
+			return http.StatusNotFound
+		}
+
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			f.Mensagens = mensagens
+			return http.StatusBadRequest
+		}
+
+		f.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	f.FrequênciaPDF = (*frequênciaPDF)(&pdf)
+	f.DefinirCabeçalho("Content-Disposition", fmt.Sprintf(`attachment; filename="numero-controle-%s.pdf"`, f.NúmeroControle))
+	return http.StatusOK
+}
+
+func (f *frequênciaAtiradorPDF) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(f).
+		Chain(interceptador.NovoBD(f))
+}
+
+// frequênciaPDF representa a folha do número de controle já no formato PDF.
+type frequênciaPDF []byte
+
+// PDF escreve o documento sem alterações.
+func (f frequênciaPDF) PDF(w io.Writer) error {
+	_, err := w.Write(f)
+	return err
+}
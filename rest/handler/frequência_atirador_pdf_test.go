@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestFrequênciaAtiradorPDF_Get(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		númeroControle     protocolo.NúmeroControle
+		códigoVerificação  string
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *frequênciaPDF
+		mensagensEsperadas protocolo.Mensagens
+		cabeçalhoEsperado  http.Header
+	}{
+		{
+			descrição:         "deve gerar corretamente o PDF do número de controle",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					return []byte("%PDF-1.3"), nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: func() *frequênciaPDF {
+				pdf := frequênciaPDF("%PDF-1.3")
+				return &pdf
+			}(),
+			cabeçalhoEsperado: http.Header{
+				"Content-Disposition": []string{`attachment; filename="numero-controle-7654-918273645.pdf"`},
+			},
+		},
+		{
+			descrição:         "deve detectar quando a configuração não foi inicializada",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:         "deve detectar quando a frequência não existe",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					return nil, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+		},
+		{
+			descrição:         "deve detectar dados inválidos da frequência",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "abc",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					return nil, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "abc"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoVerificaçãoInválida, "abc"),
+			),
+		},
+		{
+			descrição:         "deve detectar um erro na camada de serviço do atirador",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					return nil, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := frequênciaAtiradorPDF{
+			CR:                cenário.cr,
+			NúmeroControle:    cenário.númeroControle,
+			CódigoVerificação: cenário.códigoVerificação,
+		}
+		handler.DefineLogger(cenário.logger)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.FrequênciaPDF, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaAtiradorPDF_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.BD",
+	}
+
+	var handler frequênciaAtiradorPDF
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFrequênciaPDF_PDF(t *testing.T) {
+	var buffer bytes.Buffer
+	pdf := frequênciaPDF("%PDF-1.3")
+
+	err := pdf.PDF(&buffer)
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve escrever o documento sem alterações", 0)
+	verificadorResultado.DefinirEsperado("%PDF-1.3", nil)
+	if err = verificadorResultado.VerificaResultado(buffer.String(), err); err != nil {
+		t.Error(err)
+	}
+}
@@ -28,9 +28,12 @@ func TestFrequênciaAtirador_Post(t *testing.T) {
 		frequênciaPedido   protocolo.FrequênciaPedido
 		logger             gostklog.Logger
 		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		aceitaPDF          bool
 		serviçoAtirador    atirador.Serviço
 		códigoHTTPEsperado int
 		esperado           *protocolo.FrequênciaPendenteResposta
+		pdfEsperado        *frequênciaPDF
 		mensagensEsperadas protocolo.Mensagens
 		cabeçalhoEsperado  http.Header
 	}{
@@ -38,12 +41,14 @@ func TestFrequênciaAtirador_Post(t *testing.T) {
 			descrição: "deve cadastrar corretamente os dados de frequência do atirador",
 			cr:        123456789,
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma do Clube",
 				QuantidadeMunição: 50,
 				DataInício:        data,
 				DataTérmino:       data.Add(30 * time.Minute),
 			},
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
 			configuração: func() *restconfig.Configuração {
 				return new(restconfig.Configuração)
 			}(),
@@ -74,10 +79,98 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 				"Location": []string{"/frequencia/123456789/7654-918273645?verificacao=8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1"},
 			},
 		},
+		{
+			descrição: "deve gerar o PDF do número de controle quando solicitado",
+			cr:        123456789,
+			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
+				Calibre:           ".380",
+				ArmaUtilizada:     "Arma do Clube",
+				QuantidadeMunição: 50,
+				DataInício:        data,
+				DataTérmino:       data.Add(30 * time.Minute),
+			},
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			aceitaPDF:  true,
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCadastrarFrequência: func(frequênciaPedidoCompleta protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error) {
+					return protocolo.FrequênciaPendenteResposta{
+						NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+						CódigoVerificação: "8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1",
+					}, nil
+				},
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					if cr != 123456789 || númeroControle != protocolo.NovoNúmeroControle(7654, 918273645) || códigoVerificação != "8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1" {
+						t.Errorf("dados inesperados da frequência: %d, %s, %s", cr, númeroControle, códigoVerificação)
+					}
+
+					return []byte("%PDF-1.3"), nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusCreated,
+			esperado: &protocolo.FrequênciaPendenteResposta{
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1",
+			},
+			pdfEsperado: func() *frequênciaPDF {
+				pdf := frequênciaPDF("%PDF-1.3")
+				return &pdf
+			}(),
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/frequencia/123456789/7654-918273645?verificacao=8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1"},
+			},
+		},
+		{
+			descrição: "deve detectar um erro ao gerar o PDF do número de controle",
+			cr:        123456789,
+			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
+				Calibre:           ".380",
+				ArmaUtilizada:     "Arma do Clube",
+				QuantidadeMunição: 50,
+				DataInício:        data,
+				DataTérmino:       data.Add(30 * time.Minute),
+			},
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			aceitaPDF:  true,
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaCadastrarFrequência: func(frequênciaPedidoCompleta protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error) {
+					return protocolo.FrequênciaPendenteResposta{
+						NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+						CódigoVerificação: "8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1",
+					}, nil
+				},
+				SimulaPDFNúmeroControle: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+					return nil, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+			esperado: &protocolo.FrequênciaPendenteResposta{
+				NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+				CódigoVerificação: "8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1",
+			},
+			cabeçalhoEsperado: http.Header{
+				"Location": []string{"/frequencia/123456789/7654-918273645?verificacao=8bLCbDcRkTUroc5BshugiXyf8JcDVmBupmZsTVFp53F1"},
+			},
+		},
 		{
 			descrição: "deve detectar quando a configuração não foi inicializada",
 			cr:        123456789,
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma do Clube",
 				QuantidadeMunição: 50,
@@ -99,10 +192,32 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			},
 			códigoHTTPEsperado: http.StatusInternalServerError,
 		},
+		{
+			descrição: "deve recusar um usuário de outro clube",
+			cr:        123456789,
+			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
+				Calibre:           ".380",
+				ArmaUtilizada:     "Arma do Clube",
+				QuantidadeMunição: 50,
+				DataInício:        data,
+				DataTérmino:       data.Add(30 * time.Minute),
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 2},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
 		{
 			descrição: "deve detectar um erro na camada de serviço do atirador",
 			cr:        123456789,
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma do Clube",
 				QuantidadeMunição: 50,
@@ -116,6 +231,7 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 					}
 				},
 			},
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
 			configuração: func() *restconfig.Configuração {
 				return new(restconfig.Configuração)
 			}(),
@@ -130,13 +246,15 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			descrição: "deve detectar mensagens na camada de serviço do atirador",
 			cr:        123456789,
 			frequênciaPedido: protocolo.FrequênciaPedido{
+				Clube:             1,
 				Calibre:           ".380",
 				ArmaUtilizada:     "Arma do Clube",
 				QuantidadeMunição: 50,
 				DataInício:        data,
 				DataTérmino:       data.Add(30 * time.Minute),
 			},
-			logger: simulador.Logger{},
+			logger:     simulador.Logger{},
+			identidade: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
 			configuração: func() *restconfig.Configuração {
 				return new(restconfig.Configuração)
 			}(),
@@ -176,6 +294,8 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			FrequênciaPedido: cenário.frequênciaPedido,
 		}
 		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+		handler.DefinirAceitaPDF(cenário.aceitaPDF)
 
 		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
 
@@ -189,6 +309,11 @@ ZSBzaG9ydCB2ZWhlbWVuY2Ugb2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=`,
 			t.Error(err)
 		}
 
+		verificadorResultado.DefinirEsperado(cenário.pdfEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.FrequênciaPDF, nil); err != nil {
+			t.Error(err)
+		}
+
 		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
 		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
 			t.Error(err)
@@ -210,6 +335,7 @@ func TestFrequênciaAtirador_Interceptors(t *testing.T) {
 		"*interceptador.ParâmetrosConsulta",
 		"*interceptador.VariáveisEndereço",
 		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
 		"*interceptador.BD",
 	}
 
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/frequencia/{cr}/{numeroControle}/verificacao", func() handy.Handler { return &frequênciaAtiradorVerificação{} })
+}
+
+// frequênciaAtiradorVerificação disponibiliza a página pública acessada pelo
+// QRCode impresso no comprovante de frequência. Diferente da consulta em JSON,
+// a página é destinada a um fiscal utilizando o navegador do celular e não
+// expõe as imagens nem os dados do atirador.
+type frequênciaAtiradorVerificação struct {
+	básico
+	interceptador.BDCompatível
+
+	CR                    int                      `urivar:"cr"`
+	NúmeroControle        protocolo.NúmeroControle `urivar:"numeroControle"`
+	CódigoVerificação     string                   `query:"verificacao"`
+	VerificaçãoFrequência *verificaçãoFrequência   `response:"get"`
+}
+
+func (f *frequênciaAtiradorVerificação) Get() int {
+	if config.Atual() == nil {
+		f.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	serviçoAtirador := atirador.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
+	frequênciaResposta, err := serviçoAtirador.ObterResumoFrequência(f.CR, f.NúmeroControle, f.CódigoVerificação)
+	if err != nil {
+		// para o fiscal não importa o motivo da recusa, somente que o comprovante
+		// não é autêntico
+		_, éMensagem := err.(protocolo.Mensagens)
+		if errors.Equal(err, erros.NãoEncontrado) || éMensagem {
+			f.VerificaçãoFrequência = &verificaçãoFrequência{NúmeroControle: f.NúmeroControle}
+			return http.StatusNotFound
+		}
+
+		f.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	serviçoClube := clube.NovoServiço(f.Tx(), f.Logger(), config.Atual().Configuração)
+	clubeResposta, err := serviçoClube.ObterClube(frequênciaResposta.Clube)
+	if err != nil {
+		f.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	f.VerificaçãoFrequência = novaVerificaçãoFrequência(frequênciaResposta, clubeResposta)
+	return http.StatusOK
+}
+
+func (f *frequênciaAtiradorVerificação) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(f).
+		Chain(interceptador.NovoBD(f))
+}
+
+// verificaçãoFrequência contém somente os dados da frequência que podem ser
+// exibidos publicamente na página de verificação.
+type verificaçãoFrequência struct {
+	Autêntica        bool
+	NúmeroControle   protocolo.NúmeroControle
+	Situação         string
+	Clube            string
+	Calibre          string
+	DataInício       time.Time
+	DataTérmino      time.Time
+	DataConfirmação  time.Time
+	DataCancelamento time.Time
+}
+
+func novaVerificaçãoFrequência(frequênciaResposta protocolo.FrequênciaResposta, clubeResposta protocolo.ClubeResposta) *verificaçãoFrequência {
+	return &verificaçãoFrequência{
+		Autêntica:        true,
+		NúmeroControle:   frequênciaResposta.NúmeroControle,
+		Situação:         descriçõesSituação[frequênciaResposta.Situação],
+		Clube:            clubeResposta.Nome + " (" + clubeResposta.Cidade + "/" + clubeResposta.UF + ")",
+		Calibre:          frequênciaResposta.Calibre,
+		DataInício:       frequênciaResposta.DataInício,
+		DataTérmino:      frequênciaResposta.DataTérmino,
+		DataConfirmação:  frequênciaResposta.DataConfirmação,
+		DataCancelamento: frequênciaResposta.DataCancelamento,
+	}
+}
+
+// HTML escreve a página de verificação da frequência.
+func (v verificaçãoFrequência) HTML(w io.Writer) error {
+	return erros.Novo(modeloVerificaçãoFrequência.Execute(w, v))
+}
+
+// descriçõesSituação traduz as situações da frequência para textos que possam
+// ser compreendidos pelo fiscal.
+var descriçõesSituação = map[protocolo.FrequênciaSituação]string{
+	protocolo.FrequênciaSituaçãoPendente:    "Aguardando confirmação",
+	protocolo.FrequênciaSituaçãoConfirmada:  "Confirmada",
+	protocolo.FrequênciaSituaçãoCancelada:   "Cancelada",
+	protocolo.FrequênciaSituaçãoExpirada:    "Expirada sem confirmação",
+	protocolo.FrequênciaSituaçãoEmAuditoria: "Confirmada, em auditoria",
+	protocolo.FrequênciaSituaçãoInvalidada:  "Invalidada na auditoria",
+}
+
+var modeloVerificaçãoFrequência = template.Must(template.New("verificação").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Verificação de frequência {{.NúmeroControle}}</title>
+<style>
+body { font-family: sans-serif; margin: 0; padding: 1em; color: #222; }
+h1 { font-size: 1.3em; }
+.resultado { padding: 0.8em; border-radius: 0.3em; font-weight: bold; }
+.autentica { background: #dff0d8; color: #2b542c; }
+.invalida { background: #f2dede; color: #843534; }
+dl { margin: 1em 0; }
+dt { font-size: 0.85em; color: #666; margin-top: 0.8em; }
+dd { margin: 0; font-size: 1.1em; }
+</style>
+</head>
+<body>
+<h1>Frequência {{.NúmeroControle}}</h1>
+{{if .Autêntica -}}
+<p class="resultado autentica">Comprovante autêntico</p>
+<dl>
+<dt>Situação</dt>
+<dd>{{.Situação}}</dd>
+<dt>Clube</dt>
+<dd>{{.Clube}}</dd>
+<dt>Calibre</dt>
+<dd>{{.Calibre}}</dd>
+<dt>Treino</dt>
+<dd>{{.DataInício.Format "02/01/2006 15:04"}} - {{.DataTérmino.Format "15:04"}}</dd>
+{{- if not .DataConfirmação.IsZero}}
+<dt>Confirmação</dt>
+<dd>{{.DataConfirmação.Format "02/01/2006 15:04"}}</dd>
+{{- end}}
+{{- if not .DataCancelamento.IsZero}}
+<dt>Cancelamento</dt>
+<dd>{{.DataCancelamento.Format "02/01/2006 15:04"}}</dd>
+{{- end}}
+</dl>
+{{- else -}}
+<p class="resultado invalida">Comprovante não encontrado ou código de verificação inválido</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/clube"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestFrequênciaAtiradorVerificação_Get(t *testing.T) {
+	data := time.Date(2017, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	cenários := []struct {
+		descrição          string
+		cr                 int
+		númeroControle     protocolo.NúmeroControle
+		códigoVerificação  string
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		serviçoAtirador    atirador.Serviço
+		serviçoClube       clube.Serviço
+		códigoHTTPEsperado int
+		esperado           *verificaçãoFrequência
+	}{
+		{
+			descrição:         "deve obter corretamente a verificação da frequência do atirador",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterResumoFrequência: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+					return protocolo.FrequênciaResposta{
+						NúmeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+						CódigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+						Clube:             1,
+						Calibre:           ".380",
+						ArmaUtilizada:     "Arma do Clube",
+						NúmeroSérie:       "ZA785671",
+						QuantidadeMunição: 50,
+						DataInício:        data.Add(-40 * time.Minute),
+						DataTérmino:       data.Add(-10 * time.Minute),
+						DataCriação:       data.Add(-5 * time.Minute),
+						DataConfirmação:   data,
+						Imagem:            "TWFuIGlzIGRpc3Rpbmd1aXNoZWQ=",
+						Situação:          protocolo.FrequênciaSituaçãoConfirmada,
+					}, nil
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					if id != 1 {
+						t.Errorf("clube %d inesperado", id)
+					}
+
+					return protocolo.ClubeResposta{
+						ID:     1,
+						Nome:   "Clube de Tiro",
+						Cidade: "Rio de Janeiro",
+						UF:     "RJ",
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &verificaçãoFrequência{
+				Autêntica:       true,
+				NúmeroControle:  protocolo.NovoNúmeroControle(7654, 918273645),
+				Situação:        "Confirmada",
+				Clube:           "Clube de Tiro (Rio de Janeiro/RJ)",
+				Calibre:         ".380",
+				DataInício:      data.Add(-40 * time.Minute),
+				DataTérmino:     data.Add(-10 * time.Minute),
+				DataConfirmação: data,
+			},
+		},
+		{
+			descrição:         "deve detectar quando a configuração não foi inicializada",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:         "deve informar que o comprovante não é autêntico quando a frequência não existe",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterResumoFrequência: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+					return protocolo.FrequênciaResposta{}, erros.NãoEncontrado
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+			esperado: &verificaçãoFrequência{
+				NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			},
+		},
+		{
+			descrição:         "deve informar que o comprovante não é autêntico quando o código de verificação é inválido",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "abc",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterResumoFrequência: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+					return protocolo.FrequênciaResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoVerificaçãoInválida),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusNotFound,
+			esperado: &verificaçãoFrequência{
+				NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			},
+		},
+		{
+			descrição:         "deve detectar um erro na camada de serviço do atirador",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterResumoFrequência: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+					return protocolo.FrequênciaResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição:         "deve detectar um erro na camada de serviço do clube",
+			cr:                123456789,
+			númeroControle:    protocolo.NovoNúmeroControle(7654, 918273645),
+			códigoVerificação: "5JRYo4LFpvhr9gnALUTNJf8v3Z3TwAduwWQy1yxx1c4Q",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaObterResumoFrequência: func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+					return protocolo.FrequênciaResposta{Clube: 1}, nil
+				},
+			},
+			serviçoClube: simulador.ServiçoClube{
+				SimulaObterClube: func(id int64) (protocolo.ClubeResposta, error) {
+					return protocolo.ClubeResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	serviçoClubeOriginal := clube.NovoServiço
+	defer func() {
+		clube.NovoServiço = serviçoClubeOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		clube.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) clube.Serviço {
+			return cenário.serviçoClube
+		}
+
+		handler := frequênciaAtiradorVerificação{
+			CR:                cenário.cr,
+			NúmeroControle:    cenário.númeroControle,
+			CódigoVerificação: cenário.códigoVerificação,
+		}
+		handler.DefineLogger(cenário.logger)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.VerificaçãoFrequência, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFrequênciaAtiradorVerificação_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.BD",
+	}
+
+	var handler frequênciaAtiradorVerificação
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerificaçãoFrequência_HTML(t *testing.T) {
+	data := time.Date(2017, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	cenários := []struct {
+		descrição           string
+		verificação         verificaçãoFrequência
+		trechosEsperados    []string
+		trechosNãoEsperados []string
+	}{
+		{
+			descrição: "deve gerar a página de um comprovante autêntico",
+			verificação: verificaçãoFrequência{
+				Autêntica:        true,
+				NúmeroControle:   protocolo.NovoNúmeroControle(7654, 918273645),
+				Situação:         "Cancelada",
+				Clube:            "Clube <Tiro> (Rio de Janeiro/RJ)",
+				Calibre:          ".380",
+				DataInício:       data.Add(-40 * time.Minute),
+				DataTérmino:      data.Add(-10 * time.Minute),
+				DataCancelamento: data,
+			},
+			trechosEsperados: []string{
+				`<meta name="viewport"`,
+				"Frequência 7654-918273645",
+				"Comprovante autêntico",
+				"<dd>Cancelada</dd>",
+				"<dd>Clube &lt;Tiro&gt; (Rio de Janeiro/RJ)</dd>",
+				"<dd>.380</dd>",
+				"<dd>10/03/2017 13:50 - 14:20</dd>",
+				"<dt>Cancelamento</dt>\n<dd>10/03/2017 14:30</dd>",
+			},
+			trechosNãoEsperados: []string{
+				"<dt>Confirmação</dt>",
+				"inválido",
+			},
+		},
+		{
+			descrição: "deve gerar a página de um comprovante não autêntico",
+			verificação: verificaçãoFrequência{
+				NúmeroControle: protocolo.NovoNúmeroControle(7654, 918273645),
+			},
+			trechosEsperados: []string{
+				"Frequência 7654-918273645",
+				"Comprovante não encontrado ou código de verificação inválido",
+			},
+			trechosNãoEsperados: []string{
+				"Comprovante autêntico",
+				"<dl>",
+			},
+		},
+	}
+
+	for i, cenário := range cenários {
+		var buffer bytes.Buffer
+		if err := cenário.verificação.HTML(&buffer); err != nil {
+			t.Fatalf("Item %d, “%s”: erro inesperado ao gerar a página. Detalhes: %s", i, cenário.descrição, err)
+		}
+
+		for _, trecho := range cenário.trechosEsperados {
+			if !strings.Contains(buffer.String(), trecho) {
+				t.Errorf("Item %d, “%s”: trecho “%s” não encontrado na página", i, cenário.descrição, trecho)
+			}
+		}
+
+		for _, trecho := range cenário.trechosNãoEsperados {
+			if strings.Contains(buffer.String(), trecho) {
+				t.Errorf("Item %d, “%s”: trecho “%s” inesperado na página", i, cenário.descrição, trecho)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/usuário"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/login", func() handy.Handler { return &login{} })
+}
+
+type login struct {
+	básico
+	interceptador.BDCompatível
+
+	LoginPedido   protocolo.LoginPedido    `request:"post"`
+	LoginResposta *protocolo.LoginResposta `response:"post"`
+}
+
+func (l *login) Post() int {
+	if config.Atual() == nil {
+		l.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return http.StatusInternalServerError
+	}
+
+	serviçoUsuário := usuário.NovoServiço(l.Tx(), l.Logger(), config.Atual().Configuração)
+	loginResposta, err := serviçoUsuário.Autenticar(l.LoginPedido)
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			l.Mensagens = mensagens
+			return http.StatusUnauthorized
+		}
+
+		l.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	l.LoginResposta = &loginResposta
+	return http.StatusOK
+}
+
+func (l *login) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(l).
+		Chain(interceptador.NovoBD(l))
+}
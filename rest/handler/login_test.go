@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/usuário"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestLogin_Post(t *testing.T) {
+	expiração := time.Now().Add(8 * time.Hour)
+
+	cenários := []struct {
+		descrição          string
+		loginPedido        protocolo.LoginPedido
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		serviçoUsuário     usuário.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.LoginResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve autenticar corretamente o usuário",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "segredo",
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaAutenticar: func(loginPedido protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+					return protocolo.LoginResposta{
+						Token:     "abc.123",
+						Expiração: expiração,
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.LoginResposta{
+				Token:     "abc.123",
+				Expiração: expiração,
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "segredo",
+			},
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do usuário",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "segredo",
+			},
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaAutenticar: func(loginPedido protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+					return protocolo.LoginResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar credenciais inválidas",
+			loginPedido: protocolo.LoginPedido{
+				Usuário: "operador",
+				Senha:   "errada",
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaAutenticar: func(loginPedido protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+					return protocolo.LoginResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoCredenciaisInválidas),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoCredenciaisInválidas),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoUsuárioOriginal := usuário.NovoServiço
+	defer func() {
+		usuário.NovoServiço = serviçoUsuárioOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		usuário.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) usuário.Serviço {
+			return cenário.serviçoUsuário
+		}
+
+		handler := login{
+			LoginPedido: cenário.loginPedido,
+		}
+		handler.DefineLogger(cenário.logger)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Post(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.LoginResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLogin_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.BD",
+	}
+
+	var handler login
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/mensagens", func() handy.Handler { return &mensagensCatálogo{} })
+}
+
+type mensagensCatálogo struct {
+	básico
+	interceptador.IdiomaCompatível
+
+	CatálogoMensagensResposta *protocolo.CatálogoMensagensResposta `response:"get"`
+}
+
+func (m *mensagensCatálogo) Get() int {
+	catálogo := protocolo.NovoCatálogoMensagensResposta(m.Idioma())
+	m.CatálogoMensagensResposta = &catálogo
+	return http.StatusOK
+}
+
+func (m *mensagensCatálogo) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(m)
+}
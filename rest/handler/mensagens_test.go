@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+)
+
+func TestMensagensCatálogo_Get(t *testing.T) {
+	cenários := []struct {
+		descrição          string
+		idioma             protocolo.Idioma
+		códigoHTTPEsperado int
+		esperado           protocolo.CatálogoMensagensResposta
+	}{
+		{
+			descrição:          "deve listar o catálogo de mensagens no idioma solicitado",
+			idioma:             protocolo.IdiomaInglês,
+			códigoHTTPEsperado: http.StatusOK,
+			esperado:           protocolo.NovoCatálogoMensagensResposta(protocolo.IdiomaInglês),
+		},
+		{
+			descrição:          "deve listar o catálogo de mensagens no idioma padrão",
+			códigoHTTPEsperado: http.StatusOK,
+			esperado:           protocolo.NovoCatálogoMensagensResposta(protocolo.IdiomaPadrão),
+		},
+	}
+
+	for i, cenário := range cenários {
+		var handler mensagensCatálogo
+		if cenário.idioma != "" {
+			handler.DefinirIdioma(cenário.idioma)
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(&cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.CatálogoMensagensResposta, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
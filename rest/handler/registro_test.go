@@ -17,6 +17,24 @@ func TestRotas(t *testing.T) {
 		t.Error("Handler ping corrompido")
 	}
 
+	if h, ok := handler.Rotas["/relatorio/habitualidade"]; !ok {
+		t.Error("Handler do relatório de habitualidade não encontrado")
+	} else if h() == nil {
+		t.Error("Handler do relatório de habitualidade corrompido")
+	}
+
+	if h, ok := handler.Rotas["/relatorio/habitualidade.csv"]; !ok {
+		t.Error("Handler do relatório de habitualidade em CSV não encontrado")
+	} else if h() == nil {
+		t.Error("Handler do relatório de habitualidade em CSV corrompido")
+	}
+
+	if h, ok := handler.Rotas["/frequencia"]; !ok {
+		t.Error("Handler de listagem das frequências não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de listagem das frequências corrompido")
+	}
+
 	if h, ok := handler.Rotas["/frequencia/{cr}"]; !ok {
 		t.Error("Handler de cadastro da frequência do atirador não encontrado")
 	} else if h() == nil {
@@ -28,4 +46,112 @@ func TestRotas(t *testing.T) {
 	} else if h() == nil {
 		t.Error("Handler de confirmação da frequência do atirador corrompido")
 	}
+
+	if h, ok := handler.Rotas["/frequencia/{cr}/{numeroControle}/verificacao"]; !ok {
+		t.Error("Handler de verificação da frequência do atirador não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de verificação da frequência do atirador corrompido")
+	}
+
+	if h, ok := handler.Rotas["/frequencia/{cr}/{numeroControle}/numero-controle.pdf"]; !ok {
+		t.Error("Handler do PDF do número de controle não encontrado")
+	} else if h() == nil {
+		t.Error("Handler do PDF do número de controle corrompido")
+	}
+
+	if h, ok := handler.Rotas["/login"]; !ok {
+		t.Error("Handler de autenticação do usuário não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de autenticação do usuário corrompido")
+	}
+
+	if h, ok := handler.Rotas["/clube"]; !ok {
+		t.Error("Handler de cadastro do clube não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de cadastro do clube corrompido")
+	}
+
+	if h, ok := handler.Rotas["/clube/{id}"]; !ok {
+		t.Error("Handler de detalhe do clube não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de detalhe do clube corrompido")
+	}
+
+	if h, ok := handler.Rotas["/atirador"]; !ok {
+		t.Error("Handler de cadastro do atirador não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de cadastro do atirador corrompido")
+	}
+
+	if h, ok := handler.Rotas["/atirador/{cr}"]; !ok {
+		t.Error("Handler de detalhe do atirador não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de detalhe do atirador corrompido")
+	}
+
+	if h, ok := handler.Rotas["/atirador/{cr}/municao"]; !ok {
+		t.Error("Handler de consumo de munição do atirador não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de consumo de munição do atirador corrompido")
+	}
+
+	if h, ok := handler.Rotas["/atirador/{cr}/frequencias"]; !ok {
+		t.Error("Handler de frequências do atirador não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de frequências do atirador corrompido")
+	}
+
+	if h, ok := handler.Rotas["/importacao/atirador"]; !ok {
+		t.Error("Handler de importação dos atiradores não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de importação dos atiradores corrompido")
+	}
+
+	if h, ok := handler.Rotas["/arma"]; !ok {
+		t.Error("Handler de cadastro da arma não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de cadastro da arma corrompido")
+	}
+
+	if h, ok := handler.Rotas["/arma/{id}"]; !ok {
+		t.Error("Handler de detalhe da arma não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de detalhe da arma corrompido")
+	}
+
+	if h, ok := handler.Rotas["/calibre"]; !ok {
+		t.Error("Handler de listagem dos calibres não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de listagem dos calibres corrompido")
+	}
+
+	if h, ok := handler.Rotas["/auditoria/amostra"]; !ok {
+		t.Error("Handler de sorteio da amostra de auditoria não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de sorteio da amostra de auditoria corrompido")
+	}
+
+	if h, ok := handler.Rotas["/auditoria/amostra/{id}"]; !ok {
+		t.Error("Handler de detalhe da amostra de auditoria não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de detalhe da amostra de auditoria corrompido")
+	}
+
+	if h, ok := handler.Rotas["/auditoria/analise/{id}"]; !ok {
+		t.Error("Handler de análise da auditoria não encontrado")
+	} else if h() == nil {
+		t.Error("Handler de análise da auditoria corrompido")
+	}
+
+	if h, ok := handler.Rotas["/comprovante/chave"]; !ok {
+		t.Error("Handler da chave pública dos comprovantes não encontrado")
+	} else if h() == nil {
+		t.Error("Handler da chave pública dos comprovantes corrompido")
+	}
+
+	if h, ok := handler.Rotas["/mensagens"]; !ok {
+		t.Error("Handler do catálogo de mensagens não encontrado")
+	} else if h() == nil {
+		t.Error("Handler do catálogo de mensagens corrompido")
+	}
 }
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/trajber/handy"
+)
+
+func init() {
+	registrar("/relatorio/habitualidade", func() handy.Handler { return &relatórioHabitualidade{} })
+	registrar("/relatorio/habitualidade.csv", func() handy.Handler { return &relatórioHabitualidadeCSV{} })
+}
+
+// relatórioHabitualidadeComum armazena os parâmetros e o tratamento
+// compartilhados entre os formatos disponíveis do relatório de habitualidade.
+type relatórioHabitualidadeComum struct {
+	básico
+	interceptador.AutenticaçãoCompatível
+	interceptador.BDCompatível
+
+	DataInício  time.Time `query:"dataInicio"`
+	DataTérmino time.Time `query:"dataTermino"`
+	Nível       int       `query:"nivel"`
+}
+
+func (r *relatórioHabitualidadeComum) gerar() (protocolo.HabitualidadeResposta, int) {
+	if config.Atual() == nil {
+		r.Logger().Crit("Não existe configuração definida para atender a requisição")
+		return protocolo.HabitualidadeResposta{}, http.StatusInternalServerError
+	}
+
+	if !r.Identidade().Administrador() {
+		r.Mensagens = protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+		)
+		return protocolo.HabitualidadeResposta{}, http.StatusForbidden
+	}
+
+	serviçoAtirador := atirador.NovoServiço(r.Tx(), r.Logger(), config.Atual().Configuração)
+	habitualidadeResposta, err := serviçoAtirador.RelatórioHabitualidade(protocolo.HabitualidadeFiltro{
+		DataInício:  r.DataInício,
+		DataTérmino: r.DataTérmino,
+		Nível:       r.Nível,
+	})
+
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			r.Mensagens = mensagens
+			return protocolo.HabitualidadeResposta{}, http.StatusBadRequest
+		}
+
+		r.Logger().Error(erros.Novo(err))
+		return protocolo.HabitualidadeResposta{}, http.StatusInternalServerError
+	}
+
+	return habitualidadeResposta, http.StatusOK
+}
+
+type relatórioHabitualidade struct {
+	relatórioHabitualidadeComum
+
+	HabitualidadeResposta *protocolo.HabitualidadeResposta `response:"get"`
+}
+
+func (r *relatórioHabitualidade) Get() int {
+	habitualidadeResposta, códigoHTTP := r.gerar()
+	if códigoHTTP == http.StatusOK {
+		r.HabitualidadeResposta = &habitualidadeResposta
+	}
+
+	return códigoHTTP
+}
+
+func (r *relatórioHabitualidade) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(r).
+		Chain(interceptador.NovaAutenticação(r)).
+		Chain(interceptador.NovoBD(r))
+}
+
+type relatórioHabitualidadeCSV struct {
+	relatórioHabitualidadeComum
+
+	HabitualidadeCSV *habitualidadeCSV `response:"get"`
+}
+
+func (r *relatórioHabitualidadeCSV) Get() int {
+	habitualidadeResposta, códigoHTTP := r.gerar()
+	if códigoHTTP == http.StatusOK {
+		r.HabitualidadeCSV = (*habitualidadeCSV)(&habitualidadeResposta)
+		r.DefinirCabeçalho("Content-Disposition", `attachment; filename="habitualidade.csv"`)
+	}
+
+	return códigoHTTP
+}
+
+func (r *relatórioHabitualidadeCSV) Interceptors() handy.InterceptorChain {
+	return criarCorrenteBásica(r).
+		Chain(interceptador.NovaAutenticação(r)).
+		Chain(interceptador.NovoBD(r))
+}
+
+// habitualidadeCSV representa o relatório de habitualidade no formato CSV,
+// com uma linha para cada atirador que não atingiu a quantidade mínima de
+// treinos.
+type habitualidadeCSV protocolo.HabitualidadeResposta
+
+// CSV escreve o relatório no formato CSV, incluindo uma linha de cabeçalho com
+// o nome das colunas.
+func (h habitualidadeCSV) CSV(w io.Writer) error {
+	escritor := csv.NewWriter(w)
+	escritor.Write([]string{"cr", "treinos", "treinosExigidos"})
+
+	for _, habitualidadeAtirador := range h.Atiradores {
+		escritor.Write([]string{
+			strconv.Itoa(habitualidadeAtirador.CR),
+			strconv.Itoa(habitualidadeAtirador.Treinos),
+			strconv.Itoa(h.TreinosExigidos),
+		})
+	}
+
+	escritor.Flush()
+	return erros.Novo(escritor.Error())
+}
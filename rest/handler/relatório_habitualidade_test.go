@@ -0,0 +1,362 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	restconfig "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	gostklog "github.com/registrobr/gostk/log"
+)
+
+func TestRelatórioHabitualidade_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		handler            relatórioHabitualidade
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		esperado           *protocolo.HabitualidadeResposta
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve gerar corretamente o relatório de habitualidade",
+			handler: relatórioHabitualidade{
+				relatórioHabitualidadeComum: relatórioHabitualidadeComum{
+					DataInício:  data.AddDate(-1, 0, 0),
+					DataTérmino: data,
+					Nível:       2,
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRelatórioHabitualidade: func(habitualidadeFiltro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+					esperado := protocolo.HabitualidadeFiltro{
+						DataInício:  data.AddDate(-1, 0, 0),
+						DataTérmino: data,
+						Nível:       2,
+					}
+
+					if habitualidadeFiltro != esperado {
+						t.Errorf("filtro inesperado: %#v", habitualidadeFiltro)
+					}
+
+					return protocolo.HabitualidadeResposta{
+						DataInício:      data.AddDate(-1, 0, 0),
+						DataTérmino:     data,
+						Nível:           2,
+						TreinosExigidos: 12,
+						Atiradores: []protocolo.HabitualidadeAtirador{
+							{CR: 380308, Treinos: 11},
+						},
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			esperado: &protocolo.HabitualidadeResposta{
+				DataInício:      data.AddDate(-1, 0, 0),
+				DataTérmino:     data,
+				Nível:           2,
+				TreinosExigidos: 12,
+				Atiradores: []protocolo.HabitualidadeAtirador{
+					{CR: 380308, Treinos: 11},
+				},
+			},
+		},
+		{
+			descrição: "deve detectar quando a configuração não foi inicializada",
+			logger: simulador.Logger{
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para atender a requisição" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+		{
+			descrição: "deve detectar um erro na camada de serviço do atirador",
+			logger: simulador.Logger{
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRelatórioHabitualidade: func(habitualidadeFiltro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+					return protocolo.HabitualidadeResposta{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve detectar mensagens na camada de serviço do atirador",
+			handler: relatórioHabitualidade{
+				relatórioHabitualidadeComum: relatórioHabitualidadeComum{
+					Nível: 7,
+				},
+			},
+			logger: simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRelatórioHabitualidade: func(habitualidadeFiltro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+					return protocolo.HabitualidadeResposta{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNívelInválido, "7"),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagemComValor(protocolo.MensagemCódigoNívelInválido, "7"),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := cenário.handler
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.HabitualidadeResposta, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRelatórioHabitualidade_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler relatórioHabitualidade
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRelatórioHabitualidadeCSV_Get(t *testing.T) {
+	data := time.Now()
+
+	cenários := []struct {
+		descrição          string
+		handler            relatórioHabitualidadeCSV
+		logger             gostklog.Logger
+		configuração       *restconfig.Configuração
+		identidade         protocolo.Identidade
+		serviçoAtirador    atirador.Serviço
+		códigoHTTPEsperado int
+		cabeçalhoEsperado  http.Header
+		esperado           *habitualidadeCSV
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição: "deve gerar corretamente o relatório de habitualidade em CSV",
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade: protocolo.Identidade{IDUsuário: 1, Papel: protocolo.PapelAdministrador},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaRelatórioHabitualidade: func(habitualidadeFiltro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+					return protocolo.HabitualidadeResposta{
+						DataInício:      data.AddDate(-1, 0, 0),
+						DataTérmino:     data,
+						Nível:           1,
+						TreinosExigidos: 8,
+						Atiradores: []protocolo.HabitualidadeAtirador{
+							{CR: 380308, Treinos: 3},
+						},
+					}, nil
+				},
+			},
+			códigoHTTPEsperado: http.StatusOK,
+			cabeçalhoEsperado: http.Header{
+				"Content-Disposition": []string{`attachment; filename="habitualidade.csv"`},
+			},
+			esperado: &habitualidadeCSV{
+				DataInício:      data.AddDate(-1, 0, 0),
+				DataTérmino:     data,
+				Nível:           1,
+				TreinosExigidos: 8,
+				Atiradores: []protocolo.HabitualidadeAtirador{
+					{CR: 380308, Treinos: 3},
+				},
+			},
+		},
+		{
+			descrição: "deve recusar um usuário que não é administrador",
+			logger:    simulador.Logger{},
+			configuração: func() *restconfig.Configuração {
+				return new(restconfig.Configuração)
+			}(),
+			identidade:         protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelClube, IDClube: 1},
+			códigoHTTPEsperado: http.StatusForbidden,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAcessoNegado),
+			),
+		},
+	}
+
+	configuraçãoOriginal := restconfig.Atual()
+	defer func() {
+		restconfig.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = serviçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		restconfig.AtualizarConfiguração(cenário.configuração)
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		handler := cenário.handler
+		handler.DefineLogger(cenário.logger)
+		handler.DefineIdentidade(cenário.identidade)
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Get(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.HabitualidadeCSV, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRelatórioHabitualidadeCSV_Interceptors(t *testing.T) {
+	esperado := []string{
+		"*interceptador.EndereçoRemoto",
+		"*interceptador.Log",
+		"*interceptor.Introspector",
+		"*interceptador.Codificador",
+		"*interceptador.ParâmetrosConsulta",
+		"*interceptador.VariáveisEndereço",
+		"*interceptador.Padronizador",
+		"*interceptador.Autenticação",
+		"*interceptador.BD",
+	}
+
+	var handler relatórioHabitualidadeCSV
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve conter os interceptadores corretos", 0)
+	verificadorResultado.DefinirEsperado(esperado, nil)
+	if err := verificadorResultado.VerificaResultado(testes.TiposDaLista(handler.Interceptors()), nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHabitualidadeCSV_CSV(t *testing.T) {
+	h := habitualidadeCSV{
+		TreinosExigidos: 8,
+		Atiradores: []protocolo.HabitualidadeAtirador{
+			{CR: 380308, Treinos: 3},
+			{CR: 380309, Treinos: 0},
+		},
+	}
+
+	var buffer bytes.Buffer
+	err := h.CSV(&buffer)
+
+	verificadorResultado := testes.NovoVerificadorResultados("deve gerar corretamente o CSV", 0)
+	verificadorResultado.DefinirEsperado("cr,treinos,treinosExigidos\n380308,3,8\n380309,0,8\n", nil)
+	if err = verificadorResultado.VerificaResultado(buffer.String(), err); err != nil {
+		t.Error(err)
+	}
+}
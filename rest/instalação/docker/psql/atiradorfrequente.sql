@@ -1,4 +1,4 @@
-CREATE TYPE LogAcao AS ENUM ('CRIACAO', 'ATUALIZACAO');
+CREATE TYPE LogAcao AS ENUM ('CRIACAO', 'ATUALIZACAO', 'REMOCAO', 'CANCELAMENTO', 'EXPIRACAO');
 
 CREATE TABLE log (
   id SERIAL PRIMARY KEY,
@@ -6,13 +6,172 @@ CREATE TABLE log (
   endereco_remoto INET
 );
 
+CREATE TABLE clube (
+  id SERIAL PRIMARY KEY,
+  cnpj VARCHAR NOT NULL UNIQUE CONSTRAINT cnpj_mandatorio CHECK (cnpj != ''),
+  cr INT NOT NULL CONSTRAINT cr_mandatorio CHECK (cr > 0),
+  nome VARCHAR NOT NULL CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  endereco VARCHAR NOT NULL CONSTRAINT endereco_mandatorio CHECK (endereco != ''),
+  cidade VARCHAR NOT NULL CONSTRAINT cidade_mandatorio CHECK (cidade != ''),
+  uf CHAR(2) NOT NULL,
+  regiao_militar INT NOT NULL CONSTRAINT regiao_militar_mandatorio CHECK (regiao_militar > 0),
+  situacao VARCHAR NOT NULL CONSTRAINT situacao_valida CHECK (situacao IN ('ativo', 'inativo')),
+  latitude DOUBLE PRECISION CONSTRAINT latitude_valida CHECK (latitude BETWEEN -90 AND 90),
+  longitude DOUBLE PRECISION CONSTRAINT longitude_valida CHECK (longitude BETWEEN -180 AND 180),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0,
+  CONSTRAINT localizacao_completa CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE TABLE clube_log (
+  id SERIAL PRIMARY KEY,
+  id_log INT REFERENCES log(id),
+  acao LogAcao,
+  id_clube INT NOT NULL CONSTRAINT id_clube_mandatorio CHECK (id_clube > 0),
+  cnpj VARCHAR NOT NULL CONSTRAINT cnpj_mandatorio CHECK (cnpj != ''),
+  cr INT NOT NULL CONSTRAINT cr_mandatorio CHECK (cr > 0),
+  nome VARCHAR NOT NULL CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  endereco VARCHAR NOT NULL CONSTRAINT endereco_mandatorio CHECK (endereco != ''),
+  cidade VARCHAR NOT NULL CONSTRAINT cidade_mandatorio CHECK (cidade != ''),
+  uf CHAR(2) NOT NULL,
+  regiao_militar INT NOT NULL CONSTRAINT regiao_militar_mandatorio CHECK (regiao_militar > 0),
+  situacao VARCHAR NOT NULL CONSTRAINT situacao_valida CHECK (situacao IN ('ativo', 'inativo')),
+  latitude DOUBLE PRECISION,
+  longitude DOUBLE PRECISION,
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE usuario (
+  id SERIAL PRIMARY KEY,
+  usuario VARCHAR NOT NULL UNIQUE CONSTRAINT usuario_mandatorio CHECK (usuario != ''),
+  nome VARCHAR NOT NULL CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  senha VARCHAR NOT NULL CONSTRAINT senha_mandatorio CHECK (senha != ''),
+  papel VARCHAR NOT NULL CONSTRAINT papel_valido CHECK (papel IN ('clube', 'administrador', 'auditor', 'atirador')),
+  id_clube INT REFERENCES clube(id),
+  cr INT CONSTRAINT cr_valido CHECK (cr > 0),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0,
+  CONSTRAINT clube_do_operador CHECK (papel != 'clube' OR id_clube IS NOT NULL),
+  CONSTRAINT cr_do_atirador CHECK (papel != 'atirador' OR cr IS NOT NULL)
+);
+
+CREATE TABLE atirador (
+  id SERIAL PRIMARY KEY,
+  cr INT NOT NULL UNIQUE CONSTRAINT cr_mandatorio CHECK (cr > 0),
+  nome VARCHAR NOT NULL CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  cpf VARCHAR NOT NULL CONSTRAINT cpf_mandatorio CHECK (cpf != ''),
+  data_emissao TIMESTAMP NOT NULL,
+  data_validade TIMESTAMP NOT NULL CONSTRAINT data_validade_valida CHECK (data_validade > data_emissao),
+  situacao VARCHAR NOT NULL CONSTRAINT situacao_valida CHECK (situacao IN ('ativo', 'suspenso', 'cancelado')),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE atirador_log (
+  id SERIAL PRIMARY KEY,
+  id_log INT REFERENCES log(id),
+  acao LogAcao,
+  id_atirador INT NOT NULL CONSTRAINT id_atirador_mandatorio CHECK (id_atirador > 0),
+  cr INT NOT NULL CONSTRAINT cr_mandatorio CHECK (cr > 0),
+  nome VARCHAR NOT NULL CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  cpf VARCHAR NOT NULL CONSTRAINT cpf_mandatorio CHECK (cpf != ''),
+  data_emissao TIMESTAMP NOT NULL,
+  data_validade TIMESTAMP NOT NULL,
+  situacao VARCHAR NOT NULL CONSTRAINT situacao_valida CHECK (situacao IN ('ativo', 'suspenso', 'cancelado')),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE calibre (
+  id SERIAL PRIMARY KEY,
+  nome VARCHAR NOT NULL UNIQUE CONSTRAINT nome_mandatorio CHECK (nome != ''),
+  classe VARCHAR NOT NULL CONSTRAINT classe_valida CHECK (classe IN ('permitido', 'restrito'))
+);
+
+CREATE TABLE calibre_apelido (
+  id SERIAL PRIMARY KEY,
+  id_calibre INT NOT NULL REFERENCES calibre(id),
+  apelido VARCHAR NOT NULL UNIQUE CONSTRAINT apelido_mandatorio CHECK (apelido != '')
+);
+
+--
+-- Catálogo de calibres. Os nomes e apelidos são armazenados em caixa alta, da
+-- mesma forma que os calibres informados após a normalização.
+--
+
+INSERT INTO calibre (id, nome, classe) VALUES
+(1, '.22 LR', 'permitido'),
+(2, '.380 ACP', 'permitido'),
+(3, '.38 SPL', 'permitido'),
+(4, '9MM LUGER', 'permitido'),
+(5, '.40 S&W', 'permitido'),
+(6, '.45 ACP', 'permitido'),
+(7, '.357 MAGNUM', 'permitido'),
+(8, '12 GA', 'permitido'),
+(9, '.44 MAGNUM', 'restrito'),
+(10, '5,56X45MM', 'restrito'),
+(11, '7,62X51MM', 'restrito');
+
+SELECT setval('calibre_id_seq', (SELECT MAX(id) FROM calibre));
+
+INSERT INTO calibre_apelido (id_calibre, apelido) VALUES
+(1, '.22'), (1, '22LR'), (1, '.22LR'), (1, '22 LR'),
+(2, '.380'), (2, '380'), (2, '380ACP'), (2, '.380ACP'), (2, '380 ACP'), (2, '.380 AUTO'), (2, '9MM CURTO'),
+(3, '.38'), (3, '38'), (3, '.38SPL'), (3, '38SPL'), (3, '.38 SPECIAL'),
+(4, '9MM'), (4, '9X19'), (4, '9X19MM'), (4, '9MM PARABELLUM'),
+(5, '.40'), (5, '40'), (5, '40SW'), (5, '.40SW'), (5, '40 S&W'), (5, '.40 SW'),
+(6, '.45'), (6, '45'), (6, '45ACP'), (6, '.45ACP'), (6, '45 ACP'),
+(7, '.357'), (7, '357'), (7, '.357 MAG'), (7, '357MAG'),
+(8, '12'), (8, 'CALIBRE 12'), (8, '12GA'),
+(9, '.44'), (9, '.44 MAG'), (9, '44MAG'),
+(10, '5.56'), (10, '5,56'), (10, '5.56X45'), (10, '5,56X45'), (10, '5.56X45MM'),
+(11, '7.62'), (11, '7,62'), (11, '7.62X51'), (11, '7,62X51'), (11, '7.62X51MM');
+
+CREATE TABLE arma (
+  id SERIAL PRIMARY KEY,
+  numero_serie VARCHAR NOT NULL UNIQUE CONSTRAINT numero_serie_mandatorio CHECK (numero_serie != ''),
+  modelo VARCHAR NOT NULL CONSTRAINT modelo_mandatorio CHECK (modelo != ''),
+  calibre VARCHAR NOT NULL CONSTRAINT calibre_mandatorio CHECK (calibre != ''),
+  cr INT CONSTRAINT cr_valido CHECK (cr > 0),
+  id_clube INT REFERENCES clube(id),
+  tipo_registro VARCHAR NOT NULL CONSTRAINT tipo_registro_valido CHECK (tipo_registro IN ('sigma', 'sinarm')),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0,
+  CONSTRAINT proprietario_unico CHECK ((cr IS NULL) != (id_clube IS NULL))
+);
+
+CREATE TABLE arma_log (
+  id SERIAL PRIMARY KEY,
+  id_log INT REFERENCES log(id),
+  acao LogAcao,
+  id_arma INT NOT NULL CONSTRAINT id_arma_mandatorio CHECK (id_arma > 0),
+  numero_serie VARCHAR NOT NULL CONSTRAINT numero_serie_mandatorio CHECK (numero_serie != ''),
+  modelo VARCHAR NOT NULL CONSTRAINT modelo_mandatorio CHECK (modelo != ''),
+  calibre VARCHAR NOT NULL CONSTRAINT calibre_mandatorio CHECK (calibre != ''),
+  cr INT,
+  id_clube INT,
+  tipo_registro VARCHAR NOT NULL CONSTRAINT tipo_registro_valido CHECK (tipo_registro IN ('sigma', 'sinarm')),
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  data_atualizacao TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0
+);
+
 CREATE TABLE frequencia_atirador (
   id SERIAL PRIMARY KEY,
   controle VARCHAR NOT NULL CONSTRAINT controle_mandatorio CHECK (controle != ''),
+  id_clube INT NOT NULL REFERENCES clube(id),
   cr INT NOT NULL CONSTRAINT cr_mandatorio CHECK (cr > 0),
   calibre VARCHAR NOT NULL CONSTRAINT calibre_mandatorio CHECK (calibre != ''),
   arma_utilizada VARCHAR NOT NULL CONSTRAINT arma_utilizada_mandatorio CHECK (arma_utilizada != ''),
   numero_serie VARCHAR NOT NULL DEFAULT '',
+  id_arma INT REFERENCES arma(id),
   guia_de_trafego INT NOT NULL DEFAULT 0,
   quantidade_municao INT NOT NULL CONSTRAINT quantidade_municao_mandatorio CHECK (quantidade_municao > 0),
   data_inicio TIMESTAMP NOT NULL CONSTRAINT data_inicio_mandatorio CHECK (data_inicio > '2016-01-01'::TIMESTAMP),
@@ -20,21 +179,46 @@ CREATE TABLE frequencia_atirador (
   data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
   data_atualizacao TIMESTAMP,
   data_confirmacao TIMESTAMP,
+  referencia_imagem_numero_controle CHAR(64),
+  referencia_imagem_confirmacao CHAR(64),
+  referencia_miniatura_imagem_confirmacao CHAR(64),
+  referencia_exif_imagem_confirmacao CHAR(64),
+  -- imagens em base64 anteriores ao repositório de objetos, mantidas somente
+  -- até a execução do comando "rest.af migrar-imagens"
   imagem_numero_controle VARCHAR,
   imagem_confirmacao VARCHAR,
+  hash_imagem_confirmacao BIGINT,
+  data_captura_imagem TIMESTAMP,
+  modelo_camera_imagem VARCHAR NOT NULL DEFAULT '',
+  sinalizacao_imagem VARCHAR NOT NULL DEFAULT '',
+  distancia_clube DOUBLE PRECISION,
+  data_cancelamento TIMESTAMP,
+  motivo_cancelamento VARCHAR,
+  -- identificador da chave do código de verificação, vazio para a chave legada
+  chave_codigo_verificacao VARCHAR NOT NULL DEFAULT '',
+  situacao VARCHAR NOT NULL DEFAULT 'pendente' CONSTRAINT situacao_valida CHECK (situacao IN ('pendente', 'confirmada', 'expirada', 'cancelada', 'em-auditoria', 'invalidada')),
   revisao INT NOT NULL DEFAULT 0
 );
 
+-- o hash perceptual é indexado em 4 bandas de 16 bits, permitindo buscar as
+-- imagens semelhantes sem calcular a distância de Hamming de todas as linhas
+CREATE INDEX frequencia_atirador_hash_imagem_confirmacao_banda1 ON frequencia_atirador (((hash_imagem_confirmacao >> 48) & 65535));
+CREATE INDEX frequencia_atirador_hash_imagem_confirmacao_banda2 ON frequencia_atirador (((hash_imagem_confirmacao >> 32) & 65535));
+CREATE INDEX frequencia_atirador_hash_imagem_confirmacao_banda3 ON frequencia_atirador (((hash_imagem_confirmacao >> 16) & 65535));
+CREATE INDEX frequencia_atirador_hash_imagem_confirmacao_banda4 ON frequencia_atirador ((hash_imagem_confirmacao & 65535));
+
 CREATE TABLE frequencia_atirador_log (
   id SERIAL PRIMARY KEY,
   id_log INT REFERENCES log(id),
   acao LogAcao,
   id_frequencia_atirador INT NOT NULL CONSTRAINT id_frequencia_atirador_mandatorio CHECK (id_frequencia_atirador > 0),
   controle VARCHAR NOT NULL CONSTRAINT controle_mandatorio CHECK (controle != ''),
+  id_clube INT NOT NULL CONSTRAINT id_clube_mandatorio CHECK (id_clube > 0),
   cr INT NOT NULL CONSTRAINT cr_mandatorio CHECK (cr > 0),
   calibre VARCHAR NOT NULL CONSTRAINT calibre_mandatorio CHECK (calibre != ''),
   arma_utilizada VARCHAR NOT NULL CONSTRAINT arma_utilizada_mandatorio CHECK (arma_utilizada != ''),
   numero_serie VARCHAR NOT NULL DEFAULT '',
+  id_arma INT,
   guia_de_trafego INT NOT NULL DEFAULT 0,
   quantidade_municao INT NOT NULL CONSTRAINT quantidade_municao_mandatorio CHECK (quantidade_municao > 0),
   data_inicio TIMESTAMP NOT NULL CONSTRAINT data_inicio_mandatorio CHECK (data_inicio > '2016-01-01'::TIMESTAMP),
@@ -42,7 +226,59 @@ CREATE TABLE frequencia_atirador_log (
   data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
   data_atualizacao TIMESTAMP,
   data_confirmacao TIMESTAMP,
+  referencia_imagem_numero_controle CHAR(64),
+  referencia_imagem_confirmacao CHAR(64),
+  referencia_miniatura_imagem_confirmacao CHAR(64),
+  referencia_exif_imagem_confirmacao CHAR(64),
+  -- imagens em base64 anteriores ao repositório de objetos, mantidas somente
+  -- até a execução do comando "rest.af migrar-imagens"
   imagem_numero_controle VARCHAR,
   imagem_confirmacao VARCHAR,
+  hash_imagem_confirmacao BIGINT,
+  data_captura_imagem TIMESTAMP,
+  modelo_camera_imagem VARCHAR NOT NULL DEFAULT '',
+  sinalizacao_imagem VARCHAR NOT NULL DEFAULT '',
+  distancia_clube DOUBLE PRECISION,
+  data_cancelamento TIMESTAMP,
+  motivo_cancelamento VARCHAR,
+  -- identificador da chave do código de verificação, vazio para a chave legada
+  chave_codigo_verificacao VARCHAR NOT NULL DEFAULT '',
+  situacao VARCHAR NOT NULL DEFAULT 'pendente' CONSTRAINT situacao_valida CHECK (situacao IN ('pendente', 'confirmada', 'expirada', 'cancelada', 'em-auditoria', 'invalidada')),
+  revisao INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE amostra_auditoria (
+  id SERIAL PRIMARY KEY,
+  data_inicio TIMESTAMP NOT NULL CONSTRAINT data_inicio_mandatorio CHECK (data_inicio > '2016-01-01'::TIMESTAMP),
+  data_termino TIMESTAMP NOT NULL CONSTRAINT data_termino_mandatorio CHECK (data_termino > '2016-01-01'::TIMESTAMP),
+  percentual INT NOT NULL DEFAULT 0 CONSTRAINT percentual_valido CHECK (percentual >= 0 AND percentual <= 100),
+  quantidade_por_clube INT NOT NULL DEFAULT 0 CONSTRAINT quantidade_por_clube_valida CHECK (quantidade_por_clube >= 0),
+  semente BIGINT NOT NULL,
+  data_criacao TIMESTAMP NOT NULL CONSTRAINT data_criacao_mandatorio CHECK (data_criacao > '2016-01-01'::TIMESTAMP),
+  CONSTRAINT criterio_unico CHECK ((percentual > 0) != (quantidade_por_clube > 0))
+);
+
+CREATE TABLE auditoria (
+  id SERIAL PRIMARY KEY,
+  id_amostra_auditoria INT NOT NULL REFERENCES amostra_auditoria(id),
+  id_frequencia_atirador INT NOT NULL UNIQUE REFERENCES frequencia_atirador(id),
+  veredito VARCHAR CONSTRAINT veredito_valido CHECK (veredito IN ('aprovada', 'suspeita', 'fraudulenta')),
+  observacoes VARCHAR NOT NULL DEFAULT '',
+  id_usuario INT REFERENCES usuario(id),
+  data_veredito TIMESTAMP,
+  revisao INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE auditoria_log (
+  id SERIAL PRIMARY KEY,
+  id_log INT REFERENCES log(id),
+  acao LogAcao,
+  id_auditoria INT NOT NULL CONSTRAINT id_auditoria_mandatorio CHECK (id_auditoria > 0),
+  id_amostra_auditoria INT NOT NULL CONSTRAINT id_amostra_auditoria_mandatorio CHECK (id_amostra_auditoria > 0),
+  id_frequencia_atirador INT NOT NULL CONSTRAINT id_frequencia_atirador_mandatorio CHECK (id_frequencia_atirador > 0),
+  veredito VARCHAR CONSTRAINT veredito_valido CHECK (veredito IN ('aprovada', 'suspeita', 'fraudulenta')),
+  observacoes VARCHAR NOT NULL DEFAULT '',
+  id_usuario INT,
+  data_veredito TIMESTAMP,
   revisao INT NOT NULL DEFAULT 0
 );
\ No newline at end of file
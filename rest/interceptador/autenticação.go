@@ -0,0 +1,135 @@
+package interceptador
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/usuário"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/registrobr/gostk/log"
+	"github.com/trajber/handy/interceptor"
+)
+
+// esquemaAutenticação define o esquema esperado no cabeçalho HTTP
+// Authorization para o envio do token de acesso.
+const esquemaAutenticação = "Bearer"
+
+type autenticador interface {
+	Logger() log.Logger
+	Req() *http.Request
+	DefinirCabeçalho(chave, valor string)
+	DefineMensagens(protocolo.Mensagens)
+	DefineIdentidade(protocolo.Identidade)
+}
+
+// Autenticação garante que somente usuários autenticados acessem o handler,
+// disponibilizando a identidade do usuário a partir do token de acesso.
+type Autenticação struct {
+	interceptor.NopInterceptor
+	handler autenticador
+
+	// métodos restringe a autenticação aos métodos HTTP informados. Quando
+	// vazio, todas as requisições exigem autenticação.
+	métodos []string
+}
+
+// NovaAutenticação cria um novo interceptador Autenticação.
+func NovaAutenticação(a autenticador) *Autenticação {
+	return &Autenticação{handler: a}
+}
+
+// NovaAutenticaçãoMétodos cria um novo interceptador Autenticação que exige o
+// token de acesso somente nos métodos HTTP informados, permitindo que outros
+// métodos do mesmo handler continuem públicos.
+func NovaAutenticaçãoMétodos(a autenticador, métodos ...string) *Autenticação {
+	return &Autenticação{handler: a, métodos: métodos}
+}
+
+// Before extrai o token de acesso do cabeçalho HTTP Authorization e verifica a
+// sua autenticidade. Requisições sem token ou com token inválido são recusadas
+// com o código HTTP 401 (Unauthorized).
+func (a Autenticação) Before() int {
+	a.handler.Logger().Debug("Interceptador Antes: Autenticação")
+
+	if !a.protegido() {
+		return 0
+	}
+
+	if config.Atual() == nil {
+		a.handler.Logger().Crit("Não existe configuração definida para validar a autenticação")
+		return http.StatusInternalServerError
+	}
+
+	token := a.extrairToken()
+	if token == "" {
+		return a.recusar(protocolo.NovasMensagens(
+			protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+		))
+	}
+
+	serviçoUsuário := usuário.NovoServiço(nil, a.handler.Logger(), config.Atual().Configuração)
+	identidade, err := serviçoUsuário.ValidarToken(token)
+	if err != nil {
+		if mensagens, ok := err.(protocolo.Mensagens); ok {
+			return a.recusar(mensagens)
+		}
+
+		a.handler.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	a.handler.DefineIdentidade(identidade)
+	return 0
+}
+
+func (a Autenticação) protegido() bool {
+	if len(a.métodos) == 0 {
+		return true
+	}
+
+	for _, método := range a.métodos {
+		if strings.EqualFold(método, a.handler.Req().Method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a Autenticação) extrairToken() string {
+	autorização := strings.TrimSpace(a.handler.Req().Header.Get("Authorization"))
+
+	// o esquema deve ser separado do token por um espaço, evitando que um
+	// cabeçalho como "BearerXYZ" seja aceito
+	separador := strings.IndexByte(autorização, ' ')
+	if separador < 0 || !strings.EqualFold(autorização[:separador], esquemaAutenticação) {
+		return ""
+	}
+
+	return strings.TrimSpace(autorização[separador+1:])
+}
+
+func (a Autenticação) recusar(mensagens protocolo.Mensagens) int {
+	a.handler.DefinirCabeçalho("WWW-Authenticate", esquemaAutenticação)
+	a.handler.DefineMensagens(mensagens)
+	return http.StatusUnauthorized
+}
+
+// AutenticaçãoCompatível implementa os métodos que serão utilizados pelo
+// handler para acessar a identidade do usuário autenticado por este
+// interceptador.
+type AutenticaçãoCompatível struct {
+	identidade protocolo.Identidade
+}
+
+// DefineIdentidade define a identidade do usuário autenticado.
+func (a *AutenticaçãoCompatível) DefineIdentidade(identidade protocolo.Identidade) {
+	a.identidade = identidade
+}
+
+// Identidade obtém a identidade do usuário autenticado.
+func (a AutenticaçãoCompatível) Identidade() protocolo.Identidade {
+	return a.identidade
+}
@@ -0,0 +1,313 @@
+package interceptador_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	núcleolog "github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/usuário"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+	"github.com/registrobr/gostk/log"
+)
+
+func TestAutenticação_Before(t *testing.T) {
+	expiração := time.Now().Add(time.Hour)
+
+	cenários := []struct {
+		descrição          string
+		método             string
+		métodos            []string
+		autorização        string
+		configuração       *config.Configuração
+		logger             log.Logger
+		serviçoUsuário     usuário.Serviço
+		códigoHTTPEsperado int
+		identidadeEsperada protocolo.Identidade
+		mensagensEsperadas protocolo.Mensagens
+		cabeçalhoEsperado  http.Header
+	}{
+		{
+			descrição:    "deve autenticar corretamente o usuário",
+			autorização:  "Bearer abc.123",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaValidarToken: func(token string) (protocolo.Identidade, error) {
+					if token != "abc.123" {
+						t.Errorf("token inesperado: %s", token)
+					}
+
+					return protocolo.Identidade{
+						IDUsuário: 1,
+						Usuário:   "operador",
+						Papel:     protocolo.PapelClube,
+						IDClube:   7,
+						Expiração: expiração,
+					}, nil
+				},
+			},
+			identidadeEsperada: protocolo.Identidade{
+				IDUsuário: 1,
+				Usuário:   "operador",
+				Papel:     protocolo.PapelClube,
+				IDClube:   7,
+				Expiração: expiração,
+			},
+		},
+		{
+			descrição:    "deve aceitar o esquema de autenticação sem diferenciar maiúsculas",
+			autorização:  "bearer   abc.123  ",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaValidarToken: func(token string) (protocolo.Identidade, error) {
+					if token != "abc.123" {
+						t.Errorf("token inesperado: %s", token)
+					}
+
+					return protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelAdministrador}, nil
+				},
+			},
+			identidadeEsperada: protocolo.Identidade{IDUsuário: 2, Papel: protocolo.PapelAdministrador},
+		},
+		{
+			descrição:    "deve recusar uma requisição sem token",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+			),
+			cabeçalhoEsperado: http.Header{
+				"Www-Authenticate": []string{"Bearer"},
+			},
+		},
+		{
+			descrição:    "deve recusar uma requisição com outro esquema de autenticação",
+			autorização:  "Basic dXN1YXJpbzpzZW5oYQ==",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+			),
+			cabeçalhoEsperado: http.Header{
+				"Www-Authenticate": []string{"Bearer"},
+			},
+		},
+		{
+			descrição:    "deve recusar um token sem o espaço após o esquema de autenticação",
+			autorização:  "BearerXYZ",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+			),
+			cabeçalhoEsperado: http.Header{
+				"Www-Authenticate": []string{"Bearer"},
+			},
+		},
+		{
+			descrição:    "deve recusar um token inválido",
+			autorização:  "Bearer abc.123",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaValidarToken: func(token string) (protocolo.Identidade, error) {
+					return protocolo.Identidade{}, protocolo.NovasMensagens(
+						protocolo.NovaMensagem(protocolo.MensagemCódigoTokenExpirado),
+					)
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoTokenExpirado),
+			),
+			cabeçalhoEsperado: http.Header{
+				"Www-Authenticate": []string{"Bearer"},
+			},
+		},
+		{
+			descrição:    "deve detectar um erro ao validar o token",
+			autorização:  "Bearer abc.123",
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaError: func(e error) {
+					if !strings.HasSuffix(e.Error(), "erro de baixo nível") {
+						t.Error("não está adicionando o erro correto ao log")
+					}
+				},
+			},
+			serviçoUsuário: simulador.ServiçoUsuário{
+				SimulaValidarToken: func(token string) (protocolo.Identidade, error) {
+					return protocolo.Identidade{}, errors.Errorf("erro de baixo nível")
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+		{
+			descrição: "deve ignorar os métodos que não exigem autenticação",
+			método:    "GET",
+			métodos:   []string{"DELETE"},
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+		},
+		{
+			descrição:    "deve recusar uma requisição sem token em um método que exige autenticação",
+			método:       "DELETE",
+			métodos:      []string{"DELETE"},
+			configuração: new(config.Configuração),
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					if mensagem := fmt.Sprint(m...); mensagem != "Interceptador Antes: Autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			mensagensEsperadas: protocolo.NovasMensagens(
+				protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+			),
+			cabeçalhoEsperado: http.Header{
+				"Www-Authenticate": []string{"Bearer"},
+			},
+		},
+		{
+			descrição:   "deve detectar quando a configuração não foi inicializada",
+			autorização: "Bearer abc.123",
+			logger: simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para validar a autenticação" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := config.Atual()
+	defer func() {
+		config.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	serviçoUsuárioOriginal := usuário.NovoServiço
+	defer func() {
+		usuário.NovoServiço = serviçoUsuárioOriginal
+	}()
+
+	for i, cenário := range cenários {
+		config.AtualizarConfiguração(cenário.configuração)
+
+		usuário.NovoServiço = func(s *bd.SQLogger, l núcleolog.Serviço, configuração núcleoconfig.Configuração) usuário.Serviço {
+			return cenário.serviçoUsuário
+		}
+
+		método := cenário.método
+		if método == "" {
+			método = "POST"
+		}
+
+		requisição, err := http.NewRequest(método, "/teste", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cenário.autorização != "" {
+			requisição.Header.Set("Authorization", cenário.autorização)
+		}
+
+		handler := &autenticaçãoSimulado{}
+		handler.SimulaRequisição = requisição
+		handler.DefineLogger(cenário.logger)
+
+		autenticação := interceptador.NovaAutenticação(handler)
+		if len(cenário.métodos) > 0 {
+			autenticação = interceptador.NovaAutenticaçãoMétodos(handler, cenário.métodos...)
+		}
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(autenticação.Before(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.identidadeEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Identidade(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.cabeçalhoEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Cabeçalho, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type autenticaçãoSimulado struct {
+	interceptador.AutenticaçãoCompatível
+	interceptador.CabeçalhoCompatível
+	interceptador.LogCompatível
+	interceptador.MensagensCompatível
+	simulador.Handler
+}
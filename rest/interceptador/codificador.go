@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/registrobr/gostk/log"
 	"github.com/registrobr/gostk/reflect"
 )
@@ -18,6 +24,67 @@ import (
 // o tamanho excessivo dos campos de imagem.
 var filtroCampoGrande = regexp.MustCompile(`:( )*"[^"]{100,}"`)
 
+// filtroCampoSenha é a expressão regular que identifica os campos de senha no
+// corpo da requisição em formato JSON, evitando que as senhas dos usuários
+// sejam armazenadas nos logs.
+var filtroCampoSenha = regexp.MustCompile(`"senha"( )*:( )*"(\\.|[^"\\])*"`)
+
+// codificávelCSV identifica as respostas que devem ser enviadas no formato
+// CSV, permitindo que sejam abertas diretamente em planilhas.
+type codificávelCSV interface {
+	CSV(io.Writer) error
+}
+
+// codificávelHTML identifica as respostas que devem ser enviadas como uma
+// página HTML, permitindo que sejam visualizadas diretamente em um navegador.
+type codificávelHTML interface {
+	HTML(io.Writer) error
+}
+
+// codificávelPDF identifica as respostas que devem ser enviadas como um
+// documento PDF, permitindo que sejam impressas diretamente.
+type codificávelPDF interface {
+	PDF(io.Writer) error
+}
+
+// negociávelPDF identifica os handlers capazes de responder no formato PDF
+// quando o cliente o solicita no cabeçalho Accept.
+type negociávelPDF interface {
+	DefinirAceitaPDF(bool)
+}
+
+// negociávelIdioma identifica os handlers que precisam conhecer o idioma
+// solicitado pelo cliente no cabeçalho Accept-Language.
+type negociávelIdioma interface {
+	DefinirIdioma(protocolo.Idioma)
+}
+
+// decodificávelImagem identifica as requisições que aceitam receber uma imagem
+// diretamente no corpo, em formato binário ou em um formulário multipart, sem a
+// necessidade de codificá-la em base64 dentro do JSON.
+type decodificávelImagem interface {
+	DefinirImagem([]byte)
+}
+
+// mensageiro identifica os handlers capazes de informar ao cliente os
+// problemas encontrados no corpo da requisição.
+type mensageiro interface {
+	DefineMensagens(protocolo.Mensagens)
+}
+
+// tiposConteúdoImagem formatos de imagem aceitos diretamente no corpo da
+// requisição.
+var tiposConteúdoImagem = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// campoFormulárioImagem nome do campo do formulário multipart que contém a
+// imagem.
+const campoFormulárioImagem = "imagem"
+
 type codificador interface {
 	Field(string, string) interface{}
 	Logger() log.Logger
@@ -26,7 +93,9 @@ type codificador interface {
 }
 
 // Codificador popula o objeto da requisição a partir do JSON recebido na rede,
-// também é responsável por criar o JSON a partir do objeto da resposta.
+// também é responsável por criar o JSON a partir do objeto da resposta. As
+// requisições que recebem uma imagem também podem ser enviadas como um
+// formulário multipart ou com a imagem diretamente no corpo.
 type Codificador struct {
 	handler      codificador
 	tipoConteúdo string
@@ -41,6 +110,14 @@ func NovoCodificador(c codificador, tipoConteúdo string) *Codificador {
 func (c *Codificador) Before() int {
 	c.handler.Logger().Debug("Interceptador Antes: Codificador")
 
+	if handlerPDF, ok := c.handler.(negociávelPDF); ok {
+		handlerPDF.DefinirAceitaPDF(aceitaPDF(c.handler.Req()))
+	}
+
+	if handlerIdioma, ok := c.handler.(negociávelIdioma); ok {
+		handlerIdioma.DefinirIdioma(idiomaPreferido(c.handler.Req()))
+	}
+
 	método := strings.ToLower(c.handler.Req().Method)
 	campoRequisição := c.handler.Field("request", método)
 
@@ -49,6 +126,13 @@ func (c *Codificador) Before() int {
 		return 0
 	}
 
+	if requisiçãoImagem, ok := campoRequisição.(decodificávelImagem); ok {
+		tipoConteúdo, _, _ := mime.ParseMediaType(c.handler.Req().Header.Get("Content-Type"))
+		if tipoConteúdo == "multipart/form-data" || tiposConteúdoImagem[tipoConteúdo] {
+			return c.decodificarImagem(requisiçãoImagem, tipoConteúdo)
+		}
+	}
+
 	var buffer bytes.Buffer
 	tee := io.TeeReader(c.handler.Req().Body, &buffer)
 	decodificador := json.NewDecoder(tee)
@@ -64,15 +148,94 @@ func (c *Codificador) Before() int {
 		}
 	}
 
-	// TODO(rafaeljusto): Tratar casos de login para não exibir as senha nos logs
-
 	requisiçãoCorpo := strings.TrimSpace(strings.Replace(buffer.String(), "\n", "", -1))
 	requisiçãoCorpo = filtrarCampoGrande(requisiçãoCorpo)
+	requisiçãoCorpo = filtroCampoSenha.ReplaceAllString(requisiçãoCorpo, `"senha": "****"`)
 	c.handler.Logger().Debugf("Requisição corpo: “%s”", requisiçãoCorpo)
 	return 0
 }
 
-// After gera o JSON e cabeçalhos HTTP a partir do objeto de resposta.
+// decodificarImagem lê a imagem enviada no corpo da requisição ou no campo
+// "imagem" do formulário multipart, respeitando o tamanho máximo configurado. O
+// conteúdo é lido à medida que chega, sem que o formulário seja armazenado
+// integralmente em memória ou em arquivos temporários.
+func (c *Codificador) decodificarImagem(requisição decodificávelImagem, tipoConteúdo string) int {
+	if config.Atual() == nil {
+		c.handler.Logger().Crit("Não existe configuração definida para limitar o tamanho da imagem")
+		return http.StatusInternalServerError
+	}
+
+	var leitor io.Reader = c.handler.Req().Body
+
+	if tipoConteúdo == "multipart/form-data" {
+		formulário, err := c.handler.Req().MultipartReader()
+		if err != nil {
+			return c.recusarFormulário(err)
+		}
+
+		// a ausência do campo resulta em uma imagem vazia, que será rejeitada na
+		// validação da requisição
+		leitor = bytes.NewReader(nil)
+
+		for {
+			parte, err := formulário.NextPart()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return c.recusarFormulário(err)
+			}
+
+			if parte.FormName() == campoFormulárioImagem {
+				leitor = parte
+				break
+			}
+		}
+	}
+
+	// é lido um byte além do limite para identificar as imagens que o excedem
+	tamanhoMáximo := config.Atual().Servidor.TamanhoMáximoImagem
+	imagem, err := ioutil.ReadAll(io.LimitReader(leitor, tamanhoMáximo+1))
+	if err != nil && tipoConteúdo == "multipart/form-data" {
+		// o conteúdo do campo é interpretado durante a leitura, sendo afetado
+		// por um formulário mal formado
+		return c.recusarFormulário(err)
+	} else if err != nil {
+		c.handler.Logger().Error(erros.Novo(err))
+		return http.StatusInternalServerError
+	}
+
+	if int64(len(imagem)) > tamanhoMáximo {
+		c.handler.Logger().Debugf("Requisição corpo: imagem excede o tamanho máximo de %d bytes", tamanhoMáximo)
+		return http.StatusRequestEntityTooLarge
+	}
+
+	requisição.DefinirImagem(imagem)
+	c.handler.Logger().Debugf("Requisição corpo: imagem “%s” com %d bytes", tipoConteúdo, len(imagem))
+	return 0
+}
+
+// recusarFormulário informa ao cliente que o formulário multipart enviado está
+// mal formado. Como o problema está na requisição, não é registrado como um
+// erro do servidor.
+func (c *Codificador) recusarFormulário(err error) int {
+	c.handler.Logger().Debugf("Requisição corpo: formulário multipart inválido. Detalhes: %s", err)
+
+	if handlerMensagens, ok := c.handler.(mensageiro); ok {
+		handlerMensagens.DefineMensagens(protocolo.NovasMensagens(
+			protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, campoFormulárioImagem, ""),
+		))
+	}
+
+	return http.StatusBadRequest
+}
+
+// After gera o JSON e cabeçalhos HTTP a partir do objeto de resposta. Quando o
+// objeto de resposta sabe se representar no formato CSV, HTML ou PDF, este
+// formato é utilizado no lugar do JSON. Uma resposta definida no campo com a
+// tag `response:"pdf"` substitui a resposta do método, permitindo que o
+// handler atenda no mesmo endereço os clientes que solicitam um PDF. Os textos
+// das mensagens são definidos no idioma solicitado no cabeçalho
+// Accept-Language.
 func (c *Codificador) After(códigoHTTP int) int {
 	c.handler.Logger().Debug("Interceptador Depois: Codificador")
 
@@ -92,8 +255,12 @@ func (c *Codificador) After(códigoHTTP int) int {
 	método := strings.ToLower(c.handler.Req().Method)
 
 	respostaGenérica := c.handler.Field("response", "all")
+	respostaPDF := c.handler.Field("response", "pdf")
+
 	if reflect.IsDefined(respostaGenérica) {
 		resposta = respostaGenérica
+	} else if reflect.IsDefined(respostaPDF) {
+		resposta = respostaPDF
 	} else if respostaEspecífica := c.handler.Field("response", método); respostaEspecífica != nil {
 		resposta = respostaEspecífica
 	}
@@ -103,13 +270,46 @@ func (c *Codificador) After(códigoHTTP int) int {
 		return códigoHTTP
 	}
 
-	c.handler.ResponseWriter().Header().Set("Content-Type", c.tipoConteúdo)
+	if mensagens, ok := resposta.(*protocolo.Mensagens); ok {
+		// os textos das mensagens acompanham o idioma solicitado pelo cliente
+		resposta = mensagens.Traduzir(idiomaPreferido(c.handler.Req()))
+	}
+
+	tipoConteúdo := c.tipoConteúdo
+	codificar := func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(resposta)
+	}
+
+	if respostaCSV, ok := resposta.(codificávelCSV); ok {
+		tipoConteúdo = "text/csv; charset=utf-8"
+		codificar = respostaCSV.CSV
+	} else if respostaHTML, ok := resposta.(codificávelHTML); ok {
+		tipoConteúdo = "text/html; charset=utf-8"
+		codificar = respostaHTML.HTML
+	} else if respostaPDF, ok := resposta.(codificávelPDF); ok {
+		tipoConteúdo = "application/pdf"
+		codificar = respostaPDF.PDF
+	}
+
+	c.handler.ResponseWriter().Header().Set("Content-Type", tipoConteúdo)
 	c.handler.ResponseWriter().WriteHeader(códigoHTTP)
 
 	defer func() {
+		if tipoConteúdo == "application/pdf" {
+			// o conteúdo binário do documento não é adicionado ao log
+			contador := &contadorBytes{}
+			if err := codificar(io.MultiWriter(c.handler.ResponseWriter(), contador)); err != nil {
+				c.handler.Logger().Error(erros.Novo(err))
+				return
+			}
+
+			c.handler.Logger().Debugf("Resposta corpo: documento PDF com %d bytes", contador.total)
+			return
+		}
+
 		var buffer bytes.Buffer
 		w := io.MultiWriter(c.handler.ResponseWriter(), &buffer)
-		if err := json.NewEncoder(w).Encode(resposta); err != nil {
+		if err := codificar(w); err != nil {
 			c.handler.Logger().Error(erros.Novo(err))
 			return
 		}
@@ -122,6 +322,88 @@ func (c *Codificador) After(códigoHTTP int) int {
 	return códigoHTTP
 }
 
+// aceitaPDF identifica se o cliente solicitou a resposta no formato PDF
+// através do cabeçalho Accept.
+func aceitaPDF(r *http.Request) bool {
+	for _, tipo := range strings.Split(r.Header.Get("Accept"), ",") {
+		if tipoConteúdo, _, err := mime.ParseMediaType(strings.TrimSpace(tipo)); err == nil && tipoConteúdo == "application/pdf" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// idiomaPreferido identifica, entre os idiomas suportados, o idioma de maior
+// preferência do cliente no cabeçalho Accept-Language. Um idioma regional
+// também atende a solicitação somente do idioma e vice-versa. Quando nenhum
+// idioma suportado é solicitado é utilizado o idioma padrão.
+func idiomaPreferido(r *http.Request) protocolo.Idioma {
+	type preferência struct {
+		etiqueta  string
+		qualidade float64
+	}
+
+	var preferências []preferência
+	for _, item := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		partes := strings.Split(item, ";")
+
+		etiqueta := strings.ToLower(strings.TrimSpace(partes[0]))
+		if etiqueta == "" {
+			continue
+		}
+
+		qualidade := 1.0
+		for _, parâmetro := range partes[1:] {
+			parâmetro = strings.TrimSpace(parâmetro)
+			if strings.HasPrefix(parâmetro, "q=") {
+				if valor, err := strconv.ParseFloat(strings.TrimPrefix(parâmetro, "q="), 64); err == nil {
+					qualidade = valor
+				}
+			}
+		}
+
+		if qualidade > 0 {
+			preferências = append(preferências, preferência{etiqueta: etiqueta, qualidade: qualidade})
+		}
+	}
+
+	sort.SliceStable(preferências, func(i, j int) bool {
+		return preferências[i].qualidade > preferências[j].qualidade
+	})
+
+	primárioEtiqueta := func(etiqueta string) string {
+		return strings.SplitN(etiqueta, "-", 2)[0]
+	}
+
+	for _, p := range preferências {
+		for _, idioma := range protocolo.Idiomas() {
+			if p.etiqueta == strings.ToLower(string(idioma)) {
+				return idioma
+			}
+		}
+
+		for _, idioma := range protocolo.Idiomas() {
+			if primárioEtiqueta(p.etiqueta) == primárioEtiqueta(strings.ToLower(string(idioma))) {
+				return idioma
+			}
+		}
+	}
+
+	return protocolo.IdiomaPadrão
+}
+
+// contadorBytes contabiliza a quantidade de bytes escritos, descartando o
+// conteúdo.
+type contadorBytes struct {
+	total int
+}
+
+func (c *contadorBytes) Write(p []byte) (int, error) {
+	c.total += len(p)
+	return len(p), nil
+}
+
 // filtrarCampoGrande remove o excesso de caracteres dos campos com muitos
 // caracteres para inseri-los nos logs. Estamos armazenando os primeiros e os
 // últimos 50 caracteres dos campos.
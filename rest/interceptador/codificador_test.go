@@ -1,9 +1,11 @@
 package interceptador_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -11,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/rafaeljusto/atiradorfrequente/rest/interceptador"
 	"github.com/rafaeljusto/atiradorfrequente/testes"
 	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
@@ -66,6 +70,41 @@ func TestCodificador_Before(t *testing.T) {
 				},
 			},
 		},
+		{
+			descrição: "deve omitir a senha do usuário nos logs",
+			requisição: func() *http.Request {
+				requisição, err := http.NewRequest("POST", "https://exemplo.com.br/teste", strings.NewReader(`{
+  "campo1": "valor1",
+  "senha": "abc\\\"123"
+}`))
+
+				if err != nil {
+					t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+				}
+
+				return requisição
+			}(),
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Interceptador Antes: Codificador" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != `Requisição corpo: “{  "campo1": "valor1",  "senha": "****"}”` {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			tipoConteúdo: "application/json",
+			handlerEsperado: codificadorSimulado{
+				Requisição: codificadorObjetoSimulada{
+					Campo1: "valor1",
+				},
+			},
+		},
 		{
 			descrição: "deve ignorar quando não houver uma estrutura de requisição correspondente",
 			requisição: func() *http.Request {
@@ -154,6 +193,334 @@ func TestCodificador_Before(t *testing.T) {
 	}
 }
 
+func TestCodificador_BeforeImagem(t *testing.T) {
+	configuração := new(config.Configuração)
+	configuração.Servidor.TamanhoMáximoImagem = 10
+
+	formulário := func(campo string, conteúdo []byte) (io.Reader, string) {
+		var corpo bytes.Buffer
+		escritor := multipart.NewWriter(&corpo)
+
+		if err := escritor.WriteField("observacao", "teste"); err != nil {
+			t.Fatalf("Erro ao criar o formulário. Detalhes: %s", err)
+		}
+
+		parte, err := escritor.CreateFormFile(campo, "imagem.jpg")
+		if err != nil {
+			t.Fatalf("Erro ao criar o formulário. Detalhes: %s", err)
+		}
+
+		if _, err := parte.Write(conteúdo); err != nil {
+			t.Fatalf("Erro ao criar o formulário. Detalhes: %s", err)
+		}
+
+		if err := escritor.Close(); err != nil {
+			t.Fatalf("Erro ao criar o formulário. Detalhes: %s", err)
+		}
+
+		return &corpo, escritor.FormDataContentType()
+	}
+
+	corpoFormulário, tipoFormulário := formulário("imagem", []byte("imagem"))
+	corpoSemCampo, tipoSemCampo := formulário("foto", []byte("imagem"))
+	corpoGrande, tipoGrande := formulário("imagem", []byte("imagem muito grande"))
+
+	mensagensFormulárioInválido := protocolo.NovasMensagens(
+		protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoParâmetroInválido, "imagem", ""),
+	)
+
+	cenários := []struct {
+		descrição          string
+		corpo              io.Reader
+		tipoConteúdo       string
+		configuração       *config.Configuração
+		logger             log.Logger
+		códigoHTTPEsperado int
+		imagemEsperada     []byte
+		mensagensEsperadas protocolo.Mensagens
+	}{
+		{
+			descrição:    "deve preencher a imagem enviada diretamente no corpo da requisição",
+			corpo:        strings.NewReader("imagem"),
+			tipoConteúdo: "image/jpeg",
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != "Requisição corpo: imagem “image/jpeg” com 6 bytes" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			imagemEsperada: []byte("imagem"),
+		},
+		{
+			descrição:    "deve preencher a imagem enviada em um formulário multipart",
+			corpo:        corpoFormulário,
+			tipoConteúdo: tipoFormulário,
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != "Requisição corpo: imagem “multipart/form-data” com 6 bytes" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			imagemEsperada: []byte("imagem"),
+		},
+		{
+			descrição:    "deve manter a imagem vazia quando o formulário não possuir o campo",
+			corpo:        corpoSemCampo,
+			tipoConteúdo: tipoSemCampo,
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug:  func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {},
+			},
+			imagemEsperada: []byte{},
+		},
+		{
+			descrição:    "deve recusar uma imagem maior que o tamanho máximo",
+			corpo:        strings.NewReader("imagem muito grande"),
+			tipoConteúdo: "image/png",
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != "Requisição corpo: imagem excede o tamanho máximo de 10 bytes" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusRequestEntityTooLarge,
+		},
+		{
+			descrição:    "deve recusar um formulário com a imagem maior que o tamanho máximo",
+			corpo:        corpoGrande,
+			tipoConteúdo: tipoGrande,
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug:  func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {},
+			},
+			códigoHTTPEsperado: http.StatusRequestEntityTooLarge,
+		},
+		{
+			descrição:    "deve recusar um formulário sem delimitador",
+			corpo:        strings.NewReader("imagem"),
+			tipoConteúdo: "multipart/form-data",
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != "Requisição corpo: formulário multipart inválido. Detalhes: "+http.ErrMissingBoundary.Error() {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: mensagensFormulárioInválido,
+		},
+		{
+			descrição:    "deve recusar um formulário mal formado",
+			corpo:        strings.NewReader("imagem"),
+			tipoConteúdo: "multipart/form-data; boundary=xyz",
+			configuração: configuração,
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if !strings.HasPrefix(mensagem, "Requisição corpo: formulário multipart inválido. Detalhes: ") {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusBadRequest,
+			mensagensEsperadas: mensagensFormulárioInválido,
+		},
+		{
+			descrição:    "deve detectar quando a configuração não foi inicializada",
+			corpo:        strings.NewReader("imagem"),
+			tipoConteúdo: "image/jpeg",
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {},
+				SimulaCrit: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Não existe configuração definida para limitar o tamanho da imagem" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			códigoHTTPEsperado: http.StatusInternalServerError,
+		},
+	}
+
+	configuraçãoOriginal := config.Atual()
+	defer func() {
+		config.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	for i, cenário := range cenários {
+		config.AtualizarConfiguração(cenário.configuração)
+
+		requisição, err := http.NewRequest("PUT", "https://exemplo.com.br/teste", cenário.corpo)
+		if err != nil {
+			t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+		}
+		requisição.Header.Set("Content-Type", cenário.tipoConteúdo)
+
+		var handler codificadorImagemSimulado
+		handler.SimulaRequisição = requisição
+		handler.DefineLogger(cenário.logger)
+
+		estrutura := interceptor.NewIntrospector(&handler)
+		if códigoHTTP := estrutura.Before(); códigoHTTP != 0 {
+			t.Errorf("Item %d, “%s”: código HTTP %d inesperado",
+				i, cenário.descrição, códigoHTTP)
+			continue
+		}
+
+		codificador := interceptador.NovoCodificador(&handler, "application/json")
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+
+		verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+		if err := verificadorResultado.VerificaResultado(codificador.Before(), nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.imagemEsperada, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Requisição.Imagem, nil); err != nil {
+			t.Error(err)
+		}
+
+		verificadorResultado.DefinirEsperado(cenário.mensagensEsperadas, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Mensagens, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCodificador_BeforeAceitaPDF(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		aceita    string
+		esperado  bool
+	}{
+		{
+			descrição: "deve identificar quando o cliente solicita um PDF",
+			aceita:    "application/pdf",
+			esperado:  true,
+		},
+		{
+			descrição: "deve identificar um PDF entre outros formatos aceitos",
+			aceita:    "application/json;q=0.9, application/pdf;q=1.0",
+			esperado:  true,
+		},
+		{
+			descrição: "deve ignorar quando o cliente não solicita um PDF",
+			aceita:    "application/json",
+		},
+		{
+			descrição: "deve ignorar quando o cliente não informa os formatos aceitos",
+		},
+	}
+
+	for i, cenário := range cenários {
+		requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste", nil)
+		if err != nil {
+			t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+		}
+		requisição.Header.Set("Accept", cenário.aceita)
+
+		var handler codificadorPDFSimulado
+		handler.SimulaRequisição = requisição
+		handler.DefineLogger(&simulador.Logger{
+			SimulaDebug: func(m ...interface{}) {},
+		})
+
+		estrutura := interceptor.NewIntrospector(&handler)
+		estrutura.Before()
+
+		codificador := interceptador.NovoCodificador(&handler, "application/json")
+		codificador.Before()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.AceitaPDF(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCodificador_BeforeIdioma(t *testing.T) {
+	cenários := []struct {
+		descrição string
+		idiomas   string
+		esperado  protocolo.Idioma
+	}{
+		{
+			descrição: "deve identificar o idioma solicitado",
+			idiomas:   "en",
+			esperado:  protocolo.IdiomaInglês,
+		},
+		{
+			descrição: "deve identificar o idioma de uma variação regional",
+			idiomas:   "en-US,en;q=0.9",
+			esperado:  protocolo.IdiomaInglês,
+		},
+		{
+			descrição: "deve identificar a variação regional pelo idioma",
+			idiomas:   "pt",
+			esperado:  protocolo.IdiomaPortuguês,
+		},
+		{
+			descrição: "deve respeitar a ordem de preferência do cliente",
+			idiomas:   "fr, pt-BR;q=0.5, en;q=0.8",
+			esperado:  protocolo.IdiomaInglês,
+		},
+		{
+			descrição: "deve ignorar os idiomas recusados pelo cliente",
+			idiomas:   "en;q=0, fr",
+			esperado:  protocolo.IdiomaPortuguês,
+		},
+		{
+			descrição: "deve utilizar o idioma padrão quando nenhum idioma é informado",
+			esperado:  protocolo.IdiomaPortuguês,
+		},
+	}
+
+	for i, cenário := range cenários {
+		requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste", nil)
+		if err != nil {
+			t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+		}
+		requisição.Header.Set("Accept-Language", cenário.idiomas)
+
+		var handler codificadorIdiomaSimulado
+		handler.SimulaRequisição = requisição
+		handler.DefineLogger(&simulador.Logger{
+			SimulaDebug: func(m ...interface{}) {},
+		})
+
+		estrutura := interceptor.NewIntrospector(&handler)
+		estrutura.Before()
+
+		codificador := interceptador.NovoCodificador(&handler, "application/json")
+		codificador.Before()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.esperado, nil)
+		if err := verificadorResultado.VerificaResultado(handler.Idioma(), nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestCodificador_After(t *testing.T) {
 	cenários := []struct {
 		descrição                  string
@@ -353,6 +720,173 @@ func TestCodificador_After(t *testing.T) {
 			códigoHTTPEsperado: http.StatusInternalServerError,
 			cabeçalhoEsperado:  http.Header{},
 		},
+		{
+			descrição: "deve escrever corretamente a resposta no formato CSV",
+			handler: &codificadorRespostaCSVSimulado{
+				Handler: simulador.Handler{
+					SimulaRequisição: func() *http.Request {
+						requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste.csv", nil)
+
+						if err != nil {
+							t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+						}
+
+						return requisição
+					}(),
+				},
+				Resposta: &codificadorObjetoCSVSimulado{
+					Campo1: "valor1",
+					Campo2: "valor2",
+				},
+			},
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Interceptador Depois: Codificador" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != `Resposta corpo: “campo1,campo2valor1,valor2”` {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			tipoConteúdo:               "application/json",
+			códigoHTTP:                 http.StatusOK,
+			códigoHTTPEsperado:         http.StatusOK,
+			respostaCodificadaEsperada: "campo1,campo2\nvalor1,valor2\n",
+			cabeçalhoEsperado: http.Header{
+				"Content-Type": []string{"text/csv; charset=utf-8"},
+			},
+		},
+		{
+			descrição: "deve escrever corretamente a resposta no formato HTML",
+			handler: &codificadorRespostaHTMLSimulado{
+				Handler: simulador.Handler{
+					SimulaRequisição: func() *http.Request {
+						requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste", nil)
+
+						if err != nil {
+							t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+						}
+
+						return requisição
+					}(),
+				},
+				Resposta: &codificadorObjetoHTMLSimulado{
+					Campo1: "valor1",
+				},
+			},
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Interceptador Depois: Codificador" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != `Resposta corpo: “<p>valor1</p>”` {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			tipoConteúdo:               "application/json",
+			códigoHTTP:                 http.StatusOK,
+			códigoHTTPEsperado:         http.StatusOK,
+			respostaCodificadaEsperada: "<p>valor1</p>\n",
+			cabeçalhoEsperado: http.Header{
+				"Content-Type": []string{"text/html; charset=utf-8"},
+			},
+		},
+		{
+			descrição: "deve escrever a resposta em PDF no lugar da resposta do método",
+			handler: &codificadorRespostaPDFSimulado{
+				Handler: simulador.Handler{
+					SimulaRequisição: func() *http.Request {
+						requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste", nil)
+
+						if err != nil {
+							t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+						}
+
+						return requisição
+					}(),
+				},
+				Resposta: &codificadorObjetoSimulada{
+					Campo1: "valor1",
+				},
+				RespostaPDF: &codificadorObjetoPDFSimulado{
+					Conteúdo: "%PDF-1.3 conteúdo",
+				},
+			},
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Interceptador Depois: Codificador" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != "Resposta corpo: documento PDF com 18 bytes" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			tipoConteúdo:               "application/json",
+			códigoHTTP:                 http.StatusOK,
+			códigoHTTPEsperado:         http.StatusOK,
+			respostaCodificadaEsperada: "%PDF-1.3 conteúdo",
+			cabeçalhoEsperado: http.Header{
+				"Content-Type": []string{"application/pdf"},
+			},
+		},
+		{
+			descrição: "deve escrever as mensagens no idioma solicitado",
+			handler: &codificadorMensagensSimulado{
+				Handler: simulador.Handler{
+					SimulaRequisição: func() *http.Request {
+						requisição, err := http.NewRequest("GET", "https://exemplo.com.br/teste", nil)
+
+						if err != nil {
+							t.Fatalf("Erro ao criar a requisição. Detalhes: %s", err)
+						}
+
+						requisição.Header.Set("Accept-Language", "en-US")
+						return requisição
+					}(),
+				},
+				MensagensCompatível: interceptador.MensagensCompatível{
+					Mensagens: protocolo.NovasMensagens(
+						protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "calibre", ""),
+					),
+				},
+			},
+			logger: &simulador.Logger{
+				SimulaDebug: func(m ...interface{}) {
+					mensagem := fmt.Sprint(m...)
+					if mensagem != "Interceptador Depois: Codificador" {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+				SimulaDebugf: func(m string, a ...interface{}) {
+					mensagem := fmt.Sprintf(m, a...)
+					if mensagem != `Resposta corpo: “[{"codigo":"campo-nao-preenchido","campo":"calibre","texto":"Field “calibre” is required"}]”` {
+						t.Errorf("mensagem inesperada: %s", mensagem)
+					}
+				},
+			},
+			tipoConteúdo:               "application/json",
+			códigoHTTP:                 http.StatusBadRequest,
+			códigoHTTPEsperado:         http.StatusBadRequest,
+			respostaCodificadaEsperada: `[{"codigo":"campo-nao-preenchido","campo":"calibre","texto":"Field “calibre” is required"}]` + "\n",
+			cabeçalhoEsperado: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+		},
 		{
 			descrição: "deve detectar um erro ao codificar a resposta",
 			handler: &codificadorRespostaInválidaSimulado{
@@ -462,6 +996,47 @@ func (c *codificadorSimulado) DefineResposta(w http.ResponseWriter) {
 	c.SimulaResposta = w
 }
 
+type codificadorImagemSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.CabeçalhoCompatível
+	interceptador.MensagensCompatível
+	simulador.Handler
+
+	Requisição codificadorObjetoImagemSimulado `request:"put"`
+}
+
+func (c *codificadorImagemSimulado) DefineResposta(w http.ResponseWriter) {
+	c.SimulaResposta = w
+}
+
+type codificadorPDFSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.PDFCompatível
+	simulador.Handler
+}
+
+type codificadorIdiomaSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.IdiomaCompatível
+	simulador.Handler
+}
+
+type codificadorMensagensSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.MensagensCompatível
+	simulador.Handler
+
+	Resposta *codificadorObjetoSimulada `response:"get"`
+}
+
+func (c *codificadorMensagensSimulado) DefineResposta(w http.ResponseWriter) {
+	c.SimulaResposta = w
+}
+
 type codificadorHeaderInválidoSimulado struct {
 	interceptador.LogCompatível
 	interceptor.IntrospectorCompliant
@@ -504,12 +1079,60 @@ func (c *codificadorRespostaInválidaSimulado) DefineResposta(w http.ResponseWri
 	c.SimulaResposta = w
 }
 
+type codificadorRespostaCSVSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.CabeçalhoCompatível
+	simulador.Handler
+
+	Resposta *codificadorObjetoCSVSimulado `response:"get"`
+}
+
+func (c *codificadorRespostaCSVSimulado) DefineResposta(w http.ResponseWriter) {
+	c.SimulaResposta = w
+}
+
+type codificadorRespostaHTMLSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.CabeçalhoCompatível
+	simulador.Handler
+
+	Resposta *codificadorObjetoHTMLSimulado `response:"get"`
+}
+
+func (c *codificadorRespostaHTMLSimulado) DefineResposta(w http.ResponseWriter) {
+	c.SimulaResposta = w
+}
+
+type codificadorRespostaPDFSimulado struct {
+	interceptador.LogCompatível
+	interceptor.IntrospectorCompliant
+	interceptador.CabeçalhoCompatível
+	simulador.Handler
+
+	Resposta    *codificadorObjetoSimulada    `response:"get"`
+	RespostaPDF *codificadorObjetoPDFSimulado `response:"pdf"`
+}
+
+func (c *codificadorRespostaPDFSimulado) DefineResposta(w http.ResponseWriter) {
+	c.SimulaResposta = w
+}
+
 type codificadorObjetoSimulada struct {
 	Campo1 string `json:"campo1"`
 	Campo2 []int  `json:"campo2"`
 	Campo3 string `json:"campo3,omitempty"`
 }
 
+type codificadorObjetoImagemSimulado struct {
+	Imagem []byte `json:"imagem"`
+}
+
+func (c *codificadorObjetoImagemSimulado) DefinirImagem(imagem []byte) {
+	c.Imagem = imagem
+}
+
 type codificadorObjetoGenéricoSimulado []string
 
 type codificadorObjetoInválidoSimulada struct {
@@ -517,6 +1140,34 @@ type codificadorObjetoInválidoSimulada struct {
 	Campo2 []int  `json:"campo2"`
 }
 
+type codificadorObjetoCSVSimulado struct {
+	Campo1 string
+	Campo2 string
+}
+
+func (c codificadorObjetoCSVSimulado) CSV(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "campo1,campo2\n%s,%s\n", c.Campo1, c.Campo2)
+	return err
+}
+
+type codificadorObjetoHTMLSimulado struct {
+	Campo1 string
+}
+
+func (c codificadorObjetoHTMLSimulado) HTML(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "<p>%s</p>\n", c.Campo1)
+	return err
+}
+
+type codificadorObjetoPDFSimulado struct {
+	Conteúdo string
+}
+
+func (c codificadorObjetoPDFSimulado) PDF(w io.Writer) error {
+	_, err := io.WriteString(w, c.Conteúdo)
+	return err
+}
+
 func (c codificadorObjetoInválidoSimulada) MarshalJSON() ([]byte, error) {
 	return nil, fmt.Errorf("erro de codificação")
 }
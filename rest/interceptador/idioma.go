@@ -0,0 +1,25 @@
+package interceptador
+
+import "github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+
+// IdiomaCompatível informa ao handler o idioma solicitado pelo cliente. A
+// informação é definida pelo interceptador Codificador a partir do cabeçalho
+// Accept-Language.
+type IdiomaCompatível struct {
+	idioma protocolo.Idioma
+}
+
+// DefinirIdioma define o idioma solicitado pelo cliente.
+func (i *IdiomaCompatível) DefinirIdioma(idioma protocolo.Idioma) {
+	i.idioma = idioma
+}
+
+// Idioma retorna o idioma solicitado pelo cliente, ou o idioma padrão quando
+// nenhum foi definido.
+func (i IdiomaCompatível) Idioma() protocolo.Idioma {
+	if i.idioma == "" {
+		return protocolo.IdiomaPadrão
+	}
+
+	return i.idioma
+}
@@ -0,0 +1,18 @@
+package interceptador
+
+// PDFCompatível indica ao handler se o cliente solicitou a resposta no formato
+// PDF. A informação é definida pelo interceptador Codificador a partir do
+// cabeçalho Accept.
+type PDFCompatível struct {
+	aceitaPDF bool
+}
+
+// DefinirAceitaPDF define se o cliente aceita a resposta no formato PDF.
+func (p *PDFCompatível) DefinirAceitaPDF(aceita bool) {
+	p.aceitaPDF = aceita
+}
+
+// AceitaPDF retorna se o cliente solicitou a resposta no formato PDF.
+func (p PDFCompatível) AceitaPDF() bool {
+	return p.aceitaPDF
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"os"
 
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/comprovante"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
 	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/rafaeljusto/atiradorfrequente/rest/servidor"
@@ -31,18 +34,101 @@ func main() {
 		},
 	}
 
-	app.Action = cli.ActionFunc(func(c *cli.Context) error {
-		config.DefinirValoresPadrão()
+	app.Commands = []cli.Command{
+		{
+			Name:  "migrar-imagens",
+			Usage: "move as imagens armazenadas em base64 no banco de dados para o repositório de objetos",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "lote,l",
+					Value: 100,
+					Usage: "quantidade de registros migrados em cada transação",
+				},
+			},
+			// as falhas encerram o comando com código de saída diferente de zero,
+			// permitindo que scripts identifiquem uma migração incompleta
+			Action: cli.ActionFunc(func(c *cli.Context) error {
+				if !carregarConfiguração(c.GlobalString("config")) {
+					return cli.NewExitError("", 1)
+				}
+
+				if err := servidor.MigrarImagens(c.Int("lote")); err != nil {
+					return cli.NewExitError(fmt.Sprintf("Erro ao migrar as imagens. Detalhes: %s", erros.Novo(err)), 1)
+				}
+
+				return nil
+			}),
+		},
+		{
+			Name:  "chaves-verificacao",
+			Usage: "informa a quantidade de frequências pendentes que dependem de chaves aposentadas do código de verificação",
+			// as falhas encerram o comando com código de saída diferente de zero,
+			// permitindo que scripts diferenciem um erro da ausência de pendências
+			Action: cli.ActionFunc(func(c *cli.Context) error {
+				if !carregarConfiguração(c.GlobalString("config")) {
+					return cli.NewExitError("", 1)
+				}
+
+				pendências, err := servidor.PendênciasChavesAposentadas()
+				if err != nil {
+					return cli.NewExitError(fmt.Sprintf("Erro ao obter as pendências das chaves. Detalhes: %s", erros.Novo(err)), 1)
+				}
+
+				if len(pendências) == 0 {
+					fmt.Println("Nenhuma frequência pendente depende de chaves aposentadas")
+					return nil
+				}
+
+				for _, pendência := range pendências {
+					chave := pendência.Chave
+					if chave == "" {
+						chave = "(legada)"
+					}
+
+					fmt.Printf("%s: %d frequência(s) pendente(s)\n", chave, pendência.Pendentes)
+				}
+
+				return nil
+			}),
+		},
+		{
+			Name:      "verificar-comprovante",
+			Usage:     "verifica a assinatura de um comprovante lido no QR Code sem acesso ao banco de dados",
+			ArgsUsage: "<comprovante ou URL do QR Code>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "chave,k",
+					Usage: "chave pública Ed25519 em base64, obtida em /comprovante/chave",
+				},
+			},
+			// as falhas encerram o comando com código de saída diferente de zero,
+			// permitindo que scripts identifiquem um comprovante falsificado
+			Action: cli.ActionFunc(func(c *cli.Context) error {
+				chavePública, err := base64.StdEncoding.DecodeString(c.String("chave"))
+				if err != nil || len(chavePública) != ed25519.PublicKeySize {
+					return cli.NewExitError("Chave pública inválida", 1)
+				}
 
-		if arquivo := c.String("config"); arquivo != "" {
-			if err := config.CarregarDeArquivo(arquivo); err != nil {
-				fmt.Fprintf(os.Stderr, "Erro ao carregar o arquivo de configuração. Detalhes: %s\n", erros.Novo(err))
+				dados, err := comprovante.Verificar(chavePública, c.Args().First())
+				if err != nil {
+					return cli.NewExitError(fmt.Sprintf("Comprovante inválido. Detalhes: %s", err), 1)
+				}
+
+				fmt.Printf("Comprovante válido. %s\n", dados)
 				return nil
-			}
+			}),
+		},
+	}
+
+	app.Action = cli.ActionFunc(func(c *cli.Context) error {
+		if !carregarConfiguração(c.String("config")) {
+			return nil
 		}
 
-		if err := config.CarregarDeVariávelAmbiente(); err != nil {
-			fmt.Fprintf(os.Stderr, "Erro ao carregar as variáveis de ambiente. Detalhes: %s\n", erros.Novo(err))
+		// somente o servidor depende das chaves do código de verificação, por isso
+		// os comandos auxiliares não exigem uma configuração completa
+		if err := config.Atual().Validar(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuração inválida. Detalhes: %s\n", erros.Novo(err))
 			return nil
 		}
 
@@ -77,6 +163,28 @@ func main() {
 	app.Run(os.Args)
 }
 
+// carregarConfiguração define a configuração a partir dos valores padrão, do
+// arquivo informado e das variáveis de ambiente, nesta ordem de prioridade
+// crescente. Os problemas encontrados são informados na saída de erro,
+// retornando falso quando a aplicação não deve continuar.
+func carregarConfiguração(arquivo string) bool {
+	config.DefinirValoresPadrão()
+
+	if arquivo != "" {
+		if err := config.CarregarDeArquivo(arquivo); err != nil {
+			fmt.Fprintf(os.Stderr, "Erro ao carregar o arquivo de configuração. Detalhes: %s\n", erros.Novo(err))
+			return false
+		}
+	}
+
+	if err := config.CarregarDeVariávelAmbiente(); err != nil {
+		fmt.Fprintf(os.Stderr, "Erro ao carregar as variáveis de ambiente. Detalhes: %s\n", erros.Novo(err))
+		return false
+	}
+
+	return true
+}
+
 func executor(estado overseer.State) {
 	servidor.Iniciar(estado.Listener)
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
 	"io"
 	"io/ioutil"
 	"net"
@@ -11,9 +13,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/comprovante"
+	núcleoconfig "github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/rafaeljusto/atiradorfrequente/rest/servidor"
 	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/registrobr/gostk/errors"
+	"github.com/urfave/cli"
 )
 
 func Test_main(t *testing.T) {
@@ -71,6 +78,20 @@ atirador:
 				c.Atirador.DuraçãoMáximaTreino = 10 * time.Hour
 				c.Atirador.ChaveCódigoVerificação = "cba321"
 				c.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
 				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
 				c.Binário.TempoAtualização = 1 * time.Second
 				c.Servidor.Endereço = "0.0.0.0:0"
@@ -78,6 +99,7 @@ atirador:
 				c.Servidor.TLS.ArquivoCertificado = "teste.crt"
 				c.Servidor.TLS.ArquivoChave = "teste.key"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
 				c.Syslog.Endereço = "192.0.2.2:514"
 				c.Syslog.TempoEsgotadoConexão = 5 * time.Second
 				c.BancoDados.Endereço = "192.0.2.3"
@@ -90,6 +112,11 @@ atirador:
 				c.BancoDados.TempoEsgotadoTransação = 5 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 10
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 40
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
 				c.Proxies = []net.IP{
 					net.ParseIP("192.0.2.4"),
 					net.ParseIP("192.0.2.5"),
@@ -111,11 +138,26 @@ url: http://localhost:8080/binarios/rest.af
 				c.Atirador.PrazoConfirmação = 30 * time.Minute
 				c.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
 				c.Binário.URL = "http://localhost:4000/binarios/rest.af"
 				c.Binário.TempoAtualização = 5 * time.Second
 				c.Servidor.Endereço = "0.0.0.0:443"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
 				c.Syslog.Endereço = "127.0.0.1:514"
 				c.Syslog.TempoEsgotadoConexão = 2 * time.Second
 				c.BancoDados.Endereço = "127.0.0.1"
@@ -127,6 +169,11 @@ url: http://localhost:8080/binarios/rest.af
 				c.BancoDados.TempoEsgotadoTransação = 3 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 16
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 32
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
 				return c
 			}(),
 			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
@@ -168,6 +215,20 @@ url: http://localhost:8080/binarios/rest.af
 				c.Atirador.DuraçãoMáximaTreino = 10 * time.Hour
 				c.Atirador.ChaveCódigoVerificação = "cba321"
 				c.Atirador.ImagemNúmeroControle.URLQRCode = "https://exemplo.com.br/frequencia/%s/%s?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
 				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
 				c.Binário.TempoAtualização = 1 * time.Second
 				c.Servidor.Endereço = "0.0.0.0:0"
@@ -175,6 +236,7 @@ url: http://localhost:8080/binarios/rest.af
 				c.Servidor.TLS.ArquivoCertificado = "teste.crt"
 				c.Servidor.TLS.ArquivoChave = "teste.key"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
 				c.Syslog.Endereço = "192.0.2.2:514"
 				c.Syslog.TempoEsgotadoConexão = 5 * time.Second
 				c.BancoDados.Endereço = "192.0.2.3"
@@ -187,6 +249,11 @@ url: http://localhost:8080/binarios/rest.af
 				c.BancoDados.TempoEsgotadoTransação = 5 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 10
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 40
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
 				c.Proxies = []net.IP{
 					net.ParseIP("192.0.2.4"),
 					net.ParseIP("192.0.2.5"),
@@ -207,11 +274,26 @@ url: http://localhost:8080/binarios/rest.af
 				c.Atirador.PrazoConfirmação = 30 * time.Minute
 				c.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
 				c.Binário.URL = "http://localhost:4000/binarios/rest.af"
 				c.Binário.TempoAtualização = 5 * time.Second
 				c.Servidor.Endereço = "0.0.0.0:443"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
 				c.Syslog.Endereço = "127.0.0.1:514"
 				c.Syslog.TempoEsgotadoConexão = 2 * time.Second
 				c.BancoDados.Endereço = "127.0.0.1"
@@ -223,6 +305,11 @@ url: http://localhost:8080/binarios/rest.af
 				c.BancoDados.TempoEsgotadoTransação = 3 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 16
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 32
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
 				return c
 			}(),
 			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
@@ -231,20 +318,37 @@ url: http://localhost:8080/binarios/rest.af
 		{
 			descrição: "deve detectar um erro ao escutar em uma interface inválida",
 			variáveisAmbiente: map[string]string{
-				"AF_BINARIO_URL":               "http://localhost:8080/binarios/rest.af",
-				"AF_BINARIO_TEMPO_ATUALIZACAO": "1s",
-				"AF_SERVIDOR_ENDERECO":         "X.X.X.X:X",
+				"AF_BINARIO_URL":                       "http://localhost:8080/binarios/rest.af",
+				"AF_BINARIO_TEMPO_ATUALIZACAO":         "1s",
+				"AF_SERVIDOR_ENDERECO":                 "X.X.X.X:X",
+				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO": "cba321",
 			},
 			configuraçãoEsperada: func() *config.Configuração {
 				c := new(config.Configuração)
 				c.Atirador.PrazoConfirmação = 30 * time.Minute
 				c.Atirador.TempoMáximoCadastro = 12 * time.Hour
 				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
-				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s?verificacao=%s"
+				c.Atirador.ChaveCódigoVerificação = "cba321"
+				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
 				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
 				c.Binário.TempoAtualização = 1 * time.Second
 				c.Servidor.Endereço = "X.X.X.X:X"
 				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
 				c.Syslog.Endereço = "127.0.0.1:514"
 				c.Syslog.TempoEsgotadoConexão = 2 * time.Second
 				c.BancoDados.Endereço = "127.0.0.1"
@@ -256,11 +360,73 @@ url: http://localhost:8080/binarios/rest.af
 				c.BancoDados.TempoEsgotadoTransação = 3 * time.Second
 				c.BancoDados.MáximoNúmeroConexõesInativas = 16
 				c.BancoDados.MáximoNúmeroConexõesAbertas = 32
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
 				return c
 			}(),
 			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
 			saídaErroEsperada:   regexp.MustCompile(`^Erro ao executar a aplicação\. Detalhes: .* Invalid address X\.X\.X\.X:X .*$`),
 		},
+		{
+			descrição: "deve detectar quando a chave ativa do código de verificação não existe",
+			variáveisAmbiente: map[string]string{
+				"AF_BINARIO_URL":                             "http://localhost:8080/binarios/rest.af",
+				"AF_BINARIO_TEMPO_ATUALIZACAO":               "1s",
+				"AF_SERVIDOR_ENDERECO":                       "X.X.X.X:X",
+				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO":       "cba321",
+				"AF_ATIRADOR_CHAVE_CODIGO_VERIFICACAO_ATIVA": "2017a",
+			},
+			configuraçãoEsperada: func() *config.Configuração {
+				c := new(config.Configuração)
+				c.Atirador.PrazoConfirmação = 30 * time.Minute
+				c.Atirador.TempoMáximoCadastro = 12 * time.Hour
+				c.Atirador.DuraçãoMáximaTreino = 12 * time.Hour
+				c.Atirador.ChaveCódigoVerificação = "cba321"
+				c.Atirador.ChaveCódigoVerificaçãoAtiva = "2017a"
+				c.Atirador.ImagemNúmeroControle.URLQRCode = "http://localhost/frequencia/%s/%s/verificacao?verificacao=%s"
+				c.Atirador.Habitualidade = map[int]int{1: 8, 2: 12, 3: 20}
+				c.Atirador.PrazoCancelamento = time.Hour
+				c.Atirador.CotaMunição = map[string]int{"permitido": 5000, "restrito": 1000}
+				c.Atirador.DistânciaHashImagem = 5
+				c.Atirador.TolerânciaDataImagem = time.Hour
+				c.Atirador.FusoHorárioImagem.Location, _ = time.LoadLocation("America/Sao_Paulo")
+				c.Atirador.PolíticaDataImagem = núcleoconfig.PolíticaImagemSinalizar
+				c.Atirador.PolíticaImagemSemEXIF = núcleoconfig.PolíticaImagemPermitir
+				c.Atirador.RaioClube = 1000
+				c.Atirador.ImagemConfirmação.ResoluçãoMáxima = 1920
+				c.Atirador.ImagemConfirmação.Qualidade = 85
+				c.Atirador.ImagemConfirmação.ResoluçãoMiniatura = 320
+				c.Atirador.ImagemConfirmação.PixelsMáximo = 50000000
+				c.Autenticação.DuraçãoToken = 8 * time.Hour
+				c.Binário.URL = "http://localhost:8080/binarios/rest.af"
+				c.Binário.TempoAtualização = 1 * time.Second
+				c.Servidor.Endereço = "X.X.X.X:X"
+				c.Servidor.TempoEsgotadoLeitura = 5 * time.Second
+				c.Servidor.TamanhoMáximoImagem = 10 << 20
+				c.Syslog.Endereço = "127.0.0.1:514"
+				c.Syslog.TempoEsgotadoConexão = 2 * time.Second
+				c.BancoDados.Endereço = "127.0.0.1"
+				c.BancoDados.Porta = 5432
+				c.BancoDados.Nome = "atiradorfrequente"
+				c.BancoDados.Usuário = "atiradorfrequente"
+				c.BancoDados.TempoEsgotadoConexão = 3 * time.Second
+				c.BancoDados.TempoEsgotadoComando = 10 * time.Second
+				c.BancoDados.TempoEsgotadoTransação = 3 * time.Second
+				c.BancoDados.MáximoNúmeroConexõesInativas = 16
+				c.BancoDados.MáximoNúmeroConexõesAbertas = 32
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/var/lib/atiradorfrequente/imagens"
+				c.Armazenamento.S3.Região = "us-east-1"
+				c.Expiração.Intervalo = 5 * time.Minute
+				c.Expiração.Lote = 100
+				return c
+			}(),
+			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
+			saídaErroEsperada:   regexp.MustCompile(`^Configuração inválida\. Detalhes: .*chave ativa do código de verificação “2017a” não definida$`),
+		},
 	}
 
 	teste = true
@@ -313,6 +479,7 @@ url: http://localhost:8080/binarios/rest.af
 		// restringimos a uma verificação simples
 		c := config.Atual()
 		c.Atirador.ImagemNúmeroControle.Fonte.Font = nil
+		c.Atirador.ImagemNúmeroControle.Fonte.TTF = nil
 		c.Atirador.ImagemNúmeroControle.ImagemBase.Image = nil
 		config.AtualizarConfiguração(c)
 
@@ -334,6 +501,315 @@ url: http://localhost:8080/binarios/rest.af
 	}
 }
 
+func Test_migrarImagens(t *testing.T) {
+	cenários := []struct {
+		descrição           string
+		argumentos          []string
+		variáveisAmbiente   map[string]string
+		loteEsperado        int
+		erro                error
+		saídaErroEsperada   *regexp.Regexp
+		códigoSaídaEsperado int
+	}{
+		{
+			descrição:         "deve migrar as imagens com o lote padrão",
+			argumentos:        []string{"migrar-imagens"},
+			loteEsperado:      100,
+			saídaErroEsperada: regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:         "deve migrar as imagens com o lote informado",
+			argumentos:        []string{"migrar-imagens", "-lote", "20"},
+			loteEsperado:      20,
+			saídaErroEsperada: regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:           "deve detectar um erro ao migrar as imagens",
+			argumentos:          []string{"migrar-imagens"},
+			loteEsperado:        100,
+			erro:                errors.Errorf("erro de migração"),
+			saídaErroEsperada:   regexp.MustCompile(`^Erro ao migrar as imagens\. Detalhes: .*erro de migração$`),
+			códigoSaídaEsperado: 1,
+		},
+		{
+			descrição:  "deve detectar um erro ao carregar a configuração",
+			argumentos: []string{"migrar-imagens"},
+			variáveisAmbiente: map[string]string{
+				"AF_BD_PORTA": "XXXX",
+			},
+			saídaErroEsperada:   regexp.MustCompile(`^Erro ao carregar as variáveis de ambiente\. Detalhes: .*invalid syntax$`),
+			códigoSaídaEsperado: 1,
+		},
+	}
+
+	migrarImagensOriginal := servidor.MigrarImagens
+	defer func() {
+		servidor.MigrarImagens = migrarImagensOriginal
+	}()
+
+	argumentosOriginais := os.Args
+	defer func() {
+		os.Args = argumentosOriginais
+	}()
+
+	saídaErroCLIOriginal := cli.ErrWriter
+	defer func() {
+		cli.ErrWriter = saídaErroCLIOriginal
+	}()
+
+	encerrarOriginal := cli.OsExiter
+	defer func() {
+		cli.OsExiter = encerrarOriginal
+	}()
+
+	for i, cenário := range cenários {
+		os.Args = append(os.Args[:1], cenário.argumentos...)
+		os.Clearenv()
+
+		for chave, valor := range cenário.variáveisAmbiente {
+			os.Setenv(chave, valor)
+		}
+
+		config.AtualizarConfiguração(&config.Configuração{})
+
+		var lote int
+		servidor.MigrarImagens = func(l int) error {
+			lote = l
+			return cenário.erro
+		}
+
+		var códigoSaída int
+		cli.OsExiter = func(código int) {
+			códigoSaída = código
+		}
+
+		_, saídaErro := capturarSaídas(func() {
+			cli.ErrWriter = os.Stderr
+			main()
+		})
+
+		if códigoSaída != cenário.códigoSaídaEsperado {
+			t.Errorf("Item %d, “%s”: código de saída inesperado. Esperado “%d”; encontrado “%d”",
+				i, cenário.descrição, cenário.códigoSaídaEsperado, códigoSaída)
+		}
+
+		if lote != cenário.loteEsperado {
+			t.Errorf("Item %d, “%s”: lote inesperado. Esperado %d; encontrado %d",
+				i, cenário.descrição, cenário.loteEsperado, lote)
+		}
+
+		if !cenário.saídaErroEsperada.MatchString(saídaErro) {
+			t.Errorf("Item %d, “%s”: saída de erro inesperada. Detalhes: %s",
+				i, cenário.descrição, saídaErro)
+		}
+	}
+}
+
+func Test_chavesVerificação(t *testing.T) {
+	cenários := []struct {
+		descrição           string
+		variáveisAmbiente   map[string]string
+		pendências          []protocolo.FrequênciaPendênciaChave
+		erro                error
+		saídaPadrãoEsperada *regexp.Regexp
+		saídaErroEsperada   *regexp.Regexp
+		códigoSaídaEsperado int
+	}{
+		{
+			descrição: "deve informar as pendências das chaves aposentadas",
+			pendências: []protocolo.FrequênciaPendênciaChave{
+				{Chave: "", Pendentes: 3},
+				{Chave: "2017a", Pendentes: 12},
+			},
+			saídaPadrãoEsperada: regexp.MustCompile(`^\(legada\): 3 frequência\(s\) pendente\(s\)\n2017a: 12 frequência\(s\) pendente\(s\)$`),
+			saídaErroEsperada:   regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:           "deve informar quando não existem pendências",
+			saídaPadrãoEsperada: regexp.MustCompile(`^Nenhuma frequência pendente depende de chaves aposentadas$`),
+			saídaErroEsperada:   regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:           "deve detectar um erro ao obter as pendências",
+			erro:                errors.Errorf("erro de conexão"),
+			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
+			saídaErroEsperada:   regexp.MustCompile(`^Erro ao obter as pendências das chaves\. Detalhes: .*erro de conexão$`),
+			códigoSaídaEsperado: 1,
+		},
+		{
+			descrição: "deve detectar um erro ao carregar a configuração",
+			variáveisAmbiente: map[string]string{
+				"AF_BD_PORTA": "XXXX",
+			},
+			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
+			saídaErroEsperada:   regexp.MustCompile(`^Erro ao carregar as variáveis de ambiente\. Detalhes: .*invalid syntax$`),
+			códigoSaídaEsperado: 1,
+		},
+	}
+
+	pendênciasChavesAposentadasOriginal := servidor.PendênciasChavesAposentadas
+	defer func() {
+		servidor.PendênciasChavesAposentadas = pendênciasChavesAposentadasOriginal
+	}()
+
+	argumentosOriginais := os.Args
+	defer func() {
+		os.Args = argumentosOriginais
+	}()
+
+	saídaErroCLIOriginal := cli.ErrWriter
+	defer func() {
+		cli.ErrWriter = saídaErroCLIOriginal
+	}()
+
+	encerrarOriginal := cli.OsExiter
+	defer func() {
+		cli.OsExiter = encerrarOriginal
+	}()
+
+	for i, cenário := range cenários {
+		os.Args = append(os.Args[:1], "chaves-verificacao")
+		os.Clearenv()
+
+		for chave, valor := range cenário.variáveisAmbiente {
+			os.Setenv(chave, valor)
+		}
+
+		config.AtualizarConfiguração(&config.Configuração{})
+
+		servidor.PendênciasChavesAposentadas = func() ([]protocolo.FrequênciaPendênciaChave, error) {
+			return cenário.pendências, cenário.erro
+		}
+
+		var códigoSaída int
+		cli.OsExiter = func(código int) {
+			códigoSaída = código
+		}
+
+		saídaPadrão, saídaErro := capturarSaídas(func() {
+			cli.ErrWriter = os.Stderr
+			main()
+		})
+
+		if códigoSaída != cenário.códigoSaídaEsperado {
+			t.Errorf("Item %d, “%s”: código de saída inesperado. Esperado “%d”; encontrado “%d”",
+				i, cenário.descrição, cenário.códigoSaídaEsperado, códigoSaída)
+		}
+
+		if !cenário.saídaPadrãoEsperada.MatchString(saídaPadrão) {
+			t.Errorf("Item %d, “%s”: saída padrão inesperada. Detalhes: %s",
+				i, cenário.descrição, saídaPadrão)
+		}
+
+		if !cenário.saídaErroEsperada.MatchString(saídaErro) {
+			t.Errorf("Item %d, “%s”: saída de erro inesperada. Detalhes: %s",
+				i, cenário.descrição, saídaErro)
+		}
+	}
+}
+
+func Test_verificarComprovante(t *testing.T) {
+	chavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+	chavePública := base64.StdEncoding.EncodeToString(chavePrivada.Public().(ed25519.PublicKey))
+
+	outraChavePrivada := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x02}, ed25519.SeedSize))
+	outraChavePública := base64.StdEncoding.EncodeToString(outraChavePrivada.Public().(ed25519.PublicKey))
+
+	assinado, err := comprovante.Assinar(chavePrivada, comprovante.Dados{
+		ID:          7654,
+		CR:          123456789,
+		Controle:    918273645,
+		Calibre:     ".380 ACP",
+		DataInício:  time.Date(2017, time.March, 10, 14, 0, 0, 0, time.UTC),
+		DataTérmino: time.Date(2017, time.March, 10, 15, 30, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Erro ao assinar o comprovante. Detalhes: %s", err)
+	}
+
+	cenários := []struct {
+		descrição           string
+		argumentos          []string
+		saídaPadrãoEsperada *regexp.Regexp
+		saídaErroEsperada   *regexp.Regexp
+		códigoSaídaEsperado int
+	}{
+		{
+			descrição:           "deve verificar corretamente um comprovante",
+			argumentos:          []string{"verificar-comprovante", "--chave", chavePública, assinado},
+			saídaPadrãoEsperada: regexp.MustCompile(`^Comprovante válido\. Frequência 7654-918273645 do CR 123456789, calibre \.380 ACP, treino de 10/03/2017 14:00 a 10/03/2017 15:30 \(UTC\)$`),
+			saídaErroEsperada:   regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:           "deve verificar corretamente um comprovante lido do QR Code",
+			argumentos:          []string{"verificar-comprovante", "--chave", chavePública, comprovante.URL("https://exemplo.com.br/frequencia/123456789/7654-918273645/verificacao", assinado)},
+			saídaPadrãoEsperada: regexp.MustCompile(`^Comprovante válido\. Frequência 7654-918273645 do CR 123456789`),
+			saídaErroEsperada:   regexp.MustCompile(`^$`),
+		},
+		{
+			descrição:           "deve detectar um comprovante assinado com outra chave",
+			argumentos:          []string{"verificar-comprovante", "--chave", outraChavePública, assinado},
+			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
+			saídaErroEsperada:   regexp.MustCompile(`^Comprovante inválido\. Detalhes: .*assinatura do comprovante inválida$`),
+			códigoSaídaEsperado: 1,
+		},
+		{
+			descrição:           "deve detectar uma chave pública inválida",
+			argumentos:          []string{"verificar-comprovante", "--chave", "%%%", assinado},
+			saídaPadrãoEsperada: regexp.MustCompile(`^$`),
+			saídaErroEsperada:   regexp.MustCompile(`^Chave pública inválida$`),
+			códigoSaídaEsperado: 1,
+		},
+	}
+
+	argumentosOriginais := os.Args
+	defer func() {
+		os.Args = argumentosOriginais
+	}()
+
+	saídaErroCLIOriginal := cli.ErrWriter
+	defer func() {
+		cli.ErrWriter = saídaErroCLIOriginal
+	}()
+
+	encerrarOriginal := cli.OsExiter
+	defer func() {
+		cli.OsExiter = encerrarOriginal
+	}()
+
+	for i, cenário := range cenários {
+		os.Args = append(os.Args[:1], cenário.argumentos...)
+		os.Clearenv()
+
+		var códigoSaída int
+		cli.OsExiter = func(código int) {
+			códigoSaída = código
+		}
+
+		saídaPadrão, saídaErro := capturarSaídas(func() {
+			// a biblioteca escreve os erros de saída na saída de erro obtida na
+			// inicialização
+			cli.ErrWriter = os.Stderr
+			main()
+		})
+
+		if códigoSaída != cenário.códigoSaídaEsperado {
+			t.Errorf("Item %d, “%s”: código de saída inesperado. Esperado “%d”; encontrado “%d”",
+				i, cenário.descrição, cenário.códigoSaídaEsperado, códigoSaída)
+		}
+
+		if !cenário.saídaPadrãoEsperada.MatchString(saídaPadrão) {
+			t.Errorf("Item %d, “%s”: saída padrão inesperada. Detalhes: %s",
+				i, cenário.descrição, saídaPadrão)
+		}
+
+		if !cenário.saídaErroEsperada.MatchString(saídaErro) {
+			t.Errorf("Item %d, “%s”: saída de erro inesperada. Detalhes: %s",
+				i, cenário.descrição, saídaErro)
+		}
+	}
+}
+
 func capturarSaídas(f func()) (string, string) {
 	saídaPadrãoOriginal := os.Stdout
 	defer func() {
@@ -8,11 +8,15 @@ import (
 	"net/http"
 	"runtime/debug"
 
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/armazenamento"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
 	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 	"github.com/rafaeljusto/atiradorfrequente/rest/config"
 	"github.com/rafaeljusto/atiradorfrequente/rest/handler"
+	"github.com/rafaeljusto/atiradorfrequente/rest/tarefa"
 	"github.com/registrobr/gostk/db"
+	"github.com/registrobr/gostk/errors"
 	"github.com/registrobr/gostk/log"
 	"github.com/trajber/handy"
 )
@@ -49,6 +53,16 @@ var Iniciar = func(escuta net.Listener) error {
 		}
 	}()
 
+	if err := iniciarArmazenamento(); err != nil {
+		log.Critf("Erro ao inicializar o repositório de objetos. Detalhes: %s", erros.Novo(err))
+		return erros.Novo(err)
+	}
+
+	// as tarefas periódicas são encerradas junto com o servidor
+	encerrar := make(chan struct{})
+	defer close(encerrar)
+	tarefa.IniciarExpiração(encerrar)
+
 	// a execução do servidor será bloqueante até que ocorra um erro. Mesmo quando
 	// encerramos corretamente o servidor um erro será gerado referente a escuta
 	// na interface. Mais detalhes em: https://github.com/golang/go/issues/11219
@@ -81,6 +95,92 @@ func iniciarConexãoBancoDados() error {
 	return erros.Novo(err)
 }
 
+// MigrarImagens move para o repositório de objetos as imagens ainda
+// armazenadas em base64 no banco de dados, em lotes com a quantidade de
+// registros informada. Supõe que a configuração já foi carregada. Cada lote é
+// migrado em uma transação própria, permitindo interromper a migração e
+// continuá-la posteriormente. Para facilitar o teste do binário, esta função
+// pode ser substituída.
+var MigrarImagens = func(lote int) error {
+	if err := iniciarConexãoBancoDados(); err != nil {
+		return erros.Novo(err)
+	}
+	defer func() {
+		if err := bd.Conexão.Close(); err != nil {
+			log.Errorf("Erro ao fechar a conexão do banco de dados. Detalhes: %s", erros.Novo(err))
+		}
+	}()
+
+	if err := iniciarArmazenamento(); err != nil {
+		return erros.Novo(err)
+	}
+
+	total := 0
+	for {
+		quantidade, err := tarefa.MigrarImagens(lote)
+		if err != nil {
+			return erros.Novo(err)
+		}
+
+		if quantidade == 0 {
+			break
+		}
+
+		total += quantidade
+		log.Infof("%d registro(s) com imagens migrado(s)", total)
+	}
+
+	log.Infof("Migração das imagens finalizada com %d registro(s) migrado(s)", total)
+	return nil
+}
+
+// PendênciasChavesAposentadas retorna a quantidade de frequências pendentes
+// que ainda dependem de chaves aposentadas do código de verificação. Supõe que
+// a configuração já foi carregada. Para facilitar o teste do binário, esta
+// função pode ser substituída.
+var PendênciasChavesAposentadas = func() ([]protocolo.FrequênciaPendênciaChave, error) {
+	if err := iniciarConexãoBancoDados(); err != nil {
+		return nil, erros.Novo(err)
+	}
+	defer func() {
+		if err := bd.Conexão.Close(); err != nil {
+			log.Errorf("Erro ao fechar a conexão do banco de dados. Detalhes: %s", erros.Novo(err))
+		}
+	}()
+
+	pendências, err := tarefa.PendênciasChavesAposentadas()
+	return pendências, erros.Novo(err)
+}
+
+func iniciarArmazenamento() error {
+	log.Info("Inicializando repositório de objetos")
+
+	switch config.Atual().Armazenamento.Tipo {
+	case "local":
+		armazenamento.Atual = armazenamento.NovoLocal(config.Atual().Armazenamento.Diretório)
+
+	case "s3":
+		s3, err := armazenamento.NovoS3(armazenamento.ParâmetrosS3{
+			Endereço:     config.Atual().Armazenamento.S3.Endereço,
+			Região:       config.Atual().Armazenamento.S3.Região,
+			Balde:        config.Atual().Armazenamento.S3.Balde,
+			ChaveAcesso:  config.Atual().Armazenamento.S3.ChaveAcesso,
+			ChaveSecreta: config.Atual().Armazenamento.S3.ChaveSecreta,
+		})
+
+		if err != nil {
+			return erros.Novo(err)
+		}
+
+		armazenamento.Atual = s3
+
+	default:
+		return errors.Errorf("tipo de repositório de objetos desconhecido “%s”", config.Atual().Armazenamento.Tipo)
+	}
+
+	return nil
+}
+
 func iniciarServidor(escuta net.Listener) error {
 	log.Info("Inicializando servidor")
 
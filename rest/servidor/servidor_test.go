@@ -81,6 +81,8 @@ func TestIniciar(t *testing.T) {
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -95,6 +97,8 @@ func TestIniciar(t *testing.T) {
 			erroEsperado:     errors.Errorf("accept tcp %s: use of closed network connection", endereçoServidor),
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro ao iniciar o servidor\. Detalhes: .*use of closed network connection
 $`),
@@ -117,6 +121,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = "192.0.2.1:1234"
 				c.Syslog.TempoEsgotadoConexão = 100 * time.Millisecond
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -151,6 +157,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -167,6 +175,8 @@ $`),
 			erroEsperado: errors.Errorf("accept tcp %s: use of closed network connection", endereçoServidor),
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro ao iniciar o servidor\. Detalhes: .*use of closed network connection
 .*Erro ao fechar a conexão do log. Detalhes: .*erro ao encerrar a conexão
@@ -190,6 +200,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -200,6 +212,8 @@ $`),
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
 .*Erro ao conectar o banco de dados. Detalhes: .*erro de conexão
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro ao iniciar o servidor\. Detalhes: .*use of closed network connection
 $`),
@@ -222,6 +236,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -236,6 +252,8 @@ $`),
 			erroEsperado:     errors.Errorf("accept tcp %s: use of closed network connection", endereçoServidor),
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro ao iniciar o servidor\. Detalhes: .*use of closed network connection
 .*Erro ao fechar a conexão do banco de dados. Detalhes: .*erro na conexão com o banco de dados
@@ -259,6 +277,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = arquivoChave.Name()
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -285,10 +305,46 @@ $`),
 			erroEsperado: errors.Errorf("accept tcp %s: use of closed network connection", endereçoServidor),
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro grave detectado. Detalhes: pânico no sistema
 (.|\n)*
 .*Erro ao iniciar o servidor\. Detalhes: .*use of closed network connection
+$`),
+		},
+		{
+			descrição: "deve detectar um tipo de repositório de objetos desconhecido",
+			escuta: func() net.Listener {
+				escuta, err := net.Listen("tcp", "localhost:0")
+				if err != nil {
+					t.Fatalf("Erro ao inicializar o servidor. Detalhes: %s", err)
+				}
+				endereçoServidor = escuta.Addr().String()
+				return escuta
+			}(),
+			configuração: func() config.Configuração {
+				var c config.Configuração
+				c.Servidor.Endereço = endereçoServidor
+				c.Syslog.Endereço = syslog.Addr().String()
+				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "fita"
+				return c
+			}(),
+			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
+				bd.Conexão = simulador.BD{
+					SimulaClose: func() error {
+						return nil
+					},
+				}
+				return nil
+			},
+			fecharConexãoLog: log.Close,
+			erroEsperado:     errors.Errorf("tipo de repositório de objetos desconhecido “fita”"),
+			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
+.*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Erro ao inicializar o repositório de objetos\. Detalhes: .*tipo de repositório de objetos desconhecido “fita”
 $`),
 		},
 		{
@@ -309,6 +365,8 @@ $`),
 				c.Servidor.TLS.ArquivoChave = "/tmp/atiradorfrequente/nao-existo.key"
 				c.Syslog.Endereço = syslog.Addr().String()
 				c.Syslog.TempoEsgotadoConexão = 1 * time.Second
+				c.Armazenamento.Tipo = "local"
+				c.Armazenamento.Diretório = "/tmp/atiradorfrequente/imagens"
 				return c
 			}(),
 			conexãoBD: func(parâmetrosConexão db.ConnParams, txTempoEsgotado time.Duration) error {
@@ -327,6 +385,8 @@ $`),
 			},
 			mensagensEsperadas: regexp.MustCompile(`^.*Inicializando conexão com o servidor de log
 .*Inicializando conexão com o banco de dados
+.*Inicializando repositório de objetos
+.*Tarefa de expiração de frequências desabilitada
 .*Inicializando servidor
 .*Erro ao iniciar o servidor\. Detalhes: .*open /tmp/atiradorfrequente/nao-existo.crt: no such file or directory
 $`),
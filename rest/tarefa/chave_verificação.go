@@ -0,0 +1,44 @@
+package tarefa
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/registrobr/gostk/log"
+)
+
+// PendênciasChavesAposentadas retorna a quantidade de frequências pendentes
+// que ainda dependem de chaves aposentadas do código de verificação. Para
+// facilitar os testes, esta função pode ser substituída.
+var PendênciasChavesAposentadas = func() ([]protocolo.FrequênciaPendênciaChave, error) {
+	if bd.Conexão == nil {
+		return nil, erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	tx, err := bd.Conexão.Begin()
+	if err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	sqlogger := bd.NovoSQLogger(tx, endereçoLocal)
+
+	logger := log.NewLogger("chaves")
+	serviçoAtirador := atirador.NovoServiço(sqlogger, logger, config.Atual().Configuração)
+
+	pendências, err := serviçoAtirador.PendênciasChavesAposentadas()
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Errorf("Erro ao desfazer uma transação. Detalhes: %s", erros.Novo(errRollback))
+		}
+
+		return nil, erros.Novo(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, erros.Novo(err)
+	}
+
+	return pendências, nil
+}
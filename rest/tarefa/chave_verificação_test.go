@@ -0,0 +1,149 @@
+package tarefa_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
+	configREST "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/tarefa"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestPendênciasChavesAposentadas(t *testing.T) {
+	var confirmada, desfeita bool
+
+	tx := func(erroConfirmação error) bd.Tx {
+		return simulador.Tx{
+			SimulaCommit: func() error {
+				confirmada = true
+				return erroConfirmação
+			},
+			SimulaRollback: func() error {
+				desfeita = true
+				return nil
+			},
+		}
+	}
+
+	cenários := []struct {
+		descrição           string
+		conexão             bd.BD
+		serviçoAtirador     simulador.ServiçoAtirador
+		pendênciasEsperadas []protocolo.FrequênciaPendênciaChave
+		confirmadaEsperada  bool
+		desfeitaEsperada    bool
+		erroEsperado        error
+	}{
+		{
+			descrição: "deve obter corretamente as pendências das chaves aposentadas",
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPendênciasChavesAposentadas: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return []protocolo.FrequênciaPendênciaChave{
+						{Chave: "2017a", Pendentes: 5},
+					}, nil
+				},
+			},
+			pendênciasEsperadas: []protocolo.FrequênciaPendênciaChave{
+				{Chave: "2017a", Pendentes: 5},
+			},
+			confirmadaEsperada: true,
+		},
+		{
+			descrição:    "deve detectar quando não existe conexão com o banco de dados",
+			erroEsperado: erros.ObjetoIndefinido,
+		},
+		{
+			descrição: "deve detectar um erro ao iniciar a transação",
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return nil, errors.Errorf("erro ao iniciar a transação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao iniciar a transação"),
+		},
+		{
+			descrição: "deve detectar um erro ao obter as pendências",
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPendênciasChavesAposentadas: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return nil, errors.Errorf("erro ao obter as pendências")
+				},
+			},
+			desfeitaEsperada: true,
+			erroEsperado:     errors.Errorf("erro ao obter as pendências"),
+		},
+		{
+			descrição: "deve detectar um erro ao confirmar a transação",
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(errors.Errorf("erro ao confirmar")), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaPendênciasChavesAposentadas: func() ([]protocolo.FrequênciaPendênciaChave, error) {
+					return nil, nil
+				},
+			},
+			confirmadaEsperada: true,
+			erroEsperado:       errors.Errorf("erro ao confirmar"),
+		},
+	}
+
+	configuraçãoOriginal := configREST.Atual()
+	defer func() {
+		configREST.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	configREST.AtualizarConfiguração(new(configREST.Configuração))
+
+	conexãoOriginal := bd.Conexão
+	defer func() {
+		bd.Conexão = conexãoOriginal
+	}()
+
+	novoServiçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = novoServiçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		confirmada, desfeita = false, false
+		bd.Conexão = cenário.conexão
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l log.Serviço, c config.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(cenário.pendênciasEsperadas, cenário.erroEsperado)
+		if err := verificadorResultado.VerificaResultado(tarefa.PendênciasChavesAposentadas()); err != nil {
+			t.Error(err)
+		}
+
+		if confirmada != cenário.confirmadaEsperada {
+			t.Errorf("Item %d, “%s”: confirmação da transação inesperada. Esperava %t e foi %t",
+				i, cenário.descrição, cenário.confirmadaEsperada, confirmada)
+		}
+
+		if desfeita != cenário.desfeitaEsperada {
+			t.Errorf("Item %d, “%s”: cancelamento da transação inesperado. Esperava %t e foi %t",
+				i, cenário.descrição, cenário.desfeitaEsperada, desfeita)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package tarefa possuí as tarefas executadas pelo servidor REST independente
+// das requisições recebidas, sejam elas periódicas em segundo plano ou
+// disparadas pela linha de comando.
+package tarefa
@@ -0,0 +1,103 @@
+package tarefa
+
+import (
+	"net"
+	"time"
+
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/registrobr/gostk/log"
+)
+
+// chaveTravaExpiração identifica a trava no banco de dados utilizada para
+// garantir que somente um servidor execute a expiração por vez.
+const chaveTravaExpiração int64 = 1
+
+// endereçoLocal é o endereço registrado no log do banco de dados para as
+// alterações feitas pelas tarefas, que não possuem um cliente remoto.
+var endereçoLocal = net.ParseIP("127.0.0.1")
+
+// IniciarExpiração agenda a execução periódica da expiração das frequências até
+// que o canal de encerramento seja fechado. Quando o intervalo não estiver
+// configurado a tarefa não é executada. O estado da tarefa é registrado no log
+// antes do retorno, e a execução periódica ocorre em segundo plano.
+func IniciarExpiração(encerrar <-chan struct{}) {
+	intervalo := config.Atual().Expiração.Intervalo
+	if intervalo <= 0 {
+		log.Info("Tarefa de expiração de frequências desabilitada")
+		return
+	}
+
+	log.Info("Inicializando tarefa de expiração de frequências")
+	go executarExpiração(intervalo, encerrar)
+}
+
+// executarExpiração expira as frequências a cada intervalo até que o canal de
+// encerramento seja fechado.
+func executarExpiração(intervalo time.Duration, encerrar <-chan struct{}) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-encerrar:
+			return
+
+		case <-ticker.C:
+			if err := ExpirarFrequências(); err != nil {
+				log.Errorf("Erro ao expirar as frequências. Detalhes: %s", erros.Novo(err))
+			}
+		}
+	}
+}
+
+// ExpirarFrequências marca como expiradas as frequências pendentes cujo prazo
+// de confirmação já passou. Toda a execução ocorre em uma única transação que
+// obtém uma trava no banco de dados; caso outro servidor já possua a trava,
+// nada é feito. Para facilitar os testes, esta função pode ser substituída.
+var ExpirarFrequências = func() error {
+	if bd.Conexão == nil {
+		log.Warning("Conexão com o banco de dados indisponível para expirar as frequências")
+		return nil
+	}
+
+	tx, err := bd.Conexão.Begin()
+	if err != nil {
+		return erros.Novo(err)
+	}
+
+	sqlogger := bd.NovoSQLogger(tx, endereçoLocal)
+
+	obtida, err := sqlogger.Travar(chaveTravaExpiração)
+	if err != nil || !obtida {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Errorf("Erro ao desfazer uma transação. Detalhes: %s", erros.Novo(errRollback))
+		}
+
+		return erros.Novo(err)
+	}
+
+	logger := log.NewLogger("expiracao")
+	serviçoAtirador := atirador.NovoServiço(sqlogger, logger, config.Atual().Configuração)
+
+	quantidade, err := serviçoAtirador.ExpirarFrequências(config.Atual().Expiração.Lote)
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Errorf("Erro ao desfazer uma transação. Detalhes: %s", erros.Novo(errRollback))
+		}
+
+		return erros.Novo(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return erros.Novo(err)
+	}
+
+	if quantidade > 0 {
+		logger.Infof("%d frequência(s) expirada(s)", quantidade)
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package tarefa_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/erikstmartin/go-testdb"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/config"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/log"
+	configREST "github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/rafaeljusto/atiradorfrequente/rest/tarefa"
+	"github.com/rafaeljusto/atiradorfrequente/testes"
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+	"github.com/registrobr/gostk/errors"
+)
+
+func TestExpirarFrequências(t *testing.T) {
+	conexão, err := sql.Open("testdb", "")
+	if err != nil {
+		t.Fatalf("erro ao inicializar a conexão do banco de dados. Detalhes: %s", err)
+	}
+
+	travaComando := `SELECT pg_try_advisory_xact_lock($1)`
+
+	var confirmada, desfeita bool
+
+	tx := func(erroConfirmação error) bd.Tx {
+		return simulador.Tx{
+			SimulaQueryRow: func(query string, args ...interface{}) *sql.Row {
+				return conexão.QueryRow(query, args...)
+			},
+			SimulaCommit: func() error {
+				confirmada = true
+				return erroConfirmação
+			},
+			SimulaRollback: func() error {
+				desfeita = true
+				return nil
+			},
+		}
+	}
+
+	cenários := []struct {
+		descrição          string
+		simulação          func()
+		conexão            bd.BD
+		serviçoAtirador    simulador.ServiçoAtirador
+		confirmadaEsperada bool
+		desfeitaEsperada   bool
+		erroEsperado       error
+	}{
+		{
+			descrição: "deve expirar corretamente as frequências",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{true}}))
+			},
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaExpirarFrequências: func(limite int) (int, error) {
+					if limite != 50 {
+						t.Errorf("limite inesperado: %d", limite)
+					}
+
+					return 3, nil
+				},
+			},
+			confirmadaEsperada: true,
+		},
+		{
+			descrição: "deve ignorar quando outro servidor possui a trava",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{false}}))
+			},
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaExpirarFrequências: func(limite int) (int, error) {
+					t.Error("expiração executada sem a trava")
+					return 0, nil
+				},
+			},
+			desfeitaEsperada: true,
+		},
+		{
+			descrição: "deve ignorar quando não existe conexão com o banco de dados",
+		},
+		{
+			descrição: "deve detectar um erro ao iniciar a transação",
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return nil, errors.Errorf("erro ao iniciar a transação")
+				},
+			},
+			erroEsperado: errors.Errorf("erro ao iniciar a transação"),
+		},
+		{
+			descrição: "deve detectar um erro ao obter a trava",
+			simulação: func() {
+				testdb.StubQueryError(travaComando, fmt.Errorf("erro ao obter a trava"))
+			},
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			desfeitaEsperada: true,
+			erroEsperado:     errors.Errorf("erro ao obter a trava"),
+		},
+		{
+			descrição: "deve detectar um erro ao expirar as frequências",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{true}}))
+			},
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(nil), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaExpirarFrequências: func(limite int) (int, error) {
+					return 0, errors.Errorf("erro ao expirar")
+				},
+			},
+			desfeitaEsperada: true,
+			erroEsperado:     errors.Errorf("erro ao expirar"),
+		},
+		{
+			descrição: "deve detectar um erro ao confirmar a transação",
+			simulação: func() {
+				testdb.StubQuery(travaComando, testdb.RowsFromSlice([]string{"pg_try_advisory_xact_lock"}, [][]driver.Value{{true}}))
+			},
+			conexão: simulador.BD{
+				SimulaBegin: func() (bd.Tx, error) {
+					return tx(errors.Errorf("erro ao confirmar")), nil
+				},
+			},
+			serviçoAtirador: simulador.ServiçoAtirador{
+				SimulaExpirarFrequências: func(limite int) (int, error) {
+					return 1, nil
+				},
+			},
+			confirmadaEsperada: true,
+			erroEsperado:       errors.Errorf("erro ao confirmar"),
+		},
+	}
+
+	configuraçãoOriginal := configREST.Atual()
+	defer func() {
+		configREST.AtualizarConfiguração(configuraçãoOriginal)
+	}()
+
+	var configuração configREST.Configuração
+	configuração.Expiração.Lote = 50
+	configREST.AtualizarConfiguração(&configuração)
+
+	conexãoOriginal := bd.Conexão
+	defer func() {
+		bd.Conexão = conexãoOriginal
+	}()
+
+	novoServiçoAtiradorOriginal := atirador.NovoServiço
+	defer func() {
+		atirador.NovoServiço = novoServiçoAtiradorOriginal
+	}()
+
+	for i, cenário := range cenários {
+		testdb.Reset()
+		if cenário.simulação != nil {
+			cenário.simulação()
+		}
+
+		confirmada, desfeita = false, false
+		bd.Conexão = cenário.conexão
+
+		atirador.NovoServiço = func(s *bd.SQLogger, l log.Serviço, c config.Configuração) atirador.Serviço {
+			return cenário.serviçoAtirador
+		}
+
+		err := tarefa.ExpirarFrequências()
+
+		verificadorResultado := testes.NovoVerificadorResultados(cenário.descrição, i)
+		verificadorResultado.DefinirEsperado(nil, cenário.erroEsperado)
+		if err = verificadorResultado.VerificaResultado(nil, err); err != nil {
+			t.Error(err)
+		}
+
+		if confirmada != cenário.confirmadaEsperada {
+			t.Errorf("Item %d, “%s”: confirmação da transação inesperada. Esperava %t e foi %t",
+				i, cenário.descrição, cenário.confirmadaEsperada, confirmada)
+		}
+
+		if desfeita != cenário.desfeitaEsperada {
+			t.Errorf("Item %d, “%s”: cancelamento da transação inesperado. Esperava %t e foi %t",
+				i, cenário.descrição, cenário.desfeitaEsperada, desfeita)
+		}
+	}
+}
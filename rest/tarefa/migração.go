@@ -0,0 +1,45 @@
+package tarefa
+
+import (
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/atirador"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/bd"
+	"github.com/rafaeljusto/atiradorfrequente/núcleo/erros"
+	"github.com/rafaeljusto/atiradorfrequente/rest/config"
+	"github.com/registrobr/gostk/log"
+)
+
+// MigrarImagens move para o repositório de objetos um lote das imagens ainda
+// armazenadas em base64 no banco de dados, retornando a quantidade de
+// registros migrados. A execução ocorre em uma única transação, de forma que
+// as referências só são persistidas depois que as imagens foram gravadas no
+// repositório. Para facilitar os testes, esta função pode ser substituída.
+var MigrarImagens = func(lote int) (int, error) {
+	if bd.Conexão == nil {
+		return 0, erros.Novo(erros.ObjetoIndefinido)
+	}
+
+	tx, err := bd.Conexão.Begin()
+	if err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	sqlogger := bd.NovoSQLogger(tx, endereçoLocal)
+
+	logger := log.NewLogger("migracao")
+	serviçoAtirador := atirador.NovoServiço(sqlogger, logger, config.Atual().Configuração)
+
+	quantidade, err := serviçoAtirador.MigrarImagens(lote)
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			log.Errorf("Erro ao desfazer uma transação. Detalhes: %s", erros.Novo(errRollback))
+		}
+
+		return 0, erros.Novo(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, erros.Novo(err)
+	}
+
+	return quantidade, nil
+}
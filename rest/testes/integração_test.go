@@ -28,6 +28,8 @@ import (
 var endereçoServidor string
 
 func TestCriaçãoDeFrequência(t *testing.T) {
+	token := autenticar(t, "operador", "clube123")
+
 	cenários := []struct {
 		descrição          string
 		requisição         *http.Request
@@ -39,7 +41,8 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 			descrição: "deve criar corretamente uma frequência",
 			requisição: func() *http.Request {
 				frequênciaPedido := protocolo.FrequênciaPedido{
-					Calibre:           "calibre .380",
+					Clube:             1,
+					Calibre:           ".380",
 					ArmaUtilizada:     "arma do clube",
 					NúmeroSérie:       "za785671",
 					GuiaDeTráfego:     762556223,
@@ -59,6 +62,8 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
 				}
 
+				r.Header.Set("Authorization", "Bearer "+token)
+
 				return r
 			}(),
 			códigoHTTPEsperado: http.StatusCreated,
@@ -98,6 +103,8 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
 				}
 
+				r.Header.Set("Authorization", "Bearer "+token)
+
 				return r
 			}(),
 			códigoHTTPEsperado: http.StatusBadRequest,
@@ -108,12 +115,13 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 			},
 			corpoEsperado: func(corpo []byte) ([]byte, error) {
 				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "clube", "0"),
 					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "calibre", ""),
 					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "armaUtilizada", ""),
 					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoCampoNãoPreenchido, "quantidadeMunicao", "0"),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -125,7 +133,8 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 			descrição: "deve detectar campos com dados inválidos",
 			requisição: func() *http.Request {
 				frequênciaPedido := protocolo.FrequênciaPedido{
-					Calibre:           "calibre .380",
+					Clube:             1,
+					Calibre:           ".380",
 					ArmaUtilizada:     "arma do clube",
 					NúmeroSérie:       "785671", // formato inválido
 					GuiaDeTráfego:     762556223,
@@ -145,6 +154,8 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
 				}
 
+				r.Header.Set("Authorization", "Bearer "+token)
+
 				return r
 			}(),
 			códigoHTTPEsperado: http.StatusBadRequest,
@@ -159,7 +170,191 @@ func TestCriaçãoDeFrequência(t *testing.T) {
 					protocolo.NovaMensagem(protocolo.MensagemCódigoDatasPeríodoIncorreto),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar uma frequência de um CR não cadastrado",
+			requisição: func() *http.Request {
+				frequênciaPedido := protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "arma do clube",
+					QuantidadeMunição: 50,
+					DataInício:        time.Now().Add(-30 * time.Minute),
+					DataTérmino:       time.Now().Add(-10 * time.Minute),
+				}
+
+				corpo, err := json.Marshal(frequênciaPedido)
+				if err != nil {
+					t.Fatalf("Erro ao gerar os dados da requisição. Detalhes: %s", err)
+				}
+
+				url := fmt.Sprintf("http://%s/frequencia/999999", endereçoServidor)
+				r, err := http.NewRequest("POST", url, bytes.NewReader(corpo))
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+
+				r.Header.Set("Authorization", "Bearer "+token)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusBadRequest,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCRNãoCadastrado, "999999"),
+				)
+
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar uma frequência com uma arma fora do acervo",
+			requisição: func() *http.Request {
+				frequênciaPedido := protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "arma do atirador",
+					NúmeroSérie:       "xz999999",
+					QuantidadeMunição: 50,
+					DataInício:        time.Now().Add(-30 * time.Minute),
+					DataTérmino:       time.Now().Add(-10 * time.Minute),
+				}
+
+				corpo, err := json.Marshal(frequênciaPedido)
+				if err != nil {
+					t.Fatalf("Erro ao gerar os dados da requisição. Detalhes: %s", err)
+				}
+
+				url := fmt.Sprintf("http://%s/frequencia/380308", endereçoServidor)
+				r, err := http.NewRequest("POST", url, bytes.NewReader(corpo))
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+
+				r.Header.Set("Authorization", "Bearer "+token)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusBadRequest,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagemComValor(protocolo.MensagemCódigoArmaNãoCadastrada, "XZ999999"),
+				)
+
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar uma frequência com um calibre desconhecido",
+			requisição: func() *http.Request {
+				frequênciaPedido := protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           "calibre .999",
+					ArmaUtilizada:     "arma do clube",
+					QuantidadeMunição: 50,
+					DataInício:        time.Now().Add(-30 * time.Minute),
+					DataTérmino:       time.Now().Add(-10 * time.Minute),
+				}
+
+				corpo, err := json.Marshal(frequênciaPedido)
+				if err != nil {
+					t.Fatalf("Erro ao gerar os dados da requisição. Detalhes: %s", err)
+				}
+
+				url := fmt.Sprintf("http://%s/frequencia/380308", endereçoServidor)
+				r, err := http.NewRequest("POST", url, bytes.NewReader(corpo))
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+
+				r.Header.Set("Authorization", "Bearer "+token)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusBadRequest,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagemComValor(protocolo.MensagemCódigoCalibreDesconhecido, "CALIBRE .999"),
+				)
+
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar uma requisição sem autenticação",
+			requisição: func() *http.Request {
+				frequênciaPedido := protocolo.FrequênciaPedido{
+					Clube:             1,
+					Calibre:           ".380",
+					ArmaUtilizada:     "arma do clube",
+					QuantidadeMunição: 50,
+					DataInício:        time.Now().Add(-30 * time.Minute),
+					DataTérmino:       time.Now().Add(-10 * time.Minute),
+				}
+
+				corpo, err := json.Marshal(frequênciaPedido)
+				if err != nil {
+					t.Fatalf("Erro ao gerar os dados da requisição. Detalhes: %s", err)
+				}
+
+				url := fmt.Sprintf("http://%s/frequencia/380308", endereçoServidor)
+				r, err := http.NewRequest("POST", url, bytes.NewReader(corpo))
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusUnauthorized,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type":     []string{"application/json; charset=utf-8"},
+					"Www-Authenticate": []string{"Bearer"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagem(protocolo.MensagemCódigoAutenticaçãoNecessária),
+				)
+
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -379,6 +574,265 @@ func TestObterFrequência(t *testing.T) {
 	}
 }
 
+func TestListagemDeFrequências(t *testing.T) {
+	tokenAdministrador := autenticar(t, "admin", "admin123")
+	tokenClube := autenticar(t, "operador", "clube123")
+	tokenAtirador := autenticar(t, "joao", "atirador123")
+
+	cenários := []struct {
+		descrição          string
+		requisição         *http.Request
+		códigoHTTPEsperado int
+		cabeçalhoEsperado  func(corpo []byte) (http.Header, error)
+		corpoEsperado      func(corpo []byte) ([]byte, error)
+	}{
+		{
+			descrição: "deve listar corretamente as frequências",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/frequencia?cr=380308&ordenacao=dataCriacao&limite=10", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenAdministrador)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusOK,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				var frequênciaListaResposta protocolo.FrequênciaListaResposta
+				if err := json.Unmarshal(corpo, &frequênciaListaResposta); err != nil {
+					return nil, errors.Errorf("Erro ao interpretar o corpo da resposta. Detalhes: %s", err)
+				}
+
+				if len(frequênciaListaResposta.Frequências) == 0 {
+					return nil, errors.Errorf("Nenhuma frequência retornada na listagem")
+				}
+
+				for _, frequência := range frequênciaListaResposta.Frequências {
+					if frequência.CR != 380308 {
+						return nil, errors.Errorf("Frequência com CR inesperado na listagem: %d", frequência.CR)
+					}
+				}
+
+				corpoEsperado, err := json.Marshal(frequênciaListaResposta)
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve detectar uma ordenação inválida",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/frequencia?ordenacao=calibre", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenAdministrador)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusBadRequest,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				mensagens := protocolo.NovasMensagens(
+					protocolo.NovaMensagemComValor(protocolo.MensagemCódigoOrdenaçãoInválida, "calibre"),
+				)
+
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar a listagem para um usuário de clube",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/frequencia", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenClube)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusForbidden,
+		},
+		{
+			descrição: "deve listar corretamente as frequências do próprio atirador",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/atirador/380308/frequencias?limite=10", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenAtirador)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusOK,
+			cabeçalhoEsperado: func(corpo []byte) (http.Header, error) {
+				return http.Header{
+					"Content-Type": []string{"application/json; charset=utf-8"},
+				}, nil
+			},
+			corpoEsperado: func(corpo []byte) ([]byte, error) {
+				var frequênciaListaResposta protocolo.FrequênciaListaResposta
+				if err := json.Unmarshal(corpo, &frequênciaListaResposta); err != nil {
+					return nil, errors.Errorf("Erro ao interpretar o corpo da resposta. Detalhes: %s", err)
+				}
+
+				if len(frequênciaListaResposta.Frequências) == 0 {
+					return nil, errors.Errorf("Nenhuma frequência retornada na listagem")
+				}
+
+				for _, frequência := range frequênciaListaResposta.Frequências {
+					if frequência.CR != 380308 {
+						return nil, errors.Errorf("Frequência com CR inesperado na listagem: %d", frequência.CR)
+					}
+				}
+
+				corpoEsperado, err := json.Marshal(frequênciaListaResposta)
+				if err != nil {
+					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
+				}
+
+				return bytes.TrimSpace(corpoEsperado), nil
+			},
+		},
+		{
+			descrição: "deve recusar a listagem das frequências de outro atirador",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/atirador/923714/frequencias", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenAtirador)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusForbidden,
+		},
+		{
+			descrição: "deve recusar a listagem administrativa para um atirador",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/frequencia", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+				r.Header.Set("Authorization", "Bearer "+tokenAtirador)
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusForbidden,
+		},
+		{
+			descrição: "deve exigir autenticação para listar as frequências",
+			requisição: func() *http.Request {
+				url := fmt.Sprintf("http://%s/frequencia", endereçoServidor)
+				r, err := http.NewRequest("GET", url, nil)
+				if err != nil {
+					t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+				}
+
+				return r
+			}(),
+			códigoHTTPEsperado: http.StatusUnauthorized,
+		},
+	}
+
+	for _, cenário := range cenários {
+		t.Run(cenário.descrição, func(t *testing.T) {
+			var cliente http.Client
+
+			resposta, err := cliente.Do(cenário.requisição)
+			if err != nil {
+				t.Fatalf("Erro inesperado ao enviar a requisição. Detalhes: %s", err)
+			}
+			defer resposta.Body.Close()
+
+			corpo, err := ioutil.ReadAll(resposta.Body)
+			if err != nil {
+				t.Fatalf("Erro inesperado ao ler o corpo da resposta. Detalhes: %s", err)
+			}
+
+			var verificadorResultado testes.VerificadorResultados
+
+			verificadorResultado.DefinirEsperado(cenário.códigoHTTPEsperado, nil)
+			if err = verificadorResultado.VerificaResultado(resposta.StatusCode, nil); err != nil {
+				t.Error(err)
+			}
+
+			if cenário.cabeçalhoEsperado != nil {
+				cabeçalhoEsperado, err := cenário.cabeçalhoEsperado(corpo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// copia campos variáveis da resposta definitiva para não causar
+				// problemas. Infelizmente não temos como prever os valores destes
+				// campos na resposta esperada.
+
+				if data := resposta.Header.Get("Date"); data != "" {
+					cabeçalhoEsperado.Set("Date", data)
+				}
+				if tamanhoConteúdo := resposta.Header.Get("Content-Length"); tamanhoConteúdo != "" {
+					cabeçalhoEsperado.Set("Content-Length", tamanhoConteúdo)
+				}
+
+				// quando o Content-Type não esta definido, assume-se text/plain. Com
+				// exceção de quando o código HTTP é 24 (NoContent).
+				if tipoConteúdo := cabeçalhoEsperado.Get("Content-Type"); tipoConteúdo == "" && resposta.StatusCode != http.StatusNoContent {
+					cabeçalhoEsperado.Set("Content-Type", "text/plain; charset=utf-8")
+				}
+
+				verificadorResultado.DefinirEsperado(cabeçalhoEsperado, nil)
+				if err = verificadorResultado.VerificaResultado(resposta.Header, nil); err != nil {
+					t.Error(err)
+				}
+			}
+
+			if cenário.corpoEsperado != nil {
+				corpoEsperado, err := cenário.corpoEsperado(corpo)
+				if err != nil {
+					t.Fatal(err)
+				}
+				corpo = bytes.TrimSpace(corpo)
+
+				if corpoEsperado == nil && corpo != nil {
+					t.Errorf("Corpo inesperado na resposta.\n%s", string(corpo))
+
+				} else if corpoEsperado != nil && corpo == nil {
+					t.Error("Corpo inexistente na resposta")
+
+				} else {
+					verificadorResultado.DefinirEsperado(string(corpoEsperado), nil)
+					if err = verificadorResultado.VerificaResultado(string(corpo), nil); err != nil {
+						t.Error(err)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestConfirmaçãoDeFrequência(t *testing.T) {
 	cenários := []struct {
 		descrição          string
@@ -474,7 +928,7 @@ func TestConfirmaçãoDeFrequência(t *testing.T) {
 					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImagemBase64Inválido, "imagem", "@@@"),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -513,7 +967,7 @@ func TestConfirmaçãoDeFrequência(t *testing.T) {
 					protocolo.NovaMensagemComCampo(protocolo.MensagemCódigoImagemFormatoInválido, "imagem", "aXNzbyDDqSB1bSB0ZXN0ZQo="),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -552,7 +1006,7 @@ func TestConfirmaçãoDeFrequência(t *testing.T) {
 					protocolo.NovaMensagem(protocolo.MensagemCódigoPrazoConfirmaçãoExpirado),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -591,7 +1045,7 @@ func TestConfirmaçãoDeFrequência(t *testing.T) {
 					protocolo.NovaMensagem(protocolo.MensagemCódigoFrequênciaJáConfirmada),
 				)
 
-				corpoEsperado, err := json.Marshal(mensagens)
+				corpoEsperado, err := json.Marshal(mensagens.Traduzir(protocolo.IdiomaPadrão))
 				if err != nil {
 					return nil, errors.Errorf("Erro ao gerar os dados da resposta. Detalhes: %s", err)
 				}
@@ -676,6 +1130,83 @@ func TestConfirmaçãoDeFrequência(t *testing.T) {
 	}
 }
 
+func TestRelatórioHabitualidade(t *testing.T) {
+	token := autenticar(t, "admin", "admin123")
+
+	requisitar := func(endereço string) (*http.Response, []byte) {
+		r, err := http.NewRequest("GET", fmt.Sprintf("http://%s%s", endereçoServidor, endereço), nil)
+		if err != nil {
+			t.Fatalf("Erro ao gerar a requisição. Detalhes: %s", err)
+		}
+		r.Header.Set("Authorization", "Bearer "+token)
+
+		var cliente http.Client
+		resposta, err := cliente.Do(r)
+		if err != nil {
+			t.Fatalf("Erro inesperado ao enviar a requisição. Detalhes: %s", err)
+		}
+		defer resposta.Body.Close()
+
+		corpo, err := ioutil.ReadAll(resposta.Body)
+		if err != nil {
+			t.Fatalf("Erro inesperado ao ler o corpo da resposta. Detalhes: %s", err)
+		}
+
+		return resposta, corpo
+	}
+
+	t.Run("deve gerar corretamente o relatório de habitualidade", func(t *testing.T) {
+		resposta, corpo := requisitar("/relatorio/habitualidade?nivel=1")
+		if resposta.StatusCode != http.StatusOK {
+			t.Fatalf("Código HTTP inesperado: %d\n%s", resposta.StatusCode, string(corpo))
+		}
+
+		var habitualidadeResposta protocolo.HabitualidadeResposta
+		if err := json.Unmarshal(corpo, &habitualidadeResposta); err != nil {
+			t.Fatalf("Erro ao interpretar o corpo da resposta. Detalhes: %s", err)
+		}
+
+		if habitualidadeResposta.TreinosExigidos != 8 {
+			t.Errorf("Quantidade de treinos exigidos inesperada: %d", habitualidadeResposta.TreinosExigidos)
+		}
+
+		encontrados := make(map[int]int)
+		for _, atirador := range habitualidadeResposta.Atiradores {
+			encontrados[atirador.CR] = atirador.Treinos
+		}
+
+		if _, ok := encontrados[380308]; !ok {
+			t.Errorf("Atirador com habitualidade insuficiente não encontrado no relatório.\n%s", string(corpo))
+		}
+
+		if treinos, ok := encontrados[380310]; !ok || treinos != 0 {
+			t.Errorf("Atirador sem frequências não encontrado no relatório.\n%s", string(corpo))
+		}
+	})
+
+	t.Run("deve gerar corretamente o relatório de habitualidade em CSV", func(t *testing.T) {
+		resposta, corpo := requisitar("/relatorio/habitualidade.csv?nivel=1")
+		if resposta.StatusCode != http.StatusOK {
+			t.Fatalf("Código HTTP inesperado: %d\n%s", resposta.StatusCode, string(corpo))
+		}
+
+		if tipoConteúdo := resposta.Header.Get("Content-Type"); tipoConteúdo != "text/csv; charset=utf-8" {
+			t.Errorf("Tipo de conteúdo inesperado: %s", tipoConteúdo)
+		}
+
+		if !strings.HasPrefix(string(corpo), "cr,treinos,treinosExigidos\n") {
+			t.Errorf("Cabeçalho do CSV inesperado.\n%s", string(corpo))
+		}
+	})
+
+	t.Run("deve detectar um nível sem quantidade mínima de treinos configurada", func(t *testing.T) {
+		resposta, corpo := requisitar("/relatorio/habitualidade?nivel=9")
+		if resposta.StatusCode != http.StatusBadRequest {
+			t.Errorf("Código HTTP inesperado: %d\n%s", resposta.StatusCode, string(corpo))
+		}
+	})
+}
+
 func TestMain(m *testing.M) {
 	flag.Parse()
 
@@ -696,6 +1227,36 @@ func TestMain(m *testing.M) {
 	código = m.Run()
 }
 
+// autenticar obtém um token de acesso para o usuário informado, interrompendo
+// o teste caso não seja possível autenticar.
+func autenticar(t *testing.T, usuário, senha string) string {
+	corpo, err := json.Marshal(protocolo.LoginPedido{
+		Usuário: usuário,
+		Senha:   senha,
+	})
+	if err != nil {
+		t.Fatalf("Erro ao gerar os dados da autenticação. Detalhes: %s", err)
+	}
+
+	url := fmt.Sprintf("http://%s/login", endereçoServidor)
+	resposta, err := http.Post(url, "application/json", bytes.NewReader(corpo))
+	if err != nil {
+		t.Fatalf("Erro inesperado ao enviar a autenticação. Detalhes: %s", err)
+	}
+	defer resposta.Body.Close()
+
+	if resposta.StatusCode != http.StatusOK {
+		t.Fatalf("Código HTTP inesperado na autenticação: %d", resposta.StatusCode)
+	}
+
+	var loginResposta protocolo.LoginResposta
+	if err := json.NewDecoder(resposta.Body).Decode(&loginResposta); err != nil {
+		t.Fatalf("Erro ao interpretar a resposta da autenticação. Detalhes: %s", err)
+	}
+
+	return loginResposta.Token
+}
+
 func gerarCódigoVerificação(f protocolo.FrequênciaPendenteResposta, cr int, chave string) string {
 	buffer := new(bytes.Buffer)
 
@@ -1,8 +1,133 @@
-\set frequencia_atirador_campos 'id, controle, cr, calibre, arma_utilizada, numero_serie, guia_de_trafego, quantidade_municao, data_inicio, data_termino, data_criacao, data_atualizacao, data_confirmacao, imagem_numero_controle, imagem_confirmacao, revisao'
-\set frequencia_atirador_log_campos 'id_log, acao, id_frequencia_atirador, controle, cr, calibre, arma_utilizada, numero_serie, guia_de_trafego, quantidade_municao, data_inicio, data_termino, data_criacao, data_atualizacao, data_confirmacao, imagem_numero_controle, imagem_confirmacao, revisao'
-\set frq_campos 'frq.id, frq.controle, frq.cr, frq.calibre, frq.arma_utilizada, frq.numero_serie, frq.guia_de_trafego, frq.quantidade_municao, frq.data_inicio, frq.data_termino, frq.data_criacao, frq.data_atualizacao, frq.data_confirmacao, frq.imagem_numero_controle, frq.imagem_confirmacao, revisao'
+\set frequencia_atirador_campos 'id, controle, id_clube, cr, calibre, arma_utilizada, numero_serie, guia_de_trafego, quantidade_municao, data_inicio, data_termino, data_criacao, data_atualizacao, data_confirmacao, imagem_numero_controle, imagem_confirmacao, revisao'
+\set frequencia_atirador_log_campos 'id_log, acao, id_frequencia_atirador, controle, id_clube, cr, calibre, arma_utilizada, numero_serie, guia_de_trafego, quantidade_municao, data_inicio, data_termino, data_criacao, data_atualizacao, data_confirmacao, imagem_numero_controle, imagem_confirmacao, revisao'
+\set frq_campos 'frq.id, frq.controle, frq.id_clube, frq.cr, frq.calibre, frq.arma_utilizada, frq.numero_serie, frq.guia_de_trafego, frq.quantidade_municao, frq.data_inicio, frq.data_termino, frq.data_criacao, frq.data_atualizacao, frq.data_confirmacao, frq.imagem_numero_controle, frq.imagem_confirmacao, revisao'
+\set clube_campos 'id, cnpj, cr, nome, endereco, cidade, uf, regiao_militar, situacao, data_criacao, data_atualizacao, revisao'
+\set clube_log_campos 'id_log, acao, id_clube, cnpj, cr, nome, endereco, cidade, uf, regiao_militar, situacao, data_criacao, data_atualizacao, revisao'
+\set clb_campos 'clb.id, clb.cnpj, clb.cr, clb.nome, clb.endereco, clb.cidade, clb.uf, clb.regiao_militar, clb.situacao, clb.data_criacao, clb.data_atualizacao, clb.revisao'
+\set atirador_campos 'id, cr, nome, cpf, data_emissao, data_validade, situacao, data_criacao, data_atualizacao, revisao'
+\set atirador_log_campos 'id_log, acao, id_atirador, cr, nome, cpf, data_emissao, data_validade, situacao, data_criacao, data_atualizacao, revisao'
+\set atr_campos 'atr.id, atr.cr, atr.nome, atr.cpf, atr.data_emissao, atr.data_validade, atr.situacao, atr.data_criacao, atr.data_atualizacao, atr.revisao'
+\set arma_campos 'id, numero_serie, modelo, calibre, cr, id_clube, tipo_registro, data_criacao, data_atualizacao, revisao'
+\set arma_log_campos 'id_log, acao, id_arma, numero_serie, modelo, calibre, cr, id_clube, tipo_registro, data_criacao, data_atualizacao, revisao'
+\set arm_campos 'arm.id, arm.numero_serie, arm.modelo, arm.calibre, arm.cr, arm.id_clube, arm.tipo_registro, arm.data_criacao, arm.data_atualizacao, arm.revisao'
+\set usuario_campos 'id, usuario, nome, senha, papel, id_clube, cr, data_criacao, data_atualizacao, revisao'
 \set log_campos 'id, data_criacao, endereco_remoto'
 
+--
+-- Clube de Tiro ativo
+--
+
+WITH
+
+clb AS (
+  INSERT INTO clube (:clube_campos)
+  VALUES (DEFAULT, '11222333000181', 123456, 'Clube de Tiro Centro', 'Rua das Flores, 123',
+  'Rio de Janeiro', 'RJ', 1, 'ativo',
+  NOW() - interval '30 days', -- data criação
+  NULL, -- data atualização
+  0) RETURNING *
+),
+
+idLog AS (
+  INSERT INTO log (:log_campos)
+  VALUES (DEFAULT, NOW() - interval '30 days', '198.51.100.1')
+  RETURNING id
+)
+
+INSERT INTO clube_log (:clube_log_campos)
+SELECT idLog.id, 'CRIACAO', :clb_campos
+FROM idLog, clb;
+
+--
+-- Atirador com CR ativo
+--
+
+WITH
+
+atr AS (
+  INSERT INTO atirador (:atirador_campos)
+  VALUES (DEFAULT, 380308, 'João da Silva', '52998224725',
+  NOW() - interval '1 year', -- data emissão
+  NOW() + interval '2 years', -- data validade
+  'ativo',
+  NOW() - interval '30 days', -- data criação
+  NULL, -- data atualização
+  0) RETURNING *
+),
+
+idLog AS (
+  INSERT INTO log (:log_campos)
+  VALUES (DEFAULT, NOW() - interval '30 days', '198.51.100.1')
+  RETURNING id
+)
+
+INSERT INTO atirador_log (:atirador_log_campos)
+SELECT idLog.id, 'CRIACAO', :atr_campos
+FROM idLog, atr;
+
+--
+-- Atirador com CR ativo e sem frequências
+--
+
+WITH
+
+atr AS (
+  INSERT INTO atirador (:atirador_campos)
+  VALUES (DEFAULT, 380310, 'Maria de Souza', '11144477735',
+  NOW() - interval '1 year', -- data emissão
+  NOW() + interval '2 years', -- data validade
+  'ativo',
+  NOW() - interval '30 days', -- data criação
+  NULL, -- data atualização
+  0) RETURNING *
+),
+
+idLog AS (
+  INSERT INTO log (:log_campos)
+  VALUES (DEFAULT, NOW() - interval '30 days', '198.51.100.1')
+  RETURNING id
+)
+
+INSERT INTO atirador_log (:atirador_log_campos)
+SELECT idLog.id, 'CRIACAO', :atr_campos
+FROM idLog, atr;
+
+--
+-- Arma do acervo do Clube de Tiro
+--
+
+WITH
+
+arm AS (
+  INSERT INTO arma (:arma_campos)
+  VALUES (DEFAULT, 'ZA785671', 'TAURUS PT 938', '.380 ACP', NULL, 1, 'sigma',
+  NOW() - interval '30 days', -- data criação
+  NULL, -- data atualização
+  0) RETURNING *
+),
+
+idLog AS (
+  INSERT INTO log (:log_campos)
+  VALUES (DEFAULT, NOW() - interval '30 days', '198.51.100.1')
+  RETURNING id
+)
+
+INSERT INTO arma_log (:arma_log_campos)
+SELECT idLog.id, 'CRIACAO', :arm_campos
+FROM idLog, arm;
+
+--
+-- Usuários (senhas "admin123", "clube123" e "atirador123")
+--
+
+INSERT INTO usuario (:usuario_campos) VALUES
+(DEFAULT, 'admin', 'Administrador', '$2a$10$CPRxbE/XhJsbwEJWBU2B3epoQUTZTXI19NxQrPWpELUsZWJ8vGtXa',
+'administrador', NULL, NULL, NOW() - interval '30 days', NULL, 0),
+(DEFAULT, 'operador', 'Operador do Clube de Tiro Centro', '$2a$10$8O6RWur0AIuNcPGMJw.csuqio7nkurFSQd7yEU.VD9YU4w/Bzz13a',
+'clube', 1, NULL, NOW() - interval '30 days', NULL, 0),
+(DEFAULT, 'joao', 'João da Silva', '$2a$10$RH2I6ws.1k5tpYkIbTPcJuqBbPIw02SgHk/DSZ1X4uygdOlPwgGwm',
+'atirador', NULL, 380308, NOW() - interval '30 days', NULL, 0);
+
 --
 -- Frequência sem confirmação
 --
@@ -11,7 +136,7 @@ WITH
 
 frq AS (
   INSERT INTO frequencia_atirador (:frequencia_atirador_campos)
-  VALUES (DEFAULT, 1234, 380308, '.380', 'Arma do Clube', 'HG72643653', 762556223, 100,
+  VALUES (DEFAULT, 1234, 1, 380308, '.380 ACP', 'Arma do Clube', 'HG72643653', 762556223, 100,
   NOW() - interval '2 hour', -- data inicio
   NOW() - interval '30 minutes', -- data término
   NOW() - interval '29 minutes', -- data criação
@@ -39,7 +164,7 @@ WITH
 
 frq AS (
   INSERT INTO frequencia_atirador (:frequencia_atirador_campos)
-  VALUES (DEFAULT, 7344, 923714, '.45', 'Imbel 1911', 'SF9153921', 839201286, 150,
+  VALUES (DEFAULT, 7344, 1, 923714, '.45 ACP', 'Imbel 1911', 'SF9153921', 839201286, 150,
   NOW() - interval '2 hour', -- data inicio
   NOW() - interval '40 minutes', -- data término
   NOW() - interval '31 minutes', -- data criação
@@ -67,7 +192,7 @@ WITH
 
 idFrq AS (
   INSERT INTO frequencia_atirador (:frequencia_atirador_campos)
-  VALUES (DEFAULT, 1246, 114239, '.40', 'Imbel MD2', 'DL28461184', 102483466, 50,
+  VALUES (DEFAULT, 1246, 1, 114239, '.40 S&W', 'Imbel MD2', 'DL28461184', 102483466, 50,
   NOW() - interval '5 hours', -- data inicio
   NOW() - interval '4 hours' - interval '30 minutes', -- data término
   NOW() - interval '10 minutes', -- data criação
@@ -92,7 +217,7 @@ idLog2 AS (
 
 INSERT INTO frequencia_atirador_log (:frequencia_atirador_log_campos) VALUES
 
-((SELECT id FROM idLog1), 'CRIACAO', (SELECT id FROM idFrq), 1246, 114239, '.40',
+((SELECT id FROM idLog1), 'CRIACAO', (SELECT id FROM idFrq), 1246, 1, 114239, '.40 S&W',
 'Imbel MD2', 'DL28461184', 102483466, 50,
 NOW() - interval '5 hours', -- data inicio
 NOW() - interval '4 hours' - interval '30 minutes', -- data término
@@ -102,7 +227,7 @@ NULL, -- data confirmação
 'iVBORw0KGgoAAAANSUhEUgAAAAoAAAAKCAYAAACNMs+9AAAAUklEQVR4XqWQ0QmAMAxEL8FhdIp2Hvep87hFxzm5D6GE0ip9kI/A5XHESCJfBzHgPqtZKjvxAe9cawbBCVtrEmFX///G9zKaFjtGc8T1TExQ5gHN9xsWe3/FugAAAABJRU5ErkJggg==',
 NULL, 0),
 
-((SELECT id FROM idLog2), 'ATUALIZACAO', (SELECT id FROM idFrq), 1246, 114239, '.40',
+((SELECT id FROM idLog2), 'ATUALIZACAO', (SELECT id FROM idFrq), 1246, 1, 114239, '.40 S&W',
 'Imbel MD2', 'DL28461184', 102483466, 50,
 NOW() - interval '5 hours', -- data inicio
 NOW() - interval '4 hours' - interval '30 minutes', -- data término
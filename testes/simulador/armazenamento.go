@@ -0,0 +1,19 @@
+package simulador
+
+// Armazenamento simula um repositório de objetos, permitindo testar as camadas
+// que gravam imagens sem depender do sistema de arquivos ou de um serviço
+// externo.
+type Armazenamento struct {
+	SimulaArmazenar func(conteúdo []byte) (string, error)
+	SimulaObter     func(referência string) ([]byte, error)
+}
+
+// Armazenar grava o conteúdo no repositório, retornando a sua referência.
+func (a Armazenamento) Armazenar(conteúdo []byte) (string, error) {
+	return a.SimulaArmazenar(conteúdo)
+}
+
+// Obter retorna o conteúdo associado a referência.
+func (a Armazenamento) Obter(referência string) ([]byte, error) {
+	return a.SimulaObter(referência)
+}
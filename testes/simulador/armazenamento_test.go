@@ -0,0 +1,50 @@
+package simulador_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/atiradorfrequente/testes/simulador"
+)
+
+func TestArmazenamento(t *testing.T) {
+	var armazenamentoSimulado simulador.Armazenamento
+	var métodosSimulados []string
+
+	estruturaArmazenamentoSimulado := reflect.TypeOf(armazenamentoSimulado)
+	for i := 0; i < estruturaArmazenamentoSimulado.NumField(); i++ {
+		// trata somente funções como argumentos, ignorando atributos simples
+		if !strings.HasPrefix(estruturaArmazenamentoSimulado.Field(i).Type.String(), "func (") {
+			continue
+		}
+
+		métodosSimulados = append(métodosSimulados, estruturaArmazenamentoSimulado.Field(i).Name)
+	}
+
+	visitou := func(métodoSimulado string) {
+		for i := len(métodosSimulados) - 1; i >= 0; i-- {
+			if métodosSimulados[i] == métodoSimulado {
+				métodosSimulados = append(métodosSimulados[:i], métodosSimulados[i+1:]...)
+				break
+			}
+		}
+	}
+
+	armazenamentoSimulado.SimulaArmazenar = func(conteúdo []byte) (string, error) {
+		visitou("SimulaArmazenar")
+		return "", nil
+	}
+
+	armazenamentoSimulado.SimulaObter = func(referência string) ([]byte, error) {
+		visitou("SimulaObter")
+		return nil, nil
+	}
+
+	armazenamentoSimulado.Armazenar(nil)
+	armazenamentoSimulado.Obter("")
+
+	if len(métodosSimulados) > 0 {
+		t.Errorf("métodos %#v não foram chamados", métodosSimulados)
+	}
+}
@@ -5,9 +5,33 @@ import "github.com/rafaeljusto/atiradorfrequente/núcleo/protocolo"
 // ServiçoAtirador simula o serviço que representa um Atirador. Muito útil para
 // simular as camadas de serviços em testes unitários.
 type ServiçoAtirador struct {
-	SimulaCadastrarFrequência func(protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error)
-	SimulaObterFrequência     func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error)
-	SimulaConfirmarFrequência func(protocolo.FrequênciaConfirmaçãoPedidoCompleta) error
+	SimulaCadastrarFrequência   func(protocolo.FrequênciaPedidoCompleta) (protocolo.FrequênciaPendenteResposta, error)
+	SimulaObterFrequência       func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error)
+	SimulaObterResumoFrequência func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error)
+	SimulaPDFNúmeroControle     func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error)
+	SimulaConfirmarFrequência   func(protocolo.FrequênciaConfirmaçãoPedidoCompleta) error
+	SimulaCancelarFrequência    func(protocolo.FrequênciaCancelamentoPedidoCompleta) error
+	SimulaExpirarFrequências    func(limite int) (int, error)
+	SimulaMigrarImagens         func(limite int) (int, error)
+	SimulaListarFrequências     func(protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error)
+
+	SimulaListarFrequênciasAtirador func(cr int, filtro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error)
+
+	SimulaRelatórioHabitualidade func(protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error)
+	SimulaConsumoMunição         func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error)
+
+	SimulaPendênciasChavesAposentadas func() ([]protocolo.FrequênciaPendênciaChave, error)
+
+	SimulaSortearAmostraAuditoria func(protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error)
+	SimulaObterAmostraAuditoria   func(id int64) (protocolo.AmostraAuditoriaResposta, error)
+	SimulaObterAuditoria          func(id int64) (protocolo.AuditoriaResposta, error)
+	SimulaRegistrarVeredito       func(protocolo.AuditoriaVereditoPedidoCompleta) error
+
+	SimulaCadastrarAtirador  func(protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error)
+	SimulaObterAtirador      func(cr int) (protocolo.AtiradorResposta, error)
+	SimulaAtualizarAtirador  func(protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error)
+	SimulaRemoverAtirador    func(cr int) error
+	SimulaImportarAtiradores func(protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error)
 }
 
 // CadastrarFrequência persiste em banco de dados as informações básicas
@@ -25,8 +49,224 @@ func (s ServiçoAtirador) ObterFrequência(cr int, númeroControle protocolo.Nú
 	return s.SimulaObterFrequência(cr, númeroControle, códigoVerificação)
 }
 
+// ObterResumoFrequência retorna os dados do treino da frequência, sem as
+// imagens, com as mesmas validações da consulta.
+func (s ServiçoAtirador) ObterResumoFrequência(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+	return s.SimulaObterResumoFrequência(cr, númeroControle, códigoVerificação)
+}
+
+// PDFNúmeroControle gera a folha do número de controle em PDF no tamanho A4,
+// para impressão pelo clube.
+func (s ServiçoAtirador) PDFNúmeroControle(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+	return s.SimulaPDFNúmeroControle(cr, númeroControle, códigoVerificação)
+}
+
 // ConfirmarFrequência finaliza o cadastro da frequência, confirmando atraves de
 // uma imagem que o Atirador esta presente no Clube de Tiro.
 func (s ServiçoAtirador) ConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta protocolo.FrequênciaConfirmaçãoPedidoCompleta) error {
 	return s.SimulaConfirmarFrequência(frequênciaConfirmaçãoPedidoCompleta)
 }
+
+// CancelarFrequência desfaz uma frequência registrada por engano. Somente o
+// clube que reportou a frequência pode cancelá-la, desde que ainda não tenha
+// sido confirmada e dentro do prazo de cancelamento.
+func (s ServiçoAtirador) CancelarFrequência(frequênciaCancelamentoPedidoCompleta protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+	return s.SimulaCancelarFrequência(frequênciaCancelamentoPedidoCompleta)
+}
+
+// ExpirarFrequências marca como expiradas as frequências pendentes cujo prazo
+// de confirmação já passou, retornando a quantidade de frequências expiradas.
+func (s ServiçoAtirador) ExpirarFrequências(limite int) (int, error) {
+	return s.SimulaExpirarFrequências(limite)
+}
+
+// MigrarImagens move para o repositório de objetos as imagens que ainda estão
+// armazenadas em base64 no banco de dados, retornando a quantidade de registros
+// migrados.
+func (s ServiçoAtirador) MigrarImagens(limite int) (int, error) {
+	return s.SimulaMigrarImagens(limite)
+}
+
+// ListarFrequências retorna uma página das frequências que atendem ao filtro,
+// sem as imagens. Quando existirem mais frequências, a resposta contém o cursor
+// para obter a próxima página.
+func (s ServiçoAtirador) ListarFrequências(frequênciaFiltro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+	return s.SimulaListarFrequências(frequênciaFiltro)
+}
+
+// ListarFrequênciasAtirador retorna uma página das frequências registradas no
+// CR informado, permitindo que o próprio atirador identifique registros feitos
+// em seu nome.
+func (s ServiçoAtirador) ListarFrequênciasAtirador(cr int, frequênciaFiltro protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+	return s.SimulaListarFrequênciasAtirador(cr, frequênciaFiltro)
+}
+
+// RelatórioHabitualidade identifica os atiradores que não atingiram a
+// quantidade mínima de treinos confirmados no período, conforme o nível de
+// atividade informado no filtro.
+func (s ServiçoAtirador) RelatórioHabitualidade(habitualidadeFiltro protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+	return s.SimulaRelatórioHabitualidade(habitualidadeFiltro)
+}
+
+// ConsumoMunição retorna a quantidade de munição consumida pelo atirador em
+// cada calibre durante o ano civil informado, junto com a cota anual de cada
+// calibre, permitindo que o clube verifique o saldo antes do treino.
+func (s ServiçoAtirador) ConsumoMunição(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+	return s.SimulaConsumoMunição(cr, ano)
+}
+
+// PendênciasChavesAposentadas retorna a quantidade de frequências ainda
+// pendentes cujo código de verificação foi gerado com uma chave diferente da
+// chave ativa.
+func (s ServiçoAtirador) PendênciasChavesAposentadas() ([]protocolo.FrequênciaPendênciaChave, error) {
+	return s.SimulaPendênciasChavesAposentadas()
+}
+
+// SortearAmostraAuditoria sorteia as frequências confirmadas de cada clube no
+// período que serão auditadas, conforme o percentual ou a quantidade por clube
+// informados.
+func (s ServiçoAtirador) SortearAmostraAuditoria(amostraAuditoriaPedido protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+	return s.SimulaSortearAmostraAuditoria(amostraAuditoriaPedido)
+}
+
+// ObterAmostraAuditoria retorna os critérios de uma amostra de auditoria junto
+// com a lista das auditorias geradas no sorteio.
+func (s ServiçoAtirador) ObterAmostraAuditoria(id int64) (protocolo.AmostraAuditoriaResposta, error) {
+	return s.SimulaObterAmostraAuditoria(id)
+}
+
+// ObterAuditoria retorna uma auditoria com os dados da frequência sorteada,
+// incluindo as imagens para comparação.
+func (s ServiçoAtirador) ObterAuditoria(id int64) (protocolo.AuditoriaResposta, error) {
+	return s.SimulaObterAuditoria(id)
+}
+
+// RegistrarVeredito armazena a análise do auditor, invalidando a frequência
+// quando o veredito for fraudulenta.
+func (s ServiçoAtirador) RegistrarVeredito(auditoriaVereditoPedidoCompleta protocolo.AuditoriaVereditoPedidoCompleta) error {
+	return s.SimulaRegistrarVeredito(auditoriaVereditoPedidoCompleta)
+}
+
+// CadastrarAtirador persiste em banco de dados um novo Atirador. Não é
+// permitido cadastrar dois atiradores com o mesmo CR.
+func (s ServiçoAtirador) CadastrarAtirador(atiradorPedido protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+	return s.SimulaCadastrarAtirador(atiradorPedido)
+}
+
+// ObterAtirador retorna os dados do Atirador a partir do seu CR.
+func (s ServiçoAtirador) ObterAtirador(cr int) (protocolo.AtiradorResposta, error) {
+	return s.SimulaObterAtirador(cr)
+}
+
+// AtualizarAtirador substitui os dados do Atirador pelos dados informados. É
+// através desta ação que o CR de um atirador pode ser suspenso ou cancelado.
+func (s ServiçoAtirador) AtualizarAtirador(atiradorPedidoCompleto protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+	return s.SimulaAtualizarAtirador(atiradorPedidoCompleto)
+}
+
+// RemoverAtirador apaga o Atirador da base de dados. As frequências já
+// registradas para o CR são mantidas.
+func (s ServiçoAtirador) RemoverAtirador(cr int) error {
+	return s.SimulaRemoverAtirador(cr)
+}
+
+// ImportarAtiradores cadastra ou atualiza um lote de atiradores, utilizando o
+// CR para identificar os atiradores já existentes.
+func (s ServiçoAtirador) ImportarAtiradores(atiradorImportaçãoPedido protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error) {
+	return s.SimulaImportarAtiradores(atiradorImportaçãoPedido)
+}
+
+// ServiçoClube simula o serviço que representa um Clube de Tiro. Muito útil
+// para simular as camadas de serviços em testes unitários.
+type ServiçoClube struct {
+	SimulaCadastrarClube func(protocolo.ClubePedido) (protocolo.ClubeResposta, error)
+	SimulaObterClube     func(id int64) (protocolo.ClubeResposta, error)
+	SimulaAtualizarClube func(protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error)
+}
+
+// CadastrarClube persiste em banco de dados um novo Clube de Tiro. Não é
+// permitido cadastrar dois clubes com o mesmo CNPJ.
+func (s ServiçoClube) CadastrarClube(clubePedido protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+	return s.SimulaCadastrarClube(clubePedido)
+}
+
+// ObterClube retorna os dados do Clube de Tiro a partir do seu número de
+// identificação.
+func (s ServiçoClube) ObterClube(id int64) (protocolo.ClubeResposta, error) {
+	return s.SimulaObterClube(id)
+}
+
+// AtualizarClube substitui os dados do Clube de Tiro pelos dados informados. É
+// através desta ação que um clube pode ser desativado.
+func (s ServiçoClube) AtualizarClube(clubePedidoCompleto protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+	return s.SimulaAtualizarClube(clubePedidoCompleto)
+}
+
+// ServiçoArma simula o serviço que representa o acervo de armas. Muito útil
+// para simular as camadas de serviços em testes unitários.
+type ServiçoArma struct {
+	SimulaCadastrarArma           func(protocolo.ArmaPedido) (protocolo.ArmaResposta, error)
+	SimulaObterArma               func(id int64) (protocolo.ArmaResposta, error)
+	SimulaObterArmaPorNúmeroSérie func(númeroSérie string) (protocolo.ArmaResposta, error)
+	SimulaAtualizarArma           func(protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error)
+}
+
+// CadastrarArma persiste em banco de dados uma nova arma no acervo. Não é
+// permitido cadastrar duas armas com o mesmo número de série.
+func (s ServiçoArma) CadastrarArma(armaPedido protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+	return s.SimulaCadastrarArma(armaPedido)
+}
+
+// ObterArma retorna os dados da arma a partir do seu número de identificação.
+func (s ServiçoArma) ObterArma(id int64) (protocolo.ArmaResposta, error) {
+	return s.SimulaObterArma(id)
+}
+
+// ObterArmaPorNúmeroSérie retorna os dados da arma a partir do seu número de
+// série. Utilizado para identificar a arma informada nas frequências.
+func (s ServiçoArma) ObterArmaPorNúmeroSérie(númeroSérie string) (protocolo.ArmaResposta, error) {
+	return s.SimulaObterArmaPorNúmeroSérie(númeroSérie)
+}
+
+// AtualizarArma substitui os dados da arma pelos dados informados. É através
+// desta ação que uma arma é transferida para outro proprietário.
+func (s ServiçoArma) AtualizarArma(armaPedidoCompleto protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+	return s.SimulaAtualizarArma(armaPedidoCompleto)
+}
+
+// ServiçoCalibre simula o serviço que representa o catálogo de calibres.
+// Muito útil para simular as camadas de serviços em testes unitários.
+type ServiçoCalibre struct {
+	SimulaListarCalibres  func() (protocolo.CalibreListaResposta, error)
+	SimulaResolverCalibre func(nome string) (protocolo.CalibreResposta, error)
+}
+
+// ListarCalibres retorna todos os calibres do catálogo ordenados pelo nome.
+func (s ServiçoCalibre) ListarCalibres() (protocolo.CalibreListaResposta, error) {
+	return s.SimulaListarCalibres()
+}
+
+// ResolverCalibre identifica o calibre do catálogo a partir do nome canônico
+// ou de um dos seus apelidos.
+func (s ServiçoCalibre) ResolverCalibre(nome string) (protocolo.CalibreResposta, error) {
+	return s.SimulaResolverCalibre(nome)
+}
+
+// ServiçoUsuário simula o serviço de autenticação de usuários. Muito útil para
+// simular as camadas de serviços em testes unitários.
+type ServiçoUsuário struct {
+	SimulaAutenticar   func(protocolo.LoginPedido) (protocolo.LoginResposta, error)
+	SimulaValidarToken func(token string) (protocolo.Identidade, error)
+}
+
+// Autenticar verifica as credenciais do usuário e, caso estejam corretas, gera
+// um token de acesso assinado com prazo de validade.
+func (s ServiçoUsuário) Autenticar(loginPedido protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+	return s.SimulaAutenticar(loginPedido)
+}
+
+// ValidarToken verifica a assinatura e o prazo de validade do token, retornando
+// a identidade do usuário autenticado.
+func (s ServiçoUsuário) ValidarToken(token string) (protocolo.Identidade, error) {
+	return s.SimulaValidarToken(token)
+}
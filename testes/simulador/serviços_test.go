@@ -42,14 +42,310 @@ func TestServiçoAtirador(t *testing.T) {
 		return protocolo.FrequênciaResposta{}, nil
 	}
 
+	serviçoAtiradorSimulado.SimulaObterResumoFrequência = func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) (protocolo.FrequênciaResposta, error) {
+		visitou("SimulaObterResumoFrequência")
+		return protocolo.FrequênciaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaPDFNúmeroControle = func(cr int, númeroControle protocolo.NúmeroControle, códigoVerificação string) ([]byte, error) {
+		visitou("SimulaPDFNúmeroControle")
+		return nil, nil
+	}
+
 	serviçoAtiradorSimulado.SimulaConfirmarFrequência = func(protocolo.FrequênciaConfirmaçãoPedidoCompleta) error {
 		visitou("SimulaConfirmarFrequência")
 		return nil
 	}
 
+	serviçoAtiradorSimulado.SimulaCancelarFrequência = func(protocolo.FrequênciaCancelamentoPedidoCompleta) error {
+		visitou("SimulaCancelarFrequência")
+		return nil
+	}
+
+	serviçoAtiradorSimulado.SimulaExpirarFrequências = func(limite int) (int, error) {
+		visitou("SimulaExpirarFrequências")
+		return 0, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaMigrarImagens = func(limite int) (int, error) {
+		visitou("SimulaMigrarImagens")
+		return 0, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaListarFrequências = func(protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+		visitou("SimulaListarFrequências")
+		return protocolo.FrequênciaListaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaListarFrequênciasAtirador = func(int, protocolo.FrequênciaFiltro) (protocolo.FrequênciaListaResposta, error) {
+		visitou("SimulaListarFrequênciasAtirador")
+		return protocolo.FrequênciaListaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaRelatórioHabitualidade = func(protocolo.HabitualidadeFiltro) (protocolo.HabitualidadeResposta, error) {
+		visitou("SimulaRelatórioHabitualidade")
+		return protocolo.HabitualidadeResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaConsumoMunição = func(cr int, ano int) (protocolo.MuniçãoConsumoResposta, error) {
+		visitou("SimulaConsumoMunição")
+		return protocolo.MuniçãoConsumoResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaPendênciasChavesAposentadas = func() ([]protocolo.FrequênciaPendênciaChave, error) {
+		visitou("SimulaPendênciasChavesAposentadas")
+		return nil, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaSortearAmostraAuditoria = func(protocolo.AmostraAuditoriaPedido) (protocolo.AmostraAuditoriaResposta, error) {
+		visitou("SimulaSortearAmostraAuditoria")
+		return protocolo.AmostraAuditoriaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaObterAmostraAuditoria = func(id int64) (protocolo.AmostraAuditoriaResposta, error) {
+		visitou("SimulaObterAmostraAuditoria")
+		return protocolo.AmostraAuditoriaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaObterAuditoria = func(id int64) (protocolo.AuditoriaResposta, error) {
+		visitou("SimulaObterAuditoria")
+		return protocolo.AuditoriaResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaRegistrarVeredito = func(protocolo.AuditoriaVereditoPedidoCompleta) error {
+		visitou("SimulaRegistrarVeredito")
+		return nil
+	}
+
+	serviçoAtiradorSimulado.SimulaCadastrarAtirador = func(protocolo.AtiradorPedido) (protocolo.AtiradorResposta, error) {
+		visitou("SimulaCadastrarAtirador")
+		return protocolo.AtiradorResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaObterAtirador = func(cr int) (protocolo.AtiradorResposta, error) {
+		visitou("SimulaObterAtirador")
+		return protocolo.AtiradorResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaAtualizarAtirador = func(protocolo.AtiradorPedidoCompleto) (protocolo.AtiradorResposta, error) {
+		visitou("SimulaAtualizarAtirador")
+		return protocolo.AtiradorResposta{}, nil
+	}
+
+	serviçoAtiradorSimulado.SimulaRemoverAtirador = func(cr int) error {
+		visitou("SimulaRemoverAtirador")
+		return nil
+	}
+
+	serviçoAtiradorSimulado.SimulaImportarAtiradores = func(protocolo.AtiradorImportaçãoPedido) (protocolo.AtiradorImportaçãoResposta, error) {
+		visitou("SimulaImportarAtiradores")
+		return protocolo.AtiradorImportaçãoResposta{}, nil
+	}
+
 	serviçoAtiradorSimulado.CadastrarFrequência(protocolo.FrequênciaPedidoCompleta{})
 	serviçoAtiradorSimulado.ObterFrequência(0, "", "")
+	serviçoAtiradorSimulado.ObterResumoFrequência(0, "", "")
+	serviçoAtiradorSimulado.PDFNúmeroControle(0, "", "")
 	serviçoAtiradorSimulado.ConfirmarFrequência(protocolo.FrequênciaConfirmaçãoPedidoCompleta{})
+	serviçoAtiradorSimulado.CancelarFrequência(protocolo.FrequênciaCancelamentoPedidoCompleta{})
+	serviçoAtiradorSimulado.ExpirarFrequências(0)
+	serviçoAtiradorSimulado.MigrarImagens(0)
+	serviçoAtiradorSimulado.ListarFrequências(protocolo.FrequênciaFiltro{})
+	serviçoAtiradorSimulado.ListarFrequênciasAtirador(0, protocolo.FrequênciaFiltro{})
+	serviçoAtiradorSimulado.RelatórioHabitualidade(protocolo.HabitualidadeFiltro{})
+	serviçoAtiradorSimulado.ConsumoMunição(0, 0)
+	serviçoAtiradorSimulado.PendênciasChavesAposentadas()
+	serviçoAtiradorSimulado.SortearAmostraAuditoria(protocolo.AmostraAuditoriaPedido{})
+	serviçoAtiradorSimulado.ObterAmostraAuditoria(0)
+	serviçoAtiradorSimulado.ObterAuditoria(0)
+	serviçoAtiradorSimulado.RegistrarVeredito(protocolo.AuditoriaVereditoPedidoCompleta{})
+	serviçoAtiradorSimulado.CadastrarAtirador(protocolo.AtiradorPedido{})
+	serviçoAtiradorSimulado.ObterAtirador(0)
+	serviçoAtiradorSimulado.AtualizarAtirador(protocolo.AtiradorPedidoCompleto{})
+	serviçoAtiradorSimulado.RemoverAtirador(0)
+	serviçoAtiradorSimulado.ImportarAtiradores(protocolo.AtiradorImportaçãoPedido{})
+
+	if len(métodosSimulados) > 0 {
+		t.Errorf("métodos %#v não foram chamados", métodosSimulados)
+	}
+}
+
+func TestServiçoClube(t *testing.T) {
+	var serviçoClubeSimulado simulador.ServiçoClube
+	var métodosSimulados []string
+
+	estruturaSimulada := reflect.TypeOf(serviçoClubeSimulado)
+	for i := 0; i < estruturaSimulada.NumField(); i++ {
+		// trata somente funções como argumentos, ignorando atributos simples
+		if !strings.HasPrefix(estruturaSimulada.Field(i).Type.String(), "func (") {
+			continue
+		}
+
+		métodosSimulados = append(métodosSimulados, estruturaSimulada.Field(i).Name)
+	}
+
+	visitou := func(métodoSimulado string) {
+		for i := len(métodosSimulados) - 1; i >= 0; i-- {
+			if métodosSimulados[i] == métodoSimulado {
+				métodosSimulados = append(métodosSimulados[:i], métodosSimulados[i+1:]...)
+				break
+			}
+		}
+	}
+
+	serviçoClubeSimulado.SimulaCadastrarClube = func(protocolo.ClubePedido) (protocolo.ClubeResposta, error) {
+		visitou("SimulaCadastrarClube")
+		return protocolo.ClubeResposta{}, nil
+	}
+
+	serviçoClubeSimulado.SimulaObterClube = func(id int64) (protocolo.ClubeResposta, error) {
+		visitou("SimulaObterClube")
+		return protocolo.ClubeResposta{}, nil
+	}
+
+	serviçoClubeSimulado.SimulaAtualizarClube = func(protocolo.ClubePedidoCompleto) (protocolo.ClubeResposta, error) {
+		visitou("SimulaAtualizarClube")
+		return protocolo.ClubeResposta{}, nil
+	}
+
+	serviçoClubeSimulado.CadastrarClube(protocolo.ClubePedido{})
+	serviçoClubeSimulado.ObterClube(0)
+	serviçoClubeSimulado.AtualizarClube(protocolo.ClubePedidoCompleto{})
+
+	if len(métodosSimulados) > 0 {
+		t.Errorf("métodos %#v não foram chamados", métodosSimulados)
+	}
+}
+
+func TestServiçoArma(t *testing.T) {
+	var serviçoArmaSimulado simulador.ServiçoArma
+	var métodosSimulados []string
+
+	estruturaSimulada := reflect.TypeOf(serviçoArmaSimulado)
+	for i := 0; i < estruturaSimulada.NumField(); i++ {
+		// trata somente funções como argumentos, ignorando atributos simples
+		if !strings.HasPrefix(estruturaSimulada.Field(i).Type.String(), "func (") {
+			continue
+		}
+
+		métodosSimulados = append(métodosSimulados, estruturaSimulada.Field(i).Name)
+	}
+
+	visitou := func(métodoSimulado string) {
+		for i := len(métodosSimulados) - 1; i >= 0; i-- {
+			if métodosSimulados[i] == métodoSimulado {
+				métodosSimulados = append(métodosSimulados[:i], métodosSimulados[i+1:]...)
+				break
+			}
+		}
+	}
+
+	serviçoArmaSimulado.SimulaCadastrarArma = func(protocolo.ArmaPedido) (protocolo.ArmaResposta, error) {
+		visitou("SimulaCadastrarArma")
+		return protocolo.ArmaResposta{}, nil
+	}
+
+	serviçoArmaSimulado.SimulaObterArma = func(id int64) (protocolo.ArmaResposta, error) {
+		visitou("SimulaObterArma")
+		return protocolo.ArmaResposta{}, nil
+	}
+
+	serviçoArmaSimulado.SimulaObterArmaPorNúmeroSérie = func(númeroSérie string) (protocolo.ArmaResposta, error) {
+		visitou("SimulaObterArmaPorNúmeroSérie")
+		return protocolo.ArmaResposta{}, nil
+	}
+
+	serviçoArmaSimulado.SimulaAtualizarArma = func(protocolo.ArmaPedidoCompleto) (protocolo.ArmaResposta, error) {
+		visitou("SimulaAtualizarArma")
+		return protocolo.ArmaResposta{}, nil
+	}
+
+	serviçoArmaSimulado.CadastrarArma(protocolo.ArmaPedido{})
+	serviçoArmaSimulado.ObterArma(0)
+	serviçoArmaSimulado.ObterArmaPorNúmeroSérie("")
+	serviçoArmaSimulado.AtualizarArma(protocolo.ArmaPedidoCompleto{})
+
+	if len(métodosSimulados) > 0 {
+		t.Errorf("métodos %#v não foram chamados", métodosSimulados)
+	}
+}
+
+func TestServiçoCalibre(t *testing.T) {
+	var serviçoCalibreSimulado simulador.ServiçoCalibre
+	var métodosSimulados []string
+
+	estruturaSimulada := reflect.TypeOf(serviçoCalibreSimulado)
+	for i := 0; i < estruturaSimulada.NumField(); i++ {
+		// trata somente funções como argumentos, ignorando atributos simples
+		if !strings.HasPrefix(estruturaSimulada.Field(i).Type.String(), "func (") {
+			continue
+		}
+
+		métodosSimulados = append(métodosSimulados, estruturaSimulada.Field(i).Name)
+	}
+
+	visitou := func(métodoSimulado string) {
+		for i := len(métodosSimulados) - 1; i >= 0; i-- {
+			if métodosSimulados[i] == métodoSimulado {
+				métodosSimulados = append(métodosSimulados[:i], métodosSimulados[i+1:]...)
+				break
+			}
+		}
+	}
+
+	serviçoCalibreSimulado.SimulaListarCalibres = func() (protocolo.CalibreListaResposta, error) {
+		visitou("SimulaListarCalibres")
+		return protocolo.CalibreListaResposta{}, nil
+	}
+
+	serviçoCalibreSimulado.SimulaResolverCalibre = func(nome string) (protocolo.CalibreResposta, error) {
+		visitou("SimulaResolverCalibre")
+		return protocolo.CalibreResposta{}, nil
+	}
+
+	serviçoCalibreSimulado.ListarCalibres()
+	serviçoCalibreSimulado.ResolverCalibre("")
+
+	if len(métodosSimulados) > 0 {
+		t.Errorf("métodos %#v não foram chamados", métodosSimulados)
+	}
+}
+
+func TestServiçoUsuário(t *testing.T) {
+	var serviçoUsuárioSimulado simulador.ServiçoUsuário
+	var métodosSimulados []string
+
+	estruturaSimulada := reflect.TypeOf(serviçoUsuárioSimulado)
+	for i := 0; i < estruturaSimulada.NumField(); i++ {
+		// trata somente funções como argumentos, ignorando atributos simples
+		if !strings.HasPrefix(estruturaSimulada.Field(i).Type.String(), "func (") {
+			continue
+		}
+
+		métodosSimulados = append(métodosSimulados, estruturaSimulada.Field(i).Name)
+	}
+
+	visitou := func(métodoSimulado string) {
+		for i := len(métodosSimulados) - 1; i >= 0; i-- {
+			if métodosSimulados[i] == métodoSimulado {
+				métodosSimulados = append(métodosSimulados[:i], métodosSimulados[i+1:]...)
+				break
+			}
+		}
+	}
+
+	serviçoUsuárioSimulado.SimulaAutenticar = func(protocolo.LoginPedido) (protocolo.LoginResposta, error) {
+		visitou("SimulaAutenticar")
+		return protocolo.LoginResposta{}, nil
+	}
+
+	serviçoUsuárioSimulado.SimulaValidarToken = func(token string) (protocolo.Identidade, error) {
+		visitou("SimulaValidarToken")
+		return protocolo.Identidade{}, nil
+	}
+
+	serviçoUsuárioSimulado.Autenticar(protocolo.LoginPedido{})
+	serviçoUsuárioSimulado.ValidarToken("")
 
 	if len(métodosSimulados) > 0 {
 		t.Errorf("métodos %#v não foram chamados", métodosSimulados)